@@ -0,0 +1,51 @@
+package blockchain
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/shared/bls"
+)
+
+var errBatchSignatureInvalid = errors.New("batch block signature verification failed")
+
+// verifySignatureSet is the function used to verify the signature set of a block batch.
+var verifySignatureSet = func(set *bls.SignatureSet) (bool, error) {
+	return set.Verify()
+}
+
+// verifyBatchSignatures verifies the signatures of all the blocks of a batch at once in the
+// background, so that the state transitions of the next batch can be computed in the meantime.
+// The result is sent on the returned channel, which is buffered so the verification never
+// blocks on an abandoned batch. The verification is skipped once the context is cancelled.
+func verifyBatchSignatures(ctx context.Context, set *bls.SignatureSet) <-chan error {
+	verified := make(chan error, 1)
+	if len(set.Signatures) == 0 {
+		verified <- nil
+		return verified
+	}
+	verify := verifySignatureSet
+	go func() {
+		if err := ctx.Err(); err != nil {
+			verified <- err
+			return
+		}
+		valid, err := verify(set)
+		if err == nil && !valid {
+			err = errBatchSignatureInvalid
+		}
+		verified <- err
+	}()
+	return verified
+}
+
+// waitForBatchSignatures waits for the result of verifyBatchSignatures, or for the context to be
+// cancelled.
+func waitForBatchSignatures(ctx context.Context, verified <-chan error) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case err := <-verified:
+		return err
+	}
+}
@@ -0,0 +1,83 @@
+package blockchain
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/shared/bls"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func TestVerifyBatchSignatures(t *testing.T) {
+	set := &bls.SignatureSet{
+		Signatures: [][]byte{{1}},
+		PublicKeys: []bls.PublicKey{nil},
+		Messages:   [][32]byte{{1}},
+	}
+	stubVerify := func(t *testing.T, verify func(set *bls.SignatureSet) (bool, error)) {
+		original := verifySignatureSet
+		verifySignatureSet = verify
+		t.Cleanup(func() {
+			verifySignatureSet = original
+		})
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		stubVerify(t, func(*bls.SignatureSet) (bool, error) {
+			return true, nil
+		})
+		ctx := context.Background()
+		require.NoError(t, waitForBatchSignatures(ctx, verifyBatchSignatures(ctx, set)))
+	})
+
+	t.Run("empty set", func(t *testing.T) {
+		stubVerify(t, func(*bls.SignatureSet) (bool, error) {
+			return false, errors.New("empty sets should not be verified")
+		})
+		ctx := context.Background()
+		require.NoError(t, waitForBatchSignatures(ctx, verifyBatchSignatures(ctx, bls.NewSet())))
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		stubVerify(t, func(*bls.SignatureSet) (bool, error) {
+			return false, nil
+		})
+		ctx := context.Background()
+		err := waitForBatchSignatures(ctx, verifyBatchSignatures(ctx, set))
+		assert.ErrorContains(t, errBatchSignatureInvalid.Error(), err)
+	})
+
+	t.Run("verification error", func(t *testing.T) {
+		stubVerify(t, func(*bls.SignatureSet) (bool, error) {
+			return false, errors.New("bad signature encoding")
+		})
+		ctx := context.Background()
+		err := waitForBatchSignatures(ctx, verifyBatchSignatures(ctx, set))
+		assert.ErrorContains(t, "bad signature encoding", err)
+	})
+
+	t.Run("cancelled before verification", func(t *testing.T) {
+		stubVerify(t, func(*bls.SignatureSet) (bool, error) {
+			return false, errors.New("cancelled batches should not be verified")
+		})
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		err := <-verifyBatchSignatures(ctx, set)
+		assert.ErrorContains(t, context.Canceled.Error(), err)
+	})
+
+	t.Run("cancelled while waiting", func(t *testing.T) {
+		release := make(chan struct{})
+		defer close(release)
+		stubVerify(t, func(*bls.SignatureSet) (bool, error) {
+			<-release
+			return true, nil
+		})
+		ctx, cancel := context.WithCancel(context.Background())
+		verified := verifyBatchSignatures(ctx, set)
+		cancel()
+		assert.ErrorContains(t, context.Canceled.Error(), waitForBatchSignatures(ctx, verified))
+	})
+}
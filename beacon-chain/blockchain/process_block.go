@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
 	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
@@ -20,6 +21,7 @@ import (
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
 	"github.com/prysmaticlabs/prysm/shared/featureconfig"
 	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/sliceutil"
 	"go.opencensus.io/trace"
 )
 
@@ -152,6 +154,11 @@ func (s *Service) onBlock(ctx context.Context, signed interfaces.SignedBeaconBlo
 		return err
 	}
 
+	// Boost the block in fork choice if it arrived on time for its slot, and discount the
+	// votes of the validators it slashes for equivocating.
+	s.cfg.ForkChoiceStore.BoostProposerRoot(ctx, b.Slot(), blockRoot, s.genesisTime)
+	s.InsertSlashingsToForkChoiceStore(ctx, b.Body().AttesterSlashings())
+
 	// Updating next slot state cache can happen in the background. It shouldn't block rest of the process.
 	if featureconfig.Get().EnableNextSlotStateCache {
 		go func() {
@@ -245,29 +252,66 @@ func (s *Service) onBlock(ctx context.Context, signed interfaces.SignedBeaconBlo
 	return s.handleEpochBoundary(ctx, postState)
 }
 
+// blockBatch is a linear batch of blocks whose state transitions are computed, and whose signatures
+// are verified in the background. Nothing of the batch is saved before its signatures are verified.
+type blockBatch struct {
+	blks         []interfaces.SignedBeaconBlock
+	roots        [][32]byte
+	postState    iface.BeaconState
+	boundaries   map[[32]byte]iface.BeaconState
+	fCheckpoints []*ethpb.Checkpoint
+	jCheckpoints []*ethpb.Checkpoint
+	verified     <-chan error
+}
+
 func (s *Service) onBlockBatch(ctx context.Context, blks []interfaces.SignedBeaconBlock,
 	blockRoots [][32]byte) ([]*ethpb.Checkpoint, []*ethpb.Checkpoint, error) {
 	ctx, span := trace.StartSpan(ctx, "blockChain.onBlockBatch")
 	defer span.End()
 
+	batch, err := s.transitionBlockBatch(ctx, nil, blks, blockRoots)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := s.saveBlockBatch(ctx, batch); err != nil {
+		return nil, nil, err
+	}
+	return batch.fCheckpoints, batch.jCheckpoints, nil
+}
+
+// transitionBlockBatch runs the state transitions of a linear block batch without verifying any
+// signature, and starts the verification of the signatures of the whole batch in the background.
+// The transitions start from a copy of the given pre state, or from the state of the parent of the
+// first block when no pre state is given.
+func (s *Service) transitionBlockBatch(ctx context.Context, preState iface.BeaconState,
+	blks []interfaces.SignedBeaconBlock, blockRoots [][32]byte) (*blockBatch, error) {
+	ctx, span := trace.StartSpan(ctx, "blockChain.transitionBlockBatch")
+	defer span.End()
+
 	if len(blks) == 0 || len(blockRoots) == 0 {
-		return nil, nil, errors.New("no blocks provided")
+		return nil, errors.New("no blocks provided")
 	}
 	if blks[0] == nil || blks[0].IsNil() || blks[0].Block().IsNil() {
-		return nil, nil, errors.New("nil block")
+		return nil, errors.New("nil block")
 	}
 	b := blks[0].Block()
 
-	// Retrieve incoming block's pre state.
-	if err := s.verifyBlkPreState(ctx, b); err != nil {
-		return nil, nil, err
-	}
-	preState, err := s.cfg.StateGen.StateByRootInitialSync(ctx, bytesutil.ToBytes32(b.ParentRoot()))
-	if err != nil {
-		return nil, nil, err
-	}
 	if preState == nil || preState.IsNil() {
-		return nil, nil, fmt.Errorf("nil pre state for slot %d", b.Slot())
+		// Retrieve incoming block's pre state.
+		if err := s.verifyBlkPreState(ctx, b); err != nil {
+			return nil, err
+		}
+		var err error
+		preState, err = s.cfg.StateGen.StateByRootInitialSync(ctx, bytesutil.ToBytes32(b.ParentRoot()))
+		if err != nil {
+			return nil, err
+		}
+		if preState == nil || preState.IsNil() {
+			return nil, fmt.Errorf("nil pre state for slot %d", b.Slot())
+		}
+	} else {
+		// The given pre state is the post state of a previous batch, which is yet to be saved.
+		preState = preState.Copy()
 	}
 
 	jCheckpoints := make([]*ethpb.Checkpoint, len(blks))
@@ -278,17 +322,18 @@ func (s *Service) onBlockBatch(ctx context.Context, blks []interfaces.SignedBeac
 		Messages:   [][32]byte{},
 	}
 	var set *bls.SignatureSet
+	var err error
 	boundaries := make(map[[32]byte]iface.BeaconState)
 	for i, b := range blks {
 		set, preState, err = state.ExecuteStateTransitionNoVerifyAnySig(ctx, preState, b)
 		if err != nil {
-			return nil, nil, err
+			return nil, err
 		}
 		// Save potential boundary states.
 		if helpers.IsEpochStart(preState.Slot()) {
 			boundaries[blockRoots[i]] = preState.Copy()
 			if err := s.handleEpochBoundary(ctx, preState); err != nil {
-				return nil, nil, errors.Wrap(err, "could not handle epoch boundary state")
+				return nil, errors.Wrap(err, "could not handle epoch boundary state")
 			}
 		}
 
@@ -298,7 +343,7 @@ func (s *Service) onBlockBatch(ctx context.Context, blks []interfaces.SignedBeac
 			if s.getLatestSentEpoch() < nextEpoch {
 				proposerIndices, pubKeys, err := helpers.ProposerIndicesInCache(preState.Copy(), nextEpoch)
 				if err != nil {
-					return nil, nil, errors.Wrap(err, "could not get proposer indices for publishing")
+					return nil, errors.Wrap(err, "could not get proposer indices for publishing")
 				}
 				log.WithField("nextEpoch", nextEpoch).WithField("latestSentEpoch", s.latestSentEpoch).Debug("publishing latest epoch info")
 				s.publishEpochInfo(b.Block().Slot(), proposerIndices, pubKeys)
@@ -310,13 +355,27 @@ func (s *Service) onBlockBatch(ctx context.Context, blks []interfaces.SignedBeac
 		fCheckpoints[i] = preState.FinalizedCheckpoint()
 		sigSet.Join(set)
 	}
-	verify, err := sigSet.Verify()
-	if err != nil {
-		return nil, nil, err
-	}
-	if !verify {
-		return nil, nil, errors.New("batch block signature verification failed")
+	return &blockBatch{
+		blks:         blks,
+		roots:        blockRoots,
+		postState:    preState,
+		boundaries:   boundaries,
+		fCheckpoints: fCheckpoints,
+		jCheckpoints: jCheckpoints,
+		verified:     verifyBatchSignatures(ctx, sigSet),
+	}, nil
+}
+
+// saveBlockBatch waits for the signatures of a block batch to be verified, then saves its boundary
+// states and its post state, and updates the head to its last block.
+func (s *Service) saveBlockBatch(ctx context.Context, batch *blockBatch) error {
+	ctx, span := trace.StartSpan(ctx, "blockChain.saveBlockBatch")
+	defer span.End()
+
+	if err := waitForBatchSignatures(ctx, batch.verified); err != nil {
+		return err
 	}
+	blks := batch.blks
 
 	// Vanguard: Validated by vanguard node. Now intercepting the execution and publishing the block
 	// and waiting for confirmation from orchestrator. If Lukso vanguard flag is enabled then these segment of code will be executed
@@ -324,7 +383,7 @@ func (s *Service) onBlockBatch(ctx context.Context, blks []interfaces.SignedBeac
 		parentRoot := bytesutil.ToBytes32(blks[0].Block().ParentRoot())
 		parentBlk, err := s.cfg.BeaconDB.Block(s.ctx, parentRoot)
 		if err != nil {
-			return nil, nil, errors.Wrapf(errParentDoesNotExist, "could not verify pandora shard info "+
+			return errors.Wrapf(errParentDoesNotExist, "could not verify pandora shard info "+
 				"onBlockBatch with slot: %d and parentHash: %#x", blks[0].Block().Slot(), blks[0].Block().ParentRoot())
 		}
 
@@ -335,7 +394,7 @@ func (s *Service) onBlockBatch(ctx context.Context, blks []interfaces.SignedBeac
 		}
 
 		if parentBlk.IsNil() {
-			return nil, nil, errors.Wrapf(errParentDoesNotExist, "could not verify pandora shard info "+
+			return errors.Wrapf(errParentDoesNotExist, "could not verify pandora shard info "+
 				"onBlockBatch with slot: %d and parentHash: %#x", blks[0].Block().Slot(), blks[0].Block().ParentRoot())
 		}
 
@@ -345,33 +404,30 @@ func (s *Service) onBlockBatch(ctx context.Context, blks []interfaces.SignedBeac
 			}
 			// verify pandora sharding info in regular sync mode
 			if err := s.verifyPandoraShardInfo(parentBlk, blks[i]); err != nil {
-				return nil, nil, errors.Wrap(err, "could not verify pandora shard info onBlockBatch")
+				return errors.Wrap(err, "could not verify pandora shard info onBlockBatch")
 			}
 			// publish block and trigger rpc service for sending minimal consensus info
 			s.publishBlock(blks[i])
 
 			// waiting for orchestrator confirmation in regular sync mode
 			if err := s.waitForConfirmation(blks[i]); err != nil {
-				return nil, nil, errors.Wrap(err, "could not publish and verified by orchestrator client onBlock")
+				return errors.Wrap(err, "could not publish and verified by orchestrator client onBlock")
 			}
 		}
 	}
 
-	for r, st := range boundaries {
+	for r, st := range batch.boundaries {
 		if err := s.cfg.StateGen.SaveState(ctx, r, st); err != nil {
-			return nil, nil, err
+			return err
 		}
 	}
 	// Also saves the last post state which to be used as pre state for the next batch.
 	lastB := blks[len(blks)-1]
-	lastBR := blockRoots[len(blockRoots)-1]
-	if err := s.cfg.StateGen.SaveState(ctx, lastBR, preState); err != nil {
-		return nil, nil, err
-	}
-	if err := s.saveHeadNoDB(ctx, lastB, lastBR, preState); err != nil {
-		return nil, nil, err
+	lastBR := batch.roots[len(batch.roots)-1]
+	if err := s.cfg.StateGen.SaveState(ctx, lastBR, batch.postState); err != nil {
+		return err
 	}
-	return fCheckpoints, jCheckpoints, nil
+	return s.saveHeadNoDB(ctx, lastB, lastBR, batch.postState)
 }
 
 // handles a block after the block's batch has been verified, where we can save blocks
@@ -480,6 +536,20 @@ func (s *Service) insertBlockAndAttestationsToForkChoiceStore(ctx context.Contex
 	return nil
 }
 
+// InsertSlashingsToForkChoiceStore inserts the validators slashable by the given attester slashings
+// to the fork choice store, which then stops counting their votes.
+func (s *Service) InsertSlashingsToForkChoiceStore(ctx context.Context, slashings []*ethpb.AttesterSlashing) {
+	for _, slashing := range slashings {
+		if slashing == nil || slashing.Attestation_1 == nil || slashing.Attestation_2 == nil {
+			continue
+		}
+		indices := sliceutil.IntersectionUint64(slashing.Attestation_1.AttestingIndices, slashing.Attestation_2.AttestingIndices)
+		for _, index := range indices {
+			s.cfg.ForkChoiceStore.InsertSlashedIndex(ctx, types.ValidatorIndex(index))
+		}
+	}
+}
+
 func (s *Service) insertBlockToForkChoiceStore(ctx context.Context, blk interfaces.BeaconBlock,
 	root [32]byte, fCheckpoint, jCheckpoint *ethpb.Checkpoint) error {
 	if err := s.fillInForkChoiceMissingBlocks(ctx, blk, fCheckpoint, jCheckpoint); err != nil {
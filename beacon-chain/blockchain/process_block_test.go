@@ -1231,3 +1231,27 @@ func TestVanguardStore_OnBlock(t *testing.T) {
 		})
 	}
 }
+
+func TestInsertSlashingsToForkChoiceStore(t *testing.T) {
+	ctx := context.Background()
+	fcs := protoarray.New(0, 0, [32]byte{})
+	service, err := NewService(ctx, &Config{ForkChoiceStore: fcs})
+	require.NoError(t, err)
+
+	root0, root1 := [32]byte{'a'}, [32]byte{'b'}
+	require.NoError(t, fcs.ProcessBlock(ctx, 0, root0, [32]byte{}, [32]byte{}, 0, 0))
+	require.NoError(t, fcs.ProcessBlock(ctx, 1, root1, root0, [32]byte{}, 0, 0))
+
+	service.InsertSlashingsToForkChoiceStore(ctx, []*ethpb.AttesterSlashing{
+		{
+			Attestation_1: &ethpb.IndexedAttestation{AttestingIndices: []uint64{1, 2}},
+			Attestation_2: &ethpb.IndexedAttestation{AttestingIndices: []uint64{2, 3}},
+		},
+		nil,
+	})
+	// Only validator 2 attested twice, the vote of validator 3 is still counted.
+	fcs.ProcessAttestation(ctx, []uint64{2, 3}, root1, 1)
+	_, err = fcs.Head(ctx, 0, root0, []uint64{10, 10, 10, 10}, 0)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(10), fcs.Node(root1).Weight())
+}
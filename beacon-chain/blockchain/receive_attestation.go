@@ -26,6 +26,11 @@ type AttestationReceiver interface {
 	VerifyFinalizedConsistency(ctx context.Context, root []byte) error
 }
 
+// SlashingReceiver interface defines the methods of chain service for receiving validated slashings.
+type SlashingReceiver interface {
+	InsertSlashingsToForkChoiceStore(ctx context.Context, slashings []*ethpb.AttesterSlashing)
+}
+
 // ReceiveAttestationNoPubsub is a function that defines the operations that are performed on
 // attestation that is received from regular sync. The operations consist of:
 //  1. Validate attestation, update validator's latest vote
@@ -119,6 +124,9 @@ func (s *Service) processAttestationsRoutine(subscribedToStateEvents chan<- stru
 		case <-s.ctx.Done():
 			return
 		case <-st.C():
+			// The proposer boost only applies during the slot of the boosted block.
+			s.cfg.ForkChoiceStore.ResetBoostedProposerRoot(s.ctx)
+
 			// Continue when there's no fork choice attestation, there's nothing to process and update head.
 			// This covers the condition when the node is still initial syncing to the head of the chain.
 			if s.cfg.AttPool.ForkchoiceAttestationCount() == 0 {
@@ -8,6 +8,8 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
 	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	iface "github.com/prysmaticlabs/prysm/beacon-chain/state/interface"
+	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
 	"github.com/prysmaticlabs/prysm/proto/interfaces"
 	"github.com/prysmaticlabs/prysm/shared/featureconfig"
 	"github.com/prysmaticlabs/prysm/shared/timeutils"
@@ -22,6 +24,8 @@ var epochsSinceFinalitySaveHotStateDB = types.Epoch(100)
 type BlockReceiver interface {
 	ReceiveBlock(ctx context.Context, block interfaces.SignedBeaconBlock, blockRoot [32]byte) error
 	ReceiveBlockBatch(ctx context.Context, blocks []interfaces.SignedBeaconBlock, blkRoots [][32]byte) error
+	TransitionBlockBatch(ctx context.Context, preState iface.BeaconState, blocks []interfaces.SignedBeaconBlock,
+		blkRoots [][32]byte) (iface.BeaconState, func(context.Context) error, error)
 	HasInitSyncBlock(root [32]byte) bool
 }
 
@@ -102,11 +106,56 @@ func (s *Service) ReceiveBlockBatch(ctx context.Context, blocks []interfaces.Sig
 		traceutil.AnnotateError(span, err)
 		return err
 	}
+	if err := s.handleBlockBatchAfterVerify(ctx, blocks, blkRoots, fCheckpoints, jCheckpoints); err != nil {
+		traceutil.AnnotateError(span, err)
+		return err
+	}
+	return nil
+}
+
+// TransitionBlockBatch transitions the state through a linear block batch like ReceiveBlockBatch, but
+// verifies the signatures of the whole batch in the background, so that the caller can transition the
+// next batch in the meantime. The transitions start from the given pre state, which is not modified, or
+// from the state of the parent of the batch when it is nil. It returns the post state of the batch, to be
+// used as the pre state of the next batch, and a function which waits for the signatures to be verified
+// and then saves the batch. Batches must be saved in order, and the batches transitioned on top of a
+// batch which could not be saved must be dropped.
+func (s *Service) TransitionBlockBatch(ctx context.Context, preState iface.BeaconState,
+	blocks []interfaces.SignedBeaconBlock, blkRoots [][32]byte) (iface.BeaconState, func(context.Context) error, error) {
+	ctx, span := trace.StartSpan(ctx, "blockChain.TransitionBlockBatch")
+	defer span.End()
+
+	batch, err := s.transitionBlockBatch(ctx, preState, blocks, blkRoots)
+	if err != nil {
+		err := errors.Wrap(err, "could not process block in batch")
+		traceutil.AnnotateError(span, err)
+		return nil, nil, err
+	}
+	save := func(ctx context.Context) error {
+		ctx, span := trace.StartSpan(ctx, "blockChain.saveTransitionedBlockBatch")
+		defer span.End()
 
+		if err := s.saveBlockBatch(ctx, batch); err != nil {
+			err := errors.Wrap(err, "could not process block in batch")
+			traceutil.AnnotateError(span, err)
+			return err
+		}
+		if err := s.handleBlockBatchAfterVerify(ctx, batch.blks, batch.roots, batch.fCheckpoints, batch.jCheckpoints); err != nil {
+			traceutil.AnnotateError(span, err)
+			return err
+		}
+		return nil
+	}
+	return batch.postState, save, nil
+}
+
+// handleBlockBatchAfterVerify performs the appropriate actions for the blocks of a batch whose state
+// transitions and signatures have been verified.
+func (s *Service) handleBlockBatchAfterVerify(ctx context.Context, blocks []interfaces.SignedBeaconBlock, blkRoots [][32]byte,
+	fCheckpoints, jCheckpoints []*ethpb.Checkpoint) error {
 	for i, b := range blocks {
 		blockCopy := b.Copy()
-		if err = s.handleBlockAfterBatchVerify(ctx, blockCopy, blkRoots[i], fCheckpoints[i], jCheckpoints[i]); err != nil {
-			traceutil.AnnotateError(span, err)
+		if err := s.handleBlockAfterBatchVerify(ctx, blockCopy, blkRoots[i], fCheckpoints[i], jCheckpoints[i]); err != nil {
 			return err
 		}
 		// Send notification of the processed block to the state feed.
@@ -125,8 +174,6 @@ func (s *Service) ReceiveBlockBatch(ctx context.Context, blocks []interfaces.Sig
 	}
 
 	if err := s.VerifyWeakSubjectivityRoot(s.ctx); err != nil {
-		// log.Fatalf will prevent defer from being called
-		span.End()
 		// Exit run time if the node failed to verify weak subjectivity checkpoint.
 		log.Fatalf("Could not verify weak subjectivity checkpoint: %v", err)
 	}
@@ -0,0 +1,122 @@
+package blockchain
+
+import (
+	"context"
+	"testing"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/state"
+	testDB "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/forkchoice/protoarray"
+	iface "github.com/prysmaticlabs/prysm/beacon-chain/state/interface"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stategen"
+	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/proto/eth/v1alpha1/wrapper"
+	"github.com/prysmaticlabs/prysm/proto/interfaces"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+const (
+	benchmarkBatchCount = 4
+	benchmarkBatchSize  = 32
+)
+
+// BenchmarkService_ReceiveBlockBatch transitions and verifies the batches one after another.
+func BenchmarkService_ReceiveBlockBatch(b *testing.B) {
+	ctx := context.Background()
+	st, batches, roots := benchmarkBlockBatches(b)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		service := benchmarkService(b, st)
+		b.StartTimer()
+
+		for j, blks := range batches {
+			require.NoError(b, service.ReceiveBlockBatch(ctx, blks, roots[j]))
+		}
+	}
+}
+
+// BenchmarkService_TransitionBlockBatch verifies the signatures of each batch while the state
+// transitions of the next batch are computed, as done by initial sync.
+func BenchmarkService_TransitionBlockBatch(b *testing.B) {
+	ctx := context.Background()
+	st, batches, roots := benchmarkBlockBatches(b)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		service := benchmarkService(b, st)
+		b.StartTimer()
+
+		var preState iface.BeaconState
+		var save func(context.Context) error
+		for j, blks := range batches {
+			postState, nextSave, err := service.TransitionBlockBatch(ctx, preState, blks, roots[j])
+			require.NoError(b, err)
+			if save != nil {
+				require.NoError(b, save(ctx))
+			}
+			preState, save = postState, nextSave
+		}
+		require.NoError(b, save(ctx))
+	}
+}
+
+func benchmarkService(b *testing.B, st iface.BeaconState) *Service {
+	ctx := context.Background()
+	beaconDB := testDB.SetupDB(b)
+	service, err := NewService(ctx, &Config{
+		BeaconDB:        beaconDB,
+		StateGen:        stategen.New(beaconDB),
+		ForkChoiceStore: protoarray.New(0, 0, [32]byte{}),
+		StateNotifier:   &mock.MockStateNotifier{},
+	})
+	require.NoError(b, err)
+
+	stRoot, err := st.HashTreeRoot(ctx)
+	require.NoError(b, err)
+	genesis := blocks.NewGenesisBlock(stRoot[:])
+	gRoot, err := genesis.Block.HashTreeRoot()
+	require.NoError(b, err)
+	require.NoError(b, beaconDB.SaveBlock(ctx, wrapper.WrappedPhase0SignedBeaconBlock(genesis)))
+	require.NoError(b, service.cfg.StateGen.SaveState(ctx, gRoot, st))
+	service.finalizedCheckpt = &ethpb.Checkpoint{Root: gRoot[:]}
+	service.justifiedCheckpt = &ethpb.Checkpoint{Root: gRoot[:]}
+	service.prevFinalizedCheckpt = &ethpb.Checkpoint{Root: gRoot[:]}
+	return service
+}
+
+func benchmarkBlockBatches(b *testing.B) (iface.BeaconState, [][]interfaces.SignedBeaconBlock, [][][32]byte) {
+	ctx := context.Background()
+	st, keys := testutil.DeterministicGenesisState(b, 256)
+	conf := testutil.DefaultBlockGenConfig()
+	conf.NumAttestations = 4
+	bState := st.Copy()
+	batches := make([][]interfaces.SignedBeaconBlock, benchmarkBatchCount)
+	roots := make([][][32]byte, benchmarkBatchCount)
+	slot := types.Slot(1)
+	for i := range batches {
+		for j := 0; j < benchmarkBatchSize; j++ {
+			blk, err := testutil.GenerateFullBlock(bState, keys, conf, slot)
+			require.NoError(b, err)
+			wsb := wrapper.WrappedPhase0SignedBeaconBlock(blk)
+			bState, err = state.ExecuteStateTransition(ctx, bState, wsb)
+			require.NoError(b, err)
+			root, err := blk.Block.HashTreeRoot()
+			require.NoError(b, err)
+			batches[i] = append(batches[i], wsb)
+			roots[i] = append(roots[i], root)
+			slot++
+		}
+	}
+	return st, batches, roots
+}
@@ -9,6 +9,7 @@ import (
 	"sync"
 	"time"
 
+	lru "github.com/hashicorp/golang-lru"
 	"github.com/pkg/errors"
 	types "github.com/prysmaticlabs/eth2-types"
 	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
@@ -77,6 +78,7 @@ type Service struct {
 	latestSentEpochLock sync.RWMutex
 	orcRPCClient        orchestrator.Client
 	latestSentEpoch     types.Epoch
+	orcConfirmations    *lru.Cache
 }
 
 // Config options for the service.
@@ -106,6 +108,11 @@ type Config struct {
 // be registered into a running beacon node.
 func NewService(ctx context.Context, cfg *Config) (*Service, error) {
 	ctx, cancel := context.WithCancel(ctx)
+	orcConfirmations, err := lru.New(maxOrcConfirmationsSize)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
 	s := &Service{
 		cfg:                  cfg,
 		ctx:                  ctx,
@@ -120,6 +127,7 @@ func NewService(ctx context.Context, cfg *Config) (*Service, error) {
 		orcRPCClient:       cfg.OrcRPCClient,
 		enableVanguardNode: cfg.EnableVanguardNode,
 		canPropose:         true,
+		orcConfirmations:   orcConfirmations,
 	}
 
 	return s, nil
@@ -167,6 +175,9 @@ func (s *Service) Start() {
 		if err := s.initializeChainInfo(s.ctx); err != nil {
 			log.Fatalf("Could not set up chain info: %v", err)
 		}
+		if err := s.cfg.AttService.LoadPersistedPool(s.ctx, s.headState(s.ctx)); err != nil {
+			log.WithError(err).Error("Could not restore attestation pool")
+		}
 
 		// We start a counter to genesis, if needed.
 		gState, err := s.cfg.BeaconDB.GenesisState(s.ctx)
@@ -239,6 +239,18 @@ func (s *ChainService) ReceiveBlockBatch(ctx context.Context, blks []interfaces.
 	return nil
 }
 
+// TransitionBlockBatch mocks TransitionBlockBatch method in chain service, the blocks are received
+// when the batch is saved.
+func (s *ChainService) TransitionBlockBatch(_ context.Context, preState iface.BeaconState,
+	blks []interfaces.SignedBeaconBlock, roots [][32]byte) (iface.BeaconState, func(context.Context) error, error) {
+	if preState == nil {
+		preState = s.State
+	}
+	return preState, func(ctx context.Context) error {
+		return s.ReceiveBlockBatch(ctx, blks, roots)
+	}, nil
+}
+
 // ReceiveBlock mocks ReceiveBlock method in chain service.
 func (s *ChainService) ReceiveBlock(ctx context.Context, block interfaces.SignedBeaconBlock, _ [32]byte) error {
 	if s.State == nil {
@@ -322,6 +334,9 @@ func (s *ChainService) ReceiveAttestationNoPubsub(context.Context, *ethpb.Attest
 	return nil
 }
 
+// InsertSlashingsToForkChoiceStore mocks InsertSlashingsToForkChoiceStore method in chain service.
+func (s *ChainService) InsertSlashingsToForkChoiceStore(_ context.Context, _ []*ethpb.AttesterSlashing) {}
+
 // AttestationPreState mocks AttestationPreState method in chain service.
 func (s *ChainService) AttestationPreState(_ context.Context, _ *ethpb.Attestation) (iface.BeaconState, error) {
 	return s.State, nil
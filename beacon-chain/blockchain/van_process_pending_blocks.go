@@ -10,6 +10,7 @@ import (
 	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
 	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
 	"github.com/prysmaticlabs/prysm/proto/interfaces"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
 	vanTypes "github.com/prysmaticlabs/prysm/shared/params"
 	"time"
 )
@@ -19,6 +20,8 @@ const (
 	confirmationStatusFetchingInterval = 500 * time.Millisecond
 	// maxPendingBlockTryLimit is the maximum limit for pending status of a block
 	maxPendingBlockTryLimit = 40
+	// maxOrcConfirmationsSize is the number of blocks whose orchestrator confirmation is remembered
+	maxOrcConfirmationsSize = 1024
 )
 
 var (
@@ -42,6 +45,20 @@ type orcConfirmationData struct {
 	status        vanTypes.Status
 }
 
+// OrcConfirmation is the last confirmation status given by the orchestrator to a block, along with
+// the block information needed to place it in the block tree, even if it was rejected.
+type OrcConfirmation struct {
+	Slot               types.Slot
+	ParentRoot         [32]byte
+	PandoraBlockNumber uint64
+	Status             vanTypes.Status
+}
+
+// OrcConfirmationFetcher retrieves the orchestrator confirmations of the recently processed blocks.
+type OrcConfirmationFetcher interface {
+	OrcConfirmations() map[[32]byte]*OrcConfirmation
+}
+
 // PendingQueueFetcher interface use when validator calls GetBlock api for proposing new beancon block
 type PendingQueueFetcher interface {
 	CanPropose() bool
@@ -77,6 +94,48 @@ func (s *Service) activateBlockProposal() {
 	s.canPropose = true
 }
 
+// OrcConfirmations returns the orchestrator confirmations of the recently processed blocks, by block root.
+func (s *Service) OrcConfirmations() map[[32]byte]*OrcConfirmation {
+	confirmations := make(map[[32]byte]*OrcConfirmation)
+	if s.orcConfirmations == nil {
+		return confirmations
+	}
+	for _, k := range s.orcConfirmations.Keys() {
+		v, ok := s.orcConfirmations.Peek(k)
+		if !ok {
+			continue
+		}
+		c, ok := v.(*OrcConfirmation)
+		if !ok {
+			continue
+		}
+		cpy := *c
+		confirmations[k.([32]byte)] = &cpy
+	}
+	return confirmations
+}
+
+// saveOrcConfirmation records the confirmation status given by the orchestrator to a block.
+func (s *Service) saveOrcConfirmation(b interfaces.BeaconBlock, status vanTypes.Status) {
+	if s.orcConfirmations == nil {
+		return
+	}
+	root, err := b.HashTreeRoot()
+	if err != nil {
+		log.WithError(err).Error("Could not compute block root to save orchestrator confirmation")
+		return
+	}
+	c := &OrcConfirmation{
+		Slot:       b.Slot(),
+		ParentRoot: bytesutil.ToBytes32(b.ParentRoot()),
+		Status:     status,
+	}
+	if shards := b.Body().PandoraShards(); len(shards) > 0 && shards[0] != nil {
+		c.PandoraBlockNumber = shards[0].BlockNumber
+	}
+	s.orcConfirmations.Add(root, c)
+}
+
 // publishEpochInfo publishes slot and state for publishing epoch info
 func (s *Service) publishEpochInfo(
 	slot types.Slot,
@@ -172,11 +231,13 @@ func (s *Service) waitForConfirmation(b interfaces.SignedBeaconBlock) error {
 			switch status := responseData.status; status {
 			case vanTypes.Verified:
 				commonLog.Debug("got verified status from orchestrator")
+				s.saveOrcConfirmation(b.Block(), status)
 				return nil
 			case vanTypes.Pending:
 				commonLog.Debug("got pending status from orchestrator")
 				pendingBlockTryLimit = pendingBlockTryLimit - 1
 				if pendingBlockTryLimit == 0 {
+					s.saveOrcConfirmation(b.Block(), status)
 					log.WithField("slot", responseData.slot).WithError(errPendingBlockTryLimitExceed).Error(
 						"orchestrator sends pending status for this block so many times, discard this invalid block")
 					return errPendingBlockTryLimitExceed
@@ -184,6 +245,7 @@ func (s *Service) waitForConfirmation(b interfaces.SignedBeaconBlock) error {
 				continue
 			case vanTypes.Invalid:
 				commonLog.Debug("got invalid status from orchestrator, exiting goroutine")
+				s.saveOrcConfirmation(b.Block(), status)
 				return errInvalidBlock
 			default:
 				log.WithError(errUnknownStatus).WithField("slot", responseData.slot).WithField("status", "unknown").Error(
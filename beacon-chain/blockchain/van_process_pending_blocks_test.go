@@ -181,6 +181,13 @@ func TestService_WaitForConfirmation_VerifiedStatus_AfterFewPendingStatus(t *tes
 		exitRoutine <- true
 	}(t)
 	<-exitRoutine
+	root, err := blk.Block().HashTreeRoot()
+	require.NoError(t, err)
+	confirmations := s.OrcConfirmations()
+	require.Equal(t, 1, len(confirmations))
+	require.NotNil(t, confirmations[root])
+	assert.Equal(t, vanTypes.Verified, confirmations[root].Status)
+	assert.Equal(t, types.Slot(15), confirmations[root].Slot)
 }
 
 // Helper method to generate pending queue with random blocks
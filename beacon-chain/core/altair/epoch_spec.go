@@ -0,0 +1,375 @@
+package altair
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/epoch/precompute"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	iface "github.com/prysmaticlabs/prysm/beacon-chain/state/interface"
+	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/mathutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+// ProcessJustificationAndFinalization processes justification and finalization during
+// epoch processing, using participation flags to compute the target balances.
+//
+// Spec code:
+// def process_justification_and_finalization(state: BeaconState) -> None:
+//    # Initial FFG checkpoint values have a `0x00` stub for `root`.
+//    # Skip FFG updates in the first two epochs to avoid corner cases that might result in modifying this stub.
+//    if get_current_epoch(state) <= GENESIS_EPOCH + 1:
+//        return
+//    previous_indices = get_unslashed_participating_indices(state, TIMELY_TARGET_FLAG_INDEX, get_previous_epoch(state))
+//    current_indices = get_unslashed_participating_indices(state, TIMELY_TARGET_FLAG_INDEX, get_current_epoch(state))
+//    total_active_balance = get_total_active_balance(state)
+//    previous_target_balance = get_total_balance(state, previous_indices)
+//    current_target_balance = get_total_balance(state, current_indices)
+//    weigh_justification_and_finalization(state, total_active_balance, previous_target_balance, current_target_balance)
+func ProcessJustificationAndFinalization(state iface.BeaconStateAltair) (iface.BeaconStateAltair, error) {
+	if helpers.CurrentEpoch(state) <= params.BeaconConfig().GenesisEpoch+1 {
+		return state, nil
+	}
+	targetFlag := params.BeaconConfig().TimelyTargetFlagIndex
+	prevIndices, err := UnslashedParticipatingIndices(state, targetFlag, helpers.PrevEpoch(state))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get previous epoch target indices")
+	}
+	currIndices, err := UnslashedParticipatingIndices(state, targetFlag, helpers.CurrentEpoch(state))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get current epoch target indices")
+	}
+	activeBalance, err := helpers.TotalActiveBalance(state)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get total active balance")
+	}
+	bal := &precompute.Balance{
+		ActiveCurrentEpoch:         activeBalance,
+		PrevEpochTargetAttested:    helpers.TotalBalance(state, prevIndices),
+		CurrentEpochTargetAttested: helpers.TotalBalance(state, currIndices),
+	}
+	st, err := precompute.ProcessJustificationAndFinalizationPreCompute(state, bal)
+	if err != nil {
+		return nil, err
+	}
+	return st.(iface.BeaconStateAltair), nil
+}
+
+// ProcessInactivityScores updates the inactivity scores of eligible validators.
+//
+// Spec code:
+// def process_inactivity_updates(state: BeaconState) -> None:
+//    # Score updates based on previous epoch participation, skip genesis epoch
+//    if get_current_epoch(state) == GENESIS_EPOCH:
+//        return
+//
+//    for index in get_eligible_validator_indices(state):
+//        # Increase the inactivity score of inactive validators
+//        if index in get_unslashed_participating_indices(state, TIMELY_TARGET_FLAG_INDEX, get_previous_epoch(state)):
+//            state.inactivity_scores[index] -= min(1, state.inactivity_scores[index])
+//        else:
+//            state.inactivity_scores[index] += INACTIVITY_SCORE_BIAS
+//        # Decrease the inactivity score of all eligible validators during a leak-free epoch
+//        if not is_in_inactivity_leak(state):
+//            state.inactivity_scores[index] -= min(INACTIVITY_SCORE_RECOVERY_RATE, state.inactivity_scores[index])
+func ProcessInactivityScores(state iface.BeaconStateAltair) (iface.BeaconStateAltair, error) {
+	cfg := params.BeaconConfig()
+	if helpers.CurrentEpoch(state) == cfg.GenesisEpoch {
+		return state, nil
+	}
+	scores, err := state.InactivityScores()
+	if err != nil {
+		return nil, err
+	}
+	eligible, err := EligibleValidatorIndices(state)
+	if err != nil {
+		return nil, err
+	}
+	participating, err := unslashedParticipatingSet(state, cfg.TimelyTargetFlagIndex)
+	if err != nil {
+		return nil, err
+	}
+	inLeak := helpers.IsInInactivityLeak(helpers.PrevEpoch(state), state.FinalizedCheckpointEpoch())
+	for _, index := range eligible {
+		if uint64(index) >= uint64(len(scores)) {
+			return nil, errors.Errorf("index %d exceeds inactivity scores length %d", index, len(scores))
+		}
+		if participating[index] {
+			scores[index] -= mathutil.Min(1, scores[index])
+		} else {
+			scores[index] += cfg.InactivityScoreBias
+		}
+		if !inLeak {
+			scores[index] -= mathutil.Min(cfg.InactivityScoreRecoveryRate, scores[index])
+		}
+	}
+	if err := state.SetInactivityScores(scores); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// ProcessRewardsAndPenalties applies the participation flag and inactivity deltas of
+// the previous epoch to validator balances.
+//
+// Spec code:
+// def process_rewards_and_penalties(state: BeaconState) -> None:
+//    # No rewards are applied at the end of `GENESIS_EPOCH` because rewards are for work done in the previous epoch
+//    if get_current_epoch(state) == GENESIS_EPOCH:
+//        return
+//
+//    flag_deltas = [get_flag_index_deltas(state, flag_index) for flag_index in range(len(PARTICIPATION_FLAG_WEIGHTS))]
+//    deltas = flag_deltas + [get_inactivity_penalty_deltas(state)]
+//    for (rewards, penalties) in deltas:
+//        for index in range(len(state.validators)):
+//            increase_balance(state, ValidatorIndex(index), rewards[index])
+//            decrease_balance(state, ValidatorIndex(index), penalties[index])
+func ProcessRewardsAndPenalties(state iface.BeaconStateAltair) (iface.BeaconStateAltair, error) {
+	if helpers.CurrentEpoch(state) == params.BeaconConfig().GenesisEpoch {
+		return state, nil
+	}
+	rewards, penalties, err := AttestationsDelta(state)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get attestation deltas")
+	}
+	balances := state.Balances()
+	if len(balances) != len(rewards) || len(balances) != len(penalties) {
+		return nil, errors.Errorf("balances length %d does not match deltas length", len(balances))
+	}
+	for i := range balances {
+		balances[i] = helpers.IncreaseBalanceWithVal(balances[i], rewards[i])
+		balances[i] = helpers.DecreaseBalanceWithVal(balances[i], penalties[i])
+	}
+	if err := state.SetBalances(balances); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// AttestationsDelta sums up the flag index deltas and inactivity penalty deltas of every validator.
+//
+// Spec code:
+// def get_flag_index_deltas(state: BeaconState, flag_index: int) -> Tuple[Sequence[Gwei], Sequence[Gwei]]:
+//    """
+//    Return the deltas for a given ``flag_index`` by scanning through the participation flags.
+//    """
+//    rewards = [Gwei(0)] * len(state.validators)
+//    penalties = [Gwei(0)] * len(state.validators)
+//    previous_epoch = get_previous_epoch(state)
+//    unslashed_participating_indices = get_unslashed_participating_indices(state, flag_index, previous_epoch)
+//    weight = PARTICIPATION_FLAG_WEIGHTS[flag_index]
+//    unslashed_participating_balance = get_total_balance(state, unslashed_participating_indices)
+//    unslashed_participating_increments = unslashed_participating_balance // EFFECTIVE_BALANCE_INCREMENT
+//    active_increments = get_total_active_balance(state) // EFFECTIVE_BALANCE_INCREMENT
+//    for index in get_eligible_validator_indices(state):
+//        base_reward = get_base_reward(state, index)
+//        if index in unslashed_participating_indices:
+//            if not is_in_inactivity_leak(state):
+//                reward_numerator = base_reward * weight * unslashed_participating_increments
+//                rewards[index] += Gwei(reward_numerator // (active_increments * WEIGHT_DENOMINATOR))
+//        elif flag_index != TIMELY_HEAD_FLAG_INDEX:
+//            penalties[index] += Gwei(base_reward * weight // WEIGHT_DENOMINATOR)
+//    return rewards, penalties
+//
+// def get_inactivity_penalty_deltas(state: BeaconState) -> Tuple[Sequence[Gwei], Sequence[Gwei]]:
+//    """
+//    Return the inactivity penalty deltas by considering timely target participation flags and inactivity scores.
+//    """
+//    rewards = [Gwei(0) for _ in range(len(state.validators))]
+//    penalties = [Gwei(0) for _ in range(len(state.validators))]
+//    previous_epoch = get_previous_epoch(state)
+//    matching_target_indices = get_unslashed_participating_indices(state, TIMELY_TARGET_FLAG_INDEX, previous_epoch)
+//    for index in get_eligible_validator_indices(state):
+//        if index not in matching_target_indices:
+//            penalty_numerator = state.validators[index].effective_balance * state.inactivity_scores[index]
+//            penalty_denominator = INACTIVITY_SCORE_BIAS * INACTIVITY_PENALTY_QUOTIENT_ALTAIR
+//            penalties[index] += Gwei(penalty_numerator // penalty_denominator)
+//    return rewards, penalties
+func AttestationsDelta(state iface.BeaconStateAltair) ([]uint64, []uint64, error) {
+	cfg := params.BeaconConfig()
+	numValidators := state.NumValidators()
+	rewards := make([]uint64, numValidators)
+	penalties := make([]uint64, numValidators)
+
+	activeBalance, err := helpers.TotalActiveBalance(state)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not get total active balance")
+	}
+	eligible, err := EligibleValidatorIndices(state)
+	if err != nil {
+		return nil, nil, err
+	}
+	scores, err := state.InactivityScores()
+	if err != nil {
+		return nil, nil, err
+	}
+	inLeak := helpers.IsInInactivityLeak(helpers.PrevEpoch(state), state.FinalizedCheckpointEpoch())
+	activeIncrements := activeBalance / cfg.EffectiveBalanceIncrement
+
+	flags := []uint8{cfg.TimelySourceFlagIndex, cfg.TimelyTargetFlagIndex, cfg.TimelyHeadFlagIndex}
+	weights := []uint64{cfg.TimelySourceWeight, cfg.TimelyTargetWeight, cfg.TimelyHeadWeight}
+	var targetParticipating map[types.ValidatorIndex]bool
+	for i, flag := range flags {
+		indices, err := UnslashedParticipatingIndices(state, flag, helpers.PrevEpoch(state))
+		if err != nil {
+			return nil, nil, err
+		}
+		participating := indicesSet(indices)
+		if flag == cfg.TimelyTargetFlagIndex {
+			targetParticipating = participating
+		}
+		participatingBalance := helpers.TotalBalance(state, indices)
+		participatingIncrements := participatingBalance / cfg.EffectiveBalanceIncrement
+
+		for _, index := range eligible {
+			val, err := state.ValidatorAtIndexReadOnly(index)
+			if err != nil {
+				return nil, nil, err
+			}
+			baseReward, err := BaseReward(val.EffectiveBalance(), activeBalance)
+			if err != nil {
+				return nil, nil, err
+			}
+			if participating[index] {
+				if !inLeak {
+					rewardNumerator := baseReward * weights[i] * participatingIncrements
+					rewards[index] += rewardNumerator / (activeIncrements * cfg.WeightDenominator)
+				}
+			} else if flag != cfg.TimelyHeadFlagIndex {
+				penalties[index] += baseReward * weights[i] / cfg.WeightDenominator
+			}
+		}
+	}
+
+	for _, index := range eligible {
+		if targetParticipating[index] {
+			continue
+		}
+		val, err := state.ValidatorAtIndexReadOnly(index)
+		if err != nil {
+			return nil, nil, err
+		}
+		penaltyNumerator := val.EffectiveBalance() * scores[index]
+		penaltyDenominator := cfg.InactivityScoreBias * cfg.InactivityPenaltyQuotientAltair
+		penalties[index] += penaltyNumerator / penaltyDenominator
+	}
+	return rewards, penalties, nil
+}
+
+// ProcessSlashings processes the slashed validators during epoch processing,
+// using the Altair proportional slashing multiplier.
+//
+// Spec code:
+// def process_slashings(state: BeaconState) -> None:
+//    epoch = get_current_epoch(state)
+//    total_balance = get_total_active_balance(state)
+//    adjusted_total_slashing_balance = min(
+//        sum(state.slashings) * PROPORTIONAL_SLASHING_MULTIPLIER_ALTAIR,
+//        total_balance
+//    )
+//    for index, validator in enumerate(state.validators):
+//        if validator.slashed and epoch + EPOCHS_PER_SLASHINGS_VECTOR // 2 == validator.withdrawable_epoch:
+//            increment = EFFECTIVE_BALANCE_INCREMENT  # Factored out from penalty numerator to avoid uint64 overflow
+//            penalty_numerator = validator.effective_balance // increment * adjusted_total_slashing_balance
+//            penalty = penalty_numerator // total_balance * increment
+//            decrease_balance(state, ValidatorIndex(index), penalty)
+func ProcessSlashings(state iface.BeaconStateAltair) (iface.BeaconStateAltair, error) {
+	currentEpoch := helpers.CurrentEpoch(state)
+	totalBalance, err := helpers.TotalActiveBalance(state)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get total active balance")
+	}
+
+	exitLength := params.BeaconConfig().EpochsPerSlashingsVector
+	totalSlashing := uint64(0)
+	for _, slashing := range state.Slashings() {
+		totalSlashing += slashing
+	}
+
+	increment := params.BeaconConfig().EffectiveBalanceIncrement
+	minSlashing := mathutil.Min(totalSlashing*params.BeaconConfig().ProportionalSlashingMultiplierAltair, totalBalance)
+	err = state.ApplyToEveryValidator(func(idx int, val *ethpb.Validator) (bool, *ethpb.Validator, error) {
+		correctEpoch := (currentEpoch + exitLength/2) == val.WithdrawableEpoch
+		if val.Slashed && correctEpoch {
+			penaltyNumerator := val.EffectiveBalance / increment * minSlashing
+			penalty := penaltyNumerator / totalBalance * increment
+			if err := helpers.DecreaseBalance(state, types.ValidatorIndex(idx), penalty); err != nil {
+				return false, val, err
+			}
+			return true, val, nil
+		}
+		return false, val, nil
+	})
+	return state, err
+}
+
+// ProcessParticipationFlagUpdates rotates the current epoch participation into the
+// previous epoch participation and resets the current epoch participation.
+//
+// Spec code:
+// def process_participation_flag_updates(state: BeaconState) -> None:
+//    state.previous_epoch_participation = state.current_epoch_participation
+//    state.current_epoch_participation = [ParticipationFlags(0b0000_0000) for _ in range(len(state.validators))]
+func ProcessParticipationFlagUpdates(state iface.BeaconStateAltair) (iface.BeaconStateAltair, error) {
+	currentParticipation, err := state.CurrentEpochParticipation()
+	if err != nil {
+		return nil, err
+	}
+	if err := state.SetPreviousParticipationBits(currentParticipation); err != nil {
+		return nil, err
+	}
+	if err := state.SetCurrentParticipationBits(make([]byte, state.NumValidators())); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// ProcessSyncCommitteeUpdates rotates the sync committees at the end of a sync committee period.
+//
+// Spec code:
+// def process_sync_committee_updates(state: BeaconState) -> None:
+//    next_epoch = get_current_epoch(state) + Epoch(1)
+//    if next_epoch % EPOCHS_PER_SYNC_COMMITTEE_PERIOD == 0:
+//        state.current_sync_committee = state.next_sync_committee
+//        state.next_sync_committee = get_next_sync_committee(state)
+func ProcessSyncCommitteeUpdates(ctx context.Context, state iface.BeaconStateAltair) (iface.BeaconStateAltair, error) {
+	nextEpoch := helpers.NextEpoch(state)
+	if nextEpoch%params.BeaconConfig().EpochsPerSyncCommitteePeriod != 0 {
+		return state, nil
+	}
+	nextCommittee, err := state.NextSyncCommittee()
+	if err != nil {
+		return nil, err
+	}
+	if err := state.SetCurrentSyncCommittee(nextCommittee); err != nil {
+		return nil, err
+	}
+	committee, err := NextSyncCommittee(ctx, state)
+	if err != nil {
+		return nil, err
+	}
+	if err := state.SetNextSyncCommittee(committee); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// unslashedParticipatingSet returns the unslashed validators of the previous epoch
+// which have the given participation flag set, as a set.
+func unslashedParticipatingSet(state iface.BeaconStateAltair, flagIndex uint8) (map[types.ValidatorIndex]bool, error) {
+	indices, err := UnslashedParticipatingIndices(state, flagIndex, helpers.PrevEpoch(state))
+	if err != nil {
+		return nil, err
+	}
+	return indicesSet(indices), nil
+}
+
+func indicesSet(indices []types.ValidatorIndex) map[types.ValidatorIndex]bool {
+	set := make(map[types.ValidatorIndex]bool, len(indices))
+	for _, index := range indices {
+		set[index] = true
+	}
+	return set
+}
@@ -0,0 +1,150 @@
+package altair_test
+
+import (
+	"context"
+	"testing"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/altair"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	iface "github.com/prysmaticlabs/prysm/beacon-chain/state/interface"
+	stateAltair "github.com/prysmaticlabs/prysm/beacon-chain/state/v2"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func testAltairState(t *testing.T, numValidators uint64, slot types.Slot) iface.BeaconStateAltair {
+	// Active indices are cached by seed, which is the same for all of these states.
+	helpers.ClearCache()
+	cfg := params.BeaconConfig()
+	validators := make([]*ethpb.Validator, numValidators)
+	balances := make([]uint64, numValidators)
+	for i := range validators {
+		validators[i] = &ethpb.Validator{
+			PublicKey:         bytesutil.PadTo(bytesutil.Bytes8(uint64(i)), 48),
+			EffectiveBalance:  cfg.MaxEffectiveBalance,
+			ExitEpoch:         cfg.FarFutureEpoch,
+			WithdrawableEpoch: cfg.FarFutureEpoch,
+		}
+		balances[i] = cfg.MaxEffectiveBalance
+	}
+	st, err := stateAltair.InitializeFromProto(&pb.BeaconStateAltair{
+		Slot:                        slot,
+		Fork:                        &pb.Fork{PreviousVersion: cfg.GenesisForkVersion, CurrentVersion: cfg.AltairForkVersion},
+		Validators:                  validators,
+		Balances:                    balances,
+		BlockRoots:                  make([][]byte, cfg.SlotsPerHistoricalRoot),
+		StateRoots:                  make([][]byte, cfg.SlotsPerHistoricalRoot),
+		RandaoMixes:                 make([][]byte, cfg.EpochsPerHistoricalVector),
+		Slashings:                   make([]uint64, cfg.EpochsPerSlashingsVector),
+		JustificationBits:           []byte{0},
+		PreviousJustifiedCheckpoint: &ethpb.Checkpoint{Root: make([]byte, 32)},
+		CurrentJustifiedCheckpoint:  &ethpb.Checkpoint{Root: make([]byte, 32)},
+		FinalizedCheckpoint:         &ethpb.Checkpoint{Root: make([]byte, 32)},
+		PreviousEpochParticipation:  make([]byte, numValidators),
+		CurrentEpochParticipation:   make([]byte, numValidators),
+		InactivityScores:            make([]uint64, numValidators),
+	})
+	require.NoError(t, err)
+	return st
+}
+
+func TestValidatorFlags(t *testing.T) {
+	cfg := params.BeaconConfig()
+	flag := uint8(0)
+	assert.Equal(t, false, altair.HasValidatorFlag(flag, cfg.TimelySourceFlagIndex))
+	flag = altair.AddValidatorFlag(flag, cfg.TimelySourceFlagIndex)
+	flag = altair.AddValidatorFlag(flag, cfg.TimelyHeadFlagIndex)
+	assert.Equal(t, true, altair.HasValidatorFlag(flag, cfg.TimelySourceFlagIndex))
+	assert.Equal(t, false, altair.HasValidatorFlag(flag, cfg.TimelyTargetFlagIndex))
+	assert.Equal(t, true, altair.HasValidatorFlag(flag, cfg.TimelyHeadFlagIndex))
+}
+
+func TestUnslashedParticipatingIndices(t *testing.T) {
+	cfg := params.BeaconConfig()
+	st := testAltairState(t, 8, cfg.SlotsPerEpoch*2)
+	targetFlag := altair.AddValidatorFlag(0, cfg.TimelyTargetFlagIndex)
+	require.NoError(t, st.SetPreviousParticipationBits([]byte{targetFlag, 0, targetFlag, targetFlag, 0, 0, 0, targetFlag}))
+	val, err := st.ValidatorAtIndex(3)
+	require.NoError(t, err)
+	val.Slashed = true
+	require.NoError(t, st.UpdateValidatorAtIndex(3, val))
+
+	indices, err := altair.UnslashedParticipatingIndices(st, cfg.TimelyTargetFlagIndex, 1)
+	require.NoError(t, err)
+	assert.DeepEqual(t, []types.ValidatorIndex{0, 2, 7}, indices)
+
+	indices, err = altair.UnslashedParticipatingIndices(st, cfg.TimelyTargetFlagIndex, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(indices))
+
+	_, err = altair.UnslashedParticipatingIndices(st, cfg.TimelyTargetFlagIndex, 0)
+	assert.ErrorContains(t, "is not current epoch", err)
+}
+
+func TestProcessInactivityScores(t *testing.T) {
+	cfg := params.BeaconConfig()
+	st := testAltairState(t, 4, cfg.SlotsPerEpoch*2)
+	targetFlag := altair.AddValidatorFlag(0, cfg.TimelyTargetFlagIndex)
+	require.NoError(t, st.SetPreviousParticipationBits([]byte{targetFlag, 0, targetFlag, 0}))
+	require.NoError(t, st.SetInactivityScores([]uint64{10, 10, 0, 100}))
+
+	st, err := altair.ProcessInactivityScores(st)
+	require.NoError(t, err)
+	scores, err := st.InactivityScores()
+	require.NoError(t, err)
+	// Not in an inactivity leak, so every score also recovers by the recovery rate.
+	bias := cfg.InactivityScoreBias
+	recovery := cfg.InactivityScoreRecoveryRate
+	assert.DeepEqual(t, []uint64{0, 0, 0, 100 + bias - recovery}, scores)
+}
+
+func TestProcessRewardsAndPenalties(t *testing.T) {
+	cfg := params.BeaconConfig()
+	st := testAltairState(t, 4, cfg.SlotsPerEpoch*2)
+	allFlags := uint8(0)
+	for _, f := range []uint8{cfg.TimelySourceFlagIndex, cfg.TimelyTargetFlagIndex, cfg.TimelyHeadFlagIndex} {
+		allFlags = altair.AddValidatorFlag(allFlags, f)
+	}
+	require.NoError(t, st.SetPreviousParticipationBits([]byte{allFlags, allFlags, allFlags, 0}))
+
+	st, err := altair.ProcessRewardsAndPenalties(st)
+	require.NoError(t, err)
+	balances := st.Balances()
+	assert.Equal(t, true, balances[0] > cfg.MaxEffectiveBalance, "Participating validator was not rewarded")
+	assert.Equal(t, balances[0], balances[1])
+	assert.Equal(t, true, balances[3] < cfg.MaxEffectiveBalance, "Non participating validator was not penalized")
+}
+
+func TestProcessParticipationFlagUpdates(t *testing.T) {
+	st := testAltairState(t, 3, 0)
+	require.NoError(t, st.SetCurrentParticipationBits([]byte{1, 2, 3}))
+
+	st, err := altair.ProcessParticipationFlagUpdates(st)
+	require.NoError(t, err)
+	prev, err := st.PreviousEpochParticipation()
+	require.NoError(t, err)
+	assert.DeepEqual(t, []byte{1, 2, 3}, prev)
+	curr, err := st.CurrentEpochParticipation()
+	require.NoError(t, err)
+	assert.DeepEqual(t, []byte{0, 0, 0}, curr)
+}
+
+func TestNextSyncCommitteeIndices(t *testing.T) {
+	st := testAltairState(t, 64, 0)
+	indices, err := altair.NextSyncCommitteeIndices(context.Background(), st)
+	require.NoError(t, err)
+	assert.Equal(t, params.BeaconConfig().SyncCommitteeSize, uint64(len(indices)))
+	for _, index := range indices {
+		assert.Equal(t, true, index < 64, "Index %d out of range", index)
+	}
+
+	// The committee is derived from the state alone.
+	again, err := altair.NextSyncCommitteeIndices(context.Background(), st)
+	require.NoError(t, err)
+	assert.DeepEqual(t, indices, again)
+}
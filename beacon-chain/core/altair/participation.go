@@ -0,0 +1,199 @@
+package altair
+
+import (
+	"bytes"
+
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	iface "github.com/prysmaticlabs/prysm/beacon-chain/state/interface"
+	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/mathutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+// HasValidatorFlag returns true if the flag at position has set.
+func HasValidatorFlag(flag, flagPosition uint8) bool {
+	return ((flag >> flagPosition) & 1) == 1
+}
+
+// AddValidatorFlag adds new validator flag to existing one.
+func AddValidatorFlag(flag, flagPosition uint8) uint8 {
+	return flag | (1 << flagPosition)
+}
+
+// AttestationParticipationFlagIndices retrieves a validator's participation flag indices
+// for an attestation included with the given inclusion delay.
+//
+// Spec code:
+// def get_attestation_participation_flag_indices(state: BeaconState,
+//                                                data: AttestationData,
+//                                                inclusion_delay: uint64) -> Sequence[int]:
+//    """
+//    Return the flag indices that are satisfied by an attestation.
+//    """
+//    if data.target.epoch == get_current_epoch(state):
+//        justified_checkpoint = state.current_justified_checkpoint
+//    else:
+//        justified_checkpoint = state.previous_justified_checkpoint
+//
+//    # Matching roots
+//    is_matching_source = data.source == justified_checkpoint
+//    is_matching_target = is_matching_source and data.target.root == get_block_root(state, data.target.epoch)
+//    is_matching_head = is_matching_target and data.beacon_block_root == get_block_root_at_slot(state, data.slot)
+//    assert is_matching_source
+//
+//    participation_flag_indices = []
+//    if is_matching_source and inclusion_delay <= integer_squareroot(SLOTS_PER_EPOCH):
+//        participation_flag_indices.append(TIMELY_SOURCE_FLAG_INDEX)
+//    if is_matching_target and inclusion_delay <= SLOTS_PER_EPOCH:
+//        participation_flag_indices.append(TIMELY_TARGET_FLAG_INDEX)
+//    if is_matching_head and inclusion_delay == MIN_ATTESTATION_INCLUSION_DELAY:
+//        participation_flag_indices.append(TIMELY_HEAD_FLAG_INDEX)
+//
+//    return participation_flag_indices
+func AttestationParticipationFlagIndices(state iface.ReadOnlyBeaconState, data *ethpb.AttestationData, delay types.Slot) ([]uint8, error) {
+	currEpoch := helpers.CurrentEpoch(state)
+	var justifiedCheckpoint *ethpb.Checkpoint
+	if data.Target.Epoch == currEpoch {
+		justifiedCheckpoint = state.CurrentJustifiedCheckpoint()
+	} else {
+		justifiedCheckpoint = state.PreviousJustifiedCheckpoint()
+	}
+
+	matchedSrc := data.Source.Epoch == justifiedCheckpoint.Epoch && bytes.Equal(data.Source.Root, justifiedCheckpoint.Root)
+	if !matchedSrc {
+		return nil, errors.New("source epoch does not match")
+	}
+	targetRoot, err := helpers.BlockRoot(state, data.Target.Epoch)
+	if err != nil {
+		return nil, err
+	}
+	matchedTgt := matchedSrc && bytes.Equal(data.Target.Root, targetRoot)
+	headRoot, err := helpers.BlockRootAtSlot(state, data.Slot)
+	if err != nil {
+		return nil, err
+	}
+	matchedHead := matchedTgt && bytes.Equal(data.BeaconBlockRoot, headRoot)
+
+	cfg := params.BeaconConfig()
+	participatedFlags := make([]uint8, 0, 3)
+	sqrtOfSlotsPerEpoch := types.Slot(mathutil.IntegerSquareRoot(uint64(cfg.SlotsPerEpoch)))
+	if matchedSrc && delay <= sqrtOfSlotsPerEpoch {
+		participatedFlags = append(participatedFlags, cfg.TimelySourceFlagIndex)
+	}
+	if matchedTgt && delay <= cfg.SlotsPerEpoch {
+		participatedFlags = append(participatedFlags, cfg.TimelyTargetFlagIndex)
+	}
+	if matchedHead && delay == cfg.MinAttestationInclusionDelay {
+		participatedFlags = append(participatedFlags, cfg.TimelyHeadFlagIndex)
+	}
+	return participatedFlags, nil
+}
+
+// UnslashedParticipatingIndices returns set of currently unslashed participating indexes.
+//
+// Spec code:
+// def get_unslashed_participating_indices(state: BeaconState, flag_index: int, epoch: Epoch) -> Set[ValidatorIndex]:
+//    """
+//    Return the set of validator indices that are both active and unslashed for the given ``flag_index`` and ``epoch``.
+//    """
+//    assert epoch in (get_previous_epoch(state), get_current_epoch(state))
+//    if epoch == get_current_epoch(state):
+//        epoch_participation = state.current_epoch_participation
+//    else:
+//        epoch_participation = state.previous_epoch_participation
+//    active_validator_indices = get_active_validator_indices(state, epoch)
+//    participating_indices = [i for i in active_validator_indices if has_flag(epoch_participation[i], flag_index)]
+//    return set(filter(lambda index: not state.validators[index].slashed, participating_indices))
+func UnslashedParticipatingIndices(state iface.BeaconStateAltair, flagIndex uint8, epoch types.Epoch) ([]types.ValidatorIndex, error) {
+	currentEpoch := helpers.CurrentEpoch(state)
+	previousEpoch := helpers.PrevEpoch(state)
+	if epoch != currentEpoch && epoch != previousEpoch {
+		return nil, errors.Errorf("epoch %d is not current epoch %d or previous epoch %d", epoch, currentEpoch, previousEpoch)
+	}
+	var epochParticipation []byte
+	var err error
+	if epoch == currentEpoch {
+		epochParticipation, err = state.CurrentEpochParticipation()
+	} else {
+		epochParticipation, err = state.PreviousEpochParticipation()
+	}
+	if err != nil {
+		return nil, err
+	}
+	activeIndices, err := helpers.ActiveValidatorIndices(state, epoch)
+	if err != nil {
+		return nil, err
+	}
+	indices := make([]types.ValidatorIndex, 0, len(activeIndices))
+	for _, index := range activeIndices {
+		if uint64(index) >= uint64(len(epochParticipation)) {
+			return nil, errors.Errorf("index %d exceeds participation length %d", index, len(epochParticipation))
+		}
+		if !HasValidatorFlag(epochParticipation[index], flagIndex) {
+			continue
+		}
+		val, err := state.ValidatorAtIndexReadOnly(index)
+		if err != nil {
+			return nil, err
+		}
+		if !val.Slashed() {
+			indices = append(indices, index)
+		}
+	}
+	return indices, nil
+}
+
+// EligibleValidatorIndices returns the indices of validators eligible for rewards and penalties
+// in the previous epoch.
+//
+// Spec code:
+// def get_eligible_validator_indices(state: BeaconState) -> Sequence[ValidatorIndex]:
+//    previous_epoch = get_previous_epoch(state)
+//    return [
+//        ValidatorIndex(index) for index, v in enumerate(state.validators)
+//        if is_active_validator(v, previous_epoch) or (v.slashed and previous_epoch + 1 < v.withdrawable_epoch)
+//    ]
+func EligibleValidatorIndices(state iface.ReadOnlyBeaconState) ([]types.ValidatorIndex, error) {
+	previousEpoch := helpers.PrevEpoch(state)
+	indices := make([]types.ValidatorIndex, 0, state.NumValidators())
+	err := state.ReadFromEveryValidator(func(idx int, val iface.ReadOnlyValidator) error {
+		if helpers.IsActiveValidatorUsingTrie(val, previousEpoch) || (val.Slashed() && previousEpoch+1 < val.WithdrawableEpoch()) {
+			indices = append(indices, types.ValidatorIndex(idx))
+		}
+		return nil
+	})
+	return indices, err
+}
+
+// BaseRewardPerIncrement returns the base reward per effective balance increment.
+//
+// Spec code:
+// def get_base_reward_per_increment(state: BeaconState) -> Gwei:
+//    return Gwei(EFFECTIVE_BALANCE_INCREMENT * BASE_REWARD_FACTOR // integer_squareroot(get_total_active_balance(state)))
+func BaseRewardPerIncrement(activeBalance uint64) (uint64, error) {
+	if activeBalance == 0 {
+		return 0, errors.New("active balance can't be 0")
+	}
+	cfg := params.BeaconConfig()
+	return cfg.EffectiveBalanceIncrement * cfg.BaseRewardFactor / mathutil.IntegerSquareRoot(activeBalance), nil
+}
+
+// BaseReward returns the base reward of a validator with the given effective balance.
+//
+// Spec code:
+// def get_base_reward(state: BeaconState, index: ValidatorIndex) -> Gwei:
+//    """
+//    Return the base reward for the validator defined by ``index`` with respect to the current ``state``.
+//    """
+//    increments = state.validators[index].effective_balance // EFFECTIVE_BALANCE_INCREMENT
+//    return Gwei(increments * get_base_reward_per_increment(state))
+func BaseReward(effectiveBalance, activeBalance uint64) (uint64, error) {
+	rewardPerIncrement, err := BaseRewardPerIncrement(activeBalance)
+	if err != nil {
+		return 0, err
+	}
+	increments := effectiveBalance / params.BeaconConfig().EffectiveBalanceIncrement
+	return increments * rewardPerIncrement, nil
+}
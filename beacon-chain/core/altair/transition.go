@@ -0,0 +1,84 @@
+package altair
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	e "github.com/prysmaticlabs/prysm/beacon-chain/core/epoch"
+	iface "github.com/prysmaticlabs/prysm/beacon-chain/state/interface"
+	"go.opencensus.io/trace"
+)
+
+// ProcessEpoch describes the per epoch operations that are performed on the beacon state.
+//
+// Spec code:
+// def process_epoch(state: BeaconState) -> None:
+//    process_justification_and_finalization(state)  # [Modified in Altair]
+//    process_inactivity_updates(state)  # [New in Altair]
+//    process_rewards_and_penalties(state)  # [Modified in Altair]
+//    process_registry_updates(state)
+//    process_slashings(state)  # [Modified in Altair]
+//    process_eth1_data_reset(state)
+//    process_effective_balance_updates(state)
+//    process_slashings_reset(state)
+//    process_randao_mixes_reset(state)
+//    process_historical_roots_update(state)
+//    process_participation_flag_updates(state)  # [New in Altair]
+//    process_sync_committee_updates(state)  # [New in Altair]
+func ProcessEpoch(ctx context.Context, state iface.BeaconStateAltair) (iface.BeaconStateAltair, error) {
+	ctx, span := trace.StartSpan(ctx, "altair.ProcessEpoch")
+	defer span.End()
+
+	if state == nil || state.IsNil() {
+		return nil, errors.New("nil state")
+	}
+	state, err := ProcessJustificationAndFinalization(state)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not process justification")
+	}
+	state, err = ProcessInactivityScores(state)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not process inactivity updates")
+	}
+	state, err = ProcessRewardsAndPenalties(state)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not process rewards and penalties")
+	}
+	st, err := e.ProcessRegistryUpdates(state)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not process registry updates")
+	}
+	state, err = ProcessSlashings(st.(iface.BeaconStateAltair))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not process slashings")
+	}
+	st, err = e.ProcessEth1DataReset(state)
+	if err != nil {
+		return nil, err
+	}
+	st, err = e.ProcessEffectiveBalanceUpdates(st)
+	if err != nil {
+		return nil, err
+	}
+	st, err = e.ProcessSlashingsReset(st)
+	if err != nil {
+		return nil, err
+	}
+	st, err = e.ProcessRandaoMixesReset(st)
+	if err != nil {
+		return nil, err
+	}
+	st, err = e.ProcessHistoricalRootsUpdate(st)
+	if err != nil {
+		return nil, err
+	}
+	state, err = ProcessParticipationFlagUpdates(st.(iface.BeaconStateAltair))
+	if err != nil {
+		return nil, err
+	}
+	state, err = ProcessSyncCommitteeUpdates(ctx, state)
+	if err != nil {
+		return nil, err
+	}
+	return state, nil
+}
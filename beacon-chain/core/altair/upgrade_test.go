@@ -0,0 +1,54 @@
+package altair_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/altair"
+	"github.com/prysmaticlabs/prysm/shared/interfaces/version"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func TestUpgradeToAltair(t *testing.T) {
+	st, _ := testutil.DeterministicGenesisState(t, params.BeaconConfig().MaxValidatorsPerCommittee)
+	preForkState := st.Copy()
+	aState, err := altair.UpgradeToAltair(context.Background(), st)
+	require.NoError(t, err)
+	assert.Equal(t, version.Altair, aState.Version())
+
+	assert.Equal(t, preForkState.GenesisTime(), aState.GenesisTime())
+	assert.DeepSSZEqual(t, preForkState.GenesisValidatorRoot(), aState.GenesisValidatorRoot())
+	assert.Equal(t, preForkState.Slot(), aState.Slot())
+	assert.DeepSSZEqual(t, preForkState.LatestBlockHeader(), aState.LatestBlockHeader())
+	assert.DeepSSZEqual(t, preForkState.BlockRoots(), aState.BlockRoots())
+	assert.DeepSSZEqual(t, preForkState.StateRoots(), aState.StateRoots())
+	assert.DeepSSZEqual(t, preForkState.Validators(), aState.Validators())
+	assert.DeepSSZEqual(t, preForkState.Balances(), aState.Balances())
+	assert.DeepSSZEqual(t, preForkState.RandaoMixes(), aState.RandaoMixes())
+	assert.DeepSSZEqual(t, preForkState.FinalizedCheckpoint(), aState.FinalizedCheckpoint())
+
+	numValidators := aState.NumValidators()
+	p, err := aState.PreviousEpochParticipation()
+	require.NoError(t, err)
+	assert.DeepSSZEqual(t, make([]byte, numValidators), p)
+	p, err = aState.CurrentEpochParticipation()
+	require.NoError(t, err)
+	assert.DeepSSZEqual(t, make([]byte, numValidators), p)
+	s, err := aState.InactivityScores()
+	require.NoError(t, err)
+	assert.DeepSSZEqual(t, make([]uint64, numValidators), s)
+
+	f := aState.Fork()
+	assert.DeepSSZEqual(t, preForkState.Fork().CurrentVersion, f.PreviousVersion)
+	assert.DeepSSZEqual(t, params.BeaconConfig().AltairForkVersion, f.CurrentVersion)
+
+	csc, err := aState.CurrentSyncCommittee()
+	require.NoError(t, err)
+	nsc, err := aState.NextSyncCommittee()
+	require.NoError(t, err)
+	assert.DeepSSZEqual(t, csc, nsc)
+	assert.Equal(t, params.BeaconConfig().SyncCommitteeSize, uint64(len(csc.Pubkeys)))
+}
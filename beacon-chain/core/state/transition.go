@@ -11,6 +11,7 @@ import (
 	"github.com/pkg/errors"
 	types "github.com/prysmaticlabs/eth2-types"
 	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/altair"
 	b "github.com/prysmaticlabs/prysm/beacon-chain/core/blocks"
 	e "github.com/prysmaticlabs/prysm/beacon-chain/core/epoch"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/epoch/precompute"
@@ -18,6 +19,7 @@ import (
 	v "github.com/prysmaticlabs/prysm/beacon-chain/core/validators"
 	iface "github.com/prysmaticlabs/prysm/beacon-chain/state/interface"
 	"github.com/prysmaticlabs/prysm/proto/interfaces"
+	"github.com/prysmaticlabs/prysm/shared/interfaces/version"
 	"github.com/prysmaticlabs/prysm/shared/mathutil"
 	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/prysmaticlabs/prysm/shared/traceutil"
@@ -272,16 +274,39 @@ func ProcessSlots(ctx context.Context, state iface.BeaconState, slot types.Slot)
 			return nil, errors.Wrap(err, "could not process slot")
 		}
 		if CanProcessEpoch(state) {
-			state, err = ProcessEpochPrecompute(ctx, state)
-			if err != nil {
-				traceutil.AnnotateError(span, err)
-				return nil, errors.Wrap(err, "could not process epoch with optimizations")
+			switch state.Version() {
+			case version.Phase0:
+				state, err = ProcessEpochPrecompute(ctx, state)
+				if err != nil {
+					traceutil.AnnotateError(span, err)
+					return nil, errors.Wrap(err, "could not process epoch with optimizations")
+				}
+			case version.Altair:
+				altairState, ok := state.(iface.BeaconStateAltair)
+				if !ok {
+					return nil, errors.New("state is not an altair beacon state")
+				}
+				state, err = altair.ProcessEpoch(ctx, altairState)
+				if err != nil {
+					traceutil.AnnotateError(span, err)
+					return nil, errors.Wrap(err, "could not process epoch")
+				}
+			default:
+				return nil, errors.Errorf("unsupported beacon state version %d", state.Version())
 			}
 		}
 		if err := state.SetSlot(state.Slot() + 1); err != nil {
 			traceutil.AnnotateError(span, err)
 			return nil, errors.Wrap(err, "failed to increment state slot")
 		}
+
+		if CanUpgradeToAltair(state) {
+			state, err = altair.UpgradeToAltair(ctx, state)
+			if err != nil {
+				traceutil.AnnotateError(span, err)
+				return nil, errors.Wrap(err, "could not upgrade state to altair")
+			}
+		}
 	}
 
 	if highestSlot < state.Slot() {
@@ -317,6 +342,9 @@ func ProcessBlock(
 	if err = helpers.VerifyNilBeaconBlock(signed); err != nil {
 		return nil, err
 	}
+	if err := verifyPhase0State(state); err != nil {
+		return nil, err
+	}
 
 	for _, p := range processingPipeline {
 		state, err = p(ctx, state, signed)
@@ -392,6 +420,26 @@ func CanProcessEpoch(state iface.ReadOnlyBeaconState) bool {
 	return (state.Slot()+1)%params.BeaconConfig().SlotsPerEpoch == 0
 }
 
+// ErrAltairNotSupported is returned when a block is processed against an Altair state,
+// as only phase 0 block processing is implemented.
+var ErrAltairNotSupported = errors.New("altair block processing is not supported")
+
+// verifyPhase0State returns an error if the state has been upgraded past phase 0.
+func verifyPhase0State(state iface.BeaconState) error {
+	if state.Version() != version.Phase0 {
+		return errors.Wrapf(ErrAltairNotSupported, "state version %d", state.Version())
+	}
+	return nil
+}
+
+// CanUpgradeToAltair returns true if the state is a phase 0 state at the
+// first slot of the Altair fork epoch.
+func CanUpgradeToAltair(state iface.BeaconState) bool {
+	return state.Version() == version.Phase0 &&
+		helpers.IsEpochStart(state.Slot()) &&
+		helpers.SlotToEpoch(state.Slot()) == params.BeaconConfig().AltairForkEpoch
+}
+
 // ProcessEpochPrecompute describes the per epoch operations that are performed on the beacon state.
 // It's optimized by pre computing validator attested info and epoch total/attested balances upfront.
 func ProcessEpochPrecompute(ctx context.Context, state iface.BeaconState) (iface.BeaconState, error) {
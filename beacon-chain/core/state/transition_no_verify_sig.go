@@ -276,6 +276,9 @@ func ProcessBlockForStateRoot(
 	if err := helpers.VerifyNilBeaconBlock(signed); err != nil {
 		return nil, err
 	}
+	if err := verifyPhase0State(state); err != nil {
+		return nil, err
+	}
 
 	blk := signed.Block()
 	body := blk.Body()
@@ -13,6 +13,7 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/state"
 	iface "github.com/prysmaticlabs/prysm/beacon-chain/state/interface"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state/v1"
+	v2 "github.com/prysmaticlabs/prysm/beacon-chain/state/v2"
 	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
 	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
 	"github.com/prysmaticlabs/prysm/proto/eth/v1alpha1/wrapper"
@@ -737,6 +738,48 @@ func TestCanProcessEpoch_TrueOnEpochs(t *testing.T) {
 	}
 }
 
+func TestCanUpgradeToAltair(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	config := params.BeaconConfig()
+	config.AltairForkEpoch = 2
+	params.OverrideBeaconConfig(config)
+
+	tests := []struct {
+		slot       types.Slot
+		canUpgrade bool
+	}{
+		{
+			slot:       params.BeaconConfig().SlotsPerEpoch,
+			canUpgrade: false,
+		}, {
+			slot:       2*params.BeaconConfig().SlotsPerEpoch - 1,
+			canUpgrade: false,
+		}, {
+			slot:       2 * params.BeaconConfig().SlotsPerEpoch,
+			canUpgrade: true,
+		}, {
+			slot:       2*params.BeaconConfig().SlotsPerEpoch + 1,
+			canUpgrade: false,
+		},
+	}
+
+	for _, tt := range tests {
+		s, err := v1.InitializeFromProto(&pb.BeaconState{Slot: tt.slot})
+		require.NoError(t, err)
+		assert.Equal(t, tt.canUpgrade, state.CanUpgradeToAltair(s), "CanUpgradeToAltair(%d)", tt.slot)
+	}
+}
+
+func TestProcessBlock_AltairStateNotSupported(t *testing.T) {
+	s, err := v2.InitializeFromProto(&pb.BeaconStateAltair{})
+	require.NoError(t, err)
+	blk := wrapper.WrappedPhase0SignedBeaconBlock(testutil.NewBeaconBlock())
+	_, err = state.ProcessBlock(context.Background(), s, blk)
+	assert.ErrorContains(t, state.ErrAltairNotSupported.Error(), err)
+	_, err = state.ProcessBlockForStateRoot(context.Background(), s, blk)
+	assert.ErrorContains(t, state.ErrAltairNotSupported.Error(), err)
+}
+
 func TestProcessBlock_OverMaxProposerSlashings(t *testing.T) {
 	maxSlashings := params.BeaconConfig().MaxProposerSlashings
 	b := &ethpb.SignedBeaconBlock{
@@ -29,3 +29,17 @@ type SlasherDatabase = iface.SlasherDatabase
 // ErrExistingGenesisState is an error when the user attempts to save a different genesis state
 // when one already exists in a database.
 var ErrExistingGenesisState = iface.ErrExistingGenesisState
+
+// ErrNotFoundOriginBlockRoot is returned when no origin block root has been saved, which
+// means the node was started from genesis and has no history to backfill.
+var ErrNotFoundOriginBlockRoot = iface.ErrNotFoundOriginBlockRoot
+
+// ErrExistingOriginBlockRoot is returned when the node is started from an origin state
+// other than the one it was first started from.
+var ErrExistingOriginBlockRoot = iface.ErrExistingOriginBlockRoot
+
+// ErrNotFoundBackfillBlockRoot is returned when backfill has not saved any progress yet.
+var ErrNotFoundBackfillBlockRoot = iface.ErrNotFoundBackfillBlockRoot
+
+// ErrNotFoundStateDiff is returned when no state diff has been archived for an epoch.
+var ErrNotFoundStateDiff = iface.ErrNotFoundStateDiff
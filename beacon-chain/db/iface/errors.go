@@ -8,4 +8,14 @@ var (
 	// ErrExistingGenesisState is an error when the user attempts to save a different genesis state
 	// when one already exists in a database.
 	ErrExistingGenesisState = errors.New("genesis state exists already in the DB")
+	// ErrNotFoundOriginBlockRoot is returned when no origin block root has been saved, which
+	// means the node was started from genesis and has no history to backfill.
+	ErrNotFoundOriginBlockRoot = errors.New("no origin block root found in db")
+	// ErrExistingOriginBlockRoot is returned when the node is started from an origin state
+	// other than the one it was first started from.
+	ErrExistingOriginBlockRoot = errors.New("a different origin block root exists already in the DB")
+	// ErrNotFoundBackfillBlockRoot is returned when backfill has not saved any progress yet.
+	ErrNotFoundBackfillBlockRoot = errors.New("no backfill block root found in db")
+	// ErrNotFoundStateDiff is returned when no state diff has been archived for an epoch.
+	ErrNotFoundStateDiff = errors.New("no state diff found in db")
 )
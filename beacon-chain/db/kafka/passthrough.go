@@ -8,6 +8,7 @@ import (
 	types "github.com/prysmaticlabs/eth2-types"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db/filters"
 	iface "github.com/prysmaticlabs/prysm/beacon-chain/state/interface"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/statediff"
 	"github.com/prysmaticlabs/prysm/proto/beacon/db"
 	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
 	eth "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
@@ -139,6 +140,26 @@ func (e Exporter) SaveGenesisBlockRoot(ctx context.Context, blockRoot [32]byte)
 	return e.db.SaveGenesisBlockRoot(ctx, blockRoot)
 }
 
+// OriginBlockRoot -- passthrough.
+func (e Exporter) OriginBlockRoot(ctx context.Context) ([32]byte, error) {
+	return e.db.OriginBlockRoot(ctx)
+}
+
+// SaveOriginBlockRoot -- passthrough.
+func (e Exporter) SaveOriginBlockRoot(ctx context.Context, blockRoot [32]byte) error {
+	return e.db.SaveOriginBlockRoot(ctx, blockRoot)
+}
+
+// BackfillBlockRoot -- passthrough.
+func (e Exporter) BackfillBlockRoot(ctx context.Context) ([32]byte, error) {
+	return e.db.BackfillBlockRoot(ctx)
+}
+
+// SaveBackfillBlockRoot -- passthrough.
+func (e Exporter) SaveBackfillBlockRoot(ctx context.Context, blockRoot [32]byte) error {
+	return e.db.SaveBackfillBlockRoot(ctx, blockRoot)
+}
+
 // SaveState -- passthrough.
 func (e Exporter) SaveState(ctx context.Context, st iface.ReadOnlyBeaconState, blockRoot [32]byte) error {
 	return e.db.SaveState(ctx, st, blockRoot)
@@ -254,6 +275,21 @@ func (e Exporter) HighestSlotStatesBelow(ctx context.Context, slot types.Slot) (
 	return e.db.HighestSlotStatesBelow(ctx, slot)
 }
 
+// HasStateDiff -- passthrough
+func (e Exporter) HasStateDiff(ctx context.Context, epoch types.Epoch) bool {
+	return e.db.HasStateDiff(ctx, epoch)
+}
+
+// StateDiffs -- passthrough
+func (e Exporter) StateDiffs(ctx context.Context, epoch types.Epoch) ([]*statediff.Diff, error) {
+	return e.db.StateDiffs(ctx, epoch)
+}
+
+// SaveStateDiff -- passthrough
+func (e Exporter) SaveStateDiff(ctx context.Context, epoch types.Epoch, diff *statediff.Diff) error {
+	return e.db.SaveStateDiff(ctx, epoch, diff)
+}
+
 // LastArchivedSlot -- passthrough
 func (e Exporter) LastArchivedSlot(ctx context.Context) (types.Slot, error) {
 	return e.db.LastArchivedSlot(ctx)
@@ -283,3 +319,8 @@ func (e Exporter) SaveGenesisData(ctx context.Context, state iface.BeaconState)
 func (e Exporter) EnsureEmbeddedGenesis(ctx context.Context) error {
 	return e.db.EnsureEmbeddedGenesis(ctx)
 }
+
+// SaveOrigin -- passthrough.
+func (e Exporter) SaveOrigin(ctx context.Context, stateReader, blockReader io.Reader) error {
+	return e.db.SaveOrigin(ctx, stateReader, blockReader)
+}
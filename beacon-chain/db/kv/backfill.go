@@ -0,0 +1,59 @@
+package kv
+
+import (
+	"context"
+
+	dbIface "github.com/prysmaticlabs/prysm/beacon-chain/db/iface"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// OriginBlockRoot returns the root of the block the node was initialized from when
+// it was started from a non-genesis anchor, such as a checkpoint-synced state.
+func (s *Store) OriginBlockRoot(ctx context.Context) ([32]byte, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.OriginBlockRoot")
+	defer span.End()
+	return s.blockRootByKey(originBlockRootKey, dbIface.ErrNotFoundOriginBlockRoot)
+}
+
+// SaveOriginBlockRoot saves the root of the anchor block the node was initialized from.
+func (s *Store) SaveOriginBlockRoot(ctx context.Context, blockRoot [32]byte) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.SaveOriginBlockRoot")
+	defer span.End()
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(blocksBucket)
+		return bucket.Put(originBlockRootKey, blockRoot[:])
+	})
+}
+
+// BackfillBlockRoot returns the root of the lowest block saved by the backfill process.
+// Every block between this block and the origin block is present in the db.
+func (s *Store) BackfillBlockRoot(ctx context.Context) ([32]byte, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.BackfillBlockRoot")
+	defer span.End()
+	return s.blockRootByKey(backfillBlockRootKey, dbIface.ErrNotFoundBackfillBlockRoot)
+}
+
+// SaveBackfillBlockRoot records the root of the lowest block saved by the backfill process.
+func (s *Store) SaveBackfillBlockRoot(ctx context.Context, blockRoot [32]byte) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.SaveBackfillBlockRoot")
+	defer span.End()
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(blocksBucket)
+		return bucket.Put(backfillBlockRootKey, blockRoot[:])
+	})
+}
+
+func (s *Store) blockRootByKey(key []byte, notFound error) ([32]byte, error) {
+	var root [32]byte
+	err := s.db.View(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(blocksBucket)
+		rootSlice := bkt.Get(key)
+		if len(rootSlice) == 0 {
+			return notFound
+		}
+		copy(root[:], rootSlice)
+		return nil
+	})
+	return root, err
+}
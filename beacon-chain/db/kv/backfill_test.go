@@ -0,0 +1,45 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	dbIface "github.com/prysmaticlabs/prysm/beacon-chain/db/iface"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func TestStore_OriginBlockRoot(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+
+	_, err := db.OriginBlockRoot(ctx)
+	require.ErrorContains(t, dbIface.ErrNotFoundOriginBlockRoot.Error(), err)
+
+	root := bytesutil.ToBytes32([]byte("origin"))
+	require.NoError(t, db.SaveOriginBlockRoot(ctx, root))
+	retrieved, err := db.OriginBlockRoot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, root, retrieved)
+}
+
+func TestStore_BackfillBlockRoot(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+
+	_, err := db.BackfillBlockRoot(ctx)
+	require.ErrorContains(t, dbIface.ErrNotFoundBackfillBlockRoot.Error(), err)
+
+	first := bytesutil.ToBytes32([]byte("first"))
+	require.NoError(t, db.SaveBackfillBlockRoot(ctx, first))
+	retrieved, err := db.BackfillBlockRoot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, first, retrieved)
+
+	second := bytesutil.ToBytes32([]byte("second"))
+	require.NoError(t, db.SaveBackfillBlockRoot(ctx, second))
+	retrieved, err = db.BackfillBlockRoot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, second, retrieved)
+}
@@ -139,6 +139,9 @@ func NewKVStore(ctx context.Context, dirPath string, config *Config) (*Store, er
 			chainMetadataBucket,
 			checkpointBucket,
 			powchainBucket,
+			stateDiffBucket,
+			stateValidatorsBucket,
+			stateValidatorsRefsBucket,
 			stateSummaryBucket,
 			// Indices buckets.
 			attestationHeadBlockRootBucket,
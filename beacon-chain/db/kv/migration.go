@@ -8,11 +8,19 @@ import (
 
 var migrationCompleted = []byte("done")
 
-type migration func(*bolt.Tx) error
+type migration func(context.Context, *bolt.DB) error
 
 var migrations = []migration{
-	migrateArchivedIndex,
-	migrateBlockSlotIndex,
+	updateMigration(migrateArchivedIndex),
+	updateMigration(migrateBlockSlotIndex),
+	migrateStateRegistryDiff,
+}
+
+// updateMigration runs a migration within a single transaction.
+func updateMigration(m func(*bolt.Tx) error) migration {
+	return func(_ context.Context, db *bolt.DB) error {
+		return db.Update(m)
+	}
 }
 
 // RunMigrations defined in the migrations array.
@@ -22,7 +30,7 @@ func (s *Store) RunMigrations(ctx context.Context) error {
 			return ctx.Err()
 		}
 
-		if err := s.db.Update(m); err != nil {
+		if err := m(ctx, s.db); err != nil {
 			return err
 		}
 	}
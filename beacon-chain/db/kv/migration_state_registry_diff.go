@@ -0,0 +1,94 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	bolt "go.etcd.io/bbolt"
+)
+
+var migrationStateRegistryDiff0Key = []byte("state_registry_diff_0")
+
+// Number of states re-encoded per transaction by migrateStateRegistryDiff.
+const stateRegistryDiffBatchSize = 64
+
+// migrateStateRegistryDiff re-encodes the states saved in full as diffs against a shared
+// validator registry. States are visited in slot order, so that consecutive states are
+// stored against the same registry. They are re-encoded in batches, each in its own
+// transaction, and an interrupted migration resumes with the states left in full.
+func migrateStateRegistryDiff(ctx context.Context, db *bolt.DB) error {
+	var completed bool
+	var roots [][]byte
+	if err := db.View(func(tx *bolt.Tx) error {
+		if b := tx.Bucket(migrationsBucket).Get(migrationStateRegistryDiff0Key); bytes.Equal(b, migrationCompleted) {
+			completed = true
+			return nil
+		}
+
+		// Collect the state roots by slot, followed by the roots missing from the slot index.
+		indexed := make(map[[32]byte]bool)
+		if err := tx.Bucket(stateSlotIndicesBucket).ForEach(func(_, v []byte) error {
+			for i := 0; i+32 <= len(v); i += 32 {
+				root := bytesutil.ToBytes32(v[i : i+32])
+				if !indexed[root] {
+					indexed[root] = true
+					roots = append(roots, root[:])
+				}
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		return tx.Bucket(stateBucket).ForEach(func(k, _ []byte) error {
+			if !indexed[bytesutil.ToBytes32(k)] {
+				roots = append(roots, bytesutil.SafeCopyBytes(k))
+			}
+			return nil
+		})
+	}); err != nil {
+		return err
+	}
+	if completed {
+		return nil // Migration already completed.
+	}
+	if len(roots) > 0 {
+		log.WithField("count", len(roots)).Info("Migrating states to a shared validator registry")
+	}
+
+	for start := 0; start < len(roots); start += stateRegistryDiffBatchSize {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		end := start + stateRegistryDiffBatchSize
+		if end > len(roots) {
+			end = len(roots)
+		}
+		if err := db.Update(func(tx *bolt.Tx) error {
+			for _, root := range roots[start:end] {
+				enc := tx.Bucket(stateBucket).Get(root)
+				if enc == nil || bytes.HasPrefix(enc, registryDiffKey) {
+					continue
+				}
+				st, err := createState(ctx, tx, enc)
+				if err != nil {
+					return err
+				}
+				enc, err = encodeState(ctx, tx, st)
+				if err != nil {
+					return err
+				}
+				if err := putState(tx, root, enc); err != nil {
+					return err
+				}
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+
+	return db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(migrationsBucket).Put(migrationStateRegistryDiff0Key, migrationCompleted)
+	})
+}
@@ -0,0 +1,138 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	"go.etcd.io/bbolt"
+)
+
+func Test_migrateStateRegistryDiff(t *testing.T) {
+	ctx := context.Background()
+	states := registryTestStates(t, 32, 1, 3)
+	// The last state is missing from the slot index.
+	saveFullStates := func(t *testing.T, db *bbolt.DB) {
+		err := db.Update(func(tx *bbolt.Tx) error {
+			for i, st := range states {
+				enc, err := encode(ctx, st)
+				if err != nil {
+					return err
+				}
+				if err := tx.Bucket(stateBucket).Put([]byte{byte(i)}, enc); err != nil {
+					return err
+				}
+				if i < len(states)-1 {
+					if err := tx.Bucket(stateSlotIndicesBucket).Put(bytesutil.SlotToBytesBigEndian(st.Slot), []byte{byte(i)}); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		})
+		assert.NoError(t, err)
+	}
+
+	tests := []struct {
+		name  string
+		setup func(t *testing.T, db *bbolt.DB)
+		eval  func(t *testing.T, db *bbolt.DB)
+	}{
+		{
+			name: "only runs once",
+			setup: func(t *testing.T, db *bbolt.DB) {
+				saveFullStates(t, db)
+				err := db.Update(func(tx *bbolt.Tx) error {
+					return tx.Bucket(migrationsBucket).Put(migrationStateRegistryDiff0Key, migrationCompleted)
+				})
+				assert.NoError(t, err)
+			},
+			eval: func(t *testing.T, db *bbolt.DB) {
+				err := db.View(func(tx *bbolt.Tx) error {
+					for i := range states {
+						enc := tx.Bucket(stateBucket).Get([]byte{byte(i)})
+						assert.Equal(t, false, bytes.HasPrefix(enc, registryDiffKey), "State %d was migrated", i)
+					}
+					assert.Equal(t, 0, tx.Bucket(stateValidatorsBucket).Stats().KeyN)
+					return nil
+				})
+				assert.NoError(t, err)
+			},
+		},
+		{
+			name:  "migrates states",
+			setup: saveFullStates,
+			eval: func(t *testing.T, db *bbolt.DB) {
+				err := db.View(func(tx *bbolt.Tx) error {
+					for i, want := range states {
+						enc := tx.Bucket(stateBucket).Get([]byte{byte(i)})
+						assert.Equal(t, true, bytes.HasPrefix(enc, registryDiffKey), "State %d was not migrated", i)
+						st, err := createState(ctx, tx, enc)
+						require.NoError(t, err)
+						assert.DeepSSZEqual(t, want, st, "Wrong state %d", i)
+					}
+					// States are migrated in slot order, so they share a single registry.
+					assert.Equal(t, 1, tx.Bucket(stateValidatorsBucket).Stats().KeyN)
+					return nil
+				})
+				assert.NoError(t, err)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := setupDB(t).db
+			tt.setup(t, db)
+			assert.NoError(t, migrateStateRegistryDiff(ctx, db), "migrateStateRegistryDiff(ctx, db) error")
+			tt.eval(t, db)
+		})
+	}
+}
+
+func Test_migrateStateRegistryDiff_Batches(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t).db
+	states := registryTestStates(t, 8, 0, stateRegistryDiffBatchSize+2)
+	require.NoError(t, db.Update(func(tx *bbolt.Tx) error {
+		for i, st := range states {
+			enc, err := encode(ctx, st)
+			if err != nil {
+				return err
+			}
+			if err := tx.Bucket(stateBucket).Put([]byte{byte(i)}, enc); err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+	// The first state was migrated before the migration was interrupted.
+	require.NoError(t, db.Update(func(tx *bbolt.Tx) error {
+		enc, err := encodeState(ctx, tx, states[0])
+		if err != nil {
+			return err
+		}
+		return putState(tx, []byte{0}, enc)
+	}))
+
+	require.NoError(t, migrateStateRegistryDiff(ctx, db))
+	require.NoError(t, db.View(func(tx *bbolt.Tx) error {
+		for i, want := range states {
+			enc := tx.Bucket(stateBucket).Get([]byte{byte(i)})
+			st, err := createState(ctx, tx, enc)
+			require.NoError(t, err)
+			assert.DeepSSZEqual(t, want, st, "Wrong state %d", i)
+		}
+		// Every migrated state references the registry it is stored against.
+		var refs uint64
+		require.NoError(t, tx.Bucket(stateValidatorsRefsBucket).ForEach(func(_, v []byte) error {
+			refs += bytesutil.BytesToUint64BigEndian(v)
+			return nil
+		}))
+		assert.Equal(t, uint64(len(states)), refs)
+		assert.DeepEqual(t, migrationCompleted, tx.Bucket(migrationsBucket).Get(migrationStateRegistryDiff0Key))
+		return nil
+	}))
+}
@@ -0,0 +1,148 @@
+package kv
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	dbIface "github.com/prysmaticlabs/prysm/beacon-chain/db/iface"
+	state "github.com/prysmaticlabs/prysm/beacon-chain/state/v1"
+	dbpb "github.com/prysmaticlabs/prysm/proto/beacon/db"
+	pbp2p "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/proto/eth/v1alpha1/wrapper"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// SaveOrigin initializes the db from an ssz encoded non-genesis anchor state and the
+// block it was derived from, such as a finalized checkpoint state served by a trusted
+// node. The anchor block becomes the head, the finalized and justified checkpoint and
+// the origin block from which blocks are backfilled. Saving the same origin again is
+// a no-op, so the node can be restarted with the same flags.
+func (s *Store) SaveOrigin(ctx context.Context, stateReader, blockReader io.Reader) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.SaveOrigin")
+	defer span.End()
+
+	sb, err := ioutil.ReadAll(stateReader)
+	if err != nil {
+		return err
+	}
+	st := &pbp2p.BeaconState{}
+	if err := st.UnmarshalSSZ(sb); err != nil {
+		return errors.Wrap(err, "could not unmarshal origin state")
+	}
+	bb, err := ioutil.ReadAll(blockReader)
+	if err != nil {
+		return err
+	}
+	blk := &ethpb.SignedBeaconBlock{}
+	if err := blk.UnmarshalSSZ(bb); err != nil {
+		return errors.Wrap(err, "could not unmarshal origin block")
+	}
+	originState, err := state.InitializeFromProtoUnsafe(st)
+	if err != nil {
+		return err
+	}
+	blockRoot, err := blk.Block.HashTreeRoot()
+	if err != nil {
+		return err
+	}
+
+	existingRoot, err := s.OriginBlockRoot(ctx)
+	switch {
+	case err == nil && existingRoot == blockRoot:
+		return nil
+	case err == nil:
+		return dbIface.ErrExistingOriginBlockRoot
+	case !errors.Is(err, dbIface.ErrNotFoundOriginBlockRoot):
+		return err
+	}
+
+	if originState.Slot() == 0 {
+		return errors.New("origin state is a genesis state, use the genesis state flag instead")
+	}
+	// The latest block header of the state only has its state root filled in once a
+	// slot has been processed on top of the block.
+	header := originState.LatestBlockHeader()
+	if header.StateRoot == nil || params.BeaconConfig().ZeroHash == bytesutil.ToBytes32(header.StateRoot) {
+		stateRoot, err := originState.HashTreeRoot(ctx)
+		if err != nil {
+			return err
+		}
+		header.StateRoot = stateRoot[:]
+	}
+	headerRoot, err := header.HashTreeRoot()
+	if err != nil {
+		return err
+	}
+	if headerRoot != blockRoot {
+		return errors.Errorf("origin block root %#x does not match the latest block header of the origin state %#x", blockRoot, headerRoot)
+	}
+
+	genesisState, err := s.GenesisState(ctx)
+	if err != nil {
+		return err
+	}
+	if genesisState == nil || genesisState.IsNil() {
+		return errors.New("a genesis state is required to start from an origin state")
+	}
+	head, err := s.HeadBlock(ctx)
+	if err != nil {
+		return err
+	}
+	if head != nil && !head.IsNil() && head.Block().Slot() > 0 {
+		return errors.New("db already contains blocks beyond genesis, clear it before starting from an origin state")
+	}
+
+	if err := s.SaveBlock(ctx, wrapper.WrappedPhase0SignedBeaconBlock(blk)); err != nil {
+		return errors.Wrap(err, "could not save origin block")
+	}
+	if err := s.SaveState(ctx, originState, blockRoot); err != nil {
+		return errors.Wrap(err, "could not save origin state")
+	}
+	if err := s.SaveStateSummary(ctx, &pbp2p.StateSummary{
+		Slot: originState.Slot(),
+		Root: blockRoot[:],
+	}); err != nil {
+		return err
+	}
+	if err := s.SaveHeadBlockRoot(ctx, blockRoot); err != nil {
+		return errors.Wrap(err, "could not save head block root")
+	}
+	checkpoint := &ethpb.Checkpoint{
+		Epoch: helpers.SlotToEpoch(originState.Slot()),
+		Root:  blockRoot[:],
+	}
+	enc, err := encode(ctx, checkpoint)
+	if err != nil {
+		return err
+	}
+	container, err := encode(ctx, &dbpb.FinalizedBlockRootContainer{ParentRoot: blk.Block.ParentRoot})
+	if err != nil {
+		return err
+	}
+	if err := s.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(checkpointBucket)
+		if err := bkt.Put(justifiedCheckpointKey, enc); err != nil {
+			return err
+		}
+		if err := bkt.Put(finalizedCheckpointKey, enc); err != nil {
+			return err
+		}
+		// The blocks below the origin block are missing until they are backfilled, so the
+		// finalized block roots index starts at the origin block instead of genesis.
+		idx := tx.Bucket(finalizedBlockRootsIndexBucket)
+		if err := idx.Put(blockRoot[:], container); err != nil {
+			return err
+		}
+		return idx.Put(previousFinalizedCheckpointKey, enc)
+	}); err != nil {
+		return errors.Wrap(err, "could not save origin checkpoint")
+	}
+	return s.SaveOriginBlockRoot(ctx, blockRoot)
+}
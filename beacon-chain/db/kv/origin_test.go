@@ -0,0 +1,82 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	dbIface "github.com/prysmaticlabs/prysm/beacon-chain/db/iface"
+	pbp2p "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+// originTestData returns a matching ssz encoded origin state and block at the given slot.
+func originTestData(t *testing.T, slot types.Slot, parentRoot byte) ([]byte, []byte) {
+	blk := testutil.NewBeaconBlock()
+	blk.Block.Slot = slot
+	blk.Block.ParentRoot = bytesutil.PadTo([]byte{parentRoot}, 32)
+	bodyRoot, err := blk.Block.Body.HashTreeRoot()
+	require.NoError(t, err)
+	st, err := testutil.NewBeaconState(func(st *pbp2p.BeaconState) error {
+		st.Slot = slot
+		st.LatestBlockHeader = &ethpb.BeaconBlockHeader{
+			Slot:       slot,
+			ParentRoot: blk.Block.ParentRoot,
+			StateRoot:  make([]byte, 32),
+			BodyRoot:   bodyRoot[:],
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	stateRoot, err := st.HashTreeRoot(context.Background())
+	require.NoError(t, err)
+	blk.Block.StateRoot = stateRoot[:]
+	enc, err := st.MarshalSSZ()
+	require.NoError(t, err)
+	blkEnc, err := blk.MarshalSSZ()
+	require.NoError(t, err)
+	return enc, blkEnc
+}
+
+func TestStore_SaveOrigin(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t)
+	st, blk := originTestData(t, 64, 1)
+
+	require.ErrorContains(t, "genesis state is required", db.SaveOrigin(ctx, bytes.NewReader(st), bytes.NewReader(blk)))
+	gs, err := testutil.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, db.SaveGenesisData(ctx, gs))
+
+	_, otherBlk := originTestData(t, 64, 2)
+	require.ErrorContains(t, "does not match", db.SaveOrigin(ctx, bytes.NewReader(st), bytes.NewReader(otherBlk)))
+
+	require.NoError(t, db.SaveOrigin(ctx, bytes.NewReader(st), bytes.NewReader(blk)))
+	decoded := &ethpb.SignedBeaconBlock{}
+	require.NoError(t, decoded.UnmarshalSSZ(blk))
+	root, err := decoded.Block.HashTreeRoot()
+	require.NoError(t, err)
+
+	originRoot, err := db.OriginBlockRoot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, root, originRoot)
+	head, err := db.HeadBlock(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, types.Slot(64), head.Block().Slot())
+	assert.Equal(t, true, db.HasState(ctx, root))
+	finalized, err := db.FinalizedCheckpoint(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, types.Epoch(2), finalized.Epoch)
+	assert.DeepEqual(t, root[:], finalized.Root)
+	assert.Equal(t, true, db.IsFinalizedBlock(ctx, root))
+
+	// Restarting from the same origin is a no-op, while a different one is rejected.
+	require.NoError(t, db.SaveOrigin(ctx, bytes.NewReader(st), bytes.NewReader(blk)))
+	st2, blk2 := originTestData(t, 96, 1)
+	require.ErrorContains(t, dbIface.ErrExistingOriginBlockRoot.Error(), db.SaveOrigin(ctx, bytes.NewReader(st2), bytes.NewReader(blk2)))
+}
@@ -17,6 +17,10 @@ var (
 	chainMetadataBucket     = []byte("chain-metadata")
 	checkpointBucket        = []byte("check-point")
 	powchainBucket          = []byte("powchain")
+	stateDiffBucket         = []byte("state-diffs")
+	stateValidatorsBucket   = []byte("state-validators")
+	// Number of states stored against each validator registry of the state-validators bucket.
+	stateValidatorsRefsBucket = []byte("state-validators-refs")
 
 	// Deprecated: This bucket was migrated in PR 6461. Do not use, except for migrations.
 	slotsHasObjectBucket = []byte("slots-has-objects")
@@ -37,11 +41,16 @@ var (
 	// Specific item keys.
 	headBlockRootKey          = []byte("head-root")
 	genesisBlockRootKey       = []byte("genesis-root")
+	originBlockRootKey        = []byte("origin-block-root")
+	backfillBlockRootKey      = []byte("backfill-block-root")
 	depositContractAddressKey = []byte("deposit-contract")
 	justifiedCheckpointKey    = []byte("justified-checkpoint")
 	finalizedCheckpointKey    = []byte("finalized-checkpoint")
 	powchainDataKey           = []byte("powchain-data")
 
+	// Prefix of the states stored as a diff against a shared validator registry.
+	registryDiffKey = []byte("registry-diff")
+
 	// Deprecated: This index key was migrated in PR 6461. Do not use, except for migrations.
 	lastArchivedIndexKey = []byte("last-archived")
 	// Deprecated: This index key was migrated in PR 6461. Do not use, except for migrations.
@@ -3,12 +3,14 @@ package kv
 import (
 	"bytes"
 	"context"
+	"encoding/binary"
 
 	"github.com/pkg/errors"
 	types "github.com/prysmaticlabs/eth2-types"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state/genesis"
 	iface "github.com/prysmaticlabs/prysm/beacon-chain/state/interface"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/statediff"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state/v1"
 	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
 	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
@@ -26,19 +28,23 @@ func (s *Store) State(ctx context.Context, blockRoot [32]byte) (iface.BeaconStat
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.State")
 	defer span.End()
 	var st *pb.BeaconState
-	enc, err := s.stateBytes(ctx, blockRoot)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		enc := tx.Bucket(stateBucket).Get(blockRoot[:])
+		if len(enc) == 0 {
+			return nil
+		}
+		// The decoded state does not share memory with the encoding, which is only
+		// valid for the lifetime of the transaction.
+		var err error
+		st, err = createState(ctx, tx, enc)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
-
-	if len(enc) == 0 {
+	if st == nil {
 		return nil, nil
 	}
-
-	st, err = createState(ctx, enc)
-	if err != nil {
-		return nil, err
-	}
 	return v1.InitializeFromProtoUnsafe(st)
 }
 
@@ -71,7 +77,7 @@ func (s *Store) GenesisState(ctx context.Context) (iface.BeaconState, error) {
 		}
 
 		var err error
-		st, err = createState(ctx, enc)
+		st, err = createState(ctx, tx, enc)
 		return err
 	})
 	if err != nil {
@@ -98,26 +104,26 @@ func (s *Store) SaveStates(ctx context.Context, states []iface.ReadOnlyBeaconSta
 	if states == nil {
 		return errors.New("nil state")
 	}
-	multipleEncs := make([][]byte, len(states))
+	pbStates := make([]*pb.BeaconState, len(states))
 	for i, st := range states {
 		pbState, err := v1.ProtobufBeaconState(st.InnerStateUnsafe())
 		if err != nil {
 			return err
 		}
-		multipleEncs[i], err = encode(ctx, pbState)
-		if err != nil {
-			return err
-		}
+		pbStates[i] = pbState
 	}
 
 	return s.db.Update(func(tx *bolt.Tx) error {
-		bucket := tx.Bucket(stateBucket)
 		for i, rt := range blockRoots {
 			indicesByBucket := createStateIndicesFromStateSlot(ctx, states[i].Slot())
 			if err := updateValueForIndices(ctx, indicesByBucket, rt[:], tx); err != nil {
 				return errors.Wrap(err, "could not update DB indices")
 			}
-			if err := bucket.Put(rt[:], multipleEncs[i]); err != nil {
+			enc, err := encodeState(ctx, tx, pbStates[i])
+			if err != nil {
+				return err
+			}
+			if err := putState(tx, rt[:], enc); err != nil {
 				return err
 			}
 		}
@@ -164,7 +170,6 @@ func (s *Store) DeleteState(ctx context.Context, blockRoot [32]byte) error {
 
 		blockBkt := tx.Bucket(blocksBucket)
 		headBlkRoot := blockBkt.Get(headBlockRootKey)
-		bkt = tx.Bucket(stateBucket)
 		// Safe guard against deleting genesis, finalized, head state.
 		if bytes.Equal(blockRoot[:], checkpoint.Root) || bytes.Equal(blockRoot[:], genesisBlockRoot) || bytes.Equal(blockRoot[:], headBlkRoot) {
 			return errors.New("cannot delete genesis, finalized, or head state")
@@ -179,7 +184,7 @@ func (s *Store) DeleteState(ctx context.Context, blockRoot [32]byte) error {
 			return errors.Wrap(err, "could not delete root for DB indices")
 		}
 
-		return bkt.Delete(blockRoot[:])
+		return deleteState(tx, blockRoot[:])
 	})
 }
 
@@ -197,35 +202,223 @@ func (s *Store) DeleteStates(ctx context.Context, blockRoots [][32]byte) error {
 	return nil
 }
 
-// creates state from marshaled proto state bytes.
-func createState(ctx context.Context, enc []byte) (*pb.BeaconState, error) {
+// registryPatchRatio bounds the number of validators a state stored against a validator
+// registry may patch: once more than 1/registryPatchRatio of its validators differ from
+// the latest registry, a new registry is saved.
+const registryPatchRatio = 8
+
+// creates state from its encoding in the state bucket, which is either the marshaled
+// proto state bytes or a diff against a validator registry.
+func createState(ctx context.Context, tx *bolt.Tx, enc []byte) (*pb.BeaconState, error) {
 	protoState := &pb.BeaconState{}
-	if err := decode(ctx, enc, protoState); err != nil {
+	if !bytes.HasPrefix(enc, registryDiffKey) {
+		if err := decode(ctx, enc, protoState); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal encoding")
+		}
+		return protoState, nil
+	}
+
+	enc = enc[len(registryDiffKey):]
+	if len(enc) < 8 {
+		return nil, errors.New("state encoding is missing its validator registry key")
+	}
+	registryEnc := tx.Bucket(stateValidatorsBucket).Get(enc[:8])
+	if registryEnc == nil {
+		return nil, errors.Errorf("validator registry %d not found", bytesutil.BytesToUint64BigEndian(enc[:8]))
+	}
+	fieldsLen, n := binary.Uvarint(enc[8:])
+	if n <= 0 || fieldsLen > uint64(len(enc[8+n:])) {
+		return nil, errors.New("malformed state encoding")
+	}
+	fieldsEnc, diffEnc := enc[8+n:8+n+int(fieldsLen)], enc[8+n+int(fieldsLen):]
+
+	if err := decode(ctx, fieldsEnc, protoState); err != nil {
 		return nil, errors.Wrap(err, "failed to unmarshal encoding")
 	}
+	registry, err := decodeValidatorRegistry(registryEnc)
+	if err != nil {
+		return nil, err
+	}
+	diff, err := statediff.Decode(diffEnc)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal validator registry diff")
+	}
+	registry, err = diff.Apply(registry)
+	if err != nil {
+		return nil, err
+	}
+	protoState.Validators = registry.Validators
+	protoState.Balances = registry.Balances
 	return protoState, nil
 }
 
-// HasState checks if a state by root exists in the db.
-func (s *Store) stateBytes(ctx context.Context, blockRoot [32]byte) ([]byte, error) {
-	ctx, span := trace.StartSpan(ctx, "BeaconDB.stateBytes")
+// encodeState encodes a state against the latest validator registry saved in the db, so
+// that the registry, which makes up most of the size of a state, is stored only once. The
+// encoding holds the other fields of the state, the validators which differ from the
+// registry and the balances as deltas. A new registry is saved when the state can't be
+// stored compactly against the latest one.
+//
+// The encoding is prefixed with registryDiffKey, which can't be mistaken for a snappy
+// compressed state: its first byte would be the decoded length of the state, 114 bytes.
+func encodeState(ctx context.Context, tx *bolt.Tx, st *pb.BeaconState) ([]byte, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.encodeState")
 	defer span.End()
-	var dst []byte
-	err := s.db.View(func(tx *bolt.Tx) error {
-		bkt := tx.Bucket(stateBucket)
-		stBytes := bkt.Get(blockRoot[:])
-		if len(stBytes) == 0 {
-			return nil
+
+	bkt := tx.Bucket(stateValidatorsBucket)
+	target := &pb.BeaconState{Validators: st.Validators, Balances: st.Balances}
+	key, registryEnc := bkt.Cursor().Last()
+	var diff *statediff.Diff
+	if key != nil {
+		registry, err := decodeValidatorRegistry(registryEnc)
+		if err != nil {
+			return nil, err
+		}
+		if len(target.Validators) >= len(registry.Validators) && len(target.Balances) >= len(registry.Balances) {
+			diff, err = statediff.Compute(registry, target)
+			if err != nil {
+				return nil, err
+			}
 		}
-		// Due to https://github.com/boltdb/bolt/issues/204, we need to
-		// allocate a byte slice separately in the transaction or there
-		// is the possibility of a panic when accessing that particular
-		// area of memory.
-		dst = make([]byte, len(stBytes))
-		copy(dst, stBytes)
+	}
+
+	if diff == nil || len(diff.Validators) > len(target.Validators)/registryPatchRatio {
+		snapshot, err := statediff.Compute(nil, target)
+		if err != nil {
+			return nil, err
+		}
+		registryEnc, err := snapshot.Encode()
+		if err != nil {
+			return nil, err
+		}
+		// The previous latest registry is kept while it is unreferenced, as the next
+		// saved state may be stored against it. It can be dropped now.
+		if key != nil && tx.Bucket(stateValidatorsRefsBucket).Get(key) == nil {
+			if err := bkt.Delete(key); err != nil {
+				return nil, err
+			}
+		}
+		seq, err := bkt.NextSequence()
+		if err != nil {
+			return nil, err
+		}
+		key = bytesutil.Uint64ToBytesBigEndian(seq)
+		if err := bkt.Put(key, registryEnc); err != nil {
+			return nil, err
+		}
+		diff, err = statediff.Compute(target, target)
+		if err != nil {
+			return nil, err
+		}
+	}
+	diffEnc, err := diff.Encode()
+	if err != nil {
+		return nil, err
+	}
+
+	// The other fields are encoded like a full state without validators and balances.
+	fields := &pb.BeaconState{
+		GenesisTime:                 st.GenesisTime,
+		GenesisValidatorsRoot:       st.GenesisValidatorsRoot,
+		Slot:                        st.Slot,
+		Fork:                        st.Fork,
+		LatestBlockHeader:           st.LatestBlockHeader,
+		BlockRoots:                  st.BlockRoots,
+		StateRoots:                  st.StateRoots,
+		HistoricalRoots:             st.HistoricalRoots,
+		Eth1Data:                    st.Eth1Data,
+		Eth1DataVotes:               st.Eth1DataVotes,
+		Eth1DepositIndex:            st.Eth1DepositIndex,
+		RandaoMixes:                 st.RandaoMixes,
+		Slashings:                   st.Slashings,
+		PreviousEpochAttestations:   st.PreviousEpochAttestations,
+		CurrentEpochAttestations:    st.CurrentEpochAttestations,
+		JustificationBits:           st.JustificationBits,
+		PreviousJustifiedCheckpoint: st.PreviousJustifiedCheckpoint,
+		CurrentJustifiedCheckpoint:  st.CurrentJustifiedCheckpoint,
+		FinalizedCheckpoint:         st.FinalizedCheckpoint,
+	}
+	fieldsEnc, err := encode(ctx, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	enc := make([]byte, 0, len(registryDiffKey)+len(key)+binary.MaxVarintLen64+len(fieldsEnc)+len(diffEnc))
+	enc = append(enc, registryDiffKey...)
+	enc = append(enc, key...)
+	var lenBuf [binary.MaxVarintLen64]byte
+	enc = append(enc, lenBuf[:binary.PutUvarint(lenBuf[:], uint64(len(fieldsEnc)))]...)
+	enc = append(enc, fieldsEnc...)
+	return append(enc, diffEnc...), nil
+}
+
+// putState saves a state encoding under the given block root, keeping count of the states
+// stored against each validator registry.
+func putState(tx *bolt.Tx, blockRoot, enc []byte) error {
+	bkt := tx.Bucket(stateBucket)
+	if key := stateRegistryKey(enc); key != nil {
+		refs := tx.Bucket(stateValidatorsRefsBucket)
+		count := bytesutil.BytesToUint64BigEndian(refs.Get(key))
+		if err := refs.Put(key, bytesutil.Uint64ToBytesBigEndian(count+1)); err != nil {
+			return err
+		}
+	}
+	// The reference of an overwritten state is released once the new one is counted, in
+	// case both are stored against the same registry.
+	if err := releaseStateRegistry(tx, bytesutil.SafeCopyBytes(stateRegistryKey(bkt.Get(blockRoot)))); err != nil {
+		return err
+	}
+	return bkt.Put(blockRoot, enc)
+}
+
+// deleteState deletes the state saved under the given block root, along with the validator
+// registry it is stored against once no other state references it.
+func deleteState(tx *bolt.Tx, blockRoot []byte) error {
+	bkt := tx.Bucket(stateBucket)
+	if err := releaseStateRegistry(tx, bytesutil.SafeCopyBytes(stateRegistryKey(bkt.Get(blockRoot)))); err != nil {
+		return err
+	}
+	return bkt.Delete(blockRoot)
+}
+
+// releaseStateRegistry decrements the number of states stored against the validator registry
+// with the given key, and deletes the registry once it is unreferenced. The latest registry
+// is kept, as the next saved state is likely to be stored against it.
+func releaseStateRegistry(tx *bolt.Tx, key []byte) error {
+	if key == nil {
 		return nil
-	})
-	return dst, err
+	}
+	refs := tx.Bucket(stateValidatorsRefsBucket)
+	count := bytesutil.BytesToUint64BigEndian(refs.Get(key))
+	if count > 1 {
+		return refs.Put(key, bytesutil.Uint64ToBytesBigEndian(count-1))
+	}
+	if err := refs.Delete(key); err != nil {
+		return err
+	}
+	bkt := tx.Bucket(stateValidatorsBucket)
+	if last, _ := bkt.Cursor().Last(); bytes.Equal(last, key) {
+		return nil
+	}
+	return bkt.Delete(key)
+}
+
+// stateRegistryKey returns the key of the validator registry a state encoding is stored
+// against, or nil for a state saved in full.
+func stateRegistryKey(enc []byte) []byte {
+	if !bytes.HasPrefix(enc, registryDiffKey) || len(enc) < len(registryDiffKey)+8 {
+		return nil
+	}
+	return enc[len(registryDiffKey) : len(registryDiffKey)+8]
+}
+
+// decodeValidatorRegistry decodes a validator registry saved by encodeState, as a state
+// which only holds validators and balances.
+func decodeValidatorRegistry(enc []byte) (*pb.BeaconState, error) {
+	snapshot, err := statediff.Decode(enc)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal validator registry")
+	}
+	return snapshot.Apply(nil)
 }
 
 // slotByBlockRoot retrieves the corresponding slot of the input block root.
@@ -248,7 +441,7 @@ func slotByBlockRoot(ctx context.Context, tx *bolt.Tx, blockRoot []byte) (types.
 			if enc == nil {
 				return 0, errors.New("state enc can't be nil")
 			}
-			s, err := createState(ctx, enc)
+			s, err := createState(ctx, tx, enc)
 			if err != nil {
 				return 0, err
 			}
@@ -0,0 +1,89 @@
+package kv
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
+	dbIface "github.com/prysmaticlabs/prysm/beacon-chain/db/iface"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/statediff"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// SaveStateDiff saves the diff of the epoch boundary state of the given epoch against the
+// previous epoch boundary state, or a snapshot of the state.
+func (s *Store) SaveStateDiff(ctx context.Context, epoch types.Epoch, diff *statediff.Diff) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.SaveStateDiff")
+	defer span.End()
+
+	enc, err := diff.Encode()
+	if err != nil {
+		return err
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(stateDiffBucket)
+		return bucket.Put(bytesutil.EpochToBytesBigEndian(epoch), enc)
+	})
+}
+
+// HasStateDiff returns true if a state diff of the given epoch exists in the db.
+func (s *Store) HasStateDiff(ctx context.Context, epoch types.Epoch) bool {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.HasStateDiff")
+	defer span.End()
+
+	var exists bool
+	if err := s.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(stateDiffBucket)
+		exists = bucket.Get(bytesutil.EpochToBytesBigEndian(epoch)) != nil
+		return nil
+	}); err != nil { // This view never returns an error, but we'll handle anyway for sanity.
+		panic(err)
+	}
+	return exists
+}
+
+// StateDiffs returns the state diffs needed to rebuild the epoch boundary state of the given
+// epoch: the nearest snapshot at or below the epoch, followed by the diffs of every later epoch
+// up to the requested one, in ascending epoch order.
+func (s *Store) StateDiffs(ctx context.Context, epoch types.Epoch) ([]*statediff.Diff, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.StateDiffs")
+	defer span.End()
+
+	var encs [][]byte
+	err := s.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(stateDiffBucket).Cursor()
+		want := epoch
+		for k, v := c.Seek(bytesutil.EpochToBytesBigEndian(epoch)); ; k, v = c.Prev() {
+			if k == nil || bytesutil.BytesToEpochBigEndian(k) != want {
+				if len(encs) == 0 {
+					return dbIface.ErrNotFoundStateDiff
+				}
+				return errors.Errorf("missing state diff of epoch %d to rebuild epoch %d", want, epoch)
+			}
+			// Values are only valid for the lifetime of the transaction.
+			encs = append(encs, bytesutil.SafeCopyBytes(v))
+			if statediff.IsSnapshot(v) {
+				return nil
+			}
+			if want == 0 {
+				return errors.Errorf("no state snapshot to rebuild epoch %d", epoch)
+			}
+			want--
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	diffs := make([]*statediff.Diff, len(encs))
+	for i, enc := range encs {
+		d, err := statediff.Decode(enc)
+		if err != nil {
+			return nil, err
+		}
+		diffs[len(encs)-1-i] = d
+	}
+	return diffs, nil
+}
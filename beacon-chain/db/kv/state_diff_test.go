@@ -0,0 +1,84 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	dbIface "github.com/prysmaticlabs/prysm/beacon-chain/db/iface"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/statediff"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func TestStore_StateDiffs(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+
+	_, err := db.StateDiffs(ctx, 1)
+	require.ErrorContains(t, dbIface.ErrNotFoundStateDiff.Error(), err)
+	assert.Equal(t, false, db.HasStateDiff(ctx, 1))
+
+	// Epochs 1 and 4 are snapshots, epoch 3 is missing.
+	states := make([]*pb.BeaconState, 7)
+	for i := range states {
+		states[i] = &pb.BeaconState{Slot: types.Slot(i), Balances: make([]uint64, i)}
+	}
+	for _, e := range []types.Epoch{1, 2, 4, 5, 6} {
+		var base *pb.BeaconState
+		if e != 1 && e != 4 {
+			base = states[e-1]
+		}
+		d, err := statediff.Compute(base, states[e])
+		require.NoError(t, err)
+		require.NoError(t, db.SaveStateDiff(ctx, e, d))
+	}
+	assert.Equal(t, true, db.HasStateDiff(ctx, 1))
+
+	tests := []struct {
+		epoch     types.Epoch
+		wantDiffs int
+		wantErr   string
+	}{
+		{epoch: 1, wantDiffs: 1},
+		{epoch: 2, wantDiffs: 2},
+		{epoch: 3, wantErr: dbIface.ErrNotFoundStateDiff.Error()},
+		{epoch: 4, wantDiffs: 1},
+		{epoch: 6, wantDiffs: 3},
+		{epoch: 7, wantErr: dbIface.ErrNotFoundStateDiff.Error()},
+	}
+	for _, tt := range tests {
+		diffs, err := db.StateDiffs(ctx, tt.epoch)
+		if tt.wantErr != "" {
+			assert.ErrorContains(t, tt.wantErr, err)
+			continue
+		}
+		require.NoError(t, err)
+		require.Equal(t, tt.wantDiffs, len(diffs))
+		assert.Equal(t, true, diffs[0].Snapshot)
+		var st *pb.BeaconState
+		for _, d := range diffs {
+			st, err = d.Apply(st)
+			require.NoError(t, err)
+		}
+		assert.Equal(t, types.Slot(tt.epoch), st.Slot)
+		assert.Equal(t, int(tt.epoch), len(st.Balances))
+	}
+}
+
+func TestStore_StateDiffs_BrokenChain(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+
+	d, err := statediff.Compute(&pb.BeaconState{}, &pb.BeaconState{Slot: 2})
+	require.NoError(t, err)
+	require.NoError(t, db.SaveStateDiff(ctx, 2, d))
+	_, err = db.StateDiffs(ctx, 2)
+	assert.ErrorContains(t, "missing state diff of epoch 1 to rebuild epoch 2", err)
+
+	require.NoError(t, db.SaveStateDiff(ctx, 0, d))
+	require.NoError(t, db.SaveStateDiff(ctx, 1, d))
+	_, err = db.StateDiffs(ctx, 2)
+	assert.ErrorContains(t, "no state snapshot to rebuild epoch 2", err)
+}
@@ -7,14 +7,19 @@ import (
 
 	types "github.com/prysmaticlabs/eth2-types"
 	iface "github.com/prysmaticlabs/prysm/beacon-chain/state/interface"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/v1"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
 	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
 	"github.com/prysmaticlabs/prysm/proto/eth/v1alpha1/wrapper"
 	"github.com/prysmaticlabs/prysm/proto/interfaces"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/hashutil"
 	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/prysmaticlabs/prysm/shared/testutil"
 	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
 	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	bolt "go.etcd.io/bbolt"
+	"google.golang.org/protobuf/proto"
 	"gopkg.in/d4l3k/messagediff.v1"
 )
 
@@ -342,3 +347,208 @@ func TestStore_CleanUpDirtyStates_DontDeleteNonFinalized(t *testing.T) {
 		require.Equal(t, true, db.HasState(context.Background(), rt))
 	}
 }
+
+func TestStore_StatesShareValidatorRegistry(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+	// Every state modifies a validator and adds another, so the fourth state patches more than
+	// 1/8 of the validators of the first one.
+	states := registryTestStates(t, 32, 1, 5)
+
+	for i, st := range states {
+		s, err := v1.InitializeFromProtoUnsafe(st)
+		require.NoError(t, err)
+		require.NoError(t, db.SaveState(ctx, s, [32]byte{byte(i)}))
+	}
+	assert.Equal(t, 2, validatorRegistryCount(t, db))
+
+	// A state with a smaller registry can't be stored against the latest registry.
+	s, err := v1.InitializeFromProtoUnsafe(states[0])
+	require.NoError(t, err)
+	require.NoError(t, db.SaveState(ctx, s, [32]byte{'A'}))
+	assert.Equal(t, 3, validatorRegistryCount(t, db))
+
+	for i, st := range states {
+		saved, err := db.State(ctx, [32]byte{byte(i)})
+		require.NoError(t, err)
+		assert.DeepSSZEqual(t, st, saved.InnerStateUnsafe(), "Did not retrieve saved state %d", i)
+	}
+	saved, err := db.State(ctx, [32]byte{'A'})
+	require.NoError(t, err)
+	assert.DeepSSZEqual(t, states[0], saved.InnerStateUnsafe(), "Did not retrieve saved state")
+}
+
+func TestStore_DeleteStates_DeletesUnreferencedRegistries(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+	// The first four states share a registry, the last one is stored against a new one.
+	states := registryTestStates(t, 32, 1, 5)
+	roots := make([][32]byte, len(states))
+	for i, st := range states {
+		s, err := v1.InitializeFromProtoUnsafe(st)
+		require.NoError(t, err)
+		roots[i] = [32]byte{byte(i + 1)}
+		require.NoError(t, db.SaveState(ctx, s, roots[i]))
+	}
+	assert.Equal(t, 2, validatorRegistryCount(t, db))
+
+	// Overwriting a state keeps its registry referenced.
+	s, err := v1.InitializeFromProtoUnsafe(states[4])
+	require.NoError(t, err)
+	require.NoError(t, db.SaveState(ctx, s, roots[4]))
+	require.NoError(t, db.DeleteStates(ctx, roots[:1]))
+	assert.Equal(t, 2, validatorRegistryCount(t, db))
+
+	require.NoError(t, db.DeleteStates(ctx, roots[1:4]))
+	assert.Equal(t, 1, validatorRegistryCount(t, db))
+	saved, err := db.State(ctx, roots[4])
+	require.NoError(t, err)
+	assert.DeepSSZEqual(t, states[4], saved.InnerStateUnsafe(), "Did not retrieve saved state")
+
+	// The latest registry is kept while unreferenced, until a new registry replaces it.
+	require.NoError(t, db.DeleteState(ctx, roots[4]))
+	assert.Equal(t, 1, validatorRegistryCount(t, db))
+	s, err = v1.InitializeFromProtoUnsafe(states[0])
+	require.NoError(t, err)
+	require.NoError(t, db.SaveState(ctx, s, roots[0]))
+	assert.Equal(t, 1, validatorRegistryCount(t, db))
+}
+
+func TestStore_State_FullEncoding(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+	st := registryTestStates(t, 8, 0, 1)[0]
+	r := [32]byte{'A'}
+
+	// States saved before the registry encoding was introduced remain readable.
+	enc, err := encode(ctx, st)
+	require.NoError(t, err)
+	require.NoError(t, db.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(stateBucket).Put(r[:], enc)
+	}))
+	saved, err := db.State(ctx, r)
+	require.NoError(t, err)
+	assert.DeepSSZEqual(t, st, saved.InnerStateUnsafe(), "Did not retrieve saved state")
+}
+
+func BenchmarkStateEncoding(b *testing.B) {
+	// A sequence of epoch boundary states, in which every balance and a few validators change.
+	states := registryTestStates(b, 16384, 16, 32)
+	full := func(ctx context.Context, _ *bolt.Tx, st *pb.BeaconState) ([]byte, error) {
+		return encode(ctx, st)
+	}
+	for _, tt := range []struct {
+		name   string
+		encode func(context.Context, *bolt.Tx, *pb.BeaconState) ([]byte, error)
+	}{
+		{name: "full", encode: full},
+		{name: "registry_diff", encode: encodeState},
+	} {
+		b.Run(tt.name+"/save", func(b *testing.B) {
+			db := setupDB(b)
+			ctx := context.Background()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				require.NoError(b, db.db.Update(func(tx *bolt.Tx) error {
+					enc, err := tt.encode(ctx, tx, states[i%len(states)])
+					if err != nil {
+						return err
+					}
+					return tx.Bucket(stateBucket).Put(bytesutil.Uint64ToBytesBigEndian(uint64(i)), enc)
+				}))
+			}
+			b.StopTimer()
+			// Disk usage includes the validator registries states are stored against.
+			var size int
+			require.NoError(b, db.db.View(func(tx *bolt.Tx) error {
+				for _, bkt := range [][]byte{stateBucket, stateValidatorsBucket} {
+					if err := tx.Bucket(bkt).ForEach(func(_, v []byte) error {
+						size += len(v)
+						return nil
+					}); err != nil {
+						return err
+					}
+				}
+				return nil
+			}))
+			b.ReportMetric(float64(size)/float64(b.N), "disk-bytes/state")
+		})
+
+		b.Run(tt.name+"/read", func(b *testing.B) {
+			db := setupDB(b)
+			ctx := context.Background()
+			require.NoError(b, db.db.Update(func(tx *bolt.Tx) error {
+				for i, st := range states {
+					enc, err := tt.encode(ctx, tx, st)
+					if err != nil {
+						return err
+					}
+					r := bytesutil.ToBytes32(bytesutil.Uint64ToBytesBigEndian(uint64(i)))
+					if err := tx.Bucket(stateBucket).Put(r[:], enc); err != nil {
+						return err
+					}
+				}
+				return nil
+			}))
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				st, err := db.State(ctx, bytesutil.ToBytes32(bytesutil.Uint64ToBytesBigEndian(uint64(i%len(states)))))
+				require.NoError(b, err)
+				require.NotNil(b, st)
+			}
+		})
+	}
+}
+
+// registryTestStates returns a sequence of states, one per epoch, starting with the given
+// number of validators. Every following state modifies the given number of validators,
+// adds a validator and changes every balance.
+func registryTestStates(t testing.TB, validatorCount, changed, count int) []*pb.BeaconState {
+	st, err := testutil.NewBeaconState()
+	require.NoError(t, err)
+	base, err := v1.ProtobufBeaconState(st.CloneInnerState())
+	require.NoError(t, err)
+	// Public keys and withdrawal credentials are random looking, so that they compress like
+	// real ones.
+	newValidator := func(i int) *ethpb.Validator {
+		h := hashutil.Hash(bytesutil.Bytes8(uint64(i)))
+		creds := hashutil.Hash(h[:])
+		return &ethpb.Validator{
+			PublicKey:             append(h[:], creds[:16]...),
+			WithdrawalCredentials: creds[:],
+			EffectiveBalance:      params.BeaconConfig().MaxEffectiveBalance,
+			ExitEpoch:             params.BeaconConfig().FarFutureEpoch,
+			WithdrawableEpoch:     params.BeaconConfig().FarFutureEpoch,
+		}
+	}
+	for i := 0; i < validatorCount; i++ {
+		base.Validators = append(base.Validators, newValidator(i))
+		base.Balances = append(base.Balances, params.BeaconConfig().MaxEffectiveBalance)
+	}
+
+	states := []*pb.BeaconState{base}
+	for e := 1; e < count; e++ {
+		next := proto.Clone(states[e-1]).(*pb.BeaconState)
+		next.Slot += params.BeaconConfig().SlotsPerEpoch
+		for i := 0; i < changed; i++ {
+			v := next.Validators[((e-1)*changed+i)%len(next.Validators)]
+			v.EffectiveBalance -= params.BeaconConfig().EffectiveBalanceIncrement
+		}
+		next.Validators = append(next.Validators, newValidator(len(next.Validators)))
+		next.Balances = append(next.Balances, params.BeaconConfig().MaxEffectiveBalance)
+		for i := range next.Balances {
+			next.Balances[i] += uint64(i%7) * 1000
+		}
+		states = append(states, next)
+	}
+	return states
+}
+
+func validatorRegistryCount(t *testing.T, db *Store) int {
+	var count int
+	require.NoError(t, db.db.View(func(tx *bolt.Tx) error {
+		count = tx.Bucket(stateValidatorsBucket).Stats().KeyN
+		return nil
+	}))
+	return count
+}
@@ -18,6 +18,8 @@ import (
 var _ iface.SlasherDatabase = (*Store)(nil)
 
 const (
+	// SlasherDbDirName is the name of the directory containing the slasher database.
+	SlasherDbDirName = "slasherdata"
 	// DatabaseFileName is the name of the beacon node database.
 	DatabaseFileName = "slasher.db"
 	boltAllocSize    = 8 * 1024 * 1024
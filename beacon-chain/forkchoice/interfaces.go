@@ -2,6 +2,7 @@ package forkchoice
 
 import (
 	"context"
+	"time"
 
 	types "github.com/prysmaticlabs/eth2-types"
 	"github.com/prysmaticlabs/prysm/beacon-chain/forkchoice/protoarray"
@@ -14,6 +15,8 @@ type ForkChoicer interface {
 	AttestationProcessor // to track new attestation for fork choice.
 	Pruner               // to clean old data for fork choice.
 	Getter               // to retrieve fork choice information.
+	ProposerBooster      // to boost the weight of timely blocks.
+	SlashingProcessor    // to discount the votes of equivocating validators.
 }
 
 // HeadRetriever retrieves head root of the current chain.
@@ -31,6 +34,17 @@ type AttestationProcessor interface {
 	ProcessAttestation(context.Context, []uint64, [32]byte, types.Epoch)
 }
 
+// ProposerBooster boosts the weight of the block proposed on time for the current slot.
+type ProposerBooster interface {
+	BoostProposerRoot(ctx context.Context, blockSlot types.Slot, blockRoot [32]byte, genesisTime time.Time)
+	ResetBoostedProposerRoot(ctx context.Context)
+}
+
+// SlashingProcessor processes the validators found equivocating, so their votes are not counted for fork choice.
+type SlashingProcessor interface {
+	InsertSlashedIndex(context.Context, types.ValidatorIndex)
+}
+
 // Pruner prunes the fork choice upon new finalization. This is used to keep fork choice sane.
 type Pruner interface {
 	Prune(context.Context, [32]byte) error
@@ -45,4 +59,5 @@ type Getter interface {
 	HasParent(root [32]byte) bool
 	AncestorRoot(ctx context.Context, root [32]byte, slot types.Slot) ([]byte, error)
 	IsCanonical(root [32]byte) bool
+	ProposerBoostRoot() [32]byte
 }
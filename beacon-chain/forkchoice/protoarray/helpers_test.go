@@ -25,7 +25,7 @@ func TestComputeDelta_ZeroHash(t *testing.T) {
 		newBalances = append(newBalances, 0)
 	}
 
-	delta, _, err := computeDeltas(context.Background(), indices, votes, oldBalances, newBalances)
+	delta, _, err := computeDeltas(context.Background(), indices, votes, oldBalances, newBalances, map[uint64]bool{})
 	require.NoError(t, err)
 	assert.Equal(t, int(validatorCount), len(delta))
 
@@ -52,7 +52,7 @@ func TestComputeDelta_AllVoteTheSame(t *testing.T) {
 		newBalances = append(newBalances, balance)
 	}
 
-	delta, _, err := computeDeltas(context.Background(), indices, votes, oldBalances, newBalances)
+	delta, _, err := computeDeltas(context.Background(), indices, votes, oldBalances, newBalances, map[uint64]bool{})
 	require.NoError(t, err)
 	assert.Equal(t, int(validatorCount), len(delta))
 
@@ -84,7 +84,7 @@ func TestComputeDelta_DifferentVotes(t *testing.T) {
 		newBalances = append(newBalances, balance)
 	}
 
-	delta, _, err := computeDeltas(context.Background(), indices, votes, oldBalances, newBalances)
+	delta, _, err := computeDeltas(context.Background(), indices, votes, oldBalances, newBalances, map[uint64]bool{})
 	require.NoError(t, err)
 	assert.Equal(t, int(validatorCount), len(delta))
 
@@ -113,7 +113,7 @@ func TestComputeDelta_MovingVotes(t *testing.T) {
 		newBalances = append(newBalances, balance)
 	}
 
-	delta, _, err := computeDeltas(context.Background(), indices, votes, oldBalances, newBalances)
+	delta, _, err := computeDeltas(context.Background(), indices, votes, oldBalances, newBalances, map[uint64]bool{})
 	require.NoError(t, err)
 	assert.Equal(t, int(validatorCount), len(delta))
 
@@ -145,7 +145,7 @@ func TestComputeDelta_MoveOutOfTree(t *testing.T) {
 		Vote{indexToHash(1), params.BeaconConfig().ZeroHash, 0},
 		Vote{indexToHash(1), [32]byte{'A'}, 0})
 
-	delta, _, err := computeDeltas(context.Background(), indices, votes, oldBalances, newBalances)
+	delta, _, err := computeDeltas(context.Background(), indices, votes, oldBalances, newBalances, map[uint64]bool{})
 	require.NoError(t, err)
 	assert.Equal(t, 1, len(delta))
 	assert.Equal(t, 0-2*int(balance), delta[0])
@@ -173,7 +173,7 @@ func TestComputeDelta_ChangingBalances(t *testing.T) {
 		newBalances = append(newBalances, newBalance)
 	}
 
-	delta, _, err := computeDeltas(context.Background(), indices, votes, oldBalances, newBalances)
+	delta, _, err := computeDeltas(context.Background(), indices, votes, oldBalances, newBalances, map[uint64]bool{})
 	require.NoError(t, err)
 	assert.Equal(t, 16, len(delta))
 
@@ -206,7 +206,7 @@ func TestComputeDelta_ValidatorAppear(t *testing.T) {
 		Vote{indexToHash(1), indexToHash(2), 0},
 		Vote{indexToHash(1), indexToHash(2), 0})
 
-	delta, _, err := computeDeltas(context.Background(), indices, votes, oldBalances, newBalances)
+	delta, _, err := computeDeltas(context.Background(), indices, votes, oldBalances, newBalances, map[uint64]bool{})
 	require.NoError(t, err)
 	assert.Equal(t, 2, len(delta))
 	assert.Equal(t, 0-int(balance), delta[0])
@@ -231,7 +231,7 @@ func TestComputeDelta_ValidatorDisappears(t *testing.T) {
 		Vote{indexToHash(1), indexToHash(2), 0},
 		Vote{indexToHash(1), indexToHash(2), 0})
 
-	delta, _, err := computeDeltas(context.Background(), indices, votes, oldBalances, newBalances)
+	delta, _, err := computeDeltas(context.Background(), indices, votes, oldBalances, newBalances, map[uint64]bool{})
 	require.NoError(t, err)
 	assert.Equal(t, 2, len(delta))
 	assert.Equal(t, 0-2*int(balance), delta[0])
@@ -242,6 +242,31 @@ func TestComputeDelta_ValidatorDisappears(t *testing.T) {
 	}
 }
 
+func TestComputeDelta_EquivocatingValidators(t *testing.T) {
+	indices := map[[32]byte]uint64{indexToHash(1): 0, indexToHash(2): 1}
+	votes := []Vote{
+		{indexToHash(1), indexToHash(1), 0},
+		{indexToHash(1), indexToHash(2), 0},
+		{indexToHash(2), indexToHash(2), 0},
+	}
+	balances := []uint64{10, 20, 30}
+	equivocating := map[uint64]bool{0: true, 1: true}
+
+	delta, votes, err := computeDeltas(context.Background(), indices, votes, balances, balances, equivocating)
+	require.NoError(t, err)
+	// The votes of the equivocating validators are removed from their current block and
+	// the new vote of validator 1 is not counted.
+	assert.DeepEqual(t, []int{-30, 0}, delta)
+	assert.Equal(t, params.BeaconConfig().ZeroHash, votes[0].currentRoot)
+	assert.Equal(t, params.BeaconConfig().ZeroHash, votes[1].nextRoot)
+	assert.Equal(t, indexToHash(2), votes[2].currentRoot)
+
+	// The votes are not accounted for anymore.
+	delta, _, err = computeDeltas(context.Background(), indices, votes, balances, balances, equivocating)
+	require.NoError(t, err)
+	assert.DeepEqual(t, []int{0, 0}, delta)
+}
+
 func indexToHash(i uint64) [32]byte {
 	var b [8]byte
 	binary.LittleEndian.PutUint64(b[:], i)
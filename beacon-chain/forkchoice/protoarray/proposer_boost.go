@@ -0,0 +1,114 @@
+package protoarray
+
+import (
+	"context"
+	"time"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/timeutils"
+	"go.opencensus.io/trace"
+)
+
+// BoostProposerRoot sets the block root which should be boosted during
+// the LMD fork choice algorithm calculation. The block is only boosted when it is
+// received in its own slot, before the attesting interval of the slot starts.
+//
+// Spec pseudocode definition:
+//   # Add proposer score boost if the block is timely
+//   time_into_slot = (store.time - store.genesis_time) % SECONDS_PER_SLOT
+//   is_before_attesting_interval = time_into_slot < SECONDS_PER_SLOT // INTERVALS_PER_SLOT
+//   if get_current_slot(store) == block.slot and is_before_attesting_interval:
+//       store.proposer_boost_root = hash_tree_root(block)
+func (f *ForkChoice) BoostProposerRoot(ctx context.Context, blockSlot types.Slot, blockRoot [32]byte, genesisTime time.Time) {
+	_, span := trace.StartSpan(ctx, "protoArrayForkChoice.BoostProposerRoot")
+	defer span.End()
+
+	f.boostProposerRoot(blockSlot, blockRoot, genesisTime, timeutils.Now())
+}
+
+func (f *ForkChoice) boostProposerRoot(blockSlot types.Slot, blockRoot [32]byte, genesisTime, now time.Time) {
+	if now.Before(genesisTime) {
+		return
+	}
+	secondsPerSlot := params.BeaconConfig().SecondsPerSlot
+	sinceGenesis := uint64(now.Sub(genesisTime) / time.Second)
+	currentSlot := types.Slot(sinceGenesis / secondsPerSlot)
+	timeIntoSlot := sinceGenesis % secondsPerSlot
+	if currentSlot != blockSlot || timeIntoSlot >= secondsPerSlot/params.BeaconConfig().IntervalsPerSlot {
+		return
+	}
+
+	f.proposerBoostLock.Lock()
+	defer f.proposerBoostLock.Unlock()
+	f.proposerBoostRoot = blockRoot
+}
+
+// ResetBoostedProposerRoot resets the block root which should be boosted during
+// the LMD fork choice algorithm calculation. It is called at the start of every slot.
+func (f *ForkChoice) ResetBoostedProposerRoot(ctx context.Context) {
+	_, span := trace.StartSpan(ctx, "protoArrayForkChoice.ResetBoostedProposerRoot")
+	defer span.End()
+
+	f.proposerBoostLock.Lock()
+	defer f.proposerBoostLock.Unlock()
+	f.proposerBoostRoot = [32]byte{}
+}
+
+// ProposerBoostRoot returns the block root currently boosted in fork choice, or the zero
+// root when no block is boosted.
+func (f *ForkChoice) ProposerBoostRoot() [32]byte {
+	f.proposerBoostLock.RLock()
+	defer f.proposerBoostLock.RUnlock()
+	return f.proposerBoostRoot
+}
+
+// applyProposerBoostScore removes the boost given to the previously boosted block from the
+// deltas, and adds the boost of the currently boosted block computed from the justified balances.
+// The caller must hold the nodes lock.
+func (f *ForkChoice) applyProposerBoostScore(deltas []int, newBalances []uint64) error {
+	f.proposerBoostLock.Lock()
+	defer f.proposerBoostLock.Unlock()
+
+	if f.previousProposerBoostScore > 0 {
+		if i, ok := f.store.nodesIndices[f.previousProposerBoostRoot]; ok {
+			if int(i) >= len(deltas) {
+				return errInvalidNodeDelta
+			}
+			deltas[i] -= int(f.previousProposerBoostScore)
+		}
+	}
+	f.previousProposerBoostRoot = [32]byte{}
+	f.previousProposerBoostScore = 0
+
+	if f.proposerBoostRoot == [32]byte{} {
+		return nil
+	}
+	i, ok := f.store.nodesIndices[f.proposerBoostRoot]
+	if !ok {
+		return nil
+	}
+	if int(i) >= len(deltas) {
+		return errInvalidNodeDelta
+	}
+	score := computeProposerBoostScore(newBalances)
+	deltas[i] += int(score)
+	f.previousProposerBoostRoot = f.proposerBoostRoot
+	f.previousProposerBoostScore = score
+	return nil
+}
+
+// This computes the score given to a timely block, as a share of the weight of the
+// committees of a slot.
+//
+// Spec pseudocode definition:
+//   committee_weight = get_total_active_balance(state) // SLOTS_PER_EPOCH
+//   proposer_score = (committee_weight * PROPOSER_SCORE_BOOST) // 100
+func computeProposerBoostScore(balances []uint64) uint64 {
+	var total uint64
+	for _, b := range balances {
+		total += b
+	}
+	committeeWeight := total / uint64(params.BeaconConfig().SlotsPerEpoch)
+	return committeeWeight * params.BeaconConfig().ProposerScoreBoost / 100
+}
@@ -0,0 +1,162 @@
+package protoarray
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+// boostTestBalances returns balances giving a proposer boost score of 14, more than a
+// single vote of 10 but less than two.
+func boostTestBalances() []uint64 {
+	balances := make([]uint64, 2*params.BeaconConfig().SlotsPerEpoch)
+	for i := range balances {
+		balances[i] = 10
+	}
+	return balances
+}
+
+func TestForkChoice_BoostProposerRoot(t *testing.T) {
+	secondsPerSlot := time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second
+	genesis := time.Unix(1600000000, 0)
+	root := indexToHash(1)
+
+	tests := []struct {
+		name    string
+		slot    types.Slot
+		now     time.Time
+		boosted bool
+	}{
+		{name: "timely block", slot: 2, now: genesis.Add(2 * secondsPerSlot).Add(time.Second), boosted: true},
+		{name: "block received after the attesting interval", slot: 2, now: genesis.Add(2 * secondsPerSlot).Add(secondsPerSlot / 3)},
+		{name: "block of a previous slot", slot: 1, now: genesis.Add(2 * secondsPerSlot)},
+		{name: "before genesis", slot: 0, now: genesis.Add(-time.Second)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := setup(0, 0)
+			f.boostProposerRoot(tt.slot, root, genesis, tt.now)
+			if tt.boosted {
+				assert.Equal(t, root, f.ProposerBoostRoot())
+			} else {
+				assert.Equal(t, [32]byte{}, f.ProposerBoostRoot())
+			}
+		})
+	}
+
+	f := setup(0, 0)
+	f.boostProposerRoot(2, root, genesis, genesis.Add(2*secondsPerSlot))
+	f.ResetBoostedProposerRoot(context.Background())
+	assert.Equal(t, [32]byte{}, f.ProposerBoostRoot())
+}
+
+// Ported from the proposer boost fork choice spec tests: a timely block becomes the head
+// over a competing block with less attesting weight than the boost, until the end of its slot.
+func TestForkChoice_ProposerBoost_Head(t *testing.T) {
+	ctx := context.Background()
+	balances := boostTestBalances()
+	genesis := time.Unix(1600000000, 0)
+	slotStart := genesis.Add(time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second)
+	f := setup(1, 1)
+
+	// Insert blocks 1 and 2 competing for slot 1:
+	//         0
+	//        / \
+	//       1   2
+	require.NoError(t, f.ProcessBlock(ctx, 1, indexToHash(1), params.BeaconConfig().ZeroHash, [32]byte{}, 1, 1))
+	f.ProcessAttestation(ctx, []uint64{0}, indexToHash(1), 1)
+	r, err := f.Head(ctx, 1, params.BeaconConfig().ZeroHash, balances, 1)
+	require.NoError(t, err)
+	assert.Equal(t, indexToHash(1), r, "Incorrect head")
+
+	// Block 2 is timely and its boost outweighs the vote for block 1.
+	require.NoError(t, f.ProcessBlock(ctx, 1, indexToHash(2), params.BeaconConfig().ZeroHash, [32]byte{}, 1, 1))
+	f.boostProposerRoot(1, indexToHash(2), genesis, slotStart)
+	r, err = f.Head(ctx, 1, params.BeaconConfig().ZeroHash, balances, 1)
+	require.NoError(t, err)
+	assert.Equal(t, indexToHash(2), r, "Incorrect head with proposer boost")
+	assert.Equal(t, uint64(14), f.store.nodes[f.store.nodesIndices[indexToHash(2)]].weight)
+
+	// Computing the head again does not apply the boost twice.
+	r, err = f.Head(ctx, 1, params.BeaconConfig().ZeroHash, balances, 1)
+	require.NoError(t, err)
+	assert.Equal(t, indexToHash(2), r, "Incorrect head with proposer boost")
+	assert.Equal(t, uint64(14), f.store.nodes[f.store.nodesIndices[indexToHash(2)]].weight)
+
+	// Two votes for block 1 outweigh the boost.
+	f.ProcessAttestation(ctx, []uint64{1}, indexToHash(1), 1)
+	r, err = f.Head(ctx, 1, params.BeaconConfig().ZeroHash, balances, 1)
+	require.NoError(t, err)
+	assert.Equal(t, indexToHash(1), r, "Incorrect head with more votes than the boost")
+
+	// Moving the votes to block 2 and resetting the boost at the next slot keeps block 2 as head,
+	// only weighted by the votes.
+	f.ProcessAttestation(ctx, []uint64{0, 1}, indexToHash(2), 2)
+	f.ResetBoostedProposerRoot(ctx)
+	r, err = f.Head(ctx, 1, params.BeaconConfig().ZeroHash, balances, 1)
+	require.NoError(t, err)
+	assert.Equal(t, indexToHash(2), r, "Incorrect head after boost reset")
+	assert.Equal(t, uint64(20), f.store.nodes[f.store.nodesIndices[indexToHash(2)]].weight)
+	assert.Equal(t, uint64(0), f.store.nodes[f.store.nodesIndices[indexToHash(1)]].weight)
+}
+
+// Ported from the proposer boost fork choice spec tests: an untimely block of the same slot
+// does not get the boost.
+func TestForkChoice_ProposerBoost_UntimelyBlock(t *testing.T) {
+	ctx := context.Background()
+	balances := boostTestBalances()
+	genesis := time.Unix(1600000000, 0)
+	lateInSlot := genesis.Add(time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second * 3 / 2)
+	f := setup(1, 1)
+
+	require.NoError(t, f.ProcessBlock(ctx, 1, indexToHash(1), params.BeaconConfig().ZeroHash, [32]byte{}, 1, 1))
+	f.ProcessAttestation(ctx, []uint64{0}, indexToHash(1), 1)
+	require.NoError(t, f.ProcessBlock(ctx, 1, indexToHash(2), params.BeaconConfig().ZeroHash, [32]byte{}, 1, 1))
+	f.boostProposerRoot(1, indexToHash(2), genesis, lateInSlot)
+	r, err := f.Head(ctx, 1, params.BeaconConfig().ZeroHash, balances, 1)
+	require.NoError(t, err)
+	assert.Equal(t, indexToHash(1), r, "Untimely block should not be boosted")
+}
+
+// Ported from the equivocation fork choice spec tests: the votes of validators found slashable
+// are removed from the tree, and their later attestations are ignored.
+func TestForkChoice_EquivocatingValidators(t *testing.T) {
+	ctx := context.Background()
+	balances := boostTestBalances()
+	f := setup(1, 1)
+
+	//         0
+	//        / \
+	//       1   2
+	//       |
+	//       3
+	require.NoError(t, f.ProcessBlock(ctx, 1, indexToHash(1), params.BeaconConfig().ZeroHash, [32]byte{}, 1, 1))
+	require.NoError(t, f.ProcessBlock(ctx, 1, indexToHash(2), params.BeaconConfig().ZeroHash, [32]byte{}, 1, 1))
+	require.NoError(t, f.ProcessBlock(ctx, 2, indexToHash(3), indexToHash(1), [32]byte{}, 1, 1))
+	f.ProcessAttestation(ctx, []uint64{0, 1}, indexToHash(3), 1)
+	f.ProcessAttestation(ctx, []uint64{2}, indexToHash(2), 1)
+	r, err := f.Head(ctx, 1, params.BeaconConfig().ZeroHash, balances, 1)
+	require.NoError(t, err)
+	assert.Equal(t, indexToHash(3), r, "Incorrect head")
+
+	// Validators 0 and 1 are slashed, their votes for block 3 are removed.
+	f.InsertSlashedIndex(ctx, 0)
+	f.InsertSlashedIndex(ctx, 1)
+	r, err = f.Head(ctx, 1, params.BeaconConfig().ZeroHash, balances, 1)
+	require.NoError(t, err)
+	assert.Equal(t, indexToHash(2), r, "Incorrect head after slashing")
+	assert.Equal(t, uint64(0), f.store.nodes[f.store.nodesIndices[indexToHash(1)]].weight)
+	assert.Equal(t, uint64(0), f.store.nodes[f.store.nodesIndices[indexToHash(3)]].weight)
+
+	// The next attestations of the slashed validators are not counted.
+	f.ProcessAttestation(ctx, []uint64{0, 1}, indexToHash(3), 2)
+	r, err = f.Head(ctx, 1, params.BeaconConfig().ZeroHash, balances, 1)
+	require.NoError(t, err)
+	assert.Equal(t, indexToHash(2), r, "Slashed validator votes should be ignored")
+	assert.Equal(t, uint64(0), f.store.nodes[f.store.nodesIndices[indexToHash(3)]].weight)
+}
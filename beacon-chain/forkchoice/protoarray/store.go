@@ -28,6 +28,8 @@ func New(justifiedEpoch, finalizedEpoch types.Epoch, finalizedRoot [32]byte) *Fo
 		nodesIndices:   make(map[[32]byte]uint64),
 		canonicalNodes: make(map[[32]byte]bool),
 		pruneThreshold: defaultPruneThreshold,
+
+		equivocatingIndices: make(map[uint64]bool),
 	}
 
 	b := make([]uint64, 0)
@@ -57,12 +59,16 @@ func (f *ForkChoice) Head(
 	// Using the write lock here because `updateCanonicalNodes` that gets called subsequently requires a write operation.
 	f.store.nodesLock.Lock()
 	defer f.store.nodesLock.Unlock()
-	deltas, newVotes, err := computeDeltas(ctx, f.store.nodesIndices, f.votes, f.balances, newBalances)
+	deltas, newVotes, err := computeDeltas(ctx, f.store.nodesIndices, f.votes, f.balances, newBalances, f.store.equivocatingIndices)
 	if err != nil {
 		return [32]byte{}, errors.Wrap(err, "Could not compute deltas")
 	}
 	f.votes = newVotes
 
+	if err := f.applyProposerBoostScore(deltas, newBalances); err != nil {
+		return [32]byte{}, errors.Wrap(err, "Could not apply proposer boost score")
+	}
+
 	if err := f.store.applyWeightChanges(ctx, justifiedEpoch, finalizedEpoch, deltas); err != nil {
 		return [32]byte{}, errors.Wrap(err, "Could not apply score changes")
 	}
@@ -78,8 +84,15 @@ func (f *ForkChoice) ProcessAttestation(ctx context.Context, validatorIndices []
 	defer span.End()
 	f.votesLock.Lock()
 	defer f.votesLock.Unlock()
+	f.store.nodesLock.RLock()
+	defer f.store.nodesLock.RUnlock()
 
 	for _, index := range validatorIndices {
+		// The votes of equivocating validators are not counted.
+		if f.store.equivocatingIndices[index] {
+			continue
+		}
+
 		// Validator indices will grow the vote cache.
 		for index >= uint64(len(f.votes)) {
 			f.votes = append(f.votes, Vote{currentRoot: params.BeaconConfig().ZeroHash, nextRoot: params.BeaconConfig().ZeroHash})
@@ -99,6 +112,21 @@ func (f *ForkChoice) ProcessAttestation(ctx context.Context, validatorIndices []
 	processedAttestationCount.Inc()
 }
 
+// InsertSlashedIndex adds the index of a validator found equivocating by an attester slashing
+// to the fork choice store. Its vote is removed from the block weights on the next head
+// computation and its later attestations are ignored.
+func (f *ForkChoice) InsertSlashedIndex(ctx context.Context, index types.ValidatorIndex) {
+	_, span := trace.StartSpan(ctx, "protoArrayForkChoice.InsertSlashedIndex")
+	defer span.End()
+	f.store.nodesLock.Lock()
+	defer f.store.nodesLock.Unlock()
+
+	if f.store.equivocatingIndices == nil {
+		f.store.equivocatingIndices = make(map[uint64]bool)
+	}
+	f.store.equivocatingIndices[uint64(index)] = true
+}
+
 // ProcessBlock processes a new block by inserting it to the fork choice store.
 func (f *ForkChoice) ProcessBlock(
 	ctx context.Context,
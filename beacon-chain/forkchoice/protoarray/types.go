@@ -12,6 +12,11 @@ type ForkChoice struct {
 	votes     []Vote // tracks individual validator's last vote.
 	votesLock sync.RWMutex
 	balances  []uint64 // tracks individual validator's last justified balances.
+
+	proposerBoostRoot          [32]byte // root of the timely block of the current slot, boosted in fork choice.
+	previousProposerBoostRoot  [32]byte // root of the block boosted in the last head computation.
+	previousProposerBoostScore uint64   // score applied to the block boosted in the last head computation.
+	proposerBoostLock          sync.RWMutex
 }
 
 // Store defines the fork choice store which includes block nodes and the last view of checkpoint information.
@@ -24,6 +29,8 @@ type Store struct {
 	nodesIndices   map[[32]byte]uint64 // the root of block node and the nodes index in the list.
 	canonicalNodes map[[32]byte]bool   // the canonical block nodes.
 	nodesLock      sync.RWMutex
+	// equivocatingIndices are the validators found slashable by an attester slashing, whose votes are not counted.
+	equivocatingIndices map[uint64]bool
 }
 
 // Node defines the individual block which includes its block parent, ancestor and how much weight accounted for it.
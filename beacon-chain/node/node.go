@@ -21,6 +21,7 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/slasherkv"
 	"github.com/prysmaticlabs/prysm/beacon-chain/forkchoice"
 	"github.com/prysmaticlabs/prysm/beacon-chain/forkchoice/protoarray"
 	gateway2 "github.com/prysmaticlabs/prysm/beacon-chain/gateway"
@@ -28,6 +29,7 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/node/registration"
 	"github.com/prysmaticlabs/prysm/beacon-chain/operations/attestations"
 	"github.com/prysmaticlabs/prysm/beacon-chain/operations/slashings"
+	"github.com/prysmaticlabs/prysm/beacon-chain/operations/synccommittee"
 	"github.com/prysmaticlabs/prysm/beacon-chain/operations/voluntaryexits"
 	"github.com/prysmaticlabs/prysm/beacon-chain/orchestrator"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
@@ -60,23 +62,25 @@ const testSkipPowFlag = "test-skip-pow"
 // full PoS node. It handles the lifecycle of the entire system and registers
 // services to a service registry.
 type BeaconNode struct {
-	cliCtx          *cli.Context
-	ctx             context.Context
-	cancel          context.CancelFunc
-	services        *shared.ServiceRegistry
-	lock            sync.RWMutex
-	stop            chan struct{} // Channel to wait for termination notifications.
-	db              db.Database
-	attestationPool attestations.Pool
-	exitPool        voluntaryexits.PoolManager
-	slashingsPool   slashings.PoolManager
-	depositCache    *depositcache.DepositCache
-	stateFeed       *event.Feed
-	blockFeed       *event.Feed
-	opFeed          *event.Feed
-	forkChoiceStore forkchoice.ForkChoicer
-	stateGen        *stategen.State
-	collector       *bcnodeCollector
+	cliCtx            *cli.Context
+	ctx               context.Context
+	cancel            context.CancelFunc
+	services          *shared.ServiceRegistry
+	lock              sync.RWMutex
+	stop              chan struct{} // Channel to wait for termination notifications.
+	db                db.Database
+	slasherDB         db.SlasherDatabase
+	attestationPool   attestations.Pool
+	exitPool          voluntaryexits.PoolManager
+	slashingsPool     slashings.PoolManager
+	syncCommitteePool synccommittee.Pool
+	depositCache      *depositcache.DepositCache
+	stateFeed         *event.Feed
+	blockFeed         *event.Feed
+	opFeed            *event.Feed
+	forkChoiceStore   forkchoice.ForkChoicer
+	stateGen          *stategen.State
+	collector         *bcnodeCollector
 }
 
 // New creates a new node instance, sets up configuration options, and registers
@@ -100,17 +104,18 @@ func New(cliCtx *cli.Context) (*BeaconNode, error) {
 
 	ctx, cancel := context.WithCancel(cliCtx.Context)
 	beacon := &BeaconNode{
-		cliCtx:          cliCtx,
-		ctx:             ctx,
-		cancel:          cancel,
-		services:        registry,
-		stop:            make(chan struct{}),
-		stateFeed:       new(event.Feed),
-		blockFeed:       new(event.Feed),
-		opFeed:          new(event.Feed),
-		attestationPool: attestations.NewPool(),
-		exitPool:        voluntaryexits.NewPool(),
-		slashingsPool:   slashings.NewPool(),
+		cliCtx:            cliCtx,
+		ctx:               ctx,
+		cancel:            cancel,
+		services:          registry,
+		stop:              make(chan struct{}),
+		stateFeed:         new(event.Feed),
+		blockFeed:         new(event.Feed),
+		opFeed:            new(event.Feed),
+		attestationPool:   attestations.NewPool(),
+		exitPool:          voluntaryexits.NewPool(),
+		slashingsPool:     slashings.NewPool(),
+		syncCommitteePool: synccommittee.NewStore(),
 	}
 
 	depositAddress, err := registration.DepositContractAddress()
@@ -121,6 +126,12 @@ func New(cliCtx *cli.Context) (*BeaconNode, error) {
 		return nil, err
 	}
 
+	if cliCtx.Bool(flags.EnableSlasherRPCEndpoints.Name) {
+		if err := beacon.startSlasherDB(cliCtx); err != nil {
+			return nil, err
+		}
+	}
+
 	beacon.startStateGen()
 
 	if err := beacon.registerP2P(cliCtx); err != nil {
@@ -240,6 +251,11 @@ func (b *BeaconNode) Close() {
 	if err := b.db.Close(); err != nil {
 		log.Errorf("Failed to close database: %v", err)
 	}
+	if b.slasherDB != nil {
+		if err := b.slasherDB.Close(); err != nil {
+			log.Errorf("Failed to close slasher database: %v", err)
+		}
+	}
 	b.collector.unregister()
 	b.cancel()
 	close(b.stop)
@@ -327,6 +343,12 @@ func (b *BeaconNode) startDB(cliCtx *cli.Context, depositAddress string) error {
 		return err
 	}
 
+	if cliCtx.IsSet(flags.CheckpointStatePath.Name) || cliCtx.IsSet(flags.CheckpointBlockPath.Name) {
+		if err := b.loadCheckpoint(cliCtx); err != nil {
+			return errors.Wrap(err, "could not start from checkpoint")
+		}
+	}
+
 	knownContract, err := b.db.DepositContractAddress(b.ctx)
 	if err != nil {
 		return err
@@ -348,6 +370,58 @@ func (b *BeaconNode) startDB(cliCtx *cli.Context, depositAddress string) error {
 	return nil
 }
 
+// loadCheckpoint initializes the db from the checkpoint state and block files, so the
+// node syncs forward from the checkpoint and backfills the blocks before it.
+func (b *BeaconNode) loadCheckpoint(cliCtx *cli.Context) error {
+	statePath := cliCtx.String(flags.CheckpointStatePath.Name)
+	blockPath := cliCtx.String(flags.CheckpointBlockPath.Name)
+	if statePath == "" || blockPath == "" {
+		return errors.New("both --checkpoint-state and --checkpoint-block are required")
+	}
+	sr, err := os.Open(statePath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := sr.Close(); err != nil {
+			log.WithError(err).Error("Failed to close checkpoint state file")
+		}
+	}()
+	br, err := os.Open(blockPath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := br.Close(); err != nil {
+			log.WithError(err).Error("Failed to close checkpoint block file")
+		}
+	}()
+	if err := b.db.SaveOrigin(b.ctx, sr, br); err != nil {
+		if errors.Is(err, db.ErrExistingOriginBlockRoot) {
+			return errors.New("Checkpoint flags specified but the database was started from a " +
+				"different checkpoint. Run again with --clear-db to start from the given checkpoint.")
+		}
+		return err
+	}
+	return nil
+}
+
+func (b *BeaconNode) startSlasherDB(cliCtx *cli.Context) error {
+	baseDir := cliCtx.String(cmd.DataDirFlag.Name)
+	dbPath := filepath.Join(baseDir, slasherkv.SlasherDbDirName)
+
+	log.WithField("database-path", dbPath).Info("Checking slasher DB")
+
+	d, err := slasherkv.NewKVStore(b.ctx, dbPath, &slasherkv.Config{
+		InitialMMapSize: cliCtx.Int(cmd.BoltMMapInitialSizeFlag.Name),
+	})
+	if err != nil {
+		return errors.Wrap(err, "could not open slasher database")
+	}
+	b.slasherDB = d
+	return nil
+}
+
 func (b *BeaconNode) startStateGen() {
 	b.stateGen = stategen.New(b.db)
 }
@@ -357,6 +431,9 @@ func (b *BeaconNode) registerP2P(cliCtx *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	if cliCtx.Uint(cmd.P2PQUICPort.Name) != 0 && quicTransport == nil {
+		return errors.Errorf("--%s is not supported by the Go version this beacon node was built with", cmd.P2PQUICPort.Name)
+	}
 
 	svc, err := p2p.NewService(b.ctx, &p2p.Config{
 		NoDiscovery:       cliCtx.Bool(cmd.NoDiscovery.Name),
@@ -371,11 +448,14 @@ func (b *BeaconNode) registerP2P(cliCtx *cli.Context) error {
 		MetaDataDir:       cliCtx.String(cmd.P2PMetadata.Name),
 		TCPPort:           cliCtx.Uint(cmd.P2PTCPPort.Name),
 		UDPPort:           cliCtx.Uint(cmd.P2PUDPPort.Name),
+		QUICPort:          cliCtx.Uint(cmd.P2PQUICPort.Name),
 		MaxPeers:          cliCtx.Uint(cmd.P2PMaxPeers.Name),
 		AllowListCIDR:     cliCtx.String(cmd.P2PAllowList.Name),
 		DenyListCIDR:      sliceutil.SplitCommaSeparated(cliCtx.StringSlice(cmd.P2PDenyList.Name)),
 		EnableUPnP:        cliCtx.Bool(cmd.EnableUPnPFlag.Name),
 		DisableDiscv5:     cliCtx.Bool(flags.DisableDiscv5.Name),
+		ScoreParamsFile:   cliCtx.String(flags.GossipScoreParamsFile.Name),
+		QUICTransport:     quicTransport,
 		StateNotifier:     b,
 		DB:                b.db,
 	})
@@ -395,7 +475,8 @@ func (b *BeaconNode) fetchP2P() p2p.P2P {
 
 func (b *BeaconNode) registerAttestationPool() error {
 	s, err := attestations.NewService(b.ctx, &attestations.Config{
-		Pool: b.attestationPool,
+		Pool:    b.attestationPool,
+		DataDir: b.cliCtx.String(cmd.DataDirFlag.Name),
 	})
 	if err != nil {
 		return errors.Wrap(err, "could not register atts pool service")
@@ -587,6 +668,11 @@ func (b *BeaconNode) registerRPCService() error {
 	enableDebugRPCEndpoints := b.cliCtx.Bool(flags.EnableDebugRPCEndpoints.Name)
 	maxMsgSize := b.cliCtx.Int(cmd.GrpcMaxCallRecvMsgSizeFlag.Name)
 	p2pService := b.fetchP2P()
+	// The peer admin and gossip score endpoints need the concrete p2p service.
+	var p2pServiceImpl *p2p.Service
+	if err := b.services.FetchService(&p2pServiceImpl); err != nil {
+		return err
+	}
 	rpcService := rpc.NewService(b.ctx, &rpc.Config{
 		Host:                    host,
 		Port:                    port,
@@ -595,9 +681,12 @@ func (b *BeaconNode) registerRPCService() error {
 		CertFlag:                cert,
 		KeyFlag:                 key,
 		BeaconDB:                b.db,
+		SlasherDB:               b.slasherDB,
 		Broadcaster:             p2pService,
 		PeersFetcher:            p2pService,
 		PeerManager:             p2pService,
+		PeerAdmin:               p2pServiceImpl,
+		GossipScoreInspector:    p2pServiceImpl,
 		MetadataProvider:        p2pService,
 		ChainInfoFetcher:        chainService,
 		HeadFetcher:             chainService,
@@ -611,6 +700,7 @@ func (b *BeaconNode) registerRPCService() error {
 		AttestationsPool:        b.attestationPool,
 		ExitPool:                b.exitPool,
 		SlashingsPool:           b.slashingsPool,
+		SyncCommitteePool:       b.syncCommitteePool,
 		POWChainService:         web3Service,
 		ChainStartFetcher:       chainStartFetcher,
 		MockEth1Votes:           mockEth1DataVotes,
@@ -622,11 +712,13 @@ func (b *BeaconNode) registerRPCService() error {
 		OperationNotifier:       b,
 		StateGen:                b.stateGen,
 		EnableDebugRPCEndpoints: enableDebugRPCEndpoints,
+		EnablePeerAdmin:         b.cliCtx.Bool(flags.EnablePeerAdminRPCEndpoints.Name),
 		MaxMsgSize:              maxMsgSize,
 
 		// vanguard: EnableVanguardNode and UnconfirmedBlockFetcher is used for vanguard chain
-		EnableVanguardNode:  b.cliCtx.Bool(cmd.VanguardNetwork.Name),
-		PendingQueueFetcher: chainService,
+		EnableVanguardNode:     b.cliCtx.Bool(cmd.VanguardNetwork.Name),
+		PendingQueueFetcher:    chainService,
+		OrcConfirmationFetcher: chainService,
 	})
 
 	return b.services.RegisterService(rpcService)
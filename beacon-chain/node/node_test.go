@@ -1,6 +1,7 @@
 package node
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"os"
@@ -8,7 +9,9 @@ import (
 	"testing"
 
 	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/slasherkv"
 	"github.com/prysmaticlabs/prysm/shared/cmd"
+	"github.com/prysmaticlabs/prysm/shared/fileutil"
 	"github.com/prysmaticlabs/prysm/shared/testutil/require"
 	logTest "github.com/sirupsen/logrus/hooks/test"
 	"github.com/urfave/cli/v2"
@@ -60,3 +63,17 @@ func TestClearDB(t *testing.T) {
 	require.LogsContain(t, hook, "Removing database")
 	require.NoError(t, os.RemoveAll(tmp))
 }
+
+func TestNode_StartSlasherDB(t *testing.T) {
+	tmp := filepath.Join(t.TempDir(), "datadirtest")
+
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	set.String("datadir", tmp, "node data directory")
+
+	node := &BeaconNode{ctx: context.Background()}
+	require.NoError(t, node.startSlasherDB(cli.NewContext(&app, set, nil)))
+	require.NotNil(t, node.slasherDB)
+	require.NoError(t, node.slasherDB.Close())
+	require.Equal(t, true, fileutil.FileExists(filepath.Join(tmp, slasherkv.SlasherDbDirName, slasherkv.DatabaseFileName)))
+}
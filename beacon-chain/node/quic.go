@@ -0,0 +1,13 @@
+// +build go1.15,!go1.16
+
+package node
+
+import (
+	libp2pquic "github.com/libp2p/go-libp2p-quic-transport"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
+)
+
+// quicTransport is the QUIC transport constructor handed to the p2p service. The quic-go release
+// used by go-libp2p-quic-transport v0.9.3 embeds a TLS fork of Go 1.15 which panics at init with
+// any other Go release, so the transport is only linked in with Go 1.15.
+var quicTransport p2p.QUICTransportConstructor = libp2pquic.NewTransport
@@ -0,0 +1,8 @@
+// +build !go1.15 go1.16
+
+package node
+
+import "github.com/prysmaticlabs/prysm/beacon-chain/p2p"
+
+// quicTransport is nil as the QUIC transport does not support the Go release of this build.
+var quicTransport p2p.QUICTransportConstructor
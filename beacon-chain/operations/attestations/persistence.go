@@ -0,0 +1,209 @@
+package attestations
+
+import (
+	"context"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/blocks"
+	iface "github.com/prysmaticlabs/prysm/beacon-chain/state/interface"
+	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	bolt "go.etcd.io/bbolt"
+)
+
+// poolFileName is the name of the database holding the attestation pool, in the data directory.
+const poolFileName = "attestation-pool.db"
+
+var (
+	unaggregatedAttsBucket = []byte("unaggregated")
+	aggregatedAttsBucket   = []byte("aggregated")
+	blockAttsBucket        = []byte("block")
+	forkchoiceAttsBucket   = []byte("forkchoice")
+
+	// verifyAttestationSignature verifies the signatures of the persisted attestations on load.
+	verifyAttestationSignature = blocks.VerifyAttestationSignature
+)
+
+// persistedPool links a bucket of the pool database with the attestations of the pool it holds.
+type persistedPool struct {
+	bucket []byte
+	atts   func() ([]*ethpb.Attestation, error)
+	save   func(att *ethpb.Attestation) error
+}
+
+func (s *Service) persistedPools() []*persistedPool {
+	return []*persistedPool{
+		{
+			bucket: unaggregatedAttsBucket,
+			atts:   s.cfg.Pool.UnaggregatedAttestations,
+			save:   s.cfg.Pool.SaveUnaggregatedAttestation,
+		},
+		{
+			bucket: aggregatedAttsBucket,
+			atts: func() ([]*ethpb.Attestation, error) {
+				return s.cfg.Pool.AggregatedAttestations(), nil
+			},
+			save: s.cfg.Pool.SaveAggregatedAttestation,
+		},
+		{
+			bucket: blockAttsBucket,
+			atts: func() ([]*ethpb.Attestation, error) {
+				return s.cfg.Pool.BlockAttestations(), nil
+			},
+			save: s.cfg.Pool.SaveBlockAttestation,
+		},
+		{
+			bucket: forkchoiceAttsBucket,
+			atts: func() ([]*ethpb.Attestation, error) {
+				return s.cfg.Pool.ForkchoiceAttestations(), nil
+			},
+			save: s.cfg.Pool.SaveForkchoiceAttestation,
+		},
+	}
+}
+
+// persistPool saves the attestation pool every epoch until the service is stopped.
+func (s *Service) persistPool() {
+	ticker := time.NewTicker(s.cfg.persistInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.savePool(); err != nil {
+				log.WithError(err).Error("Could not save attestation pool")
+			}
+		case <-s.ctx.Done():
+			log.Debug("Context closed, exiting routine")
+			return
+		}
+	}
+}
+
+// savePool writes the content of the attestation pool to disk, replacing the previously saved
+// pool. It is a no-op when no data directory is configured.
+func (s *Service) savePool() error {
+	if s.cfg.DataDir == "" {
+		return nil
+	}
+	db, err := s.openPoolDB()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := db.Close(); err != nil {
+			log.WithError(err).Error("Could not close attestation pool database")
+		}
+	}()
+
+	return db.Update(func(tx *bolt.Tx) error {
+		for _, p := range s.persistedPools() {
+			atts, err := p.atts()
+			if err != nil {
+				return err
+			}
+			if tx.Bucket(p.bucket) != nil {
+				if err := tx.DeleteBucket(p.bucket); err != nil {
+					return err
+				}
+			}
+			bkt, err := tx.CreateBucket(p.bucket)
+			if err != nil {
+				return err
+			}
+			for i, att := range atts {
+				enc, err := att.MarshalSSZ()
+				if err != nil {
+					return errors.Wrap(err, "could not marshal attestation")
+				}
+				key := make([]byte, 8)
+				binary.BigEndian.PutUint64(key, uint64(i))
+				if err := bkt.Put(key, enc); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// LoadPersistedPool restores the attestation pool saved before the node was stopped. Attestations
+// which have expired since then are dropped, and the signatures of the others are verified against
+// the given head state before they are added back to the pool. The genesis time must be set first.
+func (s *Service) LoadPersistedPool(ctx context.Context, headState iface.ReadOnlyBeaconState) error {
+	if s.cfg.DataDir == "" {
+		return nil
+	}
+	if _, err := os.Stat(filepath.Join(s.cfg.DataDir, poolFileName)); os.IsNotExist(err) {
+		return nil
+	}
+	db, err := s.openPoolDB()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := db.Close(); err != nil {
+			log.WithError(err).Error("Could not close attestation pool database")
+		}
+	}()
+
+	restored, dropped := 0, 0
+	for _, p := range s.persistedPools() {
+		var atts []*ethpb.Attestation
+		if err := db.View(func(tx *bolt.Tx) error {
+			bkt := tx.Bucket(p.bucket)
+			if bkt == nil {
+				return nil
+			}
+			return bkt.ForEach(func(_, v []byte) error {
+				att := &ethpb.Attestation{}
+				if err := att.UnmarshalSSZ(v); err != nil {
+					return errors.Wrap(err, "could not unmarshal attestation")
+				}
+				atts = append(atts, att)
+				return nil
+			})
+		}); err != nil {
+			return err
+		}
+
+		for _, att := range atts {
+			if s.expired(att.Data.Slot) {
+				dropped++
+				continue
+			}
+			if err := verifyAttestationSignature(ctx, headState, att); err != nil {
+				log.WithError(err).WithField("slot", att.Data.Slot).Debug("Dropping persisted attestation")
+				dropped++
+				continue
+			}
+			if err := p.save(att); err != nil {
+				log.WithError(err).WithField("slot", att.Data.Slot).Debug("Could not restore persisted attestation")
+				dropped++
+				continue
+			}
+			restored++
+		}
+	}
+	log.WithFields(map[string]interface{}{
+		"restored": restored,
+		"dropped":  dropped,
+	}).Info("Restored attestation pool saved before restart")
+	s.updateMetrics()
+	return nil
+}
+
+func (s *Service) openPoolDB() (*bolt.DB, error) {
+	db, err := bolt.Open(
+		filepath.Join(s.cfg.DataDir, poolFileName),
+		params.BeaconIoConfig().ReadWritePermissions,
+		&bolt.Options{Timeout: 1 * time.Second},
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not open attestation pool database")
+	}
+	return db, nil
+}
@@ -0,0 +1,87 @@
+package attestations
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/prysmaticlabs/go-bitfield"
+	iface "github.com/prysmaticlabs/prysm/beacon-chain/state/interface"
+	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	"github.com/prysmaticlabs/prysm/shared/timeutils"
+)
+
+func TestPersistedPool_SaveAndLoad(t *testing.T) {
+	invalidSig := bytes.Repeat([]byte{0xff}, 96)
+	verify := verifyAttestationSignature
+	verifyAttestationSignature = func(_ context.Context, _ iface.ReadOnlyBeaconState, att *ethpb.Attestation) error {
+		if bytes.Equal(att.Signature, invalidSig) {
+			return errors.New("invalid signature")
+		}
+		return nil
+	}
+	defer func() {
+		verifyAttestationSignature = verify
+	}()
+
+	dataDir := t.TempDir()
+	// The pool was saved two epochs after genesis.
+	genesisTime := uint64(timeutils.Now().Unix()) - 2*uint64(params.BeaconConfig().SlotsPerEpoch.Mul(params.BeaconConfig().SecondsPerSlot))
+	s, err := NewService(context.Background(), &Config{Pool: NewPool(), DataDir: dataDir})
+	require.NoError(t, err)
+	s.SetGenesisTime(genesisTime)
+
+	expiredData := testutil.HydrateAttestationData(&ethpb.AttestationData{Slot: 1})
+	data := testutil.HydrateAttestationData(&ethpb.AttestationData{Slot: params.BeaconConfig().SlotsPerEpoch + 5})
+	require.NoError(t, s.cfg.Pool.SaveUnaggregatedAttestations([]*ethpb.Attestation{
+		{Data: data, AggregationBits: bitfield.Bitlist{0b1000, 0b1}, Signature: make([]byte, 96)},
+		{Data: data, AggregationBits: bitfield.Bitlist{0b0100, 0b1}, Signature: invalidSig},
+		{Data: expiredData, AggregationBits: bitfield.Bitlist{0b1000, 0b1}, Signature: make([]byte, 96)},
+	}))
+	require.NoError(t, s.cfg.Pool.SaveAggregatedAttestation(
+		&ethpb.Attestation{Data: data, AggregationBits: bitfield.Bitlist{0b1101, 0b1}, Signature: make([]byte, 96)},
+	))
+	require.NoError(t, s.cfg.Pool.SaveBlockAttestation(
+		&ethpb.Attestation{Data: data, AggregationBits: bitfield.Bitlist{0b0011, 0b1}, Signature: make([]byte, 96)},
+	))
+	require.NoError(t, s.cfg.Pool.SaveForkchoiceAttestation(
+		&ethpb.Attestation{Data: expiredData, AggregationBits: bitfield.Bitlist{0b0011, 0b1}, Signature: make([]byte, 96)},
+	))
+	require.NoError(t, s.Stop())
+
+	restarted, err := NewService(context.Background(), &Config{Pool: NewPool(), DataDir: dataDir})
+	require.NoError(t, err)
+	restarted.SetGenesisTime(genesisTime)
+	require.NoError(t, restarted.LoadPersistedPool(context.Background(), nil))
+
+	unaggregated, err := restarted.cfg.Pool.UnaggregatedAttestations()
+	require.NoError(t, err)
+	require.Equal(t, 1, len(unaggregated))
+	assert.DeepEqual(t, bitfield.Bitlist{0b1000, 0b1}, unaggregated[0].AggregationBits)
+	assert.Equal(t, 1, restarted.cfg.Pool.AggregatedAttestationCount())
+	assert.Equal(t, 1, len(restarted.cfg.Pool.BlockAttestations()))
+	assert.Equal(t, 0, restarted.cfg.Pool.ForkchoiceAttestationCount())
+
+	// Saving again replaces the previously saved pool.
+	require.NoError(t, restarted.cfg.Pool.DeleteBlockAttestation(restarted.cfg.Pool.BlockAttestations()[0]))
+	require.NoError(t, restarted.savePool())
+	reloaded, err := NewService(context.Background(), &Config{Pool: NewPool(), DataDir: dataDir})
+	require.NoError(t, err)
+	reloaded.SetGenesisTime(genesisTime)
+	require.NoError(t, reloaded.LoadPersistedPool(context.Background(), nil))
+	assert.Equal(t, 0, len(reloaded.cfg.Pool.BlockAttestations()))
+	assert.Equal(t, 1, reloaded.cfg.Pool.AggregatedAttestationCount())
+	assert.Equal(t, 1, reloaded.cfg.Pool.UnaggregatedAttestationCount())
+}
+
+func TestLoadPersistedPool_NoSavedPool(t *testing.T) {
+	s, err := NewService(context.Background(), &Config{Pool: NewPool(), DataDir: t.TempDir()})
+	require.NoError(t, err)
+	require.NoError(t, s.LoadPersistedPool(context.Background(), nil))
+	assert.Equal(t, 0, s.cfg.Pool.UnaggregatedAttestationCount())
+}
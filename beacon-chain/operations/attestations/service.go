@@ -25,8 +25,10 @@ type Service struct {
 
 // Config options for the service.
 type Config struct {
-	Pool          Pool
-	pruneInterval time.Duration
+	Pool            Pool
+	DataDir         string
+	pruneInterval   time.Duration
+	persistInterval time.Duration
 }
 
 // NewService instantiates a new attestation pool service instance that will
@@ -41,6 +43,10 @@ func NewService(ctx context.Context, cfg *Config) (*Service, error) {
 		// Prune expired attestations from the pool every slot interval.
 		cfg.pruneInterval = time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second
 	}
+	if cfg.persistInterval == 0 {
+		// Save the attestation pool to disk every epoch.
+		cfg.persistInterval = time.Duration(params.BeaconConfig().SlotsPerEpoch.Mul(params.BeaconConfig().SecondsPerSlot)) * time.Second
+	}
 
 	ctx, cancel := context.WithCancel(ctx)
 	return &Service{
@@ -55,12 +61,18 @@ func NewService(ctx context.Context, cfg *Config) (*Service, error) {
 func (s *Service) Start() {
 	go s.prepareForkChoiceAtts()
 	go s.pruneAttsPool()
+	if s.cfg.DataDir != "" {
+		go s.persistPool()
+	}
 }
 
 // Stop the beacon block attestation pool service's main event loop
 // and associated goroutines.
 func (s *Service) Stop() error {
 	defer s.cancel()
+	if err := s.savePool(); err != nil {
+		log.WithError(err).Error("Could not save attestation pool")
+	}
 	return nil
 }
 
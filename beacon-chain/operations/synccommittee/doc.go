@@ -0,0 +1,4 @@
+// Package synccommittee defines an in-memory pool of received
+// sync committee messages and contributions by the beacon node,
+// serving them to sync committee aggregators and block proposers.
+package synccommittee
@@ -0,0 +1,146 @@
+package synccommittee
+
+import (
+	"bytes"
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
+	prysmv2 "github.com/prysmaticlabs/prysm/proto/prysm/v2"
+	"github.com/prysmaticlabs/prysm/shared/copyutil"
+)
+
+// syncCommitteeMaxSlots is the number of most recent slots for which messages and
+// contributions are kept in the pool. Anything older is no longer useful to an
+// aggregator or a proposer.
+const syncCommitteeMaxSlots = 4
+
+var (
+	errNilMessage      = errors.New("nil sync committee message")
+	errNilContribution = errors.New("nil sync committee contribution")
+)
+
+// Pool defines the necessary methods for the sync committee pool to serve
+// sync committee aggregators and block proposers.
+type Pool interface {
+	// Methods for sync committee messages.
+	SaveSyncCommitteeMessage(msg *prysmv2.SyncCommitteeMessage) error
+	SyncCommitteeMessages(slot types.Slot) ([]*prysmv2.SyncCommitteeMessage, error)
+
+	// Methods for sync committee contributions.
+	SaveSyncCommitteeContribution(contribution *prysmv2.SyncCommitteeContribution) error
+	SyncCommitteeContributions(slot types.Slot) ([]*prysmv2.SyncCommitteeContribution, error)
+}
+
+// Store is a concrete implementation of Pool. Messages and contributions are
+// grouped by slot and only the most recent slots are retained.
+type Store struct {
+	messageLock      sync.RWMutex
+	messages         map[types.Slot][]*prysmv2.SyncCommitteeMessage
+	contributionLock sync.RWMutex
+	contributions    map[types.Slot][]*prysmv2.SyncCommitteeContribution
+}
+
+// NewStore initializes a new sync committee pool.
+func NewStore() *Store {
+	return &Store{
+		messages:      make(map[types.Slot][]*prysmv2.SyncCommitteeMessage),
+		contributions: make(map[types.Slot][]*prysmv2.SyncCommitteeContribution),
+	}
+}
+
+// SaveSyncCommitteeMessage saves a sync committee message into the pool. A message
+// from the same validator for the same slot replaces the previously saved one.
+func (s *Store) SaveSyncCommitteeMessage(msg *prysmv2.SyncCommitteeMessage) error {
+	if msg == nil {
+		return errNilMessage
+	}
+	s.messageLock.Lock()
+	defer s.messageLock.Unlock()
+
+	copied := copyutil.CopySyncCommitteeMessage(msg)
+	msgs := s.messages[msg.Slot]
+	for i, m := range msgs {
+		if m.ValidatorIndex == msg.ValidatorIndex {
+			msgs[i] = copied
+			return nil
+		}
+	}
+	s.messages[msg.Slot] = append(msgs, copied)
+
+	slots := make([]types.Slot, 0, len(s.messages))
+	for slot := range s.messages {
+		slots = append(slots, slot)
+	}
+	for _, slot := range expiredSlots(slots) {
+		delete(s.messages, slot)
+	}
+	return nil
+}
+
+// SyncCommitteeMessages returns copies of the sync committee messages saved for the given slot.
+func (s *Store) SyncCommitteeMessages(slot types.Slot) ([]*prysmv2.SyncCommitteeMessage, error) {
+	s.messageLock.RLock()
+	defer s.messageLock.RUnlock()
+
+	msgs := s.messages[slot]
+	copied := make([]*prysmv2.SyncCommitteeMessage, len(msgs))
+	for i, m := range msgs {
+		copied[i] = copyutil.CopySyncCommitteeMessage(m)
+	}
+	return copied, nil
+}
+
+// SaveSyncCommitteeContribution saves a sync committee contribution into the pool.
+// Exact duplicates of an already saved contribution are ignored.
+func (s *Store) SaveSyncCommitteeContribution(contribution *prysmv2.SyncCommitteeContribution) error {
+	if contribution == nil {
+		return errNilContribution
+	}
+	s.contributionLock.Lock()
+	defer s.contributionLock.Unlock()
+
+	cs := s.contributions[contribution.Slot]
+	for _, c := range cs {
+		if c.SubcommitteeIndex == contribution.SubcommitteeIndex &&
+			bytes.Equal(c.BlockRoot, contribution.BlockRoot) &&
+			bytes.Equal(c.AggregationBits, contribution.AggregationBits) {
+			return nil
+		}
+	}
+	s.contributions[contribution.Slot] = append(cs, copyutil.CopySyncCommitteeContribution(contribution))
+
+	slots := make([]types.Slot, 0, len(s.contributions))
+	for slot := range s.contributions {
+		slots = append(slots, slot)
+	}
+	for _, slot := range expiredSlots(slots) {
+		delete(s.contributions, slot)
+	}
+	return nil
+}
+
+// SyncCommitteeContributions returns copies of the sync committee contributions saved for the given slot.
+func (s *Store) SyncCommitteeContributions(slot types.Slot) ([]*prysmv2.SyncCommitteeContribution, error) {
+	s.contributionLock.RLock()
+	defer s.contributionLock.RUnlock()
+
+	cs := s.contributions[slot]
+	copied := make([]*prysmv2.SyncCommitteeContribution, len(cs))
+	for i, c := range cs {
+		copied[i] = copyutil.CopySyncCommitteeContribution(c)
+	}
+	return copied, nil
+}
+
+// expiredSlots returns the slots which fall outside of the syncCommitteeMaxSlots most recent ones.
+func expiredSlots(slots []types.Slot) []types.Slot {
+	if len(slots) <= syncCommitteeMaxSlots {
+		return nil
+	}
+	sort.Slice(slots, func(i, j int) bool {
+		return slots[i] < slots[j]
+	})
+	return slots[:len(slots)-syncCommitteeMaxSlots]
+}
@@ -0,0 +1,85 @@
+package synccommittee
+
+import (
+	"testing"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/go-bitfield"
+	prysmv2 "github.com/prysmaticlabs/prysm/proto/prysm/v2"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func TestStore_SyncCommitteeMessages(t *testing.T) {
+	s := NewStore()
+	require.ErrorContains(t, errNilMessage.Error(), s.SaveSyncCommitteeMessage(nil))
+
+	msgs := []*prysmv2.SyncCommitteeMessage{
+		{Slot: 1, ValidatorIndex: 1, BlockRoot: []byte{'a'}},
+		{Slot: 1, ValidatorIndex: 2, BlockRoot: []byte{'a'}},
+		{Slot: 2, ValidatorIndex: 1, BlockRoot: []byte{'b'}},
+	}
+	for _, m := range msgs {
+		require.NoError(t, s.SaveSyncCommitteeMessage(m))
+	}
+	got, err := s.SyncCommitteeMessages(1)
+	require.NoError(t, err)
+	assert.DeepSSZEqual(t, msgs[:2], got)
+
+	// A newer message from the same validator replaces the previous one.
+	replacement := &prysmv2.SyncCommitteeMessage{Slot: 1, ValidatorIndex: 2, BlockRoot: []byte{'c'}}
+	require.NoError(t, s.SaveSyncCommitteeMessage(replacement))
+	got, err = s.SyncCommitteeMessages(1)
+	require.NoError(t, err)
+	assert.DeepSSZEqual(t, []*prysmv2.SyncCommitteeMessage{msgs[0], replacement}, got)
+
+	// Returned messages are copies.
+	got[0].BlockRoot[0] = 'z'
+	got, err = s.SyncCommitteeMessages(1)
+	require.NoError(t, err)
+	assert.DeepEqual(t, []byte{'a'}, got[0].BlockRoot)
+
+	got, err = s.SyncCommitteeMessages(3)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(got))
+}
+
+func TestStore_SyncCommitteeContributions(t *testing.T) {
+	s := NewStore()
+	require.ErrorContains(t, errNilContribution.Error(), s.SaveSyncCommitteeContribution(nil))
+
+	bits := bitfield.NewBitvector128()
+	bits.SetBitAt(1, true)
+	c1 := &prysmv2.SyncCommitteeContribution{Slot: 1, SubcommitteeIndex: 0, BlockRoot: []byte{'a'}, AggregationBits: bits}
+	c2 := &prysmv2.SyncCommitteeContribution{Slot: 1, SubcommitteeIndex: 1, BlockRoot: []byte{'a'}, AggregationBits: bits}
+	require.NoError(t, s.SaveSyncCommitteeContribution(c1))
+	require.NoError(t, s.SaveSyncCommitteeContribution(c2))
+	// Duplicates are ignored.
+	require.NoError(t, s.SaveSyncCommitteeContribution(c1))
+
+	got, err := s.SyncCommitteeContributions(1)
+	require.NoError(t, err)
+	assert.DeepSSZEqual(t, []*prysmv2.SyncCommitteeContribution{c1, c2}, got)
+}
+
+func TestStore_PrunesOldSlots(t *testing.T) {
+	s := NewStore()
+	for i := types.Slot(0); i < syncCommitteeMaxSlots+2; i++ {
+		require.NoError(t, s.SaveSyncCommitteeMessage(&prysmv2.SyncCommitteeMessage{Slot: i}))
+		require.NoError(t, s.SaveSyncCommitteeContribution(&prysmv2.SyncCommitteeContribution{Slot: i}))
+	}
+	assert.Equal(t, syncCommitteeMaxSlots, len(s.messages))
+	assert.Equal(t, syncCommitteeMaxSlots, len(s.contributions))
+	for i := types.Slot(0); i < syncCommitteeMaxSlots+2; i++ {
+		msgs, err := s.SyncCommitteeMessages(i)
+		require.NoError(t, err)
+		cs, err := s.SyncCommitteeContributions(i)
+		require.NoError(t, err)
+		want := 1
+		if i < 2 {
+			want = 0
+		}
+		assert.Equal(t, want, len(msgs), "Unexpected message count at slot %d", i)
+		assert.Equal(t, want, len(cs), "Unexpected contribution count at slot %d", i)
+	}
+}
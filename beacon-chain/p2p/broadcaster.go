@@ -9,6 +9,7 @@ import (
 
 	"github.com/pkg/errors"
 	eth "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
+	prysmv2 "github.com/prysmaticlabs/prysm/proto/prysm/v2"
 	"github.com/prysmaticlabs/prysm/shared/hashutil"
 	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/prysmaticlabs/prysm/shared/traceutil"
@@ -107,6 +108,29 @@ func (s *Service) broadcastAttestation(ctx context.Context, subnet uint64, att *
 	}
 }
 
+// BroadcastSyncCommitteeMessage broadcasts a sync committee message to the p2p network, on the
+// sync committee subnet of the given subcommittee.
+func (s *Service) BroadcastSyncCommitteeMessage(ctx context.Context, subnet uint64, msg *prysmv2.SyncCommitteeMessage) error {
+	ctx, span := trace.StartSpan(ctx, "p2p.BroadcastSyncCommitteeMessage")
+	defer span.End()
+	span.AddAttributes(
+		trace.Int64Attribute("slot", int64(msg.Slot)),
+		trace.Int64Attribute("subnet", int64(subnet)),
+	)
+
+	oneSlot := time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second
+	ctx, cancel := context.WithTimeout(ctx, oneSlot)
+	defer cancel()
+
+	forkDigest, err := s.forkDigest()
+	if err != nil {
+		err := errors.Wrap(err, "could not retrieve fork digest")
+		traceutil.AnnotateError(span, err)
+		return err
+	}
+	return s.broadcastObject(ctx, msg, syncCommitteeToTopic(subnet, forkDigest))
+}
+
 // method to broadcast messages to other peers in our gossip mesh.
 func (s *Service) broadcastObject(ctx context.Context, obj interface{}, topic string) error {
 	_, span := trace.StartSpan(ctx, "p2p.broadcastObject")
@@ -138,3 +162,7 @@ func (s *Service) broadcastObject(ctx context.Context, obj interface{}, topic st
 func attestationToTopic(subnet uint64, forkDigest [4]byte) string {
 	return fmt.Sprintf(AttestationSubnetTopicFormat, forkDigest, subnet)
 }
+
+func syncCommitteeToTopic(subnet uint64, forkDigest [4]byte) string {
+	return fmt.Sprintf(SyncCommitteeSubnetTopicFormat, forkDigest, subnet)
+}
@@ -23,9 +23,12 @@ type Config struct {
 	MetaDataDir         string
 	TCPPort             uint
 	UDPPort             uint
+	QUICPort            uint
 	MaxPeers            uint
 	AllowListCIDR       string
 	DenyListCIDR        []string
+	ScoreParamsFile     string
+	QUICTransport       QUICTransportConstructor
 	StateNotifier       statefeed.Notifier
 	DB                  db.ReadOnlyDatabase
 }
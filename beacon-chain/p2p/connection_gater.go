@@ -36,11 +36,19 @@ func (s *Service) InterceptAddrDial(pid peer.ID, m multiaddr.Multiaddr) (allow b
 	if s.peers.IsBad(pid) {
 		return false
 	}
+	if s.isBannedAddr(m) {
+		return false
+	}
 	return filterConnections(s.addrFilter, m)
 }
 
 // InterceptAccept checks whether the incidental inbound connection is allowed.
 func (s *Service) InterceptAccept(n network.ConnMultiaddrs) (allow bool) {
+	if s.isBannedAddr(n.RemoteMultiaddr()) {
+		log.WithFields(logrus.Fields{"peer": n.RemoteMultiaddr(),
+			"reason": "banned ip address"}).Trace("Not accepting inbound dial from ip address")
+		return false
+	}
 	if !s.validateDial(n.RemoteMultiaddr()) {
 		// Allow other go-routines to run in the event
 		// we receive a large amount of junk connections.
@@ -59,8 +67,9 @@ func (s *Service) InterceptAccept(n network.ConnMultiaddrs) (allow bool) {
 
 // InterceptSecured tests whether a given connection, now authenticated,
 // is allowed.
-func (s *Service) InterceptSecured(_ network.Direction, _ peer.ID, _ network.ConnMultiaddrs) (allow bool) {
-	return true
+func (s *Service) InterceptSecured(_ network.Direction, pid peer.ID, _ network.ConnMultiaddrs) (allow bool) {
+	// Disallow banned peers, which can only be identified once the connection is secured.
+	return !s.peers.IsBanned(pid)
 }
 
 // InterceptUpgraded tests whether a fully capable connection is allowed.
@@ -81,6 +90,15 @@ func (s *Service) validateDial(addr multiaddr.Multiaddr) bool {
 	return true
 }
 
+// isBannedAddr checks whether the ip address of the given multiaddr is banned.
+func (s *Service) isBannedAddr(addr multiaddr.Multiaddr) bool {
+	ip, err := manet.ToIP(addr)
+	if err != nil {
+		return false
+	}
+	return s.peers.IsBannedIP(ip)
+}
+
 var privateCIDRList = []string{
 	// Private ip addresses specified by rfc-1918.
 	// See: https://tools.ietf.org/html/rfc1918
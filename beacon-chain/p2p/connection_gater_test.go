@@ -3,10 +3,13 @@ package p2p
 import (
 	"context"
 	"fmt"
+	"net"
 	"testing"
+	"time"
 
 	"github.com/kevinms/leakybucket-go"
 	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p-core/network"
 	"github.com/libp2p/go-libp2p-core/peer"
 	ma "github.com/multiformats/go-multiaddr"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers"
@@ -95,6 +98,33 @@ func TestService_InterceptBannedIP(t *testing.T) {
 	}
 }
 
+func TestService_InterceptBannedPeers(t *testing.T) {
+	s := &Service{
+		ipLimiter: leakybucket.NewCollector(ipLimit, ipBurst, false),
+		peers: peers.NewStatus(context.Background(), &peers.StatusConfig{
+			PeerLimit:    20,
+			ScorerParams: &scorers.Config{},
+		}),
+	}
+	var err error
+	s.addrFilter, err = configureFilter(&Config{})
+	require.NoError(t, err)
+	ip := "212.67.10.122"
+	multiAddress, err := ma.NewMultiaddr(fmt.Sprintf("/ip4/%s/tcp/%d", ip, 3000))
+	require.NoError(t, err)
+
+	s.peers.Ban("banned", time.Hour)
+	assert.Equal(t, false, s.InterceptSecured(network.DirInbound, "banned", &maEndpoints{raddr: multiAddress}))
+	assert.Equal(t, false, s.InterceptAddrDial("banned", multiAddress))
+	assert.Equal(t, true, s.InterceptSecured(network.DirInbound, "other", &maEndpoints{raddr: multiAddress}))
+
+	s.peers.BanIP(net.ParseIP(ip), time.Hour)
+	assert.Equal(t, false, s.InterceptAccept(&maEndpoints{raddr: multiAddress}))
+	assert.Equal(t, false, s.InterceptAddrDial("other", multiAddress))
+	s.peers.UnbanIP(net.ParseIP(ip))
+	assert.Equal(t, true, s.InterceptAddrDial("other", multiAddress))
+}
+
 func TestService_RejectInboundPeersBeyondLimit(t *testing.T) {
 	limit := 20
 	s := &Service{
@@ -80,7 +80,7 @@ func (s *Service) listenForNewNodes() {
 			break
 		}
 		node := iterator.Node()
-		peerInfo, _, err := convertToAddrInfo(node)
+		peerInfo, _, err := s.nodeAddrInfo(node)
 		if err != nil {
 			log.WithError(err).Error("Could not convert to peer info")
 			continue
@@ -199,6 +199,9 @@ func (s *Service) createLocalNode(
 	localNode.Set(ipEntry)
 	localNode.Set(udpEntry)
 	localNode.Set(tcpEntry)
+	if s.quicEnabled() {
+		localNode.Set(quicEntry(s.cfg.QUICPort))
+	}
 	localNode.SetFallbackIP(ipAddr)
 	localNode.SetFallbackUDP(udpPort)
 
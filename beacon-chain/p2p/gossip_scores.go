@@ -0,0 +1,142 @@
+package p2p
+
+import (
+	"sort"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+)
+
+var _ GossipScoreInspector = (*Service)(nil)
+
+// GossipScoreBreakdown is the gossipsub score of a peer, broken down into its weighted
+// components, as of the last score inspection.
+type GossipScoreBreakdown struct {
+	PeerID peer.ID
+	// Score is the overall score computed by gossipsub.
+	Score float64
+	// TopicScore is the sum of the weighted topic scores, capped by the topic score cap.
+	TopicScore float64
+	Topics     []*TopicScoreBreakdown
+	// AppSpecific is the weighted application specific score (P5).
+	AppSpecific float64
+	// IPColocation is the weighted ip colocation penalty (P6).
+	IPColocation float64
+	// BehaviourPenalty is the weighted behaviour penalty (P7).
+	BehaviourPenalty float64
+}
+
+// TopicScoreBreakdown is the score of a peer in a topic, broken down into its weighted
+// components. The mesh failure penalty (P3b) is not exposed by gossipsub, so it is only
+// accounted for in the overall score of the peer.
+type TopicScoreBreakdown struct {
+	Topic       string
+	TopicWeight float64
+	// TimeInMesh is the weighted time in mesh score (P1).
+	TimeInMesh float64
+	// FirstMessageDeliveries is the weighted first message deliveries score (P2).
+	FirstMessageDeliveries float64
+	// MeshMessageDeliveries is the weighted mesh message delivery deficit penalty (P3).
+	MeshMessageDeliveries float64
+	// InvalidMessageDeliveries is the weighted invalid message deliveries penalty (P4).
+	InvalidMessageDeliveries float64
+	// Score is the topic score, before the topic weight is applied.
+	Score float64
+	// Counters as reported by gossipsub.
+	MeshTime                      time.Duration
+	FirstMessageDeliveriesCount   float64
+	MeshMessageDeliveriesCount    float64
+	InvalidMessageDeliveriesCount float64
+}
+
+// GossipScores returns the gossipsub score breakdowns of all the peers known to gossipsub,
+// sorted by peer ID.
+func (s *Service) GossipScores() []*GossipScoreBreakdown {
+	s.gossipScoresLock.RLock()
+	defer s.gossipScoresLock.RUnlock()
+
+	scoreParams, _ := peerScoringParams()
+	breakdowns := make([]*GossipScoreBreakdown, 0, len(s.gossipScores))
+	for pid, snap := range s.gossipScores {
+		breakdowns = append(breakdowns, gossipScoreBreakdown(pid, snap, scoreParams, s.topicParams))
+	}
+	sort.Slice(breakdowns, func(i, j int) bool {
+		return breakdowns[i].PeerID < breakdowns[j].PeerID
+	})
+	return breakdowns
+}
+
+// GossipScore returns the gossipsub score breakdown of the given peer, if known to gossipsub.
+func (s *Service) GossipScore(pid peer.ID) (*GossipScoreBreakdown, bool) {
+	s.gossipScoresLock.RLock()
+	defer s.gossipScoresLock.RUnlock()
+
+	snap, ok := s.gossipScores[pid]
+	if !ok {
+		return nil, false
+	}
+	scoreParams, _ := peerScoringParams()
+	return gossipScoreBreakdown(pid, snap, scoreParams, s.topicParams), true
+}
+
+// gossipScoreBreakdown computes the weighted score components of a peer from its score
+// snapshot, following the gossipsub scoring function.
+func gossipScoreBreakdown(
+	pid peer.ID,
+	snap *pubsub.PeerScoreSnapshot,
+	scoreParams *pubsub.PeerScoreParams,
+	topicParams map[string]*pubsub.TopicScoreParams,
+) *GossipScoreBreakdown {
+	b := &GossipScoreBreakdown{
+		PeerID:       pid,
+		Score:        snap.Score,
+		Topics:       make([]*TopicScoreBreakdown, 0, len(snap.Topics)),
+		AppSpecific:  snap.AppSpecificScore * scoreParams.AppSpecificWeight,
+		IPColocation: snap.IPColocationFactor * scoreParams.IPColocationFactorWeight,
+	}
+	for topic, ts := range snap.Topics {
+		tp, ok := topicParams[topic]
+		if !ok {
+			// The topic is not scored.
+			continue
+		}
+		t := &TopicScoreBreakdown{
+			Topic:                         topic,
+			TopicWeight:                   tp.TopicWeight,
+			MeshTime:                      ts.TimeInMesh,
+			FirstMessageDeliveriesCount:   ts.FirstMessageDeliveries,
+			MeshMessageDeliveriesCount:    ts.MeshMessageDeliveries,
+			InvalidMessageDeliveriesCount: ts.InvalidMessageDeliveries,
+		}
+		if ts.TimeInMesh > 0 && tp.TimeInMeshQuantum > 0 {
+			p1 := float64(ts.TimeInMesh / tp.TimeInMeshQuantum)
+			if p1 > tp.TimeInMeshCap {
+				p1 = tp.TimeInMeshCap
+			}
+			t.TimeInMesh = p1 * tp.TimeInMeshWeight
+		}
+		t.FirstMessageDeliveries = ts.FirstMessageDeliveries * tp.FirstMessageDeliveriesWeight
+		// Mesh message deliveries are only scored once the peer has been in the mesh for the
+		// activation period.
+		if ts.TimeInMesh >= tp.MeshMessageDeliveriesActivation && ts.MeshMessageDeliveries < tp.MeshMessageDeliveriesThreshold {
+			deficit := tp.MeshMessageDeliveriesThreshold - ts.MeshMessageDeliveries
+			t.MeshMessageDeliveries = deficit * deficit * tp.MeshMessageDeliveriesWeight
+		}
+		t.InvalidMessageDeliveries = ts.InvalidMessageDeliveries * ts.InvalidMessageDeliveries * tp.InvalidMessageDeliveriesWeight
+		t.Score = t.TimeInMesh + t.FirstMessageDeliveries + t.MeshMessageDeliveries + t.InvalidMessageDeliveries
+		b.TopicScore += t.Score * t.TopicWeight
+		b.Topics = append(b.Topics, t)
+	}
+	if scoreParams.TopicScoreCap > 0 && b.TopicScore > scoreParams.TopicScoreCap {
+		b.TopicScore = scoreParams.TopicScoreCap
+	}
+	if snap.BehaviourPenalty > scoreParams.BehaviourPenaltyThreshold {
+		excess := snap.BehaviourPenalty - scoreParams.BehaviourPenaltyThreshold
+		b.BehaviourPenalty = excess * excess * scoreParams.BehaviourPenaltyWeight
+	}
+	sort.Slice(b.Topics, func(i, j int) bool {
+		return b.Topics[i].Topic < b.Topics[j].Topic
+	})
+	return b
+}
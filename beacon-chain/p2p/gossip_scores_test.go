@@ -0,0 +1,85 @@
+package p2p
+
+import (
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func TestService_GossipScores(t *testing.T) {
+	blockTopic := "/eth2/d31f6191/beacon_block/ssz_snappy"
+	exitTopic := "/eth2/d31f6191/voluntary_exit/ssz_snappy"
+	blockParams := defaultBlockTopicParams()
+	blockParams.MeshMessageDeliveriesWeight = -1
+	s := &Service{
+		topicParams: map[string]*pubsub.TopicScoreParams{
+			blockTopic: blockParams,
+		},
+	}
+	assert.Equal(t, 0, len(s.GossipScores()))
+
+	pid1, pid2 := peer.ID("a"), peer.ID("b")
+	scoreParams, _ := peerScoringParams()
+	s.gossipScores = map[peer.ID]*pubsub.PeerScoreSnapshot{
+		pid2: {
+			Score: 12.5,
+			Topics: map[string]*pubsub.TopicScoreSnapshot{
+				blockTopic: {
+					TimeInMesh:               blockParams.MeshMessageDeliveriesActivation + 10*blockParams.TimeInMeshQuantum,
+					FirstMessageDeliveries:   3,
+					MeshMessageDeliveries:    blockParams.MeshMessageDeliveriesThreshold - 2,
+					InvalidMessageDeliveries: 2,
+				},
+				// Topics without scoring parameters are not scored.
+				exitTopic: {FirstMessageDeliveries: 1},
+			},
+			IPColocationFactor: 4,
+			BehaviourPenalty:   scoreParams.BehaviourPenaltyThreshold + 3,
+		},
+		pid1: {Score: 1},
+	}
+
+	scores := s.GossipScores()
+	require.Equal(t, 2, len(scores))
+	assert.Equal(t, pid1, scores[0].PeerID)
+	assert.Equal(t, 0, len(scores[0].Topics))
+
+	b, ok := s.GossipScore(pid2)
+	require.Equal(t, true, ok)
+	assert.DeepEqual(t, scores[1], b)
+	assert.Equal(t, 12.5, b.Score)
+	assert.Equal(t, 4*scoreParams.IPColocationFactorWeight, b.IPColocation)
+	assert.Equal(t, 9*scoreParams.BehaviourPenaltyWeight, b.BehaviourPenalty)
+	require.Equal(t, 1, len(b.Topics))
+	topic := b.Topics[0]
+	assert.Equal(t, blockTopic, topic.Topic)
+	p1 := float64((blockParams.MeshMessageDeliveriesActivation + 10*blockParams.TimeInMeshQuantum) / blockParams.TimeInMeshQuantum)
+	if p1 > blockParams.TimeInMeshCap {
+		p1 = blockParams.TimeInMeshCap
+	}
+	assert.Equal(t, p1*blockParams.TimeInMeshWeight, topic.TimeInMesh)
+	assert.Equal(t, 3*blockParams.FirstMessageDeliveriesWeight, topic.FirstMessageDeliveries)
+	assert.Equal(t, float64(-4), topic.MeshMessageDeliveries)
+	assert.Equal(t, 4*blockParams.InvalidMessageDeliveriesWeight, topic.InvalidMessageDeliveries)
+	assert.Equal(t, float64(2), topic.InvalidMessageDeliveriesCount)
+	total := topic.TimeInMesh + topic.FirstMessageDeliveries + topic.MeshMessageDeliveries + topic.InvalidMessageDeliveries
+	assert.Equal(t, total, topic.Score)
+
+	_, ok = s.GossipScore(peer.ID("c"))
+	assert.Equal(t, false, ok)
+}
+
+func TestGossipScoreBreakdown_TopicScoreCap(t *testing.T) {
+	topic := "/eth2/d31f6191/beacon_block/ssz_snappy"
+	scoreParams, _ := peerScoringParams()
+	b := gossipScoreBreakdown("a", &pubsub.PeerScoreSnapshot{
+		Topics: map[string]*pubsub.TopicScoreSnapshot{
+			topic: {TimeInMesh: time.Hour, FirstMessageDeliveries: 1000},
+		},
+	}, scoreParams, map[string]*pubsub.TopicScoreParams{topic: defaultBlockTopicParams()})
+	assert.Equal(t, scoreParams.TopicScoreCap, b.TopicScore)
+}
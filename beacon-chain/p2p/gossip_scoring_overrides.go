@@ -0,0 +1,156 @@
+package p2p
+
+import (
+	"io/ioutil"
+	"strings"
+	"time"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"gopkg.in/yaml.v2"
+)
+
+// gossipTopicKinds are the topic kinds whose scoring parameters can be overridden, as matched
+// against the full topic names.
+var gossipTopicKinds = []string{
+	"beacon_block",
+	"beacon_aggregate_and_proof",
+	"beacon_attestation",
+	"voluntary_exit",
+	"proposer_slashing",
+	"attester_slashing",
+}
+
+// GossipScoreOverrides are the topic scoring parameters overriding the defaults, by topic kind
+// (such as "beacon_block" or "beacon_attestation"). They are loaded from a YAML file of the form:
+//
+//	topics:
+//	  beacon_attestation:
+//	    topic_weight: 0.1
+//	    first_message_deliveries_cap: 10
+//	    mesh_message_deliveries_activation: 6m24s
+type GossipScoreOverrides struct {
+	Topics map[string]*TopicScoreOverrides `yaml:"topics"`
+}
+
+// TopicScoreOverrides are the overridden scoring parameters of a topic. Parameters which are not
+// set keep their default value.
+type TopicScoreOverrides struct {
+	TopicWeight                     *float64       `yaml:"topic_weight"`
+	TimeInMeshWeight                *float64       `yaml:"time_in_mesh_weight"`
+	TimeInMeshQuantum               *time.Duration `yaml:"time_in_mesh_quantum"`
+	TimeInMeshCap                   *float64       `yaml:"time_in_mesh_cap"`
+	FirstMessageDeliveriesWeight    *float64       `yaml:"first_message_deliveries_weight"`
+	FirstMessageDeliveriesDecay     *float64       `yaml:"first_message_deliveries_decay"`
+	FirstMessageDeliveriesCap       *float64       `yaml:"first_message_deliveries_cap"`
+	MeshMessageDeliveriesWeight     *float64       `yaml:"mesh_message_deliveries_weight"`
+	MeshMessageDeliveriesDecay      *float64       `yaml:"mesh_message_deliveries_decay"`
+	MeshMessageDeliveriesCap        *float64       `yaml:"mesh_message_deliveries_cap"`
+	MeshMessageDeliveriesThreshold  *float64       `yaml:"mesh_message_deliveries_threshold"`
+	MeshMessageDeliveriesWindow     *time.Duration `yaml:"mesh_message_deliveries_window"`
+	MeshMessageDeliveriesActivation *time.Duration `yaml:"mesh_message_deliveries_activation"`
+	MeshFailurePenaltyWeight        *float64       `yaml:"mesh_failure_penalty_weight"`
+	MeshFailurePenaltyDecay         *float64       `yaml:"mesh_failure_penalty_decay"`
+	InvalidMessageDeliveriesWeight  *float64       `yaml:"invalid_message_deliveries_weight"`
+	InvalidMessageDeliveriesDecay   *float64       `yaml:"invalid_message_deliveries_decay"`
+}
+
+// LoadGossipScoreOverrides reads the topic scoring parameter overrides from the given YAML file.
+func LoadGossipScoreOverrides(path string) (*GossipScoreOverrides, error) {
+	enc, err := ioutil.ReadFile(path) // #nosec G304
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read gossip score parameters file")
+	}
+	return parseGossipScoreOverrides(enc)
+}
+
+func parseGossipScoreOverrides(enc []byte) (*GossipScoreOverrides, error) {
+	overrides := &GossipScoreOverrides{}
+	if err := yaml.UnmarshalStrict(enc, overrides); err != nil {
+		return nil, errors.Wrap(err, "could not parse gossip score parameters")
+	}
+	for kind, o := range overrides.Topics {
+		if !isGossipTopicKind(kind) {
+			return nil, errors.Errorf("unknown gossip topic %q, expected one of %s", kind, strings.Join(gossipTopicKinds, ", "))
+		}
+		if o == nil {
+			delete(overrides.Topics, kind)
+		}
+	}
+	return overrides, nil
+}
+
+func isGossipTopicKind(kind string) bool {
+	for _, k := range gossipTopicKinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// forTopic returns the overrides of the given full topic name, if any.
+func (o *GossipScoreOverrides) forTopic(topic string) *TopicScoreOverrides {
+	if o == nil {
+		return nil
+	}
+	for _, kind := range gossipTopicKinds {
+		if strings.Contains(topic, kind) {
+			return o.Topics[kind]
+		}
+	}
+	return nil
+}
+
+// apply returns a copy of the given topic parameters with the overrides applied.
+func (o *TopicScoreOverrides) apply(p *pubsub.TopicScoreParams) *pubsub.TopicScoreParams {
+	res := *p
+	setFloat := func(dst *float64, v *float64) {
+		if v != nil {
+			*dst = *v
+		}
+	}
+	setDuration := func(dst *time.Duration, v *time.Duration) {
+		if v != nil {
+			*dst = *v
+		}
+	}
+	setFloat(&res.TopicWeight, o.TopicWeight)
+	setFloat(&res.TimeInMeshWeight, o.TimeInMeshWeight)
+	setDuration(&res.TimeInMeshQuantum, o.TimeInMeshQuantum)
+	setFloat(&res.TimeInMeshCap, o.TimeInMeshCap)
+	setFloat(&res.FirstMessageDeliveriesWeight, o.FirstMessageDeliveriesWeight)
+	setFloat(&res.FirstMessageDeliveriesDecay, o.FirstMessageDeliveriesDecay)
+	setFloat(&res.FirstMessageDeliveriesCap, o.FirstMessageDeliveriesCap)
+	setFloat(&res.MeshMessageDeliveriesWeight, o.MeshMessageDeliveriesWeight)
+	setFloat(&res.MeshMessageDeliveriesDecay, o.MeshMessageDeliveriesDecay)
+	setFloat(&res.MeshMessageDeliveriesCap, o.MeshMessageDeliveriesCap)
+	setFloat(&res.MeshMessageDeliveriesThreshold, o.MeshMessageDeliveriesThreshold)
+	setDuration(&res.MeshMessageDeliveriesWindow, o.MeshMessageDeliveriesWindow)
+	setDuration(&res.MeshMessageDeliveriesActivation, o.MeshMessageDeliveriesActivation)
+	setFloat(&res.MeshFailurePenaltyWeight, o.MeshFailurePenaltyWeight)
+	setFloat(&res.MeshFailurePenaltyDecay, o.MeshFailurePenaltyDecay)
+	setFloat(&res.InvalidMessageDeliveriesWeight, o.InvalidMessageDeliveriesWeight)
+	setFloat(&res.InvalidMessageDeliveriesDecay, o.InvalidMessageDeliveriesDecay)
+	return &res
+}
+
+// baseTopicParams are the parameters overrides are applied to for the attestation topics, when
+// there are too few active validators for default parameters to be derived from the expected
+// message rates. Only the time in mesh and the invalid deliveries are scored, as for the
+// slashing topics, unless overridden.
+func baseTopicParams(topic string) *pubsub.TopicScoreParams {
+	weight := aggregateWeight
+	if strings.Contains(topic, "beacon_attestation") {
+		weight = attestationTotalWeight / float64(params.BeaconNetworkConfig().AttestationSubnetCount)
+	}
+	return &pubsub.TopicScoreParams{
+		TopicWeight:                    weight,
+		TimeInMeshWeight:               maxInMeshScore / inMeshCap(),
+		TimeInMeshQuantum:              inMeshTime(),
+		TimeInMeshCap:                  inMeshCap(),
+		InvalidMessageDeliveriesWeight: -maxScore() / weight,
+		InvalidMessageDeliveriesDecay:  scoreDecay(50 * oneEpochDuration()),
+	}
+}
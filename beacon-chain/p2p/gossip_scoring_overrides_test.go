@@ -0,0 +1,99 @@
+package p2p
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	bh "github.com/libp2p/go-libp2p-blankhost"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	swarmt "github.com/libp2p/go-libp2p-swarm/testing"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func TestLoadGossipScoreOverrides(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "scoring.yaml")
+	require.NoError(t, ioutil.WriteFile(path, []byte(`
+topics:
+  beacon_block:
+    topic_weight: 0.5
+    mesh_message_deliveries_activation: 6m24s
+  beacon_attestation:
+    first_message_deliveries_cap: 10
+`), 0600))
+
+	overrides, err := LoadGossipScoreOverrides(path)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(overrides.Topics))
+
+	blockOverrides := overrides.forTopic("/eth2/d31f6191/beacon_block/ssz_snappy")
+	require.NotNil(t, blockOverrides)
+	p := blockOverrides.apply(defaultBlockTopicParams())
+	assert.Equal(t, 0.5, p.TopicWeight)
+	assert.Equal(t, 6*time.Minute+24*time.Second, p.MeshMessageDeliveriesActivation)
+	// Parameters which are not overridden keep their default value.
+	assert.Equal(t, defaultBlockTopicParams().FirstMessageDeliveriesCap, p.FirstMessageDeliveriesCap)
+	assert.Equal(t, beaconBlockWeight, defaultBlockTopicParams().TopicWeight, "Defaults were modified")
+
+	assert.NotNil(t, overrides.forTopic("/eth2/d31f6191/beacon_attestation_3/ssz_snappy"))
+	assert.Equal(t, (*TopicScoreOverrides)(nil), overrides.forTopic("/eth2/d31f6191/voluntary_exit/ssz_snappy"))
+
+	_, err = LoadGossipScoreOverrides(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.ErrorContains(t, "could not read gossip score parameters file", err)
+}
+
+func TestParseGossipScoreOverrides_Invalid(t *testing.T) {
+	_, err := parseGossipScoreOverrides([]byte("topics:\n  beacon_blob:\n    topic_weight: 1\n"))
+	assert.ErrorContains(t, "unknown gossip topic", err)
+	_, err = parseGossipScoreOverrides([]byte("topics:\n  beacon_block:\n    topic_weigth: 1\n"))
+	assert.ErrorContains(t, "could not parse gossip score parameters", err)
+	_, err = parseGossipScoreOverrides([]byte("topics:\n  beacon_block:\n    time_in_mesh_quantum: soon\n"))
+	assert.ErrorContains(t, "could not parse gossip score parameters", err)
+}
+
+func TestTopicScoreParams_Overrides(t *testing.T) {
+	overrides, err := parseGossipScoreOverrides([]byte(`
+topics:
+  beacon_attestation:
+    first_message_deliveries_weight: 1
+    first_message_deliveries_decay: 0.5
+    first_message_deliveries_cap: 10
+  voluntary_exit:
+    topic_weight: 0.1
+`))
+	require.NoError(t, err)
+	// Too few active validators for the default attestation subnet parameters.
+	s := &Service{activeValidatorCount: 16, scoreOverrides: overrides}
+
+	attTopic := "/eth2/d31f6191/beacon_attestation_3/ssz_snappy"
+	attParams, err := s.topicScoreParams(attTopic)
+	require.NoError(t, err)
+	require.NotNil(t, attParams)
+	assert.Equal(t, float64(1), attParams.FirstMessageDeliveriesWeight)
+	assert.Equal(t, float64(10), attParams.FirstMessageDeliveriesCap)
+
+	exitTopic := "/eth2/d31f6191/voluntary_exit/ssz_snappy"
+	exitParams, err := s.topicScoreParams(exitTopic)
+	require.NoError(t, err)
+	assert.Equal(t, 0.1, exitParams.TopicWeight)
+
+	// Topics without overrides keep their default parameters.
+	p, err := s.topicScoreParams("/eth2/d31f6191/beacon_aggregate_and_proof/ssz_snappy")
+	require.NoError(t, err)
+	defaultParams, err := defaultAggregateTopicParams(16)
+	require.NoError(t, err)
+	assert.DeepEqual(t, defaultParams, p)
+
+	// The resulting parameters are accepted by gossipsub.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	h := bh.NewBlankHost(swarmt.GenSwarm(t, ctx))
+	scoreParams, thresholds := peerScoringParams()
+	scoreParams.Topics[attTopic] = attParams
+	scoreParams.Topics[exitTopic] = exitParams
+	_, err = pubsub.NewGossipSub(ctx, h, pubsub.WithPeerScore(scoreParams, thresholds))
+	require.NoError(t, err)
+}
@@ -81,6 +81,22 @@ func peerScoringParams() (*pubsub.PeerScoreParams, *pubsub.PeerScoreThresholds)
 }
 
 func (s *Service) topicScoreParams(topic string) (*pubsub.TopicScoreParams, error) {
+	scoreParams, err := s.defaultTopicScoreParams(topic)
+	if err != nil {
+		return nil, err
+	}
+	overrides := s.scoreOverrides.forTopic(topic)
+	if overrides == nil {
+		return scoreParams, nil
+	}
+	if scoreParams == nil {
+		// Default parameters are skipped for too few active validators.
+		scoreParams = baseTopicParams(topic)
+	}
+	return overrides.apply(scoreParams), nil
+}
+
+func (s *Service) defaultTopicScoreParams(topic string) (*pubsub.TopicScoreParams, error) {
 	activeValidators, err := s.retrieveActiveValidators()
 	if err != nil {
 		return nil, err
@@ -2,6 +2,8 @@ package p2p
 
 import (
 	"context"
+	"net"
+	"time"
 
 	"github.com/ethereum/go-ethereum/p2p/enr"
 	"github.com/libp2p/go-libp2p-core/connmgr"
@@ -13,6 +15,7 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/encoder"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers"
 	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
+	prysmv2 "github.com/prysmaticlabs/prysm/proto/prysm/v2"
 	"github.com/prysmaticlabs/prysm/shared/interfaces"
 	"google.golang.org/protobuf/proto"
 )
@@ -35,6 +38,7 @@ type P2P interface {
 type Broadcaster interface {
 	Broadcast(context.Context, proto.Message) error
 	BroadcastAttestation(ctx context.Context, subnet uint64, att *ethpb.Attestation) error
+	BroadcastSyncCommitteeMessage(ctx context.Context, subnet uint64, msg *prysmv2.SyncCommitteeMessage) error
 }
 
 // SetStreamHandler configures p2p to handle streams of a certain topic ID.
@@ -80,6 +84,24 @@ type PeerManager interface {
 	AddPingMethod(reqFunc func(ctx context.Context, id peer.ID) error)
 }
 
+// PeerAdmin provides runtime control over the peers the node connects to.
+type PeerAdmin interface {
+	PeersProvider
+	BanPeer(pid peer.ID, duration time.Duration) error
+	UnbanPeer(pid peer.ID)
+	BanIP(ip net.IP, duration time.Duration) error
+	UnbanIP(ip net.IP)
+	TrustPeer(info peer.AddrInfo) error
+	UntrustPeer(pid peer.ID)
+	Disconnect(pid peer.ID) error
+}
+
+// GossipScoreInspector provides the gossipsub score breakdowns of the peers.
+type GossipScoreInspector interface {
+	GossipScores() []*GossipScoreBreakdown
+	GossipScore(pid peer.ID) (*GossipScoreBreakdown, bool)
+}
+
 // Sender abstracts the sending functionality from libp2p.
 type Sender interface {
 	Send(context.Context, interface{}, string, peer.ID) (network.Stream, error)
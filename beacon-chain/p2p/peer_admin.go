@@ -0,0 +1,110 @@
+package p2p
+
+import (
+	"net"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/peerstore"
+	ma "github.com/multiformats/go-multiaddr"
+	manet "github.com/multiformats/go-multiaddr/net"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+var _ PeerAdmin = (*Service)(nil)
+
+// BanPeer bans the given peer for the given duration, and disconnects from it.
+func (s *Service) BanPeer(pid peer.ID, duration time.Duration) error {
+	if pid == s.host.ID() {
+		return errors.New("cannot ban self")
+	}
+	if duration <= 0 {
+		return errors.New("ban duration must be positive")
+	}
+	s.peers.Ban(pid, duration)
+	log.WithFields(logrus.Fields{"peer": pid, "duration": duration}).Info("Banned peer")
+	if s.host.Network().Connectedness(pid) == network.Connected {
+		return s.Disconnect(pid)
+	}
+	return nil
+}
+
+// UnbanPeer lifts the ban of the given peer.
+func (s *Service) UnbanPeer(pid peer.ID) {
+	s.peers.Unban(pid)
+	log.WithField("peer", pid).Info("Unbanned peer")
+}
+
+// BanIP bans the given ip address for the given duration, and disconnects from the peers
+// connected from it.
+func (s *Service) BanIP(ip net.IP, duration time.Duration) error {
+	if duration <= 0 {
+		return errors.New("ban duration must be positive")
+	}
+	s.peers.BanIP(ip, duration)
+	log.WithFields(logrus.Fields{"ip": ip, "duration": duration}).Info("Banned ip address")
+	for _, conn := range s.host.Network().Conns() {
+		remoteIP, err := manet.ToIP(conn.RemoteMultiaddr())
+		if err != nil || !remoteIP.Equal(ip) {
+			continue
+		}
+		if err := s.Disconnect(conn.RemotePeer()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UnbanIP lifts the ban of the given ip address.
+func (s *Service) UnbanIP(ip net.IP) {
+	s.peers.UnbanIP(ip)
+	log.WithField("ip", ip).Info("Unbanned ip address")
+}
+
+// TrustPeer marks the given peer as trusted, so it is never pruned and is reconnected whenever
+// the connection to it is lost. The addresses of the peer are only required if it is not known yet.
+func (s *Service) TrustPeer(info peer.AddrInfo) error {
+	if info.ID == s.host.ID() {
+		return errors.New("cannot trust self")
+	}
+	if len(info.Addrs) > 0 {
+		s.host.Peerstore().AddAddrs(info.ID, info.Addrs, peerstore.PermanentAddrTTL)
+		if addr, err := s.peers.Address(info.ID); err != nil || addr == nil {
+			s.peers.Add(nil /* ENR */, info.ID, info.Addrs[0], network.DirOutbound)
+		}
+	} else if addr, err := s.peers.Address(info.ID); err != nil || addr == nil {
+		return errors.New("address of unknown peer is required")
+	}
+	s.peers.SetTrusted(info.ID, true)
+	log.WithField("peer", info.ID).Info("Trusted peer")
+	go s.connectTrustedPeers()
+	return nil
+}
+
+// UntrustPeer removes the given peer from the trusted peers.
+func (s *Service) UntrustPeer(pid peer.ID) {
+	s.peers.SetTrusted(pid, false)
+	log.WithField("peer", pid).Info("Untrusted peer")
+}
+
+// connectTrustedPeers dials the trusted peers the node is not connected to.
+func (s *Service) connectTrustedPeers() {
+	for _, pid := range s.peers.Trusted() {
+		if s.host.Network().Connectedness(pid) == network.Connected {
+			continue
+		}
+		addr, err := s.peers.Address(pid)
+		if err != nil || addr == nil {
+			continue
+		}
+		addrs := s.host.Peerstore().Addrs(pid)
+		if len(addrs) == 0 {
+			addrs = []ma.Multiaddr{addr}
+		}
+		if err := s.connectWithPeer(s.ctx, peer.AddrInfo{ID: pid, Addrs: addrs}); err != nil {
+			log.WithError(err).WithField("peer", pid).Debug("Could not reconnect to trusted peer")
+		}
+	}
+}
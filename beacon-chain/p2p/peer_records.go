@@ -0,0 +1,176 @@
+package p2p
+
+import (
+	"encoding/json"
+	"path"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/runutil"
+	"github.com/prysmaticlabs/prysm/shared/timeutils"
+	bolt "go.etcd.io/bbolt"
+)
+
+const (
+	// peerRecordsFileName is the name of the database holding the peer records, in the data directory.
+	peerRecordsFileName = "peerstore.db"
+	// peerRecordsSaveInterval defines how often peer records are saved.
+	peerRecordsSaveInterval = 5 * time.Minute
+	// peerRecordExpiry is the time after which a peer that has not been seen is forgotten.
+	peerRecordExpiry = 7 * 24 * time.Hour
+)
+
+var (
+	peerRecordsBucket         = []byte("peers")
+	peerRecordsMetadataBucket = []byte("metadata")
+	peerRecordsSavedAtKey     = []byte("saved-at")
+)
+
+// startPeerRecords restores the peers saved by the previous run of the node, then saves the peer
+// records periodically until the service is stopped.
+func (s *Service) startPeerRecords() {
+	if err := s.openPeerRecords(); err != nil {
+		log.WithError(err).Error("Could not open peer records")
+		return
+	}
+	if err := s.restorePeers(); err != nil {
+		log.WithError(err).Error("Could not restore peers")
+	}
+	runutil.RunEvery(s.ctx, peerRecordsSaveInterval, func() {
+		if err := s.savePeerRecords(); err != nil {
+			log.WithError(err).Error("Could not save peer records")
+		}
+	})
+}
+
+// stopPeerRecords saves the peer records a last time and closes their database.
+func (s *Service) stopPeerRecords() {
+	if s.peerRecords == nil {
+		return
+	}
+	if err := s.savePeerRecords(); err != nil {
+		log.WithError(err).Error("Could not save peer records")
+	}
+	if err := s.peerRecords.Close(); err != nil {
+		log.WithError(err).Error("Could not close peer records database")
+	}
+}
+
+// openPeerRecords opens the database holding the peer records.
+func (s *Service) openPeerRecords() error {
+	db, err := bolt.Open(
+		path.Join(s.cfg.DataDir, peerRecordsFileName),
+		params.BeaconIoConfig().ReadWritePermissions,
+		&bolt.Options{Timeout: 1 * time.Second},
+	)
+	if err != nil {
+		return errors.Wrap(err, "could not open peer records database")
+	}
+	if err := db.Update(func(tx *bolt.Tx) error {
+		for _, bkt := range [][]byte{peerRecordsBucket, peerRecordsMetadataBucket} {
+			if _, err := tx.CreateBucketIfNotExists(bkt); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	s.peerRecords = db
+	return nil
+}
+
+// restorePeers adds the peers saved before the node was stopped to the peer status, with their
+// scores decayed for the time the node was offline, and dials the restored peers which are not bad.
+func (s *Service) restorePeers() error {
+	records := make(map[peer.ID]*peers.Record)
+	var savedAt time.Time
+	if err := s.peerRecords.View(func(tx *bolt.Tx) error {
+		if enc := tx.Bucket(peerRecordsMetadataBucket).Get(peerRecordsSavedAtKey); enc != nil {
+			if err := savedAt.UnmarshalBinary(enc); err != nil {
+				return err
+			}
+		}
+		return tx.Bucket(peerRecordsBucket).ForEach(func(k, v []byte) error {
+			record := &peers.Record{}
+			if err := json.Unmarshal(v, record); err != nil {
+				return errors.Wrapf(err, "could not decode record of peer %s", peer.ID(k))
+			}
+			// Peers which were never connected are only remembered for their bad responses,
+			// until those decay.
+			if timeutils.Since(record.LastSeen) < peerRecordExpiry || (record.LastSeen.IsZero() && record.BadResponses > 0) {
+				records[peer.ID(k)] = record
+			}
+			return nil
+		})
+	}); err != nil {
+		return err
+	}
+	if len(records) == 0 {
+		return nil
+	}
+
+	var offline time.Duration
+	if !savedAt.IsZero() {
+		offline = timeutils.Since(savedAt)
+	}
+	restored := s.peers.Restore(records, offline)
+	log.WithField("count", len(restored)).Info("Restored peers saved before restart")
+
+	dialed := 0
+	for _, pid := range restored {
+		if dialed >= int(s.cfg.MaxPeers) {
+			break
+		}
+		if s.peers.IsBad(pid) {
+			continue
+		}
+		addr, err := ma.NewMultiaddr(records[pid].Multiaddrs[0])
+		if err != nil {
+			continue
+		}
+		go func(info peer.AddrInfo) {
+			if err := s.connectWithPeer(s.ctx, info); err != nil {
+				log.WithError(err).Tracef("Could not connect with restored peer %s", info.String())
+			}
+		}(peer.AddrInfo{ID: pid, Addrs: []ma.Multiaddr{addr}})
+		dialed++
+	}
+	return nil
+}
+
+// savePeerRecords replaces the saved peer records with the records of the currently known peers.
+func (s *Service) savePeerRecords() error {
+	records := s.peers.Records()
+	encs := make(map[peer.ID][]byte, len(records))
+	for pid, record := range records {
+		enc, err := json.Marshal(record)
+		if err != nil {
+			return err
+		}
+		encs[pid] = enc
+	}
+	savedAt, err := timeutils.Now().MarshalBinary()
+	if err != nil {
+		return err
+	}
+	return s.peerRecords.Update(func(tx *bolt.Tx) error {
+		if err := tx.DeleteBucket(peerRecordsBucket); err != nil {
+			return err
+		}
+		bkt, err := tx.CreateBucket(peerRecordsBucket)
+		if err != nil {
+			return err
+		}
+		for pid, enc := range encs {
+			if err := bkt.Put([]byte(pid), enc); err != nil {
+				return err
+			}
+		}
+		return tx.Bucket(peerRecordsMetadataBucket).Put(peerRecordsSavedAtKey, savedAt)
+	})
+}
@@ -0,0 +1,103 @@
+package p2p
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/network"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers/scorers"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	bolt "go.etcd.io/bbolt"
+)
+
+func TestService_PeerRecords_SaveRestore(t *testing.T) {
+	dataDir := t.TempDir()
+	newService := func() *Service {
+		s := &Service{
+			ctx: context.Background(),
+			// Restored peers are not dialed.
+			cfg: &Config{DataDir: dataDir, MaxPeers: 0},
+			peers: peers.NewStatus(context.Background(), &peers.StatusConfig{
+				PeerLimit:    30,
+				ScorerParams: &scorers.Config{},
+			}),
+		}
+		require.NoError(t, s.openPeerRecords())
+		return s
+	}
+
+	s := newService()
+	addr, err := ma.NewMultiaddr("/ip4/213.202.254.180/tcp/13000")
+	require.NoError(t, err)
+	s.peers.Add(nil, "good", addr, network.DirOutbound)
+	s.peers.SetConnectionState("good", peers.PeerConnected)
+	s.peers.Add(nil, "bad", addr, network.DirInbound)
+	for i := 0; i < s.peers.Scorers().BadResponsesScorer().Params().Threshold; i++ {
+		s.peers.Scorers().BadResponsesScorer().Increment("bad")
+	}
+	s.peers.Add(nil, "unseen", addr, network.DirInbound)
+	s.stopPeerRecords()
+
+	s = newService()
+	defer s.stopPeerRecords()
+	require.NoError(t, s.restorePeers())
+	state, err := s.peers.ConnectionState("good")
+	require.NoError(t, err)
+	assert.Equal(t, peers.PeerDisconnected, state)
+	assert.Equal(t, true, s.peers.IsBad("bad"))
+	_, err = s.peers.ConnectionState("unseen")
+	assert.NotNil(t, err, "Peer which was never seen was restored")
+}
+
+func TestService_PeerRecords_Expiry(t *testing.T) {
+	s := &Service{
+		ctx: context.Background(),
+		cfg: &Config{DataDir: t.TempDir()},
+		peers: peers.NewStatus(context.Background(), &peers.StatusConfig{
+			PeerLimit:    30,
+			ScorerParams: &scorers.Config{},
+		}),
+	}
+	require.NoError(t, s.openPeerRecords())
+	defer s.stopPeerRecords()
+
+	// A bad peer seen long ago, saved recently, and a peer seen long ago, saved long ago.
+	savedAt, err := time.Now().Add(-2 * time.Hour).MarshalBinary()
+	require.NoError(t, err)
+	records := map[string]*peers.Record{
+		"bad": {
+			Multiaddrs:   []string{"/ip4/213.202.254.180/tcp/13000"},
+			LastSeen:     time.Now().Add(-peerRecordExpiry / 2),
+			BadResponses: scorers.DefaultBadResponsesThreshold,
+		},
+		"expired": {
+			Multiaddrs: []string{"/ip4/213.202.254.180/tcp/13000"},
+			LastSeen:   time.Now().Add(-peerRecordExpiry - time.Hour),
+		},
+	}
+	require.NoError(t, s.peerRecords.Update(func(tx *bolt.Tx) error {
+		for pid, record := range records {
+			enc, err := json.Marshal(record)
+			if err != nil {
+				return err
+			}
+			if err := tx.Bucket(peerRecordsBucket).Put([]byte(pid), enc); err != nil {
+				return err
+			}
+		}
+		return tx.Bucket(peerRecordsMetadataBucket).Put(peerRecordsSavedAtKey, savedAt)
+	}))
+
+	require.NoError(t, s.restorePeers())
+	_, err = s.peers.ConnectionState("expired")
+	assert.NotNil(t, err, "Expired peer was restored")
+	// Bad responses decayed for the two hours the node was offline.
+	count, err := s.peers.Scorers().BadResponsesScorer().Count("bad")
+	require.NoError(t, err)
+	assert.Equal(t, scorers.DefaultBadResponsesThreshold-2, count)
+}
@@ -49,6 +49,9 @@ type PeerData struct {
 	ConnState     PeerConnectionState
 	Enr           *enr.Record
 	NextValidTime time.Time
+	LastSeen      time.Time
+	BannedUntil   time.Time
+	Trusted       bool
 	// Chain related data.
 	MetaData                  interfaces.Metadata
 	ChainState                *pb.Status
@@ -0,0 +1,118 @@
+package peers
+
+import (
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/p2p/enr"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers/peerdata"
+)
+
+// Record holds the data of a peer which is kept across restarts of the node.
+type Record struct {
+	// ENR is the RLP encoded ENR of the peer.
+	ENR                  []byte    `json:"enr,omitempty"`
+	Multiaddrs           []string  `json:"multiaddrs,omitempty"`
+	LastSeen             time.Time `json:"last_seen"`
+	BadResponses         int       `json:"bad_responses,omitempty"`
+	ProcessedBlocks      uint64    `json:"processed_blocks,omitempty"`
+	BlockProviderUpdated time.Time `json:"block_provider_updated"`
+}
+
+// Records returns the records of the peers worth remembering across restarts, which are the peers
+// the node has been connected to and the peers with bad responses.
+func (p *Status) Records() map[peer.ID]*Record {
+	p.store.RLock()
+	defer p.store.RUnlock()
+
+	records := make(map[peer.ID]*Record)
+	for pid, peerData := range p.store.Peers() {
+		if peerData.Address == nil || (peerData.LastSeen.IsZero() && peerData.BadResponses == 0) {
+			continue
+		}
+		record := &Record{
+			Multiaddrs:           []string{peerData.Address.String()},
+			LastSeen:             peerData.LastSeen,
+			BadResponses:         peerData.BadResponses,
+			ProcessedBlocks:      peerData.ProcessedBlocks,
+			BlockProviderUpdated: peerData.BlockProviderUpdated,
+		}
+		// The ENR is optional, peers can be dialed using their address alone.
+		if peerData.Enr != nil {
+			if enc, err := rlp.EncodeToBytes(peerData.Enr); err == nil {
+				record.ENR = enc
+			}
+		}
+		records[pid] = record
+	}
+	return records
+}
+
+// Restore adds the peers of the given records as disconnected peers, with their scores decayed for
+// the time the node was offline. The most recently seen peers are restored first, up to the peer
+// limit, and records of already known peers are ignored. The restored peers are returned in the
+// order they were restored.
+func (p *Status) Restore(records map[peer.ID]*Record, offline time.Duration) []peer.ID {
+	pids := make([]peer.ID, 0, len(records))
+	for pid := range records {
+		pids = append(pids, pid)
+	}
+	sort.Slice(pids, func(i, j int) bool {
+		return records[pids[i]].LastSeen.After(records[pids[j]].LastSeen)
+	})
+
+	p.store.Lock()
+	defer p.store.Unlock()
+
+	restored := make([]peer.ID, 0, len(pids))
+	for _, pid := range pids {
+		if len(p.store.Peers()) >= p.store.Config().MaxPeers {
+			break
+		}
+		if _, ok := p.store.PeerData(pid); ok {
+			continue
+		}
+		peerData, err := recordPeerData(records[pid])
+		if err != nil {
+			// Skip malformed records.
+			continue
+		}
+		p.scorers.DecayElapsed(peerData, offline)
+		p.store.SetPeerData(pid, peerData)
+		p.addIpToTracker(pid)
+		restored = append(restored, pid)
+	}
+	return restored
+}
+
+// recordPeerData returns the data of a disconnected peer from its record.
+func recordPeerData(record *Record) (*peerdata.PeerData, error) {
+	peerData := &peerdata.PeerData{
+		Direction:            network.DirUnknown,
+		ConnState:            PeerDisconnected,
+		LastSeen:             record.LastSeen,
+		BadResponses:         record.BadResponses,
+		ProcessedBlocks:      record.ProcessedBlocks,
+		BlockProviderUpdated: record.BlockProviderUpdated,
+	}
+	if len(record.Multiaddrs) == 0 {
+		return nil, errors.New("no peer address")
+	}
+	addr, err := ma.NewMultiaddr(record.Multiaddrs[0])
+	if err != nil {
+		return nil, err
+	}
+	peerData.Address = addr
+	if len(record.ENR) > 0 {
+		peerData.Enr = &enr.Record{}
+		if err := rlp.DecodeBytes(record.ENR, peerData.Enr); err != nil {
+			return nil, err
+		}
+	}
+	return peerData, nil
+}
@@ -0,0 +1,131 @@
+package peers_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/ethereum/go-ethereum/p2p/enr"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers/scorers"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func TestStatus_Records(t *testing.T) {
+	p := peers.NewStatus(context.Background(), &peers.StatusConfig{
+		PeerLimit:    30,
+		ScorerParams: &scorers.Config{},
+	})
+	address, err := ma.NewMultiaddr("/ip4/213.202.254.180/tcp/13000")
+	require.NoError(t, err)
+	record := signedRecord(t)
+
+	// A peer which was connected.
+	p.Add(record, "seen", address, network.DirOutbound)
+	p.SetConnectionState("seen", peers.PeerConnected)
+	p.Scorers().BlockProviderScorer().IncrementProcessedBlocks("seen", 64)
+	// A peer which was never connected, but gave bad responses.
+	p.Add(nil, "bad", address, network.DirInbound)
+	p.Scorers().BadResponsesScorer().Increment("bad")
+	// A peer which was never connected.
+	p.Add(nil, "unseen", address, network.DirInbound)
+	// A peer without an address.
+	p.SetConnectionState("no-address", peers.PeerConnected)
+
+	records := p.Records()
+	require.Equal(t, 2, len(records))
+	assert.DeepEqual(t, []string{address.String()}, records["seen"].Multiaddrs)
+	assert.Equal(t, false, records["seen"].LastSeen.IsZero())
+	assert.Equal(t, uint64(64), records["seen"].ProcessedBlocks)
+	assert.NotEqual(t, 0, len(records["seen"].ENR))
+	assert.Equal(t, 1, records["bad"].BadResponses)
+	assert.Equal(t, true, records["bad"].LastSeen.IsZero())
+	assert.Equal(t, 0, len(records["bad"].ENR))
+}
+
+func TestStatus_Restore(t *testing.T) {
+	p := peers.NewStatus(context.Background(), &peers.StatusConfig{
+		PeerLimit:    30,
+		ScorerParams: &scorers.Config{},
+	})
+	address, err := ma.NewMultiaddr("/ip4/213.202.254.180/tcp/13000")
+	require.NoError(t, err)
+	record := signedRecord(t)
+	p.Add(record, "seen", address, network.DirOutbound)
+	p.SetConnectionState("seen", peers.PeerConnected)
+	p.Scorers().BlockProviderScorer().IncrementProcessedBlocks("seen", 640)
+	for i := 0; i < 3; i++ {
+		p.Scorers().BadResponsesScorer().Increment("seen")
+	}
+	records := p.Records()
+	records["older"] = &peers.Record{
+		Multiaddrs: []string{address.String()},
+		LastSeen:   records["seen"].LastSeen.Add(-time.Hour),
+	}
+	records["malformed"] = &peers.Record{Multiaddrs: []string{"not an address"}}
+
+	restoredStatus := peers.NewStatus(context.Background(), &peers.StatusConfig{
+		PeerLimit:    30,
+		ScorerParams: &scorers.Config{},
+	})
+	restored := restoredStatus.Restore(records, 2*time.Hour)
+	assert.DeepEqual(t, []peer.ID{"seen", "older"}, restored)
+
+	state, err := restoredStatus.ConnectionState("seen")
+	require.NoError(t, err)
+	assert.Equal(t, peers.PeerDisconnected, state)
+	resAddress, err := restoredStatus.Address("seen")
+	require.NoError(t, err)
+	assert.Equal(t, address.String(), resAddress.String())
+	resRecord, err := restoredStatus.ENR("seen")
+	require.NoError(t, err)
+	var entry []byte
+	require.NoError(t, resRecord.Load(enr.WithEntry("test", &entry)))
+	assert.DeepEqual(t, []byte{'a'}, entry)
+
+	// Scores are decayed for the time the node was offline.
+	badResponses, err := restoredStatus.Scorers().BadResponsesScorer().Count("seen")
+	require.NoError(t, err)
+	assert.Equal(t, 1, badResponses)
+	assert.Equal(t, uint64(0), restoredStatus.Scorers().BlockProviderScorer().ProcessedBlocks("seen"))
+
+	// Known peers are not overwritten.
+	assert.Equal(t, 0, len(restoredStatus.Restore(records, 0)))
+}
+
+func TestStatus_Restore_PeerLimit(t *testing.T) {
+	p := peers.NewStatus(context.Background(), &peers.StatusConfig{
+		PeerLimit:    0,
+		ScorerParams: &scorers.Config{},
+	})
+	records := make(map[peer.ID]*peers.Record)
+	now := time.Now()
+	for i := 0; i < p.MaxPeerLimit()+10; i++ {
+		records[peer.ID(strconv.Itoa(i))] = &peers.Record{
+			Multiaddrs: []string{"/ip4/127.0.0.1/tcp/13000"},
+			LastSeen:   now.Add(-time.Duration(i) * time.Second),
+		}
+	}
+	restored := p.Restore(records, 0)
+	require.Equal(t, p.MaxPeerLimit(), len(restored))
+	// The most recently seen peers are restored.
+	for i, pid := range restored {
+		assert.Equal(t, peer.ID(strconv.Itoa(i)), pid)
+	}
+}
+
+func signedRecord(t *testing.T) *enr.Record {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	record := new(enr.Record)
+	record.Set(enr.WithEntry("test", []byte{'a'}))
+	require.NoError(t, enode.SignV4(record, key))
+	return record
+}
@@ -153,3 +153,14 @@ func (s *BadResponsesScorer) Decay() {
 		}
 	}
 }
+
+// decayElapsed applies to the peer data the decay of all the decay intervals within the elapsed
+// time at once, as if Decay was called at every interval.
+func (s *BadResponsesScorer) decayElapsed(peerData *peerdata.PeerData, elapsed time.Duration) {
+	steps := int64(elapsed / s.config.DecayInterval)
+	if steps >= int64(peerData.BadResponses) {
+		peerData.BadResponses = 0
+		return
+	}
+	peerData.BadResponses -= int(steps)
+}
@@ -204,6 +204,25 @@ func (s *BlockProviderScorer) Decay() {
 	}
 }
 
+// decayElapsed applies to the peer data the decay of all the decay intervals within the elapsed
+// time at once, as if Decay was called at every interval.
+func (s *BlockProviderScorer) decayElapsed(peerData *peerdata.PeerData, elapsed time.Duration) {
+	steps := uint64(elapsed / s.config.DecayInterval)
+	if steps == 0 {
+		return
+	}
+	// A total decay that does not fit in uint64 exceeds any counter value.
+	if s.config.Decay != 0 && steps > math.MaxUint64/s.config.Decay {
+		peerData.ProcessedBlocks = 0
+		return
+	}
+	if decay := steps * s.config.Decay; peerData.ProcessedBlocks > decay {
+		peerData.ProcessedBlocks -= decay
+	} else {
+		peerData.ProcessedBlocks = 0
+	}
+}
+
 // WeightSorted returns a list of block providers weight sorted by score, where items are selected
 // probabilistically with more "heavy" items having a higher chance of being picked.
 func (s *BlockProviderScorer) WeightSorted(
@@ -3,12 +3,15 @@ package scorers_test
 import (
 	"context"
 	"fmt"
+	"math"
 	"sort"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers/peerdata"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers/scorers"
 	"github.com/prysmaticlabs/prysm/cmd/beacon-chain/flags"
 	"github.com/prysmaticlabs/prysm/shared/featureconfig"
@@ -486,3 +489,55 @@ func TestScorers_BlockProvider_BadPeerMarking(t *testing.T) {
 	assert.Equal(t, false, scorer.IsBadPeer("peer1"))
 	assert.Equal(t, 0, len(scorer.BadPeers()))
 }
+
+func TestScorers_BlockProvider_DecayElapsed(t *testing.T) {
+	tests := []struct {
+		name            string
+		processedBlocks uint64
+		decay           uint64
+		steps           uint64
+	}{
+		{name: "no intervals", processedBlocks: 10, decay: 3, steps: 0},
+		{name: "partial decay", processedBlocks: 10, decay: 3, steps: 3},
+		{name: "decay equal to counter", processedBlocks: 9, decay: 3, steps: 3},
+		{name: "decay above counter", processedBlocks: 10, decay: 3, steps: 4},
+		{name: "counter not divisible by intervals", processedBlocks: 11, decay: 5, steps: 2},
+		{name: "zero decay", processedBlocks: 10, decay: 0, steps: 5},
+		{name: "many intervals", processedBlocks: 1000, decay: 7, steps: 200},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			peerStatuses := peers.NewStatus(context.Background(), &peers.StatusConfig{
+				ScorerParams: &scorers.Config{
+					BlockProviderScorerConfig: &scorers.BlockProviderScorerConfig{
+						DecayInterval: time.Minute,
+						Decay:         tt.decay,
+					},
+				},
+			})
+			scorer := peerStatuses.Scorers().BlockProviderScorer()
+			scorer.IncrementProcessedBlocks("peer1", tt.processedBlocks)
+			for i := uint64(0); i < tt.steps; i++ {
+				scorer.Decay()
+			}
+
+			peerData := &peerdata.PeerData{ProcessedBlocks: tt.processedBlocks}
+			peerStatuses.Scorers().DecayElapsed(peerData, time.Duration(tt.steps)*time.Minute)
+			assert.Equal(t, scorer.ProcessedBlocks("peer1"), peerData.ProcessedBlocks)
+		})
+	}
+
+	t.Run("total decay overflows", func(t *testing.T) {
+		peerStatuses := peers.NewStatus(context.Background(), &peers.StatusConfig{
+			ScorerParams: &scorers.Config{
+				BlockProviderScorerConfig: &scorers.BlockProviderScorerConfig{
+					DecayInterval: time.Nanosecond,
+					Decay:         math.MaxUint64 / 2,
+				},
+			},
+		})
+		peerData := &peerdata.PeerData{ProcessedBlocks: math.MaxUint64}
+		peerStatuses.Scorers().DecayElapsed(peerData, 3*time.Nanosecond)
+		assert.Equal(t, uint64(0), peerData.ProcessedBlocks)
+	})
+}
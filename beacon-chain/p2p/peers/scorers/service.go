@@ -163,6 +163,17 @@ func (s *Service) ValidationError(pid peer.ID) error {
 	return peerData.ChainStateValidationError
 }
 
+// DecayElapsed applies to the given peer data the decay of all scorers over the elapsed time, as if
+// the periodic decay had run meanwhile. This is used for the data of peers restored after the node
+// was offline, before it is added to the store.
+func (s *Service) DecayElapsed(peerData *peerdata.PeerData, elapsed time.Duration) {
+	if peerData == nil || elapsed <= 0 {
+		return
+	}
+	s.scorers.badResponsesScorer.decayElapsed(peerData, elapsed)
+	s.scorers.blockProviderScorer.decayElapsed(peerData, elapsed)
+}
+
 // loop handles background tasks.
 func (s *Service) loop(ctx context.Context) {
 	decayBadResponsesStats := time.NewTicker(s.scorers.badResponsesScorer.Params().DecayInterval)
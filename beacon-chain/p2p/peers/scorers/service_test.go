@@ -8,6 +8,7 @@ import (
 	"github.com/libp2p/go-libp2p-core/network"
 	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers/peerdata"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers/scorers"
 	"github.com/prysmaticlabs/prysm/cmd/beacon-chain/flags"
 	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
@@ -303,3 +304,41 @@ func TestScorers_Service_BadPeers(t *testing.T) {
 	assert.Equal(t, true, peerStatuses.Scorers().IsBadPeer("peer3"))
 	assert.Equal(t, 2, len(peerStatuses.Scorers().BadPeers()))
 }
+
+func TestScorers_Service_DecayElapsed(t *testing.T) {
+	peerStatuses := peers.NewStatus(context.Background(), &peers.StatusConfig{
+		PeerLimit: 30,
+		ScorerParams: &scorers.Config{
+			BadResponsesScorerConfig: &scorers.BadResponsesScorerConfig{
+				Threshold:     5,
+				DecayInterval: time.Hour,
+			},
+			BlockProviderScorerConfig: &scorers.BlockProviderScorerConfig{
+				DecayInterval: time.Minute,
+				Decay:         64,
+			},
+		},
+	})
+	s := peerStatuses.Scorers()
+
+	tests := []struct {
+		name                string
+		elapsed             time.Duration
+		wantBadResponses    int
+		wantProcessedBlocks uint64
+	}{
+		{name: "no time elapsed", elapsed: 0, wantBadResponses: 4, wantProcessedBlocks: 512},
+		{name: "less than an interval", elapsed: 59 * time.Second, wantBadResponses: 4, wantProcessedBlocks: 512},
+		{name: "partial decay", elapsed: 2*time.Hour + 30*time.Second, wantBadResponses: 2, wantProcessedBlocks: 0},
+		{name: "some block provider intervals", elapsed: 3 * time.Minute, wantBadResponses: 4, wantProcessedBlocks: 320},
+		{name: "full decay", elapsed: 365 * 24 * time.Hour, wantBadResponses: 0, wantProcessedBlocks: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			peerData := &peerdata.PeerData{BadResponses: 4, ProcessedBlocks: 512}
+			s.DecayElapsed(peerData, tt.elapsed)
+			assert.Equal(t, tt.wantBadResponses, peerData.BadResponses)
+			assert.Equal(t, tt.wantProcessedBlocks, peerData.ProcessedBlocks)
+		})
+	}
+}
@@ -25,6 +25,7 @@ package peers
 import (
 	"context"
 	"math"
+	"net"
 	"sort"
 	"time"
 
@@ -86,6 +87,7 @@ type Status struct {
 	scorers   *scorers.Service
 	store     *peerdata.Store
 	ipTracker map[string]uint64
+	bannedIPs map[string]time.Time
 	rand      *rand.Rand
 }
 
@@ -107,6 +109,7 @@ func NewStatus(ctx context.Context, config *StatusConfig) *Status {
 		store:     store,
 		scorers:   scorers.NewService(ctx, store, config.ScorerParams),
 		ipTracker: map[string]uint64{},
+		bannedIPs: map[string]time.Time{},
 		// Random generator used to calculate dial backoff period.
 		// It is ok to use deterministic generator, no need for true entropy.
 		rand: rand.NewDeterministicGenerator(),
@@ -310,6 +313,9 @@ func (p *Status) SetConnectionState(pid peer.ID, state peerdata.PeerConnectionSt
 
 	peerData := p.store.PeerDataGetOrCreate(pid)
 	peerData.ConnState = state
+	if state == PeerConnected || state == PeerDisconnecting {
+		peerData.LastSeen = timeutils.Now()
+	}
 }
 
 // ConnectionState gets the connection state of the given remote peer.
@@ -338,10 +344,150 @@ func (p *Status) ChainStateLastUpdated(pid peer.ID) (time.Time, error) {
 
 // IsBad states if the peer is to be considered bad (by *any* of the registered scorers).
 // If the peer is unknown this will return `false`, which makes using this function easier than returning an error.
+// Banned peers are always bad, trusted peers which are not banned are never bad.
 func (p *Status) IsBad(pid peer.ID) bool {
+	if p.IsBanned(pid) {
+		return true
+	}
+	if p.IsTrusted(pid) {
+		return false
+	}
 	return p.isfromBadIP(pid) || p.scorers.IsBadPeer(pid)
 }
 
+// Ban bans the given peer for the given duration. The peer does not need to be known.
+func (p *Status) Ban(pid peer.ID, duration time.Duration) {
+	p.store.Lock()
+	defer p.store.Unlock()
+
+	peerData := p.store.PeerDataGetOrCreate(pid)
+	peerData.BannedUntil = timeutils.Now().Add(duration)
+}
+
+// Unban lifts the ban of the given peer, if any.
+func (p *Status) Unban(pid peer.ID) {
+	p.store.Lock()
+	defer p.store.Unlock()
+
+	if peerData, ok := p.store.PeerData(pid); ok {
+		peerData.BannedUntil = time.Time{}
+	}
+}
+
+// IsBanned states if the given peer, or the ip address it connects from, is currently banned.
+func (p *Status) IsBanned(pid peer.ID) bool {
+	p.store.RLock()
+	defer p.store.RUnlock()
+
+	peerData, ok := p.store.PeerData(pid)
+	if !ok {
+		return false
+	}
+	if timeutils.Now().Before(peerData.BannedUntil) {
+		return true
+	}
+	if peerData.Address == nil {
+		return false
+	}
+	ip, err := manet.ToIP(peerData.Address)
+	if err != nil {
+		return false
+	}
+	return p.isBannedIP(ip)
+}
+
+// Banned returns the currently banned peers, with the time their ban expires.
+func (p *Status) Banned() map[peer.ID]time.Time {
+	p.store.RLock()
+	defer p.store.RUnlock()
+
+	now := timeutils.Now()
+	banned := make(map[peer.ID]time.Time)
+	for pid, peerData := range p.store.Peers() {
+		if now.Before(peerData.BannedUntil) {
+			banned[pid] = peerData.BannedUntil
+		}
+	}
+	return banned
+}
+
+// BanIP bans the given ip address for the given duration.
+func (p *Status) BanIP(ip net.IP, duration time.Duration) {
+	p.store.Lock()
+	defer p.store.Unlock()
+
+	p.bannedIPs[ip.String()] = timeutils.Now().Add(duration)
+}
+
+// UnbanIP lifts the ban of the given ip address, if any.
+func (p *Status) UnbanIP(ip net.IP) {
+	p.store.Lock()
+	defer p.store.Unlock()
+
+	delete(p.bannedIPs, ip.String())
+}
+
+// IsBannedIP states if the given ip address is currently banned.
+func (p *Status) IsBannedIP(ip net.IP) bool {
+	p.store.RLock()
+	defer p.store.RUnlock()
+
+	return p.isBannedIP(ip)
+}
+
+// BannedIPs returns the currently banned ip addresses, with the time their ban expires.
+func (p *Status) BannedIPs() map[string]time.Time {
+	p.store.RLock()
+	defer p.store.RUnlock()
+
+	now := timeutils.Now()
+	banned := make(map[string]time.Time)
+	for ip, until := range p.bannedIPs {
+		if now.Before(until) {
+			banned[ip] = until
+		}
+	}
+	return banned
+}
+
+// SetTrusted marks the given peer as trusted or not. Trusted peers are never pruned nor
+// considered bad by the scorers.
+func (p *Status) SetTrusted(pid peer.ID, trusted bool) {
+	p.store.Lock()
+	defer p.store.Unlock()
+
+	if !trusted {
+		if peerData, ok := p.store.PeerData(pid); ok {
+			peerData.Trusted = false
+		}
+		return
+	}
+	p.store.PeerDataGetOrCreate(pid).Trusted = true
+}
+
+// IsTrusted states if the given peer is trusted.
+func (p *Status) IsTrusted(pid peer.ID) bool {
+	p.store.RLock()
+	defer p.store.RUnlock()
+
+	peerData, ok := p.store.PeerData(pid)
+	return ok && peerData.Trusted
+}
+
+// Trusted returns the trusted peers.
+func (p *Status) Trusted() []peer.ID {
+	p.store.RLock()
+	defer p.store.RUnlock()
+
+	peers := make([]peer.ID, 0)
+	for pid, peerData := range p.store.Peers() {
+		if peerData.Trusted {
+			peers = append(peers, pid)
+		}
+	}
+	return peers
+}
+
 // NextValidTime gets the earliest possible time it is to contact/dial
 // a peer again. This is used to back-off from peers in the event
 // they are 'full' or have banned us.
@@ -548,6 +694,12 @@ func (p *Status) Prune() {
 	p.store.Lock()
 	defer p.store.Unlock()
 
+	for ip, until := range p.bannedIPs {
+		if !timeutils.Now().Before(until) {
+			delete(p.bannedIPs, ip)
+		}
+	}
+
 	// Exit early if there is nothing to prune.
 	if len(p.store.Peers()) <= p.store.Config().MaxPeers {
 		return
@@ -561,8 +713,13 @@ func (p *Status) Prune() {
 		badResp int
 	}
 	peersToPrune := make([]*peerResp, 0)
-	// Select disconnected peers with a smaller bad response count.
+	now := timeutils.Now()
+	// Select disconnected peers with a smaller bad response count, keeping the
+	// trusted and banned peers.
 	for pid, peerData := range p.store.Peers() {
+		if peerData.Trusted || now.Before(peerData.BannedUntil) {
+			continue
+		}
 		if peerData.ConnState == PeerDisconnected && notBadPeer(peerData) {
 			peersToPrune = append(peersToPrune, &peerResp{
 				pid:     pid,
@@ -717,10 +874,10 @@ func (p *Status) PeersToPrune() []peer.ID {
 		badResp int
 	}
 	peersToPrune := make([]*peerResp, 0)
-	// Select connected and inbound peers to prune.
+	// Select connected and inbound peers to prune, trusted peers are never pruned.
 	for pid, peerData := range p.store.Peers() {
 		if peerData.ConnState == PeerConnected &&
-			peerData.Direction == network.DirInbound {
+			peerData.Direction == network.DirInbound && !peerData.Trusted {
 			peersToPrune = append(peersToPrune, &peerResp{
 				pid:     pid,
 				badResp: peerData.BadResponses,
@@ -804,6 +961,13 @@ func (p *Status) isfromBadIP(pid peer.ID) bool {
 	return false
 }
 
+// isBannedIP states if the given ip address is currently banned.
+// Important: it is assumed that store mutex is locked when calling this method.
+func (p *Status) isBannedIP(ip net.IP) bool {
+	until, ok := p.bannedIPs[ip.String()]
+	return ok && timeutils.Now().Before(until)
+}
+
 func (p *Status) addIpToTracker(pid peer.ID) {
 	data, ok := p.store.PeerData(pid)
 	if !ok {
@@ -3,6 +3,8 @@ package peers_test
 import (
 	"context"
 	"crypto/rand"
+	"net"
+	"sort"
 	"strconv"
 	"testing"
 	"time"
@@ -746,6 +748,88 @@ func TestPrunePeers(t *testing.T) {
 	}
 }
 
+func TestStatus_Ban(t *testing.T) {
+	p := peers.NewStatus(context.Background(), &peers.StatusConfig{
+		PeerLimit:    30,
+		ScorerParams: &scorers.Config{},
+	})
+	addr, err := ma.NewMultiaddr("/ip4/213.202.254.180/tcp/13000")
+	require.NoError(t, err)
+	pid := createPeer(t, p, addr, network.DirInbound, peers.PeerConnected)
+	assert.Equal(t, false, p.IsBad(pid))
+
+	p.Ban(pid, time.Hour)
+	assert.Equal(t, true, p.IsBanned(pid))
+	assert.Equal(t, true, p.IsBad(pid))
+	assert.Equal(t, 1, len(p.Banned()))
+	p.Unban(pid)
+	assert.Equal(t, false, p.IsBad(pid))
+	assert.Equal(t, 0, len(p.Banned()))
+
+	// Bans expire.
+	p.Ban(pid, -time.Second)
+	assert.Equal(t, false, p.IsBanned(pid))
+
+	// Unknown peers can be banned.
+	p.Ban("unknown", time.Hour)
+	assert.Equal(t, true, p.IsBad("unknown"))
+
+	// Peers are banned when their ip address is banned.
+	ip := net.ParseIP("213.202.254.180")
+	p.BanIP(ip, time.Hour)
+	assert.Equal(t, true, p.IsBannedIP(ip))
+	assert.Equal(t, true, p.IsBad(pid))
+	assert.Equal(t, 1, len(p.BannedIPs()))
+	p.UnbanIP(ip)
+	assert.Equal(t, false, p.IsBad(pid))
+	assert.Equal(t, 0, len(p.BannedIPs()))
+}
+
+func TestStatus_Trusted(t *testing.T) {
+	p := peers.NewStatus(context.Background(), &peers.StatusConfig{
+		PeerLimit: 0,
+		ScorerParams: &scorers.Config{
+			BadResponsesScorerConfig: &scorers.BadResponsesScorerConfig{
+				Threshold: 1,
+			},
+		},
+	})
+	// A bad peer which is trusted is not bad, unless banned.
+	bad := createPeer(t, p, nil, network.DirInbound, peers.PeerConnected)
+	p.Scorers().BadResponsesScorer().Increment(bad)
+	assert.Equal(t, true, p.IsBad(bad))
+	p.SetTrusted(bad, true)
+	assert.Equal(t, false, p.IsBad(bad))
+	p.Ban(bad, time.Hour)
+	assert.Equal(t, true, p.IsBad(bad))
+	p.Unban(bad)
+
+	// Trusted inbound peers are never pruned.
+	trusted := createPeer(t, p, nil, network.DirInbound, peers.PeerConnected)
+	p.SetTrusted(trusted, true)
+	assert.DeepEqual(t, sortedPeers([]peer.ID{bad, trusted}), sortedPeers(p.Trusted()))
+	for i := 0; i < 10; i++ {
+		createPeer(t, p, nil, network.DirInbound, peers.PeerConnected)
+	}
+	peersToPrune := p.PeersToPrune()
+	assert.Equal(t, 10, len(peersToPrune))
+	for _, pid := range peersToPrune {
+		assert.NotEqual(t, trusted, pid)
+	}
+
+	// Trusted disconnected peers are kept in the peer store.
+	p.SetTrusted(bad, false)
+	p.SetConnectionState(trusted, peers.PeerDisconnected)
+	for i := 0; i < p.MaxPeerLimit(); i++ {
+		createPeer(t, p, nil, network.DirInbound, peers.PeerDisconnected)
+	}
+	p.Prune()
+	assert.Equal(t, p.MaxPeerLimit(), len(p.All()))
+	_, err := p.ConnectionState(trusted)
+	require.NoError(t, err)
+	assert.Equal(t, false, p.IsTrusted(bad))
+}
+
 func TestStatus_BestPeer(t *testing.T) {
 	type peerConfig struct {
 		headSlot       types.Slot
@@ -1048,3 +1132,10 @@ func createPeer(t *testing.T, p *peers.Status, addr ma.Multiaddr,
 	p.SetConnectionState(id, state)
 	return id
 }
+
+func sortedPeers(pids []peer.ID) []peer.ID {
+	sort.Slice(pids, func(i, j int) bool {
+		return pids[i] < pids[j]
+	})
+	return pids
+}
@@ -105,6 +105,12 @@ func (s *Service) SubscribeToTopic(topic string, opts ...pubsub.SubOpt) (*pubsub
 		if err := topicHandle.SetScoreParams(scoringParams); err != nil {
 			return nil, err
 		}
+		s.gossipScoresLock.Lock()
+		if s.topicParams == nil {
+			s.topicParams = make(map[string]*pubsub.TopicScoreParams)
+		}
+		s.topicParams[topic] = scoringParams
+		s.gossipScoresLock.Unlock()
 		logGossipParameters(topic, scoringParams)
 	}
 	return topicHandle.Subscribe(opts...)
@@ -119,6 +125,10 @@ func (s *Service) peerInspector(peerMap map[peer.ID]*pubsub.PeerScoreSnapshot) {
 		s.peers.Scorers().GossipScorer().SetGossipData(pid, snap.Score,
 			snap.BehaviourPenalty, convertTopicScores(snap.Topics))
 	}
+	// Keep the snapshots for the score breakdowns.
+	s.gossipScoresLock.Lock()
+	s.gossipScores = peerMap
+	s.gossipScoresLock.Unlock()
 }
 
 // Content addressable ID function.
@@ -73,6 +73,11 @@ func TestService_CanSubscribe(t *testing.T) {
 			topic: fmt.Sprintf(AttestationSubnetTopicFormat, currentFork, 55 /*subnet*/) + validProtocolSuffix,
 			want:  true,
 		},
+		{
+			name:  "sync committee subnet topic on current fork",
+			topic: fmt.Sprintf(SyncCommitteeSubnetTopicFormat, currentFork, 3 /*subnet*/) + validProtocolSuffix,
+			want:  true,
+		},
 		{
 			name:  "att subnet topic on unknown fork",
 			topic: fmt.Sprintf(AttestationSubnetTopicFormat, [4]byte{0xCC, 0xBB, 0xAA, 0xA1} /*fork digest*/, 54 /*subnet*/) + validProtocolSuffix,
@@ -84,8 +89,8 @@ func TestService_CanSubscribe(t *testing.T) {
 	for topic := range GossipTopicMappings {
 		formatting := []interface{}{currentFork}
 
-		// Special case for attestation subnets which have a second formatting placeholder.
-		if topic == AttestationSubnetTopicFormat {
+		// Special case for attestation and sync committee subnets which have a second formatting placeholder.
+		if topic == AttestationSubnetTopicFormat || topic == SyncCommitteeSubnetTopicFormat {
 			formatting = append(formatting, 0 /* some subnet ID */)
 		}
 
@@ -0,0 +1,60 @@
+package p2p
+
+import (
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/libp2p/go-libp2p-core/connmgr"
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/pnet"
+	"github.com/libp2p/go-libp2p-core/transport"
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+// QUICTransportConstructor builds the QUIC transport of the libp2p host, such as the NewTransport
+// function of go-libp2p-quic-transport. It is passed in through the config so that this package does
+// not depend on quic-go, whose TLS fork only works with the Go versions it was released for.
+type QUICTransportConstructor func(key crypto.PrivKey, psk pnet.PSK, gater connmgr.ConnectionGater) (transport.Transport, error)
+
+// quicEntry is the ENR entry advertising the QUIC port of a node.
+type quicEntry uint16
+
+// ENRKey of the QUIC port entry.
+func (quicEntry) ENRKey() string { return "quic" }
+
+// quicEnabled returns whether the node listens on and dials QUIC. The QUIC transport is provided
+// by the caller through the config, along with the port to listen on.
+func (s *Service) quicEnabled() bool {
+	return s.cfg != nil && s.cfg.QUICPort != 0 && s.cfg.QUICTransport != nil
+}
+
+// nodeAddrInfo returns the addresses to dial the given node on. When both the node and the
+// local node support QUIC, the QUIC address of the node is listed before its TCP address, so
+// that it is preferred: QUIC connections are established faster and use a single socket.
+func (s *Service) nodeAddrInfo(node *enode.Node) (*peer.AddrInfo, ma.Multiaddr, error) {
+	info, multiAddr, err := convertToAddrInfo(node)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !s.quicEnabled() {
+		return info, multiAddr, nil
+	}
+	quicAddr, ok := convertToQUICMultiAddr(node)
+	if !ok {
+		return info, multiAddr, nil
+	}
+	info.Addrs = append([]ma.Multiaddr{quicAddr}, info.Addrs...)
+	return info, multiAddr, nil
+}
+
+// convertToQUICMultiAddr returns the QUIC address advertised by the given node, if any.
+func convertToQUICMultiAddr(node *enode.Node) (ma.Multiaddr, bool) {
+	var port quicEntry
+	if err := node.Load(&port); err != nil || port == 0 || node.IP() == nil {
+		return nil, false
+	}
+	addr, err := quicMultiAddressBuilder(node.IP().String(), uint(port))
+	if err != nil {
+		return nil, false
+	}
+	return addr, true
+}
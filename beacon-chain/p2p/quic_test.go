@@ -0,0 +1,77 @@
+package p2p
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/connmgr"
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/pnet"
+	"github.com/libp2p/go-libp2p-core/transport"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func TestQUICMultiAddressBuilder(t *testing.T) {
+	addr, err := quicMultiAddressBuilder("192.168.0.1", 9001)
+	require.NoError(t, err)
+	assert.Equal(t, "/ip4/192.168.0.1/udp/9001/quic", addr.String())
+
+	addr, err = quicMultiAddressBuilder("::1", 9001)
+	require.NoError(t, err)
+	assert.Equal(t, "/ip6/::1/udp/9001/quic", addr.String())
+
+	_, err = quicMultiAddressBuilder("invalidIP", 9001)
+	assert.ErrorContains(t, "invalid ip address provided", err)
+}
+
+func TestService_NodeAddrInfo_PrefersQUIC(t *testing.T) {
+	ipAddr := net.ParseIP("192.168.0.1")
+	_, pkey := createAddrAndPrivKey(t)
+	quicService := &Service{
+		cfg: &Config{
+			QUICPort: 4000,
+			// The transport is not used: the QUIC connection itself is tested along with the
+			// transport, in the builds supported by the transport.
+			QUICTransport: func(crypto.PrivKey, pnet.PSK, connmgr.ConnectionGater) (transport.Transport, error) {
+				return nil, nil
+			},
+		},
+		genesisTime:           time.Now(),
+		genesisValidatorsRoot: bytesutil.PadTo([]byte{'A'}, 32),
+	}
+	tcpService := &Service{
+		cfg:                   &Config{},
+		genesisTime:           time.Now(),
+		genesisValidatorsRoot: bytesutil.PadTo([]byte{'A'}, 32),
+	}
+
+	// Nodes with QUIC enabled advertise their QUIC port in their record.
+	quicNode, err := quicService.createLocalNode(pkey, ipAddr, 3000, 3001)
+	require.NoError(t, err)
+	var port quicEntry
+	require.NoError(t, quicNode.Node().Load(&port))
+	assert.Equal(t, quicEntry(4000), port)
+	tcpNode, err := tcpService.createLocalNode(pkey, ipAddr, 3000, 3001)
+	require.NoError(t, err)
+	assert.NotNil(t, tcpNode.Node().Load(&port))
+
+	info, multiAddr, err := quicService.nodeAddrInfo(quicNode.Node())
+	require.NoError(t, err)
+	require.Equal(t, 2, len(info.Addrs))
+	assert.Equal(t, "/ip4/192.168.0.1/udp/4000/quic", info.Addrs[0].String())
+	assert.Equal(t, "/ip4/192.168.0.1/tcp/3001", info.Addrs[1].String())
+	assert.Equal(t, "/ip4/192.168.0.1/tcp/3001/p2p/"+info.ID.String(), multiAddr.String())
+
+	// TCP is dialed when either side does not support QUIC.
+	info, _, err = quicService.nodeAddrInfo(tcpNode.Node())
+	require.NoError(t, err)
+	require.Equal(t, 1, len(info.Addrs))
+	assert.Equal(t, "/ip4/192.168.0.1/tcp/3001", info.Addrs[0].String())
+	info, _, err = tcpService.nodeAddrInfo(quicNode.Node())
+	require.NoError(t, err)
+	require.Equal(t, 1, len(info.Addrs))
+	assert.Equal(t, "/ip4/192.168.0.1/tcp/3001", info.Addrs[0].String())
+}
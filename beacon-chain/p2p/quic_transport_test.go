@@ -0,0 +1,80 @@
+// +build go1.15,!go1.16
+
+package p2p
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/kevinms/leakybucket-go"
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p-core/host"
+	libp2pquic "github.com/libp2p/go-libp2p-quic-transport"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers/scorers"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func TestService_DialsOverQUIC(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ipAddr := net.ParseIP("127.0.0.1")
+
+	// newHost starts a libp2p host configured the way the service configures its own host, and
+	// returns the record the host would advertise through discovery.
+	newHost := func(tcpPort, udpPort, quicPort uint) (*Service, host.Host, *enode.Node) {
+		pkey, err := privKey(&Config{DataDir: t.TempDir()})
+		require.NoError(t, err)
+		s := &Service{
+			cfg: &Config{
+				TCPPort:       tcpPort,
+				UDPPort:       udpPort,
+				QUICPort:      quicPort,
+				QUICTransport: libp2pquic.NewTransport,
+			},
+			ipLimiter:             leakybucket.NewCollector(ipLimit, ipBurst, false),
+			genesisTime:           time.Now(),
+			genesisValidatorsRoot: bytesutil.PadTo([]byte{'A'}, 32),
+		}
+		s.peers = peers.NewStatus(ctx, &peers.StatusConfig{
+			PeerLimit:    30,
+			ScorerParams: &scorers.Config{},
+		})
+		s.addrFilter, err = configureFilter(s.cfg)
+		require.NoError(t, err)
+		h, err := libp2p.New(ctx, s.buildOptions(ipAddr, pkey)...)
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			require.NoError(t, h.Close())
+		})
+		localNode, err := s.createLocalNode(pkey, ipAddr, int(udpPort), int(tcpPort))
+		require.NoError(t, err)
+		return s, h, localNode.Node()
+	}
+
+	dialer, dialerHost, _ := newHost(5100, 5101, 5102)
+	_, quicHost, quicNode := newHost(5200, 5201, 5202)
+	_, tcpHost, tcpNode := newHost(5300, 5301, 0)
+
+	// The QUIC address advertised by the remote peer is dialed.
+	info, _, err := dialer.nodeAddrInfo(quicNode)
+	require.NoError(t, err)
+	require.NoError(t, dialerHost.Connect(ctx, *info))
+	conns := dialerHost.Network().ConnsToPeer(quicHost.ID())
+	require.Equal(t, 1, len(conns))
+	assert.Equal(t, fmt.Sprintf("/ip4/%s/udp/5202/quic", ipAddr), conns[0].RemoteMultiaddr().String())
+
+	// Peers without QUIC support are still reached over TCP.
+	info, _, err = dialer.nodeAddrInfo(tcpNode)
+	require.NoError(t, err)
+	require.NoError(t, dialerHost.Connect(ctx, *info))
+	conns = dialerHost.Network().ConnsToPeer(tcpHost.ID())
+	require.Equal(t, 1, len(conns))
+	assert.Equal(t, fmt.Sprintf("/ip4/%s/tcp/5300", ipAddr), conns[0].RemoteMultiaddr().String())
+}
@@ -32,6 +32,7 @@ import (
 	"github.com/prysmaticlabs/prysm/shared/runutil"
 	"github.com/prysmaticlabs/prysm/shared/slotutil"
 	"github.com/sirupsen/logrus"
+	bolt "go.etcd.io/bbolt"
 	"go.opencensus.io/trace"
 )
 
@@ -72,6 +73,7 @@ type Service struct {
 	subnetsLockLock       sync.Mutex // Lock access to subnetsLock
 	initializationLock    sync.Mutex
 	dv5Listener           Listener
+	peerRecords           *bolt.DB
 	startupErr            error
 	stateNotifier         statefeed.Notifier
 	ctx                   context.Context
@@ -79,6 +81,10 @@ type Service struct {
 	genesisTime           time.Time
 	genesisValidatorsRoot []byte
 	activeValidatorCount  uint64
+	scoreOverrides        *GossipScoreOverrides
+	topicParams           map[string]*pubsub.TopicScoreParams
+	gossipScores          map[peer.ID]*pubsub.PeerScoreSnapshot
+	gossipScoresLock      sync.RWMutex
 }
 
 // NewService initializes a new p2p service compatible with shared.Service interface. No
@@ -97,6 +103,13 @@ func NewService(ctx context.Context, cfg *Config) (*Service, error) {
 		joinedTopics:  make(map[string]*pubsub.Topic, len(GossipTopicMappings)),
 		subnetsLock:   make(map[uint64]*sync.RWMutex),
 	}
+	if cfg.ScoreParamsFile != "" {
+		s.scoreOverrides, err = LoadGossipScoreOverrides(cfg.ScoreParamsFile)
+		if err != nil {
+			log.WithError(err).Error("Failed to load gossip score parameters")
+			return nil, err
+		}
+	}
 
 	dv5Nodes := parseBootStrapAddrs(s.cfg.BootstrapNodeAddr)
 
@@ -218,10 +231,15 @@ func (s *Service) Start() {
 		s.connectWithAllPeers(addrs)
 	}
 
+	if s.cfg.DataDir != "" {
+		s.startPeerRecords()
+	}
+
 	// Periodic functions.
 	runutil.RunEvery(s.ctx, params.BeaconNetworkConfig().TtfbTimeout, func() {
 		ensurePeerConnections(s.ctx, s.host, peersToWatch...)
 	})
+	runutil.RunEvery(s.ctx, params.BeaconNetworkConfig().TtfbTimeout, s.connectTrustedPeers)
 	runutil.RunEvery(s.ctx, 30*time.Minute, s.Peers().Prune)
 	runutil.RunEvery(s.ctx, params.BeaconNetworkConfig().RespTimeout, s.updateMetrics)
 	runutil.RunEvery(s.ctx, refreshRate, func() {
@@ -259,6 +277,7 @@ func (s *Service) Stop() error {
 	if s.dv5Listener != nil {
 		s.dv5Listener.Close()
 	}
+	s.stopPeerRecords()
 	return nil
 }
 
@@ -49,7 +49,7 @@ func (s *Service) FindPeersWithSubnet(ctx context.Context, topic string,
 		}
 		nodes := enode.ReadNodes(iterator, int(params.BeaconNetworkConfig().MinimumPeersInSubnetSearch))
 		for _, node := range nodes {
-			info, _, err := convertToAddrInfo(node)
+			info, _, err := s.nodeAddrInfo(node)
 			if err != nil {
 				continue
 			}
@@ -13,4 +13,8 @@ const (
 	AttesterSlashingSubnetTopicFormat = "/eth2/%x/attester_slashing"
 	// AggregateAndProofSubnetTopicFormat is the topic format for the aggregate and proof subnet.
 	AggregateAndProofSubnetTopicFormat = "/eth2/%x/beacon_aggregate_and_proof"
+	// SyncCommitteeSubnetTopicFormat is the topic format for the sync committee subnet.
+	SyncCommitteeSubnetTopicFormat = "/eth2/%x/sync_committee_%d"
+	// SyncContributionAndProofSubnetTopicFormat is the topic format for the sync committee contribution and proof subnet.
+	SyncContributionAndProofSubnetTopicFormat = "/eth2/%x/sync_committee_contribution_and_proof"
 )
@@ -43,6 +43,7 @@ func (f *BeaconEndpointFactory) Paths() []string {
 		"/eth/v1/node/health",
 		"/eth/v1/debug/beacon/states/{state_id}",
 		"/eth/v1/debug/beacon/heads",
+		"/eth/v1/debug/beacon/forkchoice/tree",
 		"/eth/v1/config/fork_schedule",
 		"/eth/v1/config/deposit_contract",
 		"/eth/v1/config/spec",
@@ -211,6 +212,11 @@ func (f *BeaconEndpointFactory) Create(path string) (*gateway.Endpoint, error) {
 			GetResponse: &forkChoiceHeadsResponseJson{},
 			Err:         &gateway.DefaultErrorJson{},
 		}
+	case "/eth/v1/debug/beacon/forkchoice/tree":
+		endpoint = gateway.Endpoint{
+			GetResponse: &forkChoiceTreeResponseJson{},
+			Err:         &gateway.DefaultErrorJson{},
+		}
 	case "/eth/v1/config/fork_schedule":
 		endpoint = gateway.Endpoint{
 			GetResponse: &forkScheduleResponseJson{},
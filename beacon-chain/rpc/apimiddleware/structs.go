@@ -159,6 +159,11 @@ type forkChoiceHeadsResponseJson struct {
 	Data []*forkChoiceHeadJson `json:"data"`
 }
 
+// forkChoiceTreeResponseJson is used in /debug/beacon/forkchoice/tree API endpoint.
+type forkChoiceTreeResponseJson struct {
+	Data *forkChoiceTreeJson `json:"data"`
+}
+
 // forkScheduleResponseJson is used in /config/fork_schedule API endpoint.
 type forkScheduleResponseJson struct {
 	Data []*forkJson `json:"data"`
@@ -420,6 +425,29 @@ type forkChoiceHeadJson struct {
 	Slot string `json:"slot"`
 }
 
+// forkChoiceTreeJson is a JSON representation of the fork choice tree.
+type forkChoiceTreeJson struct {
+	JustifiedEpoch string                    `json:"justified_epoch"`
+	FinalizedEpoch string                    `json:"finalized_epoch"`
+	HeadRoot       string                    `json:"head_root" hex:"true"`
+	Nodes          []*forkChoiceTreeNodeJson `json:"nodes"`
+}
+
+// forkChoiceTreeNodeJson is a JSON representation of a fork choice tree node.
+type forkChoiceTreeNodeJson struct {
+	Slot                  string `json:"slot"`
+	Root                  string `json:"root" hex:"true"`
+	ParentRoot            string `json:"parent_root" hex:"true"`
+	Weight                string `json:"weight"`
+	JustifiedEpoch        string `json:"justified_epoch"`
+	FinalizedEpoch        string `json:"finalized_epoch"`
+	PandoraBlockNumber    string `json:"pandora_block_number"`
+	OrchestratorStatus    string `json:"orchestrator_status"`
+	OrchestratorConfirmed bool   `json:"orchestrator_confirmed"`
+	Head                  bool   `json:"head"`
+	InForkChoice          bool   `json:"in_fork_choice"`
+}
+
 // depositContractJson is a JSON representation of the deposit contract.
 type depositContractJson struct {
 	ChainId string `json:"chain_id"`
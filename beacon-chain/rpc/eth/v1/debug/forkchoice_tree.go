@@ -0,0 +1,174 @@
+package debug
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"sort"
+
+	"github.com/emicklei/dot"
+	"github.com/prysmaticlabs/prysm/beacon-chain/blockchain"
+	"github.com/prysmaticlabs/prysm/beacon-chain/forkchoice/protoarray"
+	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"go.opencensus.io/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// GetForkChoiceTree retrieves the fork choice tree of the node, annotated with the Pandora shard and
+// orchestrator confirmation of each block, to debug the reorgs of a Vanguard chain. The blocks rejected
+// by the orchestrator whose parent is in the tree are listed after the fork choice nodes.
+func (ds *Server) GetForkChoiceTree(ctx context.Context, _ *emptypb.Empty) (*ethpb.ForkChoiceTreeResponse, error) {
+	ctx, span := trace.StartSpan(ctx, "debugv1.GetForkChoiceTree")
+	defer span.End()
+
+	store := ds.HeadFetcher.ProtoArrayStore()
+	if store == nil {
+		return nil, status.Error(codes.Unavailable, "Fork choice store is not initialized")
+	}
+	headRoot, err := ds.HeadFetcher.HeadRoot(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get head root: %v", err)
+	}
+	confirmations := make(map[[32]byte]*blockchain.OrcConfirmation)
+	if ds.OrcConfirmationFetcher != nil {
+		confirmations = ds.OrcConfirmationFetcher.OrcConfirmations()
+	}
+
+	nodes := store.Nodes()
+	tree := &ethpb.ForkChoiceTree{
+		JustifiedEpoch: store.JustifiedEpoch(),
+		FinalizedEpoch: store.FinalizedEpoch(),
+		HeadRoot:       headRoot,
+		Nodes:          make([]*ethpb.ForkChoiceTreeNode, 0, len(nodes)),
+	}
+	inTree := make(map[[32]byte]bool, len(nodes))
+	for _, n := range nodes {
+		root := n.Root()
+		inTree[root] = true
+		node := &ethpb.ForkChoiceTreeNode{
+			Slot:           n.Slot(),
+			Root:           root[:],
+			Weight:         n.Weight(),
+			JustifiedEpoch: n.JustifiedEpoch(),
+			FinalizedEpoch: n.FinalizedEpoch(),
+			Head:           bytes.Equal(root[:], headRoot),
+			InForkChoice:   true,
+		}
+		if n.Parent() != protoarray.NonExistentNode && n.Parent() < uint64(len(nodes)) {
+			parentRoot := nodes[n.Parent()].Root()
+			node.ParentRoot = parentRoot[:]
+		}
+		if c, ok := confirmations[root]; ok {
+			node.PandoraBlockNumber = c.PandoraBlockNumber
+			node.OrchestratorStatus = string(c.Status)
+			node.OrchestratorConfirmed = c.Status == params.Verified
+		} else if err := ds.setPandoraBlockNumber(ctx, root, node); err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not get block %#x: %v", root, err)
+		}
+		tree.Nodes = append(tree.Nodes, node)
+	}
+
+	rejected := make([]*ethpb.ForkChoiceTreeNode, 0)
+	for root, c := range confirmations {
+		if inTree[root] || !inTree[c.ParentRoot] {
+			continue
+		}
+		root, parentRoot := root, c.ParentRoot
+		rejected = append(rejected, &ethpb.ForkChoiceTreeNode{
+			Slot:               c.Slot,
+			Root:               root[:],
+			ParentRoot:         parentRoot[:],
+			PandoraBlockNumber: c.PandoraBlockNumber,
+			OrchestratorStatus: string(c.Status),
+		})
+	}
+	sort.Slice(rejected, func(i, j int) bool {
+		if rejected[i].Slot == rejected[j].Slot {
+			return bytes.Compare(rejected[i].Root, rejected[j].Root) < 0
+		}
+		return rejected[i].Slot < rejected[j].Slot
+	})
+	tree.Nodes = append(tree.Nodes, rejected...)
+	return &ethpb.ForkChoiceTreeResponse{Data: tree}, nil
+}
+
+// setPandoraBlockNumber sets the Pandora shard block number of a fork choice node from its block.
+// Blocks which are not in the database, like the genesis alias of fork choice, are skipped.
+func (ds *Server) setPandoraBlockNumber(ctx context.Context, root [32]byte, node *ethpb.ForkChoiceTreeNode) error {
+	if ds.BeaconDB == nil {
+		return nil
+	}
+	blk, err := ds.BeaconDB.Block(ctx, root)
+	if err != nil {
+		return err
+	}
+	if blk == nil || blk.IsNil() {
+		return nil
+	}
+	if shards := blk.Block().Body().PandoraShards(); len(shards) > 0 && shards[0] != nil {
+		node.PandoraBlockNumber = shards[0].BlockNumber
+	}
+	return nil
+}
+
+// ForkChoiceTreeDot renders a fork choice tree in the Graphviz DOT language. The head is drawn in
+// green, blocks rejected by the orchestrator in red and blocks still pending confirmation in orange.
+func ForkChoiceTreeDot(tree *ethpb.ForkChoiceTree) string {
+	graph := dot.NewGraph(dot.Directed)
+	graph.Attr("rankdir", "RL")
+	graph.Attr("labeljust", "l")
+
+	dotNodes := make(map[string]dot.Node, len(tree.Nodes))
+	for _, n := range tree.Nodes {
+		root := hexRoot(n.Root)
+		label := fmt.Sprintf(
+			"slot: %d\n root: %s\n weight: %d\n justified: %d\n finalized: %d\n pandora block: %d\n orchestrator: %s",
+			n.Slot, shortRoot(root), n.Weight/params.BeaconConfig().GweiPerEth, n.JustifiedEpoch, n.FinalizedEpoch,
+			n.PandoraBlockNumber, orcStatusLabel(n.OrchestratorStatus),
+		)
+		dotN := graph.Node(root).Box().Attr("label", label)
+		switch {
+		case n.Head:
+			dotN = dotN.Attr("color", "green")
+		case !n.InForkChoice || n.OrchestratorStatus == string(params.Invalid):
+			dotN = dotN.Attr("color", "red").Attr("style", "dashed")
+		case n.OrchestratorStatus == string(params.Pending):
+			dotN = dotN.Attr("color", "orange")
+		}
+		dotNodes[root] = dotN
+	}
+	for _, n := range tree.Nodes {
+		if len(n.ParentRoot) == 0 {
+			continue
+		}
+		parent, ok := dotNodes[hexRoot(n.ParentRoot)]
+		if !ok {
+			continue
+		}
+		graph.Edge(dotNodes[hexRoot(n.Root)], parent)
+	}
+	return graph.String()
+}
+
+func orcStatusLabel(status string) string {
+	if status == "" {
+		return "unknown"
+	}
+	return status
+}
+
+func hexRoot(root []byte) string {
+	return "0x" + hex.EncodeToString(root)
+}
+
+// shortRoot returns the first four bytes of a hex encoded root.
+func shortRoot(root string) string {
+	if len(root) < 10 {
+		return root
+	}
+	return root[:10]
+}
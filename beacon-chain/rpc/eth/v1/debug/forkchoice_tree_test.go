@@ -0,0 +1,108 @@
+package debug
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/blockchain"
+	blockchainmock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	dbtest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/forkchoice/protoarray"
+	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/proto/eth/v1alpha1/wrapper"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	sharedtestutil "github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type mockOrcConfirmationFetcher struct {
+	confirmations map[[32]byte]*blockchain.OrcConfirmation
+}
+
+func (m *mockOrcConfirmationFetcher) OrcConfirmations() map[[32]byte]*blockchain.OrcConfirmation {
+	return m.confirmations
+}
+
+// forkChoiceTreeTestServer returns a server with the fork choice tree:
+//
+//	genesis <- a (pandora block 7, in the database) <- b (head, pending confirmation)
+//	        <- c (rejected by the orchestrator)
+func forkChoiceTreeTestServer(t *testing.T) *Server {
+	ctx := context.Background()
+	beaconDB := dbtest.SetupDB(t)
+	blkA := sharedtestutil.NewBeaconBlock()
+	blkA.Block.Slot = 1
+	blkA.Block.Body.PandoraShard = []*ethpb.PandoraShard{{
+		BlockNumber: 7,
+		Hash:        make([]byte, 32),
+		ParentHash:  make([]byte, 32),
+		StateRoot:   make([]byte, 32),
+		TxHash:      make([]byte, 32),
+		ReceiptHash: make([]byte, 32),
+		SealHash:    make([]byte, 32),
+		Signature:   make([]byte, params.BeaconConfig().BLSSignatureLength),
+	}}
+	require.NoError(t, beaconDB.SaveBlock(ctx, wrapper.WrappedPhase0SignedBeaconBlock(blkA)))
+	rootA, err := blkA.Block.HashTreeRoot()
+	require.NoError(t, err)
+	genesis, rootB, rootC := [32]byte{'g'}, [32]byte{'b'}, [32]byte{'c'}
+
+	fc := protoarray.New(0, 0, genesis)
+	require.NoError(t, fc.ProcessBlock(ctx, 0, genesis, [32]byte{}, [32]byte{}, 0, 0))
+	require.NoError(t, fc.ProcessBlock(ctx, 1, rootA, genesis, [32]byte{}, 0, 0))
+	require.NoError(t, fc.ProcessBlock(ctx, 2, rootB, rootA, [32]byte{}, 0, 0))
+
+	return &Server{
+		BeaconDB:    beaconDB,
+		HeadFetcher: &blockchainmock.ChainService{ForkChoiceStore: fc.Store(), Root: rootB[:]},
+		OrcConfirmationFetcher: &mockOrcConfirmationFetcher{confirmations: map[[32]byte]*blockchain.OrcConfirmation{
+			rootB: {Slot: 2, ParentRoot: rootA, PandoraBlockNumber: 8, Status: params.Pending},
+			rootC: {Slot: 2, ParentRoot: genesis, PandoraBlockNumber: 9, Status: params.Invalid},
+			// Rejected blocks built on blocks unknown to fork choice are not listed.
+			{'d'}: {Slot: 3, ParentRoot: [32]byte{'e'}, Status: params.Invalid},
+		}},
+	}
+}
+
+func TestGetForkChoiceTree(t *testing.T) {
+	s := forkChoiceTreeTestServer(t)
+	resp, err := s.GetForkChoiceTree(context.Background(), &emptypb.Empty{})
+	require.NoError(t, err)
+	tree := resp.Data
+
+	require.Equal(t, 4, len(tree.Nodes))
+	genesis, a, b, c := tree.Nodes[0], tree.Nodes[1], tree.Nodes[2], tree.Nodes[3]
+	assert.Equal(t, 0, len(genesis.ParentRoot))
+	assert.DeepEqual(t, genesis.Root, a.ParentRoot)
+	assert.Equal(t, uint64(7), a.PandoraBlockNumber)
+	assert.Equal(t, "unknown", orcStatusLabel(a.OrchestratorStatus))
+	assert.DeepEqual(t, a.Root, b.ParentRoot)
+	assert.DeepEqual(t, tree.HeadRoot, b.Root)
+	assert.Equal(t, true, b.Head)
+	assert.Equal(t, uint64(8), b.PandoraBlockNumber)
+	assert.Equal(t, string(params.Pending), b.OrchestratorStatus)
+	assert.Equal(t, false, b.OrchestratorConfirmed)
+	assert.DeepEqual(t, genesis.Root, c.ParentRoot)
+	assert.Equal(t, false, c.InForkChoice)
+	assert.Equal(t, string(params.Invalid), c.OrchestratorStatus)
+}
+
+func TestGetForkChoiceTree_NoForkChoiceStore(t *testing.T) {
+	s := &Server{HeadFetcher: &blockchainmock.ChainService{}}
+	_, err := s.GetForkChoiceTree(context.Background(), &emptypb.Empty{})
+	assert.ErrorContains(t, "Fork choice store is not initialized", err)
+}
+
+func TestForkChoiceTreeDot(t *testing.T) {
+	resp, err := forkChoiceTreeTestServer(t).GetForkChoiceTree(context.Background(), &emptypb.Empty{})
+	require.NoError(t, err)
+
+	graph := ForkChoiceTreeDot(resp.Data)
+	assert.Equal(t, true, strings.HasPrefix(graph, "digraph"))
+	assert.Equal(t, 3, strings.Count(graph, "->"), "Rejected block should be linked to its parent")
+	assert.Equal(t, true, strings.Contains(graph, "pandora block: 9"))
+	assert.Equal(t, true, strings.Contains(graph, "orchestrator: Invalid"))
+}
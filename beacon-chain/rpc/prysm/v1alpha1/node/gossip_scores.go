@@ -0,0 +1,65 @@
+package node
+
+import (
+	"context"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
+	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ListGossipScores retrieves the gossipsub scores of the peers broken down into their weighted
+// components, so operators can see which component of the score of a peer is responsible for its
+// changes. Only the score of the requested peer is returned when a peer ID is given.
+func (ns *Server) ListGossipScores(_ context.Context, req *ethpb.GossipScoresRequest) (*ethpb.GossipScores, error) {
+	if ns.GossipScoreInspector == nil {
+		return nil, status.Error(codes.Unavailable, "Gossip scores are not available")
+	}
+	if req.PeerId != "" {
+		pid, err := peer.Decode(req.PeerId)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid peer ID: %v", err)
+		}
+		score, ok := ns.GossipScoreInspector.GossipScore(pid)
+		if !ok {
+			return nil, status.Error(codes.NotFound, "Peer not known to gossipsub")
+		}
+		return &ethpb.GossipScores{Peers: []*ethpb.GossipScore{gossipScore(score)}}, nil
+	}
+	scores := ns.GossipScoreInspector.GossipScores()
+	resp := &ethpb.GossipScores{Peers: make([]*ethpb.GossipScore, len(scores))}
+	for i, score := range scores {
+		resp.Peers[i] = gossipScore(score)
+	}
+	return resp, nil
+}
+
+func gossipScore(score *p2p.GossipScoreBreakdown) *ethpb.GossipScore {
+	res := &ethpb.GossipScore{
+		PeerId:           score.PeerID.String(),
+		Score:            score.Score,
+		TopicScore:       score.TopicScore,
+		Topics:           make([]*ethpb.TopicScore, len(score.Topics)),
+		AppSpecific:      score.AppSpecific,
+		IpColocation:     score.IPColocation,
+		BehaviourPenalty: score.BehaviourPenalty,
+	}
+	for i, t := range score.Topics {
+		res.Topics[i] = &ethpb.TopicScore{
+			Topic:                         t.Topic,
+			TopicWeight:                   t.TopicWeight,
+			Score:                         t.Score,
+			TimeInMesh:                    t.TimeInMesh,
+			FirstMessageDeliveries:        t.FirstMessageDeliveries,
+			MeshMessageDeliveries:         t.MeshMessageDeliveries,
+			InvalidMessageDeliveries:      t.InvalidMessageDeliveries,
+			MeshTimeMs:                    t.MeshTime.Milliseconds(),
+			FirstMessageDeliveriesCount:   t.FirstMessageDeliveriesCount,
+			MeshMessageDeliveriesCount:    t.MeshMessageDeliveriesCount,
+			InvalidMessageDeliveriesCount: t.InvalidMessageDeliveriesCount,
+		}
+	}
+	return res
+}
@@ -0,0 +1,80 @@
+package node
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
+	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type mockGossipScoreInspector struct {
+	scores []*p2p.GossipScoreBreakdown
+}
+
+func (m *mockGossipScoreInspector) GossipScores() []*p2p.GossipScoreBreakdown {
+	return m.scores
+}
+
+func (m *mockGossipScoreInspector) GossipScore(pid peer.ID) (*p2p.GossipScoreBreakdown, bool) {
+	for _, score := range m.scores {
+		if score.PeerID == pid {
+			return score, true
+		}
+	}
+	return nil, false
+}
+
+func TestServer_ListGossipScores(t *testing.T) {
+	ctx := context.Background()
+	pid, err := peer.Decode(testPeerID)
+	require.NoError(t, err)
+	ns := &Server{
+		GossipScoreInspector: &mockGossipScoreInspector{
+			scores: []*p2p.GossipScoreBreakdown{
+				{
+					PeerID:     pid,
+					Score:      -20,
+					TopicScore: 4,
+					Topics: []*p2p.TopicScoreBreakdown{
+						{
+							Topic:                         "/eth2/d31f6191/beacon_block/ssz_snappy",
+							TopicWeight:                   0.8,
+							TimeInMesh:                    5,
+							InvalidMessageDeliveries:      -140,
+							MeshTime:                      time.Minute,
+							InvalidMessageDeliveriesCount: 1,
+						},
+					},
+					BehaviourPenalty: -24,
+				},
+			},
+		},
+	}
+
+	resp, err := ns.ListGossipScores(ctx, &ethpb.GossipScoresRequest{})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(resp.Peers))
+	assert.Equal(t, testPeerID, resp.Peers[0].PeerId)
+	assert.Equal(t, float64(-24), resp.Peers[0].BehaviourPenalty)
+	require.Equal(t, 1, len(resp.Peers[0].Topics))
+	topic := resp.Peers[0].Topics[0]
+	assert.Equal(t, float64(5), topic.TimeInMesh)
+	assert.Equal(t, float64(-140), topic.InvalidMessageDeliveries)
+	assert.Equal(t, int64(60000), topic.MeshTimeMs)
+
+	resp, err = ns.ListGossipScores(ctx, &ethpb.GossipScoresRequest{PeerId: testPeerID})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(resp.Peers))
+
+	_, err = ns.ListGossipScores(ctx, &ethpb.GossipScoresRequest{PeerId: "16Uiu2HAkyWZ4Ni1TpvDS8dPxsozmHY85KaiFjodQuV6Tz5tkHVeR"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	_, err = ns.ListGossipScores(ctx, &ethpb.GossipScoresRequest{PeerId: "foo"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
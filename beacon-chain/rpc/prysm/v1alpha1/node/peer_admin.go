@@ -0,0 +1,171 @@
+package node
+
+import (
+	"context"
+	"net"
+	"sort"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/libp2p/go-libp2p-core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// The peer admin endpoints control the peers of the node at runtime. They are only served when
+// enabled with --enable-peer-admin-rpc-endpoints, and their changes last until the node is restarted.
+
+// BanPeer bans exactly one of a peer ID or an ip address for the requested duration, such as "1h30m".
+func (ns *Server) BanPeer(_ context.Context, req *ethpb.BanPeerRequest) (*empty.Empty, error) {
+	if err := ns.checkPeerAdminEnabled(); err != nil {
+		return nil, err
+	}
+	duration, err := time.ParseDuration(req.Duration)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid duration: %v", err)
+	}
+	switch {
+	case req.PeerId != "" && req.Ip == "":
+		pid, err := peer.Decode(req.PeerId)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid peer ID: %v", err)
+		}
+		if err := ns.PeerAdmin.BanPeer(pid, duration); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "Could not ban peer: %v", err)
+		}
+	case req.Ip != "" && req.PeerId == "":
+		ip := net.ParseIP(req.Ip)
+		if ip == nil {
+			return nil, status.Error(codes.InvalidArgument, "Invalid ip address")
+		}
+		if err := ns.PeerAdmin.BanIP(ip, duration); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "Could not ban ip address: %v", err)
+		}
+	default:
+		return nil, status.Error(codes.InvalidArgument, "Exactly one of peer ID and ip address is required")
+	}
+	return &empty.Empty{}, nil
+}
+
+// UnbanPeer lifts the ban of exactly one of a peer ID or an ip address.
+func (ns *Server) UnbanPeer(_ context.Context, req *ethpb.UnbanPeerRequest) (*empty.Empty, error) {
+	if err := ns.checkPeerAdminEnabled(); err != nil {
+		return nil, err
+	}
+	switch {
+	case req.PeerId != "" && req.Ip == "":
+		pid, err := peer.Decode(req.PeerId)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid peer ID: %v", err)
+		}
+		ns.PeerAdmin.UnbanPeer(pid)
+	case req.Ip != "" && req.PeerId == "":
+		ip := net.ParseIP(req.Ip)
+		if ip == nil {
+			return nil, status.Error(codes.InvalidArgument, "Invalid ip address")
+		}
+		ns.PeerAdmin.UnbanIP(ip)
+	default:
+		return nil, status.Error(codes.InvalidArgument, "Exactly one of peer ID and ip address is required")
+	}
+	return &empty.Empty{}, nil
+}
+
+// ListPeerBans retrieves the active bans of peer IDs and ip addresses.
+func (ns *Server) ListPeerBans(_ context.Context, _ *empty.Empty) (*ethpb.PeerBans, error) {
+	if err := ns.checkPeerAdminEnabled(); err != nil {
+		return nil, err
+	}
+	resp := &ethpb.PeerBans{
+		Peers: make([]*ethpb.PeerBan, 0),
+		Ips:   make([]*ethpb.PeerBan, 0),
+	}
+	for pid, until := range ns.PeerAdmin.Peers().Banned() {
+		resp.Peers = append(resp.Peers, &ethpb.PeerBan{PeerId: pid.String(), BannedUntil: timestamppb.New(until)})
+	}
+	for ip, until := range ns.PeerAdmin.Peers().BannedIPs() {
+		resp.Ips = append(resp.Ips, &ethpb.PeerBan{Ip: ip, BannedUntil: timestamppb.New(until)})
+	}
+	sort.Slice(resp.Peers, func(i, j int) bool {
+		return resp.Peers[i].PeerId < resp.Peers[j].PeerId
+	})
+	sort.Slice(resp.Ips, func(i, j int) bool {
+		return resp.Ips[i].Ip < resp.Ips[j].Ip
+	})
+	return resp, nil
+}
+
+// TrustPeer trusts a peer. The addresses are only required for peers unknown to the node.
+func (ns *Server) TrustPeer(_ context.Context, req *ethpb.TrustPeerRequest) (*empty.Empty, error) {
+	if err := ns.checkPeerAdminEnabled(); err != nil {
+		return nil, err
+	}
+	pid, err := peer.Decode(req.PeerId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid peer ID: %v", err)
+	}
+	info := peer.AddrInfo{ID: pid}
+	for _, addr := range req.Multiaddrs {
+		a, err := ma.NewMultiaddr(addr)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid multiaddr %s: %v", addr, err)
+		}
+		info.Addrs = append(info.Addrs, a)
+	}
+	if err := ns.PeerAdmin.TrustPeer(info); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Could not trust peer: %v", err)
+	}
+	return &empty.Empty{}, nil
+}
+
+// UntrustPeer stops trusting a peer.
+func (ns *Server) UntrustPeer(_ context.Context, req *ethpb.PeerRequest) (*empty.Empty, error) {
+	if err := ns.checkPeerAdminEnabled(); err != nil {
+		return nil, err
+	}
+	pid, err := peer.Decode(req.PeerId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid peer ID: %v", err)
+	}
+	ns.PeerAdmin.UntrustPeer(pid)
+	return &empty.Empty{}, nil
+}
+
+// ListTrustedPeers retrieves the peer IDs of the trusted peers.
+func (ns *Server) ListTrustedPeers(_ context.Context, _ *empty.Empty) (*ethpb.TrustedPeers, error) {
+	if err := ns.checkPeerAdminEnabled(); err != nil {
+		return nil, err
+	}
+	resp := &ethpb.TrustedPeers{PeerIds: make([]string, 0)}
+	for _, pid := range ns.PeerAdmin.Peers().Trusted() {
+		resp.PeerIds = append(resp.PeerIds, pid.String())
+	}
+	sort.Strings(resp.PeerIds)
+	return resp, nil
+}
+
+// DisconnectPeer disconnects from a peer.
+func (ns *Server) DisconnectPeer(_ context.Context, req *ethpb.PeerRequest) (*empty.Empty, error) {
+	if err := ns.checkPeerAdminEnabled(); err != nil {
+		return nil, err
+	}
+	pid, err := peer.Decode(req.PeerId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid peer ID: %v", err)
+	}
+	if err := ns.PeerAdmin.Disconnect(pid); err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not disconnect from peer: %v", err)
+	}
+	log.WithField("peer", pid).Info("Disconnected from peer")
+	return &empty.Empty{}, nil
+}
+
+func (ns *Server) checkPeerAdminEnabled() error {
+	if !ns.EnablePeerAdmin || ns.PeerAdmin == nil {
+		return status.Error(codes.PermissionDenied, "Peer admin endpoints are disabled, enable them with --enable-peer-admin-rpc-endpoints")
+	}
+	return nil
+}
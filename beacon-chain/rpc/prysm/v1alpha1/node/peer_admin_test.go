@@ -0,0 +1,161 @@
+package node
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers/scorers"
+	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const testPeerID = "16Uiu2HAmQqFdEcHbSmQTQuLoAhnMUrgoWoBkcRcGfTzWn6pAbW9k"
+
+type mockPeerAdmin struct {
+	status       *peers.Status
+	disconnected []peer.ID
+	trusted      []peer.AddrInfo
+}
+
+func (m *mockPeerAdmin) Peers() *peers.Status {
+	return m.status
+}
+
+func (m *mockPeerAdmin) BanPeer(pid peer.ID, duration time.Duration) error {
+	m.status.Ban(pid, duration)
+	return nil
+}
+
+func (m *mockPeerAdmin) UnbanPeer(pid peer.ID) {
+	m.status.Unban(pid)
+}
+
+func (m *mockPeerAdmin) BanIP(ip net.IP, duration time.Duration) error {
+	m.status.BanIP(ip, duration)
+	return nil
+}
+
+func (m *mockPeerAdmin) UnbanIP(ip net.IP) {
+	m.status.UnbanIP(ip)
+}
+
+func (m *mockPeerAdmin) TrustPeer(info peer.AddrInfo) error {
+	m.trusted = append(m.trusted, info)
+	m.status.SetTrusted(info.ID, true)
+	return nil
+}
+
+func (m *mockPeerAdmin) UntrustPeer(pid peer.ID) {
+	m.status.SetTrusted(pid, false)
+}
+
+func (m *mockPeerAdmin) Disconnect(pid peer.ID) error {
+	m.disconnected = append(m.disconnected, pid)
+	return nil
+}
+
+func TestServer_PeerAdmin(t *testing.T) {
+	ctx := context.Background()
+	admin := &mockPeerAdmin{
+		status: peers.NewStatus(ctx, &peers.StatusConfig{
+			PeerLimit:    30,
+			ScorerParams: &scorers.Config{},
+		}),
+	}
+	ns := &Server{PeerAdmin: admin, EnablePeerAdmin: true}
+	pid, err := peer.Decode(testPeerID)
+	require.NoError(t, err)
+
+	t.Run("ban and unban peer", func(t *testing.T) {
+		_, err := ns.BanPeer(ctx, &ethpb.BanPeerRequest{PeerId: testPeerID, Duration: "1h"})
+		require.NoError(t, err)
+		assert.Equal(t, true, admin.status.IsBanned(pid))
+
+		bans, err := ns.ListPeerBans(ctx, &emptypb.Empty{})
+		require.NoError(t, err)
+		require.Equal(t, 1, len(bans.Peers))
+		assert.Equal(t, testPeerID, bans.Peers[0].PeerId)
+		assert.Equal(t, 0, len(bans.Ips))
+
+		_, err = ns.UnbanPeer(ctx, &ethpb.UnbanPeerRequest{PeerId: testPeerID})
+		require.NoError(t, err)
+		assert.Equal(t, false, admin.status.IsBanned(pid))
+	})
+
+	t.Run("ban and unban ip", func(t *testing.T) {
+		_, err := ns.BanPeer(ctx, &ethpb.BanPeerRequest{Ip: "213.202.254.180", Duration: "30m"})
+		require.NoError(t, err)
+		assert.Equal(t, true, admin.status.IsBannedIP(net.ParseIP("213.202.254.180")))
+
+		_, err = ns.UnbanPeer(ctx, &ethpb.UnbanPeerRequest{Ip: "213.202.254.180"})
+		require.NoError(t, err)
+		assert.Equal(t, false, admin.status.IsBannedIP(net.ParseIP("213.202.254.180")))
+	})
+
+	t.Run("invalid ban requests", func(t *testing.T) {
+		for _, req := range []*ethpb.BanPeerRequest{
+			{PeerId: testPeerID},
+			{PeerId: testPeerID, Ip: "213.202.254.180", Duration: "1h"},
+			{Duration: "1h"},
+			{PeerId: "not a peer", Duration: "1h"},
+			{Ip: "not an ip", Duration: "1h"},
+		} {
+			_, err := ns.BanPeer(ctx, req)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		}
+	})
+
+	t.Run("trust and untrust", func(t *testing.T) {
+		req := &ethpb.TrustPeerRequest{PeerId: testPeerID, Multiaddrs: []string{"/ip4/213.202.254.180/tcp/13000"}}
+		_, err := ns.TrustPeer(ctx, req)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(admin.trusted))
+		assert.Equal(t, pid, admin.trusted[0].ID)
+		assert.Equal(t, "/ip4/213.202.254.180/tcp/13000", admin.trusted[0].Addrs[0].String())
+
+		trusted, err := ns.ListTrustedPeers(ctx, &emptypb.Empty{})
+		require.NoError(t, err)
+		assert.DeepEqual(t, []string{testPeerID}, trusted.PeerIds)
+
+		_, err = ns.UntrustPeer(ctx, &ethpb.PeerRequest{PeerId: testPeerID})
+		require.NoError(t, err)
+		assert.Equal(t, false, admin.status.IsTrusted(pid))
+
+		req.Multiaddrs = []string{"not an address"}
+		_, err = ns.TrustPeer(ctx, req)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("disconnect", func(t *testing.T) {
+		_, err := ns.DisconnectPeer(ctx, &ethpb.PeerRequest{PeerId: testPeerID})
+		require.NoError(t, err)
+		assert.DeepEqual(t, []peer.ID{pid}, admin.disconnected)
+	})
+}
+
+func TestServer_PeerAdmin_Disabled(t *testing.T) {
+	ctx := context.Background()
+	admin := &mockPeerAdmin{
+		status: peers.NewStatus(ctx, &peers.StatusConfig{
+			PeerLimit:    30,
+			ScorerParams: &scorers.Config{},
+		}),
+	}
+	ns := &Server{PeerAdmin: admin}
+	_, err := ns.BanPeer(ctx, &ethpb.BanPeerRequest{PeerId: testPeerID, Duration: "1h"})
+	assert.ErrorContains(t, "--enable-peer-admin-rpc-endpoints", err)
+	assert.Equal(t, 0, len(admin.status.Banned()))
+	_, err = ns.ListTrustedPeers(ctx, &emptypb.Empty{})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = ns.DisconnectPeer(ctx, &ethpb.PeerRequest{PeerId: testPeerID})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	assert.Equal(t, 0, len(admin.disconnected))
+}
@@ -38,6 +38,9 @@ type Server struct {
 	BeaconDB             db.ReadOnlyDatabase
 	PeersFetcher         p2p.PeersProvider
 	PeerManager          p2p.PeerManager
+	PeerAdmin            p2p.PeerAdmin
+	EnablePeerAdmin      bool
+	GossipScoreInspector p2p.GossipScoreInspector
 	GenesisTimeFetcher   blockchain.TimeFetcher
 	GenesisFetcher       blockchain.GenesisFetcher
 	BeaconMonitoringHost string
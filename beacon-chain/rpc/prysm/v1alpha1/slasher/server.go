@@ -0,0 +1,164 @@
+// Package slasher defines a gRPC server implementation of the slasher service, which allows
+// validator clients to check whether a block or an attestation would be slashable against the
+// slashing history recorded in the slasher database of the beacon node data directory, this server
+// is gated behind the flag --enable-slasher-rpc-endpoints.
+package slasher
+
+import (
+	"context"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	slashertypes "github.com/prysmaticlabs/prysm/beacon-chain/slasher/types"
+	pbrpc "github.com/prysmaticlabs/prysm/proto/beacon/rpc/v1"
+	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Maximum number of target epochs scanned on each side of an attestation for surround votes, as
+// each scanned epoch is a database read per attesting index. It matches the default history length
+// of slashing detection, beyond which attestation records are pruned from the slasher database, so
+// the cap only skips records detection would have pruned. Surround votes against attestations whose
+// target is further than this from the checked target are not reported.
+const maxSurroundScanEpochs = types.Epoch(4096)
+
+// Server defines a server implementation of the gRPC Slasher service, checking blocks and
+// attestations against the slasher database without modifying it.
+type Server struct {
+	SlasherDB db.SlasherDatabase
+}
+
+// IsSlashableAttestation returns an attester slashing if the indexed attestation is a double vote,
+// surrounds or is surrounded by an attestation of one of its attesters in the slasher database.
+func (s *Server) IsSlashableAttestation(
+	ctx context.Context, req *ethpb.IndexedAttestation,
+) (*pbrpc.AttesterSlashingResponse, error) {
+	if req == nil || req.Data == nil || req.Data.Source == nil || req.Data.Target == nil {
+		return nil, status.Error(codes.InvalidArgument, "Incomplete attestation data")
+	}
+	signingRoot, err := req.Data.HashTreeRoot()
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Could not compute attestation data root: %v", err)
+	}
+	att := &slashertypes.IndexedAttestationWrapper{
+		IndexedAttestation: req,
+		SigningRoot:        signingRoot,
+	}
+	doubleVotes, err := s.SlasherDB.CheckAttesterDoubleVotes(ctx, []*slashertypes.IndexedAttestationWrapper{att})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not check for double votes: %v", err)
+	}
+	if len(doubleVotes) > 0 {
+		return &pbrpc.AttesterSlashingResponse{
+			AttesterSlashing: &ethpb.AttesterSlashing{
+				Attestation_1: doubleVotes[0].PrevAttestationWrapper.IndexedAttestation,
+				Attestation_2: req,
+			},
+		}, nil
+	}
+	for _, idx := range req.AttestingIndices {
+		existing, err := s.surroundVote(ctx, types.ValidatorIndex(idx), req.Data.Source.Epoch, req.Data.Target.Epoch)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not check for surround votes: %v", err)
+		}
+		if existing != nil {
+			return &pbrpc.AttesterSlashingResponse{
+				AttesterSlashing: &ethpb.AttesterSlashing{
+					Attestation_1: existing.IndexedAttestation,
+					Attestation_2: req,
+				},
+			}, nil
+		}
+	}
+	return &pbrpc.AttesterSlashingResponse{}, nil
+}
+
+// surroundVote returns an attestation of the validator in the slasher database which surrounds
+// or is surrounded by an attestation with the given source and target epochs, if any.
+func (s *Server) surroundVote(
+	ctx context.Context, idx types.ValidatorIndex, source, target types.Epoch,
+) (*slashertypes.IndexedAttestationWrapper, error) {
+	// Attestations surrounded by the incoming one have a target epoch between its source and target.
+	start := source + 1
+	if target > maxSurroundScanEpochs && target-maxSurroundScanEpochs > start {
+		start = target - maxSurroundScanEpochs
+	}
+	for epoch := start; epoch < target; epoch++ {
+		existing, err := s.SlasherDB.AttestationRecordForValidator(ctx, idx, epoch)
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil && existing.IndexedAttestation.Data.Source.Epoch > source {
+			return existing, nil
+		}
+	}
+
+	// Attestations surrounding the incoming one have a target epoch above its target, up to the
+	// highest target epoch attested to by the validator.
+	highest, err := s.SlasherDB.HighestAttestations(ctx, []types.ValidatorIndex{idx})
+	if err != nil {
+		return nil, err
+	}
+	if len(highest) == 0 {
+		return nil, nil
+	}
+	end := highest[0].HighestTargetEpoch
+	if end-target > maxSurroundScanEpochs {
+		end = target + maxSurroundScanEpochs
+	}
+	for epoch := target + 1; epoch <= end; epoch++ {
+		existing, err := s.SlasherDB.AttestationRecordForValidator(ctx, idx, epoch)
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil && existing.IndexedAttestation.Data.Source.Epoch < source {
+			return existing, nil
+		}
+	}
+	return nil, nil
+}
+
+// IsSlashableBlock returns a proposer slashing if another block was proposed by the same
+// proposer at the same slot according to the slasher database.
+func (s *Server) IsSlashableBlock(
+	ctx context.Context, req *ethpb.SignedBeaconBlockHeader,
+) (*pbrpc.ProposerSlashingResponse, error) {
+	if req == nil || req.Header == nil {
+		return nil, status.Error(codes.InvalidArgument, "Incomplete block header")
+	}
+	signingRoot, err := req.Header.HashTreeRoot()
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Could not compute block header root: %v", err)
+	}
+	if req.Signature == nil {
+		req.Signature = params.BeaconConfig().EmptySignature[:]
+	}
+	slashings, err := s.SlasherDB.CheckDoubleBlockProposals(ctx, []*slashertypes.SignedBlockHeaderWrapper{
+		{SignedBeaconBlockHeader: req, SigningRoot: signingRoot},
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not check for double proposals: %v", err)
+	}
+	if len(slashings) == 0 {
+		return &pbrpc.ProposerSlashingResponse{}, nil
+	}
+	return &pbrpc.ProposerSlashingResponse{ProposerSlashing: slashings[0]}, nil
+}
+
+// HighestAttestations returns the highest source and target epochs attested to by the requested
+// validators according to the slasher database.
+func (s *Server) HighestAttestations(
+	ctx context.Context, req *pbrpc.HighestAttestationRequest,
+) (*pbrpc.HighestAttestationResponse, error) {
+	indices := make([]types.ValidatorIndex, len(req.ValidatorIndices))
+	for i, idx := range req.ValidatorIndices {
+		indices[i] = types.ValidatorIndex(idx)
+	}
+	atts, err := s.SlasherDB.HighestAttestations(ctx, indices)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get highest attestations: %v", err)
+	}
+	return &pbrpc.HighestAttestationResponse{Attestations: atts}, nil
+}
@@ -0,0 +1,126 @@
+package slasher
+
+import (
+	"context"
+	"testing"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	dbtest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	slashertypes "github.com/prysmaticlabs/prysm/beacon-chain/slasher/types"
+	pbrpc "github.com/prysmaticlabs/prysm/proto/beacon/rpc/v1"
+	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func createAttestation(t *testing.T, source, target types.Epoch, indices []uint64, root byte) *slashertypes.IndexedAttestationWrapper {
+	data := &ethpb.AttestationData{
+		BeaconBlockRoot: bytesutil.PadTo([]byte{root}, 32),
+		Source:          &ethpb.Checkpoint{Epoch: source, Root: make([]byte, 32)},
+		Target:          &ethpb.Checkpoint{Epoch: target, Root: make([]byte, 32)},
+	}
+	signingRoot, err := data.HashTreeRoot()
+	require.NoError(t, err)
+	return &slashertypes.IndexedAttestationWrapper{
+		IndexedAttestation: &ethpb.IndexedAttestation{
+			AttestingIndices: indices,
+			Data:             data,
+			Signature:        params.BeaconConfig().EmptySignature[:],
+		},
+		SigningRoot: signingRoot,
+	}
+}
+
+func createBlockHeader(t *testing.T, slot types.Slot, proposerIndex types.ValidatorIndex, root byte) *slashertypes.SignedBlockHeaderWrapper {
+	header := &ethpb.BeaconBlockHeader{
+		Slot:          slot,
+		ProposerIndex: proposerIndex,
+		ParentRoot:    make([]byte, 32),
+		StateRoot:     bytesutil.PadTo([]byte{root}, 32),
+		BodyRoot:      make([]byte, 32),
+	}
+	signingRoot, err := header.HashTreeRoot()
+	require.NoError(t, err)
+	return &slashertypes.SignedBlockHeaderWrapper{
+		SignedBeaconBlockHeader: &ethpb.SignedBeaconBlockHeader{
+			Header:    header,
+			Signature: params.BeaconConfig().EmptySignature[:],
+		},
+		SigningRoot: signingRoot,
+	}
+}
+
+func TestServer_IsSlashableAttestation(t *testing.T) {
+	ctx := context.Background()
+	slasherDB := dbtest.SetupSlasherDB(t)
+	existing := createAttestation(t, 3, 5, []uint64{1, 2}, 1)
+	require.NoError(t, slasherDB.SaveAttestationRecordsForValidators(ctx, []*slashertypes.IndexedAttestationWrapper{existing}))
+	server := &Server{SlasherDB: slasherDB}
+
+	tests := []struct {
+		name      string
+		att       *slashertypes.IndexedAttestationWrapper
+		slashable bool
+	}{
+		{name: "same attestation", att: createAttestation(t, 3, 5, []uint64{1}, 1), slashable: false},
+		{name: "double vote", att: createAttestation(t, 3, 5, []uint64{2}, 2), slashable: true},
+		{name: "surrounding vote", att: createAttestation(t, 2, 6, []uint64{1}, 1), slashable: true},
+		{name: "surrounded vote", att: createAttestation(t, 4, 4, []uint64{2}, 1), slashable: true},
+		{name: "other validator", att: createAttestation(t, 2, 6, []uint64{3}, 1), slashable: false},
+		{name: "later attestation", att: createAttestation(t, 5, 6, []uint64{1, 2}, 1), slashable: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := server.IsSlashableAttestation(ctx, tt.att.IndexedAttestation)
+			require.NoError(t, err)
+			assert.Equal(t, tt.slashable, resp.AttesterSlashing != nil)
+			if tt.slashable {
+				assert.DeepEqual(t, existing.IndexedAttestation, resp.AttesterSlashing.Attestation_1)
+				assert.DeepEqual(t, tt.att.IndexedAttestation, resp.AttesterSlashing.Attestation_2)
+			}
+		})
+	}
+
+	_, err := server.IsSlashableAttestation(ctx, &ethpb.IndexedAttestation{})
+	require.ErrorContains(t, "Incomplete attestation data", err)
+}
+
+func TestServer_IsSlashableBlock(t *testing.T) {
+	ctx := context.Background()
+	slasherDB := dbtest.SetupSlasherDB(t)
+	existing := createBlockHeader(t, 10, 1, 1)
+	require.NoError(t, slasherDB.SaveBlockProposals(ctx, []*slashertypes.SignedBlockHeaderWrapper{existing}))
+	server := &Server{SlasherDB: slasherDB}
+
+	resp, err := server.IsSlashableBlock(ctx, createBlockHeader(t, 10, 1, 1).SignedBeaconBlockHeader)
+	require.NoError(t, err)
+	assert.Equal(t, true, resp.ProposerSlashing == nil)
+
+	resp, err = server.IsSlashableBlock(ctx, createBlockHeader(t, 11, 1, 2).SignedBeaconBlockHeader)
+	require.NoError(t, err)
+	assert.Equal(t, true, resp.ProposerSlashing == nil)
+
+	incoming := createBlockHeader(t, 10, 1, 2).SignedBeaconBlockHeader
+	resp, err = server.IsSlashableBlock(ctx, incoming)
+	require.NoError(t, err)
+	require.NotNil(t, resp.ProposerSlashing)
+	assert.DeepEqual(t, existing.SignedBeaconBlockHeader, resp.ProposerSlashing.Header_1)
+	assert.DeepEqual(t, incoming, resp.ProposerSlashing.Header_2)
+}
+
+func TestServer_HighestAttestations(t *testing.T) {
+	ctx := context.Background()
+	slasherDB := dbtest.SetupSlasherDB(t)
+	require.NoError(t, slasherDB.SaveAttestationRecordsForValidators(ctx, []*slashertypes.IndexedAttestationWrapper{
+		createAttestation(t, 1, 2, []uint64{1}, 1),
+		createAttestation(t, 2, 3, []uint64{1}, 1),
+	}))
+	server := &Server{SlasherDB: slasherDB}
+	resp, err := server.HighestAttestations(ctx, &pbrpc.HighestAttestationRequest{ValidatorIndices: []uint64{1}})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(resp.Attestations))
+	assert.Equal(t, types.Epoch(2), resp.Attestations[0].HighestSourceEpoch)
+	assert.Equal(t, types.Epoch(3), resp.Attestations[0].HighestTargetEpoch)
+}
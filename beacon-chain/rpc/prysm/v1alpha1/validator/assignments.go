@@ -136,6 +136,15 @@ func (vs *Server) duties(ctx context.Context, req *ethpb.DutiesRequest) (*ethpb.
 		return nil, status.Errorf(codes.Internal, "Could not compute next committee assignments: %v", err)
 	}
 
+	syncCommitteeMembers, err := syncCommitteeMembersAtEpoch(s, req.Epoch)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not compute sync committee assignments: %v", err)
+	}
+	nextSyncCommitteeMembers, err := syncCommitteeMembersAtEpoch(s, req.Epoch+1)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not compute next sync committee assignments: %v", err)
+	}
+
 	validatorAssignments := make([]*ethpb.DutiesResponse_Duty, 0, len(req.PublicKeys))
 	nextValidatorAssignments := make([]*ethpb.DutiesResponse_Duty, 0, len(req.PublicKeys))
 	for _, pubKey := range req.PublicKeys {
@@ -173,6 +182,8 @@ func (vs *Server) duties(ctx context.Context, req *ethpb.DutiesRequest) (*ethpb.
 				nextAssignment.AttesterSlot = ca.AttesterSlot
 				nextAssignment.CommitteeIndex = ca.CommitteeIndex
 			}
+			assignment.IsSyncCommittee = syncCommitteeMembers[bytesutil.ToBytes48(pubKey)]
+			nextAssignment.IsSyncCommittee = nextSyncCommitteeMembers[bytesutil.ToBytes48(pubKey)]
 		} else {
 			// If the validator isn't in the beacon state, try finding their deposit to determine their status.
 			vStatus, _ := vs.validatorStatus(ctx, s, pubKey)
@@ -139,8 +139,8 @@ func (vs *Server) GetBlock(ctx context.Context, req *ethpb.BlockRequest) (*ethpb
 			Deposits:          deposits,
 			Attestations:      atts,
 			RandaoReveal:      req.RandaoReveal,
-			ProposerSlashings: vs.SlashingsPool.PendingProposerSlashings(ctx, head, false /*noLimit*/),
-			AttesterSlashings: vs.SlashingsPool.PendingAttesterSlashings(ctx, head, false /*noLimit*/),
+			ProposerSlashings: vs.packProposerSlashings(ctx, head),
+			AttesterSlashings: vs.packAttesterSlashings(ctx, head),
 			VoluntaryExits:    vs.ExitPool.PendingExits(head, req.Slot, false /*noLimit*/),
 			Graffiti:          graffiti[:],
 		},
@@ -627,7 +627,7 @@ func (vs *Server) filterAttestationsForBlockInclusion(ctx context.Context, st if
 	if err != nil {
 		return nil, err
 	}
-	sorted, err := deduped.sortByProfitability()
+	sorted, err := deduped.sortForInclusion(ctx, st)
 	if err != nil {
 		return nil, err
 	}
@@ -713,7 +713,7 @@ func (vs *Server) packAttestations(ctx context.Context, latestState iface.Beacon
 		if err != nil {
 			return nil, err
 		}
-		sorted, err := deduped.sortByProfitability()
+		sorted, err := deduped.sortForInclusion(ctx, latestState)
 		if err != nil {
 			return nil, err
 		}
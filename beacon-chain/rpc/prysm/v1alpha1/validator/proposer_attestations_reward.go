@@ -0,0 +1,316 @@
+package validator
+
+import (
+	"bytes"
+	"container/heap"
+	"context"
+	"sort"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	iface "github.com/prysmaticlabs/prysm/beacon-chain/state/interface"
+	pbp2p "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
+	attaggregation "github.com/prysmaticlabs/prysm/shared/aggregation/attestations"
+	"github.com/prysmaticlabs/prysm/shared/attestationutil"
+	"github.com/prysmaticlabs/prysm/shared/featureconfig"
+	"github.com/prysmaticlabs/prysm/shared/mathutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/sliceutil"
+	"go.opencensus.io/trace"
+)
+
+// attesterKey identifies the vote of a validator for a target epoch, which is rewarded at most once.
+type attesterKey struct {
+	epoch     types.Epoch
+	validator types.ValidatorIndex
+}
+
+// attRewardCandidate is an attestation considered for inclusion, along with the reward each of
+// its attesters would bring if the attestation was the first to include their vote.
+type attRewardCandidate struct {
+	att        *ethpb.Attestation
+	epoch      types.Epoch
+	validators []types.ValidatorIndex
+	rewards    []uint64
+	lastGain   uint64
+}
+
+// gain returns the rewards the candidate brings, counting only the attesters not yet included.
+func (c *attRewardCandidate) gain(included map[attesterKey]bool) uint64 {
+	var gain uint64
+	for i, idx := range c.validators {
+		if !included[attesterKey{epoch: c.epoch, validator: idx}] {
+			gain += c.rewards[i]
+		}
+	}
+	return gain
+}
+
+// attRewardHeap is a max-heap of candidates, ordered by their last computed gain.
+type attRewardHeap []*attRewardCandidate
+
+func (h attRewardHeap) Len() int            { return len(h) }
+func (h attRewardHeap) Less(i, j int) bool  { return h[i].lastGain > h[j].lastGain }
+func (h attRewardHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *attRewardHeap) Push(x interface{}) { *h = append(*h, x.(*attRewardCandidate)) }
+func (h *attRewardHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	cand := old[n-1]
+	old[n-1] = nil
+	*h = old[:n-1]
+	return cand
+}
+
+// attRewardCalculator estimates the rewards of including attestations in a block built on top of
+// the given state.
+type attRewardCalculator struct {
+	st          iface.ReadOnlyBeaconState
+	balanceSqrt uint64
+}
+
+func newAttRewardCalculator(st iface.ReadOnlyBeaconState) (*attRewardCalculator, error) {
+	totalBalance, err := helpers.TotalActiveBalance(st)
+	if err != nil {
+		return nil, err
+	}
+	balanceSqrt := mathutil.IntegerSquareRoot(totalBalance)
+	// Balance square root cannot be 0, this prevents division by 0.
+	if balanceSqrt == 0 {
+		balanceSqrt = 1
+	}
+	return &attRewardCalculator{st: st, balanceSqrt: balanceSqrt}, nil
+}
+
+// includedAttesters returns the attesters whose votes were already included in the chain of the
+// state, through its pending attestations. Including their votes again brings no reward.
+func (c *attRewardCalculator) includedAttesters(ctx context.Context) map[attesterKey]bool {
+	included := make(map[attesterKey]bool)
+	var pending []*pbp2p.PendingAttestation
+	if atts, err := c.st.PreviousEpochAttestations(); err == nil {
+		pending = append(pending, atts...)
+	}
+	if atts, err := c.st.CurrentEpochAttestations(); err == nil {
+		pending = append(pending, atts...)
+	}
+	for _, att := range pending {
+		if ctx.Err() != nil {
+			return included
+		}
+		committee, err := helpers.BeaconCommitteeFromState(c.st, att.Data.Slot, att.Data.CommitteeIndex)
+		if err != nil {
+			continue
+		}
+		indices, err := attestationutil.AttestingIndices(att.AggregationBits, committee)
+		if err != nil {
+			continue
+		}
+		for _, idx := range indices {
+			included[attesterKey{epoch: att.Data.Target.Epoch, validator: types.ValidatorIndex(idx)}] = true
+		}
+	}
+	return included
+}
+
+// candidate computes the reward of each attester of the given attestation. Each attester earns
+// the proposer its share of the base reward, plus its own inclusion reward which decreases with
+// the inclusion delay, and its source, target and head rewards for the votes that are correct.
+// The latter are approximated with the full base reward, which they reach with full participation.
+func (c *attRewardCalculator) candidate(att *ethpb.Attestation) (*attRewardCandidate, error) {
+	committee, err := helpers.BeaconCommitteeFromState(c.st, att.Data.Slot, att.Data.CommitteeIndex)
+	if err != nil {
+		return nil, err
+	}
+	indices, err := attestationutil.AttestingIndices(att.AggregationBits, committee)
+	if err != nil {
+		return nil, err
+	}
+
+	delay := uint64(1)
+	if c.st.Slot() > att.Data.Slot {
+		delay = uint64(c.st.Slot() - att.Data.Slot)
+	}
+	// The source vote is correct, as attestations are filtered against the state beforehand.
+	correctVotes := uint64(1)
+	if root, err := helpers.BlockRoot(c.st, att.Data.Target.Epoch); err == nil && bytes.Equal(root, att.Data.Target.Root) {
+		correctVotes++
+	}
+	if root, err := helpers.BlockRootAtSlot(c.st, att.Data.Slot); err == nil && bytes.Equal(root, att.Data.BeaconBlockRoot) {
+		correctVotes++
+	}
+
+	cand := &attRewardCandidate{
+		att:        att,
+		epoch:      att.Data.Target.Epoch,
+		validators: make([]types.ValidatorIndex, len(indices)),
+		rewards:    make([]uint64, len(indices)),
+	}
+	cfg := params.BeaconConfig()
+	for i, idx := range indices {
+		val, err := c.st.ValidatorAtIndexReadOnly(types.ValidatorIndex(idx))
+		if err != nil {
+			return nil, err
+		}
+		baseReward := val.EffectiveBalance() * cfg.BaseRewardFactor / c.balanceSqrt / cfg.BaseRewardsPerEpoch
+		proposerReward := baseReward / cfg.ProposerRewardQuotient
+		inclusionReward := (baseReward - proposerReward) * uint64(cfg.MinAttestationInclusionDelay) / delay
+		cand.validators[i] = types.ValidatorIndex(idx)
+		cand.rewards[i] = proposerReward + inclusionReward + correctVotes*baseReward
+	}
+	return cand, nil
+}
+
+// sortByReward orders attestations by the rewards their inclusion brings on top of the given
+// state. Attestations are greedily selected by the rewards of the attesters they include for the
+// first time, so that votes which are already on chain, or included by attestations selected
+// before, are not counted. Attestations which bring no reward are appended at the end.
+func (a proposerAtts) sortByReward(ctx context.Context, st iface.ReadOnlyBeaconState) (proposerAtts, error) {
+	ctx, span := trace.StartSpan(ctx, "ProposerServer.sortByReward")
+	defer span.End()
+
+	if len(a) == 0 {
+		return a, nil
+	}
+	calc, err := newAttRewardCalculator(st)
+	if err != nil {
+		return nil, err
+	}
+	candidates := make([]*attRewardCandidate, 0, len(a))
+	var unscored proposerAtts
+	for _, att := range a {
+		cand, err := calc.candidate(att)
+		if err != nil {
+			unscored = append(unscored, att)
+			continue
+		}
+		candidates = append(candidates, cand)
+	}
+	included := calc.includedAttesters(ctx)
+
+	// The gain of a candidate can only decrease as other candidates are selected, so the gains
+	// computed before are upper bounds: a candidate is selected when its updated gain is still the
+	// highest one (lazy greedy evaluation).
+	h := &attRewardHeap{}
+	for _, cand := range candidates {
+		cand.lastGain = cand.gain(included)
+		heap.Push(h, cand)
+	}
+	sorted := make(proposerAtts, 0, len(a))
+	var leftover proposerAtts
+	for uint64(len(sorted)) < params.BeaconConfig().MaxAttestations && h.Len() > 0 {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		cand := heap.Pop(h).(*attRewardCandidate)
+		cand.lastGain = cand.gain(included)
+		if cand.lastGain == 0 {
+			leftover = append(leftover, cand.att)
+			continue
+		}
+		if h.Len() > 0 && (*h)[0].lastGain > cand.lastGain {
+			heap.Push(h, cand)
+			continue
+		}
+		for _, idx := range cand.validators {
+			included[attesterKey{epoch: cand.epoch, validator: idx}] = true
+		}
+		sorted = append(sorted, cand.att)
+	}
+	for _, cand := range *h {
+		leftover = append(leftover, cand.att)
+	}
+
+	// Leftover attestations bring no additional reward or do not fit in the block, order them by
+	// the number of bits set.
+	leftover = append(leftover, unscored...)
+	sort.SliceStable(leftover, func(i, j int) bool {
+		return leftover[i].AggregationBits.Count() > leftover[j].AggregationBits.Count()
+	})
+	return append(sorted, leftover...), nil
+}
+
+// maxRewardPacking returns whether block contents are selected by the rewards they bring.
+func maxRewardPacking() bool {
+	strategy := attaggregation.AttestationAggregationStrategy(featureconfig.Get().AttestationAggregationStrategy)
+	return strategy == attaggregation.MaxRewardAggregation
+}
+
+// sortForInclusion orders attestations for block inclusion, using the packing strategy selected
+// with --attestation-aggregation-strategy.
+func (a proposerAtts) sortForInclusion(ctx context.Context, st iface.ReadOnlyBeaconState) (proposerAtts, error) {
+	if maxRewardPacking() {
+		return a.sortByReward(ctx, st)
+	}
+	return a.sortByProfitability()
+}
+
+// packProposerSlashings returns the proposer slashings to include in a block built on top of the
+// given state. With the max_reward strategy, the slashings of the validators with the highest
+// effective balance, which bring the highest whistleblower rewards, are selected first.
+func (vs *Server) packProposerSlashings(ctx context.Context, st iface.ReadOnlyBeaconState) []*ethpb.ProposerSlashing {
+	if !maxRewardPacking() {
+		return vs.SlashingsPool.PendingProposerSlashings(ctx, st, false /*noLimit*/)
+	}
+	pending := append([]*ethpb.ProposerSlashing{}, vs.SlashingsPool.PendingProposerSlashings(ctx, st, true /*noLimit*/)...)
+	balance := func(s *ethpb.ProposerSlashing) uint64 {
+		val, err := st.ValidatorAtIndexReadOnly(s.Header_1.Header.ProposerIndex)
+		if err != nil {
+			return 0
+		}
+		return val.EffectiveBalance()
+	}
+	sort.SliceStable(pending, func(i, j int) bool {
+		return balance(pending[i]) > balance(pending[j])
+	})
+	if uint64(len(pending)) > params.BeaconConfig().MaxProposerSlashings {
+		pending = pending[:params.BeaconConfig().MaxProposerSlashings]
+	}
+	return pending
+}
+
+// packAttesterSlashings returns the attester slashings to include in a block built on top of the
+// given state. With the max_reward strategy, slashings are greedily selected by the effective
+// balance of the validators they slash which are not slashed by the slashings selected before, as
+// the whistleblower rewards are proportional to it.
+func (vs *Server) packAttesterSlashings(ctx context.Context, st iface.ReadOnlyBeaconState) []*ethpb.AttesterSlashing {
+	if !maxRewardPacking() {
+		return vs.SlashingsPool.PendingAttesterSlashings(ctx, st, false /*noLimit*/)
+	}
+	pending := vs.SlashingsPool.PendingAttesterSlashings(ctx, st, true /*noLimit*/)
+	epoch := helpers.CurrentEpoch(st)
+	slashed := make(map[uint64]bool)
+	gain := func(s *ethpb.AttesterSlashing) uint64 {
+		var gain uint64
+		for _, idx := range sliceutil.IntersectionUint64(s.Attestation_1.AttestingIndices, s.Attestation_2.AttestingIndices) {
+			if slashed[idx] {
+				continue
+			}
+			val, err := st.ValidatorAtIndexReadOnly(types.ValidatorIndex(idx))
+			if err != nil || !helpers.IsSlashableValidatorUsingTrie(val, epoch) {
+				continue
+			}
+			gain += val.EffectiveBalance()
+		}
+		return gain
+	}
+
+	selected := make([]*ethpb.AttesterSlashing, 0, params.BeaconConfig().MaxAttesterSlashings)
+	for uint64(len(selected)) < params.BeaconConfig().MaxAttesterSlashings {
+		var best *ethpb.AttesterSlashing
+		var bestGain uint64
+		for _, s := range pending {
+			if g := gain(s); g > bestGain {
+				best, bestGain = s, g
+			}
+		}
+		if best == nil {
+			break
+		}
+		for _, idx := range sliceutil.IntersectionUint64(best.Attestation_1.AttestingIndices, best.Attestation_2.AttestingIndices) {
+			slashed[idx] = true
+		}
+		selected = append(selected, best)
+	}
+	return selected
+}
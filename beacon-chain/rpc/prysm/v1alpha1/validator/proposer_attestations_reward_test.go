@@ -0,0 +1,256 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/operations/slashings"
+	iface "github.com/prysmaticlabs/prysm/beacon-chain/state/interface"
+	pbp2p "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
+	aggtesting "github.com/prysmaticlabs/prysm/shared/aggregation/testing"
+	"github.com/prysmaticlabs/prysm/shared/copyutil"
+	"github.com/prysmaticlabs/prysm/shared/featureconfig"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+// rewardTestState returns a state at the given slot of the first epoch, with the given number of
+// active validators.
+func rewardTestState(t testing.TB, numValidators uint64, slot types.Slot) iface.BeaconState {
+	helpers.ClearCache()
+	validators := make([]*ethpb.Validator, numValidators)
+	balances := make([]uint64, numValidators)
+	for i := range validators {
+		validators[i] = &ethpb.Validator{
+			PublicKey:             make([]byte, params.BeaconConfig().BLSPubkeyLength),
+			WithdrawalCredentials: make([]byte, 32),
+			EffectiveBalance:      params.BeaconConfig().MaxEffectiveBalance,
+			ExitEpoch:             params.BeaconConfig().FarFutureEpoch,
+			WithdrawableEpoch:     params.BeaconConfig().FarFutureEpoch,
+		}
+		balances[i] = params.BeaconConfig().MaxEffectiveBalance
+	}
+	st, err := testutil.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, st.SetValidators(validators))
+	require.NoError(t, st.SetBalances(balances))
+	require.NoError(t, st.SetSlot(slot))
+	return st
+}
+
+func rewardTestAtt(slot types.Slot, committeeIndex types.CommitteeIndex, size uint64, bits ...uint64) *ethpb.Attestation {
+	aggBits := bitfield.NewBitlist(size)
+	for _, b := range bits {
+		aggBits.SetBitAt(b, true)
+	}
+	return testutil.HydrateAttestation(&ethpb.Attestation{
+		AggregationBits: aggBits,
+		Data:            &ethpb.AttestationData{Slot: slot, CommitteeIndex: committeeIndex},
+	})
+}
+
+// blockAttestationsReward returns the estimated rewards of including the given attestations, in
+// order, in a block built on top of the given state.
+func blockAttestationsReward(t testing.TB, st iface.ReadOnlyBeaconState, atts []*ethpb.Attestation) uint64 {
+	calc, err := newAttRewardCalculator(st)
+	require.NoError(t, err)
+	included := calc.includedAttesters(context.Background())
+	var reward uint64
+	for _, att := range atts {
+		cand, err := calc.candidate(att)
+		require.NoError(t, err)
+		reward += cand.gain(included)
+		for _, idx := range cand.validators {
+			included[attesterKey{epoch: cand.epoch, validator: idx}] = true
+		}
+	}
+	return reward
+}
+
+func TestProposerAtts_SortByReward(t *testing.T) {
+	// 256 validators form a single committee of 8 validators per slot.
+	committeeSize := uint64(8)
+
+	t.Run("votes already on chain", func(t *testing.T) {
+		st := rewardTestState(t, 256, 10)
+		onChain := rewardTestAtt(9, 0, committeeSize, 0, 1, 2)
+		require.NoError(t, st.AppendCurrentEpochAttestations(&pbp2p.PendingAttestation{
+			AggregationBits: onChain.AggregationBits,
+			Data:            onChain.Data,
+			InclusionDelay:  1,
+		}))
+
+		atts := proposerAtts{
+			rewardTestAtt(9, 0, committeeSize, 0, 1, 2, 3),
+			rewardTestAtt(8, 0, committeeSize, 0, 1),
+		}
+		sorted, err := atts.sortByReward(context.Background(), st)
+		require.NoError(t, err)
+		// Only one new vote is brought by the attestation of the latest slot.
+		assert.DeepEqual(t, proposerAtts{atts[1], atts[0]}, sorted)
+
+		bySlot, err := copyProposerAtts(atts).sortByProfitability()
+		require.NoError(t, err)
+		assert.Equal(t, types.Slot(9), bySlot[0].Data.Slot)
+		assert.Equal(t, true, blockAttestationsReward(t, st, sorted[:1]) > blockAttestationsReward(t, st, bySlot[:1]))
+	})
+
+	t.Run("overlapping attestations", func(t *testing.T) {
+		st := rewardTestState(t, 256, 10)
+		atts := proposerAtts{
+			rewardTestAtt(9, 0, committeeSize, 2, 3, 4),
+			rewardTestAtt(9, 0, committeeSize, 4, 5),
+			rewardTestAtt(9, 0, committeeSize, 0, 1, 2, 3),
+		}
+		sorted, err := atts.sortByReward(context.Background(), st)
+		require.NoError(t, err)
+		assert.DeepEqual(t, proposerAtts{atts[2], atts[1], atts[0]}, sorted)
+	})
+
+	t.Run("inclusion delay", func(t *testing.T) {
+		st := rewardTestState(t, 256, 10)
+		atts := proposerAtts{
+			rewardTestAtt(1, 0, committeeSize, 0, 1, 2),
+			rewardTestAtt(9, 0, committeeSize, 0, 1, 2),
+		}
+		sorted, err := atts.sortByReward(context.Background(), st)
+		require.NoError(t, err)
+		assert.DeepEqual(t, proposerAtts{atts[1], atts[0]}, sorted)
+	})
+}
+
+func TestProposerAtts_SortForInclusion(t *testing.T) {
+	st := rewardTestState(t, 256, 10)
+	atts := proposerAtts{
+		rewardTestAtt(9, 0, 8, 0),
+		rewardTestAtt(8, 0, 8, 0, 1, 2, 3),
+	}
+
+	resetCfg := featureconfig.InitWithReset(&featureconfig.Flags{AttestationAggregationStrategy: "max_cover"})
+	sorted, err := copyProposerAtts(atts).sortForInclusion(context.Background(), st)
+	require.NoError(t, err)
+	assert.Equal(t, types.Slot(9), sorted[0].Data.Slot)
+	resetCfg()
+
+	resetCfg = featureconfig.InitWithReset(&featureconfig.Flags{AttestationAggregationStrategy: "max_reward"})
+	defer resetCfg()
+	sorted, err = copyProposerAtts(atts).sortForInclusion(context.Background(), st)
+	require.NoError(t, err)
+	assert.Equal(t, types.Slot(8), sorted[0].Data.Slot)
+}
+
+func TestServer_PackSlashings_MaxReward(t *testing.T) {
+	resetCfg := featureconfig.InitWithReset(&featureconfig.Flags{AttestationAggregationStrategy: "max_reward"})
+	defer resetCfg()
+	st := rewardTestState(t, 256, 10)
+	// Validators 4 and 5 have a lower effective balance, and validator 6 is already slashed.
+	for _, idx := range []types.ValidatorIndex{4, 5, 6} {
+		val, err := st.ValidatorAtIndex(idx)
+		require.NoError(t, err)
+		val.EffectiveBalance = params.BeaconConfig().MaxEffectiveBalance / 2
+		val.Slashed = idx == 6
+		require.NoError(t, st.UpdateValidatorAtIndex(idx, val))
+	}
+	attSlashing := func(indices ...uint64) *ethpb.AttesterSlashing {
+		return &ethpb.AttesterSlashing{
+			Attestation_1: &ethpb.IndexedAttestation{AttestingIndices: indices},
+			Attestation_2: &ethpb.IndexedAttestation{AttestingIndices: indices},
+		}
+	}
+	propSlashing := func(idx types.ValidatorIndex) *ethpb.ProposerSlashing {
+		return &ethpb.ProposerSlashing{
+			Header_1: &ethpb.SignedBeaconBlockHeader{Header: &ethpb.BeaconBlockHeader{ProposerIndex: idx}},
+		}
+	}
+	pool := &slashings.PoolMock{
+		PendingAttSlashings: []*ethpb.AttesterSlashing{
+			attSlashing(4, 5, 6),
+			attSlashing(1, 2),
+			attSlashing(1, 2, 3),
+			attSlashing(6),
+		},
+		PendingPropSlashings: []*ethpb.ProposerSlashing{propSlashing(4), propSlashing(7)},
+	}
+	vs := &Server{SlashingsPool: pool}
+
+	attSlashings := vs.packAttesterSlashings(context.Background(), st)
+	// Slashings which only slash validators slashed before bring no reward.
+	assert.DeepEqual(t, []*ethpb.AttesterSlashing{pool.PendingAttSlashings[2], pool.PendingAttSlashings[0]}, attSlashings)
+	propSlashings := vs.packProposerSlashings(context.Background(), st)
+	assert.DeepEqual(t, []*ethpb.ProposerSlashing{pool.PendingPropSlashings[1], pool.PendingPropSlashings[0]}, propSlashings)
+}
+
+func copyProposerAtts(atts proposerAtts) proposerAtts {
+	res := make(proposerAtts, len(atts))
+	for i, att := range atts {
+		res[i] = copyutil.CopyAttestation(att)
+	}
+	return res
+}
+
+// BenchmarkProposerAtts_Rewards compares the rewards of the attestations packed in a block by the
+// max cover and max reward strategies, reported in Gwei per block.
+func BenchmarkProposerAtts_Rewards(b *testing.B) {
+	numValidators := uint64(8192)
+	committeeCount := helpers.SlotCommitteeCount(numValidators)
+	committeeSize := numValidators / uint64(params.BeaconConfig().SlotsPerEpoch) / committeeCount
+	slot := params.BeaconConfig().SlotsPerEpoch - 1
+
+	tests := []struct {
+		name      string
+		numAtts   int
+		bitsSet   uint64
+		onChainPc int
+	}{
+		{name: "256 attestations with 16 bits set", numAtts: 256, bitsSet: 16},
+		{name: "256 attestations with 64 bits set, half on chain", numAtts: 256, bitsSet: 64, onChainPc: 50},
+		{name: "1024 attestations with 32 bits set, a quarter on chain", numAtts: 1024, bitsSet: 32, onChainPc: 25},
+	}
+
+	for _, tt := range tests {
+		st := rewardTestState(b, numValidators, slot)
+		r := rand.New(rand.NewSource(int64(tt.numAtts)))
+		bitlists := aggtesting.BitlistsWithMultipleBitSet(b, uint64(tt.numAtts), committeeSize, tt.bitsSet)
+		atts := make(proposerAtts, len(bitlists))
+		for i, bl := range bitlists {
+			atts[i] = rewardTestAtt(types.Slot(r.Intn(int(slot))), types.CommitteeIndex(r.Intn(int(committeeCount))), committeeSize)
+			atts[i].AggregationBits = bl
+			if r.Intn(100) < tt.onChainPc {
+				require.NoError(b, st.AppendCurrentEpochAttestations(&pbp2p.PendingAttestation{
+					AggregationBits: bl,
+					Data:            atts[i].Data,
+					InclusionDelay:  1,
+				}))
+			}
+		}
+
+		b.Run(fmt.Sprintf("max-cover_%s", tt.name), func(b *testing.B) {
+			resetCfg := featureconfig.InitWithReset(&featureconfig.Flags{ProposerAttsSelectionUsingMaxCover: true})
+			defer resetCfg()
+			var reward uint64
+			for i := 0; i < b.N; i++ {
+				sorted, err := copyProposerAtts(atts).sortByProfitability()
+				require.NoError(b, err)
+				reward = blockAttestationsReward(b, st, sorted.limitToMaxAttestations())
+			}
+			b.ReportMetric(float64(reward), "gwei/block")
+		})
+		b.Run(fmt.Sprintf("max-reward_%s", tt.name), func(b *testing.B) {
+			var reward uint64
+			for i := 0; i < b.N; i++ {
+				sorted, err := copyProposerAtts(atts).sortByReward(context.Background(), st)
+				require.NoError(b, err)
+				reward = blockAttestationsReward(b, st, sorted.limitToMaxAttestations())
+			}
+			b.ReportMetric(float64(reward), "gwei/block")
+		})
+	}
+}
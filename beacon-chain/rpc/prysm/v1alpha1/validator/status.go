@@ -7,9 +7,12 @@ import (
 	types "github.com/prysmaticlabs/eth2-types"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
 	iface "github.com/prysmaticlabs/prysm/beacon-chain/state/interface"
+	pbp2p "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
 	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/attestationutil"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
 	"github.com/prysmaticlabs/prysm/shared/depositutil"
+	"github.com/prysmaticlabs/prysm/shared/interfaces/version"
 	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/prysmaticlabs/prysm/shared/traceutil"
 	"go.opencensus.io/trace"
@@ -88,7 +91,10 @@ func (vs *Server) MultipleValidatorStatus(
 	}, nil
 }
 
-// CheckDoppelGanger checks if the provided keys are currently active in the network.
+// CheckDoppelGanger checks if the provided keys are currently active in the network, from the
+// participation of the validators in the previous and current epochs of the head state. Each request
+// holds the last epoch the requesting validator attested in, so a validator found attesting in a later
+// epoch is attesting from another instance.
 func (vs *Server) CheckDoppelGanger(ctx context.Context, req *ethpb.DoppelGangerRequest) (*ethpb.DoppelGangerResponse, error) {
 	if vs.SyncChecker.Syncing() {
 		return nil, status.Errorf(codes.Unavailable, "Syncing to latest head, not ready to respond")
@@ -102,89 +108,94 @@ func (vs *Server) CheckDoppelGanger(ctx context.Context, req *ethpb.DoppelGanger
 	if err != nil {
 		return nil, status.Error(codes.Internal, "Could not get head state")
 	}
-	// We walk back from the current head state to the state at the beginning of the previous 2 epochs.
-	// Where S_i , i := 0,1,2. i = 0 would signify the current head state in this epoch.
-	currEpoch := helpers.SlotToEpoch(headState.Slot())
-	previousEpoch, err := currEpoch.SafeSub(1)
+	currEpoch := helpers.CurrentEpoch(headState)
+	prevEpoch := helpers.PrevEpoch(headState)
+	prevParticipants, currParticipants, err := epochParticipants(headState)
 	if err != nil {
-		previousEpoch = currEpoch
-	}
-	olderEpoch, err := previousEpoch.SafeSub(1)
-	if err != nil {
-		olderEpoch = previousEpoch
-	}
-	prevState, err := vs.StateGen.StateBySlot(ctx, params.BeaconConfig().SlotsPerEpoch.Mul(uint64(previousEpoch)))
-	if err != nil {
-		return nil, status.Error(codes.Internal, "Could not get previous state")
-	}
-	olderState, err := vs.StateGen.StateBySlot(ctx, params.BeaconConfig().SlotsPerEpoch.Mul(uint64(olderEpoch)))
-	if err != nil {
-		return nil, status.Error(codes.Internal, "Could not get older state")
+		return nil, status.Errorf(codes.Internal, "Could not get epoch participation: %v", err)
 	}
 	resp := &ethpb.DoppelGangerResponse{
 		Responses: []*ethpb.DoppelGangerResponse_ValidatorResponse{},
 	}
 	for _, v := range req.ValidatorRequests {
-		// If the validator's last recorded epoch was
-		// less than or equal to 2 epochs ago, this method will not
-		// be able to catch duplicates. This is due to how attestation
-		// inclusion works, where an attestation for the current epoch
-		// is able to included in the current or next epoch. Depending
-		// on which epoch it is included the balance change will be
-		// reflected in the following epoch.
-		if v.Epoch+2 >= currEpoch {
-			resp.Responses = append(resp.Responses,
-				&ethpb.DoppelGangerResponse_ValidatorResponse{
-					PublicKey:       v.PublicKey,
-					DuplicateExists: false,
-				})
-			continue
-		}
-		valIndex, ok := olderState.ValidatorIndexByPubkey(bytesutil.ToBytes48(v.PublicKey))
+		valIndex, ok := headState.ValidatorIndexByPubkey(bytesutil.ToBytes48(v.PublicKey))
 		if !ok {
 			// Ignore if validator pubkey doesn't exist.
 			continue
 		}
-		baseBal, err := olderState.BalanceAtIndex(valIndex)
+		// Attestations of the validator's own last recorded epoch are not duplicates,
+		// but any attestation in a later epoch is.
+		duplicate := (currParticipants[valIndex] && v.Epoch < currEpoch) ||
+			(prevParticipants[valIndex] && v.Epoch < prevEpoch)
+		resp.Responses = append(resp.Responses,
+			&ethpb.DoppelGangerResponse_ValidatorResponse{
+				PublicKey:       v.PublicKey,
+				DuplicateExists: duplicate,
+			})
+	}
+	return resp, nil
+}
+
+// epochParticipants returns the validators which attested in the previous and in the current
+// epoch of the state, from the participation flags of Altair states or from the pending
+// attestations of phase 0 states.
+func epochParticipants(st iface.BeaconState) (map[types.ValidatorIndex]bool, map[types.ValidatorIndex]bool, error) {
+	if st.Version() == version.Altair {
+		prevFlags, err := st.PreviousEpochParticipation()
 		if err != nil {
-			return nil, status.Error(codes.Internal, "Could not get validator's balance")
+			return nil, nil, err
 		}
-		nextBal, err := prevState.BalanceAtIndex(valIndex)
+		currFlags, err := st.CurrentEpochParticipation()
 		if err != nil {
-			return nil, status.Error(codes.Internal, "Could not get validator's balance")
+			return nil, nil, err
 		}
-		// If the next epoch's balance is higher, we mark it as an existing
-		// duplicate.
-		if nextBal > baseBal {
-			resp.Responses = append(resp.Responses,
-				&ethpb.DoppelGangerResponse_ValidatorResponse{
-					PublicKey:       v.PublicKey,
-					DuplicateExists: true,
-				})
-			continue
+		return flaggedParticipants(prevFlags), flaggedParticipants(currFlags), nil
+	}
+	prevAtts, err := st.PreviousEpochAttestations()
+	if err != nil {
+		return nil, nil, err
+	}
+	currAtts, err := st.CurrentEpochAttestations()
+	if err != nil {
+		return nil, nil, err
+	}
+	prev, err := attestingParticipants(st, prevAtts)
+	if err != nil {
+		return nil, nil, err
+	}
+	curr, err := attestingParticipants(st, currAtts)
+	if err != nil {
+		return nil, nil, err
+	}
+	return prev, curr, nil
+}
+
+func flaggedParticipants(flags []byte) map[types.ValidatorIndex]bool {
+	participants := make(map[types.ValidatorIndex]bool)
+	for i, f := range flags {
+		if f != 0 {
+			participants[types.ValidatorIndex(i)] = true
 		}
-		currBal, err := headState.BalanceAtIndex(valIndex)
+	}
+	return participants
+}
+
+func attestingParticipants(st iface.ReadOnlyBeaconState, atts []*pbp2p.PendingAttestation) (map[types.ValidatorIndex]bool, error) {
+	participants := make(map[types.ValidatorIndex]bool)
+	for _, a := range atts {
+		committee, err := helpers.BeaconCommitteeFromState(st, a.Data.Slot, a.Data.CommitteeIndex)
 		if err != nil {
-			return nil, status.Error(codes.Internal, "Could not get validator's balance")
+			return nil, err
 		}
-		// If the current epoch's balance is higher, we mark it as an existing
-		// duplicate.
-		if currBal > nextBal {
-			resp.Responses = append(resp.Responses,
-				&ethpb.DoppelGangerResponse_ValidatorResponse{
-					PublicKey:       v.PublicKey,
-					DuplicateExists: true,
-				})
-			continue
+		indices, err := attestationutil.AttestingIndices(a.AggregationBits, committee)
+		if err != nil {
+			return nil, err
+		}
+		for _, i := range indices {
+			participants[types.ValidatorIndex(i)] = true
 		}
-		// Mark the public key as valid.
-		resp.Responses = append(resp.Responses,
-			&ethpb.DoppelGangerResponse_ValidatorResponse{
-				PublicKey:       v.PublicKey,
-				DuplicateExists: false,
-			})
 	}
-	return resp, nil
+	return participants, nil
 }
 
 // activationStatus returns the validator status response for the set of validators
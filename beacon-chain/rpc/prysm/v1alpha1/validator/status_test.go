@@ -7,19 +7,18 @@ import (
 	"time"
 
 	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/go-bitfield"
 	mockChain "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
 	"github.com/prysmaticlabs/prysm/beacon-chain/cache/depositcache"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
 	dbutil "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
 	mockPOW "github.com/prysmaticlabs/prysm/beacon-chain/powchain/testing"
-	iface "github.com/prysmaticlabs/prysm/beacon-chain/state/interface"
-	"github.com/prysmaticlabs/prysm/beacon-chain/state/stategen"
 	v1 "github.com/prysmaticlabs/prysm/beacon-chain/state/v1"
+	stateAltair "github.com/prysmaticlabs/prysm/beacon-chain/state/v2"
 	mockSync "github.com/prysmaticlabs/prysm/beacon-chain/sync/initial-sync/testing"
 	pbp2p "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
 	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
 	"github.com/prysmaticlabs/prysm/proto/eth/v1alpha1/wrapper"
-	"github.com/prysmaticlabs/prysm/shared/bls"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
 	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/prysmaticlabs/prysm/shared/testutil"
@@ -972,308 +971,132 @@ func Test_DepositStatus(t *testing.T) {
 }
 
 func TestServer_CheckDoppelGanger(t *testing.T) {
+	headEpoch := types.Epoch(4)
+	validators := make([]*ethpb.Validator, 64)
+	for i := range validators {
+		validators[i] = &ethpb.Validator{
+			PublicKey:        pubKey(uint64(i)),
+			EffectiveBalance: params.BeaconConfig().MaxEffectiveBalance,
+			ExitEpoch:        params.BeaconConfig().FarFutureEpoch,
+		}
+	}
+	prevParticipation := make([]byte, len(validators))
+	currParticipation := make([]byte, len(validators))
+	currParticipation[0] = 1
+	prevParticipation[1] = 1
+	prevParticipation[2] = 1
+	currParticipation[3] = 1
+	altairState, err := stateAltair.InitializeFromProto(&pbp2p.BeaconStateAltair{
+		Slot:                       params.BeaconConfig().SlotsPerEpoch.Mul(uint64(headEpoch)),
+		Validators:                 validators,
+		RandaoMixes:                make([][]byte, params.BeaconConfig().EpochsPerHistoricalVector),
+		PreviousEpochParticipation: prevParticipation,
+		CurrentEpochParticipation:  currParticipation,
+	})
+	require.NoError(t, err)
+
 	tests := []struct {
 		name    string
-		wantErr bool
+		wantErr string
 		svSetup func(t *testing.T) (*Server, *ethpb.DoppelGangerRequest, *ethpb.DoppelGangerResponse)
 	}{
 		{
-			name:    "normal doppelganger request",
-			wantErr: false,
+			name: "altair participation",
 			svSetup: func(t *testing.T) (*Server, *ethpb.DoppelGangerRequest, *ethpb.DoppelGangerResponse) {
-				db := dbutil.SetupDB(t)
-				mockGen := stategen.NewMockService()
-				hs, ps, os, keys := createStateSetup(t, 4, mockGen)
-				// Previous Epoch State
-				for i := 0; i < 3; i++ {
-					bal, err := ps.BalanceAtIndex(types.ValidatorIndex(i))
-					assert.NoError(t, err)
-					// Add 100 gwei, to mock an inactivity leak
-					assert.NoError(t, ps.UpdateBalancesAtIndex(types.ValidatorIndex(i), bal+100))
-				}
-				// Older Epoch State
-				for i := 0; i < 3; i++ {
-					bal, err := os.BalanceAtIndex(types.ValidatorIndex(i))
-					assert.NoError(t, err)
-					// Add 200 gwei, to mock an inactivity leak
-					assert.NoError(t, os.UpdateBalancesAtIndex(types.ValidatorIndex(i), bal+200))
-				}
 				vs := &Server{
-					BeaconDB: db,
-					StateGen: mockGen,
-					HeadFetcher: &mockChain.ChainService{
-						State: hs,
-					},
+					HeadFetcher: &mockChain.ChainService{State: altairState},
 					SyncChecker: &mockSync.Sync{IsSyncing: false},
 				}
 				request := &ethpb.DoppelGangerRequest{
-					ValidatorRequests: make([]*ethpb.DoppelGangerRequest_ValidatorRequest, 0),
-				}
-				response := &ethpb.DoppelGangerResponse{Responses: make([]*ethpb.DoppelGangerResponse_ValidatorResponse, 0)}
-				for i := 0; i < 3; i++ {
-					request.ValidatorRequests = append(request.ValidatorRequests, &ethpb.DoppelGangerRequest_ValidatorRequest{
-						PublicKey:  keys[i].PublicKey().Marshal(),
-						Epoch:      1,
-						SignedRoot: []byte{'A'},
-					})
-					response.Responses = append(response.Responses, &ethpb.DoppelGangerResponse_ValidatorResponse{
-						PublicKey:       keys[i].PublicKey().Marshal(),
-						DuplicateExists: false,
-					})
-				}
-				return vs, request, response
-			},
-		},
-		{
-			name:    "doppelganger exists current epoch",
-			wantErr: false,
-			svSetup: func(t *testing.T) (*Server, *ethpb.DoppelGangerRequest, *ethpb.DoppelGangerResponse) {
-				db := dbutil.SetupDB(t)
-				mockGen := stategen.NewMockService()
-
-				hs, ps, os, keys := createStateSetup(t, 4, mockGen)
-				// Previous Epoch State
-				for i := 0; i < 2; i++ {
-					bal, err := ps.BalanceAtIndex(types.ValidatorIndex(i))
-					assert.NoError(t, err)
-					// Add 100 gwei, to mock an inactivity leak
-					assert.NoError(t, ps.UpdateBalancesAtIndex(types.ValidatorIndex(i), bal+100))
-				}
-				bal, err := ps.BalanceAtIndex(types.ValidatorIndex(2))
-				assert.NoError(t, err)
-				// Sub 100 gwei, to mock an active validator.
-				assert.NoError(t, ps.UpdateBalancesAtIndex(types.ValidatorIndex(2), bal-100))
-
-				// Older Epoch State
-				for i := 0; i < 2; i++ {
-					bal, err := os.BalanceAtIndex(types.ValidatorIndex(i))
-					assert.NoError(t, err)
-					// Add 200 gwei, to mock an inactivity leak
-					assert.NoError(t, os.UpdateBalancesAtIndex(types.ValidatorIndex(i), bal+200))
-				}
-				bal, err = os.BalanceAtIndex(types.ValidatorIndex(2))
-				assert.NoError(t, err)
-				// Sub 100 gwei, to mock an active validator.
-				assert.NoError(t, os.UpdateBalancesAtIndex(types.ValidatorIndex(2), bal-100))
-
-				vs := &Server{
-					BeaconDB: db,
-					StateGen: mockGen,
-					HeadFetcher: &mockChain.ChainService{
-						State: hs,
+					ValidatorRequests: []*ethpb.DoppelGangerRequest_ValidatorRequest{
+						// Attested in the current epoch after its last recorded epoch.
+						{PublicKey: pubKey(0), Epoch: headEpoch - 1},
+						// Attested in the previous epoch, which is its last recorded epoch.
+						{PublicKey: pubKey(1), Epoch: headEpoch - 1},
+						// Attested in the previous epoch after its last recorded epoch.
+						{PublicKey: pubKey(2), Epoch: headEpoch - 2},
+						// Attested in the current epoch, which is its last recorded epoch.
+						{PublicKey: pubKey(3), Epoch: headEpoch},
+						// Did not attest.
+						{PublicKey: pubKey(4), Epoch: 0},
+						// Unknown validator.
+						{PublicKey: pubKey(100), Epoch: 0},
 					},
-					SyncChecker: &mockSync.Sync{IsSyncing: false},
-				}
-				request := &ethpb.DoppelGangerRequest{
-					ValidatorRequests: make([]*ethpb.DoppelGangerRequest_ValidatorRequest, 0),
 				}
-				response := &ethpb.DoppelGangerResponse{Responses: make([]*ethpb.DoppelGangerResponse_ValidatorResponse, 0)}
-				for i := 0; i < 2; i++ {
-					request.ValidatorRequests = append(request.ValidatorRequests, &ethpb.DoppelGangerRequest_ValidatorRequest{
-						PublicKey:  keys[i].PublicKey().Marshal(),
-						Epoch:      1,
-						SignedRoot: []byte{'A'},
-					})
-					response.Responses = append(response.Responses, &ethpb.DoppelGangerResponse_ValidatorResponse{
-						PublicKey:       keys[i].PublicKey().Marshal(),
-						DuplicateExists: false,
-					})
+				response := &ethpb.DoppelGangerResponse{
+					Responses: []*ethpb.DoppelGangerResponse_ValidatorResponse{
+						{PublicKey: pubKey(0), DuplicateExists: true},
+						{PublicKey: pubKey(1), DuplicateExists: false},
+						{PublicKey: pubKey(2), DuplicateExists: true},
+						{PublicKey: pubKey(3), DuplicateExists: false},
+						{PublicKey: pubKey(4), DuplicateExists: false},
+					},
 				}
-
-				// Add in for duplicate validator
-				request.ValidatorRequests = append(request.ValidatorRequests, &ethpb.DoppelGangerRequest_ValidatorRequest{
-					PublicKey:  keys[2].PublicKey().Marshal(),
-					Epoch:      1,
-					SignedRoot: []byte{'A'},
-				})
-				response.Responses = append(response.Responses, &ethpb.DoppelGangerResponse_ValidatorResponse{
-					PublicKey:       keys[2].PublicKey().Marshal(),
-					DuplicateExists: true,
-				})
 				return vs, request, response
 			},
 		},
 		{
-			name:    "doppelganger exists previous epoch",
-			wantErr: false,
+			name: "phase 0 pending attestations",
 			svSetup: func(t *testing.T) (*Server, *ethpb.DoppelGangerRequest, *ethpb.DoppelGangerResponse) {
-				db := dbutil.SetupDB(t)
-				mockGen := stategen.NewMockService()
-
-				hs, ps, os, keys := createStateSetup(t, 4, mockGen)
-				// Previous Epoch State
-				for i := 0; i < 2; i++ {
-					bal, err := ps.BalanceAtIndex(types.ValidatorIndex(i))
-					assert.NoError(t, err)
-					// Add 100 gwei, to mock an inactivity leak
-					assert.NoError(t, ps.UpdateBalancesAtIndex(types.ValidatorIndex(i), bal+100))
-				}
-				bal, err := ps.BalanceAtIndex(types.ValidatorIndex(2))
-				assert.NoError(t, err)
-				// Sub 100 gwei, to mock an active validator.
-				assert.NoError(t, ps.UpdateBalancesAtIndex(types.ValidatorIndex(2), bal-100))
-
-				// Older Epoch State
-				for i := 0; i < 2; i++ {
-					bal, err := os.BalanceAtIndex(types.ValidatorIndex(i))
-					assert.NoError(t, err)
-					// Add 200 gwei, to mock an inactivity leak
-					assert.NoError(t, os.UpdateBalancesAtIndex(types.ValidatorIndex(i), bal+200))
-				}
-				bal, err = os.BalanceAtIndex(types.ValidatorIndex(2))
-				assert.NoError(t, err)
-				// Sub 200 gwei, to mock an active validator.
-				assert.NoError(t, os.UpdateBalancesAtIndex(types.ValidatorIndex(2), bal-200))
-
-				vs := &Server{
-					BeaconDB: db,
-					StateGen: mockGen,
-					HeadFetcher: &mockChain.ChainService{
-						State: hs,
+				st, err := testutil.NewBeaconState()
+				require.NoError(t, err)
+				headSlot := params.BeaconConfig().SlotsPerEpoch.Mul(uint64(headEpoch))
+				require.NoError(t, st.SetSlot(headSlot))
+				require.NoError(t, st.SetValidators(validators))
+				committee, err := helpers.BeaconCommitteeFromState(st, headSlot, 0)
+				require.NoError(t, err)
+				require.Equal(t, true, len(committee) > 1)
+				bits := bitfield.NewBitlist(uint64(len(committee)))
+				bits.SetBitAt(0, true)
+				require.NoError(t, st.AppendCurrentEpochAttestations(&pbp2p.PendingAttestation{
+					AggregationBits: bits,
+					Data: &ethpb.AttestationData{
+						Slot:            headSlot,
+						BeaconBlockRoot: make([]byte, 32),
+						Source:          &ethpb.Checkpoint{Root: make([]byte, 32)},
+						Target:          &ethpb.Checkpoint{Epoch: headEpoch, Root: make([]byte, 32)},
 					},
+				}))
+				vs := &Server{
+					HeadFetcher: &mockChain.ChainService{State: st},
 					SyncChecker: &mockSync.Sync{IsSyncing: false},
 				}
 				request := &ethpb.DoppelGangerRequest{
-					ValidatorRequests: make([]*ethpb.DoppelGangerRequest_ValidatorRequest, 0),
+					ValidatorRequests: []*ethpb.DoppelGangerRequest_ValidatorRequest{
+						{PublicKey: pubKey(uint64(committee[0])), Epoch: headEpoch - 1},
+						{PublicKey: pubKey(uint64(committee[1])), Epoch: headEpoch - 1},
+					},
 				}
-				response := &ethpb.DoppelGangerResponse{Responses: make([]*ethpb.DoppelGangerResponse_ValidatorResponse, 0)}
-				for i := 0; i < 2; i++ {
-					request.ValidatorRequests = append(request.ValidatorRequests, &ethpb.DoppelGangerRequest_ValidatorRequest{
-						PublicKey:  keys[i].PublicKey().Marshal(),
-						Epoch:      1,
-						SignedRoot: []byte{'A'},
-					})
-					response.Responses = append(response.Responses, &ethpb.DoppelGangerResponse_ValidatorResponse{
-						PublicKey:       keys[i].PublicKey().Marshal(),
-						DuplicateExists: false,
-					})
+				response := &ethpb.DoppelGangerResponse{
+					Responses: []*ethpb.DoppelGangerResponse_ValidatorResponse{
+						{PublicKey: pubKey(uint64(committee[0])), DuplicateExists: true},
+						{PublicKey: pubKey(uint64(committee[1])), DuplicateExists: false},
+					},
 				}
-
-				// Add in for duplicate validator
-				request.ValidatorRequests = append(request.ValidatorRequests, &ethpb.DoppelGangerRequest_ValidatorRequest{
-					PublicKey:  keys[2].PublicKey().Marshal(),
-					Epoch:      1,
-					SignedRoot: []byte{'A'},
-				})
-				response.Responses = append(response.Responses, &ethpb.DoppelGangerResponse_ValidatorResponse{
-					PublicKey:       keys[2].PublicKey().Marshal(),
-					DuplicateExists: true,
-				})
 				return vs, request, response
 			},
 		},
 		{
-			name:    "multiple doppelganger exists",
-			wantErr: false,
+			name: "empty request",
 			svSetup: func(t *testing.T) (*Server, *ethpb.DoppelGangerRequest, *ethpb.DoppelGangerResponse) {
-				db := dbutil.SetupDB(t)
-				mockGen := stategen.NewMockService()
-
-				hs, ps, os, keys := createStateSetup(t, 4, mockGen)
-				// Previous Epoch State
-				for i := 10; i < 15; i++ {
-					bal, err := ps.BalanceAtIndex(types.ValidatorIndex(i))
-					assert.NoError(t, err)
-					// Add 100 gwei, to mock an inactivity leak
-					assert.NoError(t, ps.UpdateBalancesAtIndex(types.ValidatorIndex(i), bal-100))
-				}
-
-				// Older Epoch State
-				for i := 10; i < 15; i++ {
-					bal, err := os.BalanceAtIndex(types.ValidatorIndex(i))
-					assert.NoError(t, err)
-					// Add 200 gwei, to mock an inactivity leak
-					assert.NoError(t, os.UpdateBalancesAtIndex(types.ValidatorIndex(i), bal-200))
-				}
-
 				vs := &Server{
-					BeaconDB: db,
-					StateGen: mockGen,
-					HeadFetcher: &mockChain.ChainService{
-						State: hs,
-					},
+					HeadFetcher: &mockChain.ChainService{State: altairState},
 					SyncChecker: &mockSync.Sync{IsSyncing: false},
 				}
-				request := &ethpb.DoppelGangerRequest{
-					ValidatorRequests: make([]*ethpb.DoppelGangerRequest_ValidatorRequest, 0),
-				}
-				response := &ethpb.DoppelGangerResponse{Responses: make([]*ethpb.DoppelGangerResponse_ValidatorResponse, 0)}
-				for i := 0; i < 10; i++ {
-					request.ValidatorRequests = append(request.ValidatorRequests, &ethpb.DoppelGangerRequest_ValidatorRequest{
-						PublicKey:  keys[i].PublicKey().Marshal(),
-						Epoch:      1,
-						SignedRoot: []byte{'A'},
-					})
-					response.Responses = append(response.Responses, &ethpb.DoppelGangerResponse_ValidatorResponse{
-						PublicKey:       keys[i].PublicKey().Marshal(),
-						DuplicateExists: false,
-					})
+				return vs, &ethpb.DoppelGangerRequest{}, &ethpb.DoppelGangerResponse{
+					Responses: []*ethpb.DoppelGangerResponse_ValidatorResponse{},
 				}
-				for i := 10; i < 15; i++ {
-					// Add in for duplicate validator
-					request.ValidatorRequests = append(request.ValidatorRequests, &ethpb.DoppelGangerRequest_ValidatorRequest{
-						PublicKey:  keys[i].PublicKey().Marshal(),
-						Epoch:      1,
-						SignedRoot: []byte{'A'},
-					})
-					response.Responses = append(response.Responses, &ethpb.DoppelGangerResponse_ValidatorResponse{
-						PublicKey:       keys[i].PublicKey().Marshal(),
-						DuplicateExists: true,
-					})
-				}
-
-				return vs, request, response
 			},
 		},
 		{
-			name:    "attesters are too recent",
-			wantErr: false,
+			name:    "syncing",
+			wantErr: "Syncing to latest head",
 			svSetup: func(t *testing.T) (*Server, *ethpb.DoppelGangerRequest, *ethpb.DoppelGangerResponse) {
-				db := dbutil.SetupDB(t)
-				mockGen := stategen.NewMockService()
-
-				hs, ps, os, keys := createStateSetup(t, 4, mockGen)
-				// Previous Epoch State
-				for i := 10; i < 15; i++ {
-					bal, err := ps.BalanceAtIndex(types.ValidatorIndex(i))
-					assert.NoError(t, err)
-					// Add 100 gwei, to mock an active validator
-					assert.NoError(t, ps.UpdateBalancesAtIndex(types.ValidatorIndex(i), bal-100))
-				}
-
-				// Older Epoch State
-				for i := 10; i < 15; i++ {
-					bal, err := os.BalanceAtIndex(types.ValidatorIndex(i))
-					assert.NoError(t, err)
-					// Add 200 gwei, to mock an active validator
-					assert.NoError(t, os.UpdateBalancesAtIndex(types.ValidatorIndex(i), bal-200))
-				}
-
 				vs := &Server{
-					BeaconDB: db,
-					StateGen: mockGen,
-					HeadFetcher: &mockChain.ChainService{
-						State: hs,
-					},
-					SyncChecker: &mockSync.Sync{IsSyncing: false},
+					HeadFetcher: &mockChain.ChainService{State: altairState},
+					SyncChecker: &mockSync.Sync{IsSyncing: true},
 				}
-				request := &ethpb.DoppelGangerRequest{
-					ValidatorRequests: make([]*ethpb.DoppelGangerRequest_ValidatorRequest, 0),
-				}
-				response := &ethpb.DoppelGangerResponse{Responses: make([]*ethpb.DoppelGangerResponse_ValidatorResponse, 0)}
-				for i := 0; i < 15; i++ {
-					request.ValidatorRequests = append(request.ValidatorRequests, &ethpb.DoppelGangerRequest_ValidatorRequest{
-						PublicKey:  keys[i].PublicKey().Marshal(),
-						Epoch:      2,
-						SignedRoot: []byte{'A'},
-					})
-					response.Responses = append(response.Responses, &ethpb.DoppelGangerResponse_ValidatorResponse{
-						PublicKey:       keys[i].PublicKey().Marshal(),
-						DuplicateExists: false,
-					})
-				}
-
-				return vs, request, response
+				return vs, &ethpb.DoppelGangerRequest{}, nil
 			},
 		},
 	}
@@ -1281,39 +1104,14 @@ func TestServer_CheckDoppelGanger(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			vs, req, resp := tt.svSetup(t)
 			got, err := vs.CheckDoppelGanger(context.Background(), req)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("CheckDoppelGanger() error = %v, wantErr %v", err, tt.wantErr)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, tt.wantErr, err)
 				return
 			}
+			require.NoError(t, err)
 			if !reflect.DeepEqual(got, resp) {
 				t.Errorf("CheckDoppelGanger() got = %v, want %v", got.String(), resp.String())
 			}
 		})
 	}
 }
-
-func createStateSetup(t *testing.T, head types.Epoch, mockgen *stategen.MockStateManager) (iface.BeaconState,
-	iface.BeaconState, iface.BeaconState, []bls.SecretKey) {
-	gs, keys := testutil.DeterministicGenesisState(t, 64)
-	hs := gs.Copy()
-	// Head State
-	headEpoch := head
-	headSlot := types.Slot(headEpoch) * params.BeaconConfig().SlotsPerEpoch
-	assert.NoError(t, hs.SetSlot(headSlot))
-	mockgen.StatesBySlot[headSlot] = hs
-
-	// Previous Epoch State
-	prevEpoch := headEpoch - 1
-	ps := gs.Copy()
-	prevSlot := types.Slot(prevEpoch) * params.BeaconConfig().SlotsPerEpoch
-	assert.NoError(t, ps.SetSlot(prevSlot))
-	mockgen.StatesBySlot[prevSlot] = ps
-
-	// Older Epoch State
-	olderEpoch := prevEpoch - 1
-	os := gs.Copy()
-	olderSlot := types.Slot(olderEpoch) * params.BeaconConfig().SlotsPerEpoch
-	assert.NoError(t, os.SetSlot(olderSlot))
-	mockgen.StatesBySlot[olderSlot] = os
-	return hs, ps, os, keys
-}
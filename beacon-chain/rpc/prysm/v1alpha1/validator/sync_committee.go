@@ -0,0 +1,282 @@
+package validator
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	iface "github.com/prysmaticlabs/prysm/beacon-chain/state/interface"
+	pbp2p "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
+	prysmv2 "github.com/prysmaticlabs/prysm/proto/prysm/v2"
+	"github.com/prysmaticlabs/prysm/shared/bls"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/interfaces/version"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"go.opencensus.io/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// AltairServer exposes the sync committee endpoints of the validator server through the
+// Altair validator service. Altair block production is not supported yet.
+type AltairServer struct {
+	*Server
+}
+
+var _ prysmv2.BeaconNodeValidatorAltairServer = (*AltairServer)(nil)
+
+// GetBlock is not supported until Altair block production is implemented.
+func (as *AltairServer) GetBlock(_ context.Context, _ *ethpb.BlockRequest) (*prysmv2.BeaconBlockAltair, error) {
+	return nil, status.Error(codes.Unimplemented, "Altair blocks are not supported")
+}
+
+// ProposeBlock is not supported until Altair block production is implemented.
+func (as *AltairServer) ProposeBlock(_ context.Context, _ *prysmv2.SignedBeaconBlockAltair) (*ethpb.ProposeResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "Altair blocks are not supported")
+}
+
+// StreamBlocks is not supported until Altair block production is implemented.
+func (as *AltairServer) StreamBlocks(_ *ethpb.StreamBlocksRequest, _ prysmv2.BeaconNodeValidatorAltair_StreamBlocksServer) error {
+	return status.Error(codes.Unimplemented, "Altair blocks are not supported")
+}
+
+// GetSyncMessageBlockRoot retrieves the head block root for a sync committee member to sign over.
+func (vs *Server) GetSyncMessageBlockRoot(ctx context.Context, _ *emptypb.Empty) (*prysmv2.SyncMessageBlockRootResponse, error) {
+	ctx, span := trace.StartSpan(ctx, "SyncCommitteeServer.GetSyncMessageBlockRoot")
+	defer span.End()
+
+	if vs.SyncChecker.Syncing() {
+		return nil, status.Errorf(codes.Unavailable, "Syncing to latest head, not ready to respond")
+	}
+	r, err := vs.HeadFetcher.HeadRoot(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not retrieve head root: %v", err)
+	}
+	return &prysmv2.SyncMessageBlockRootResponse{Root: r}, nil
+}
+
+// SubmitSyncMessage saves a sync committee message in the sync committee pool, from which it is
+// later aggregated into sync committee contributions, and broadcasts it on the subnet of every
+// subcommittee of the validator. The message must be signed by a member of the sync committee
+// responsible for its slot.
+func (vs *Server) SubmitSyncMessage(ctx context.Context, msg *prysmv2.SyncCommitteeMessage) (*emptypb.Empty, error) {
+	ctx, span := trace.StartSpan(ctx, "SyncCommitteeServer.SubmitSyncMessage")
+	defer span.End()
+
+	if msg == nil || len(msg.BlockRoot) != 32 || len(msg.Signature) != params.BeaconConfig().BLSSignatureLength {
+		return nil, status.Error(codes.InvalidArgument, "Malformed sync committee message")
+	}
+	st, err := vs.HeadFetcher.HeadState(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not determine head state: %v", err)
+	}
+	if uint64(msg.ValidatorIndex) >= uint64(st.NumValidators()) {
+		return nil, status.Errorf(codes.InvalidArgument, "Unknown validator index %d", msg.ValidatorIndex)
+	}
+	committee, err := syncCommitteeAtSlot(st, msg.Slot)
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "Could not retrieve sync committee: %v", err)
+	}
+	pubKey := st.PubkeyAtIndex(msg.ValidatorIndex)
+	subnets := syncSubcommitteeIndices(committee, pubKey[:])
+	if len(subnets) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "Validator %d is not a member of the sync committee", msg.ValidatorIndex)
+	}
+	d, err := helpers.Domain(st.Fork(), helpers.SlotToEpoch(msg.Slot), params.BeaconConfig().DomainSyncCommittee, st.GenesisValidatorRoot())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get sync committee domain: %v", err)
+	}
+	blockRoot := types.SSZBytes(msg.BlockRoot)
+	if err := helpers.VerifySigningRoot(&blockRoot, pubKey[:], msg.Signature, d); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid sync committee message signature: %v", err)
+	}
+	if err := vs.SyncCommitteePool.SaveSyncCommitteeMessage(msg); err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not save sync committee message: %v", err)
+	}
+	for _, subnet := range subnets {
+		if err := vs.P2P.BroadcastSyncCommitteeMessage(ctx, subnet, msg); err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not broadcast sync committee message: %v", err)
+		}
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// GetSyncSubcommitteeIndex returns the sync subcommittee indices of the validator with the given
+// public key, at the sync committee period of the requested slot. A validator which is not a
+// member of the sync committee receives an empty list.
+func (vs *Server) GetSyncSubcommitteeIndex(ctx context.Context, req *prysmv2.SyncSubcommitteeIndexRequest) (*prysmv2.SyncSubcommitteeIndexResponse, error) {
+	ctx, span := trace.StartSpan(ctx, "SyncCommitteeServer.GetSyncSubcommitteeIndex")
+	defer span.End()
+
+	st, err := vs.HeadFetcher.HeadState(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not determine head state: %v", err)
+	}
+	committee, err := syncCommitteeAtSlot(st, req.Slot)
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "Could not retrieve sync committee: %v", err)
+	}
+	return &prysmv2.SyncSubcommitteeIndexResponse{
+		Indices: syncSubcommitteeIndices(committee, req.PublicKey),
+	}, nil
+}
+
+// GetSyncCommitteeContribution aggregates the sync committee messages in the pool for the requested
+// slot and subcommittee which vote for the current head, into a contribution for the aggregator to sign.
+func (vs *Server) GetSyncCommitteeContribution(
+	ctx context.Context, req *prysmv2.SyncCommitteeContributionRequest,
+) (*prysmv2.SyncCommitteeContribution, error) {
+	ctx, span := trace.StartSpan(ctx, "SyncCommitteeServer.GetSyncCommitteeContribution")
+	defer span.End()
+	span.AddAttributes(trace.Int64Attribute("slot", int64(req.Slot)))
+
+	if vs.SyncChecker.Syncing() {
+		return nil, status.Errorf(codes.Unavailable, "Syncing to latest head, not ready to respond")
+	}
+	if req.SubnetId >= params.BeaconConfig().SyncCommitteeSubnetCount {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid subnet %d", req.SubnetId)
+	}
+
+	st, err := vs.HeadFetcher.HeadState(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not determine head state: %v", err)
+	}
+	headRoot, err := vs.HeadFetcher.HeadRoot(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not retrieve head root: %v", err)
+	}
+	committee, err := syncCommitteeAtSlot(st, req.Slot)
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "Could not retrieve sync committee: %v", err)
+	}
+	msgs, err := vs.SyncCommitteePool.SyncCommitteeMessages(req.Slot)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not retrieve sync committee messages: %v", err)
+	}
+
+	subcommitteeSize := params.BeaconConfig().SyncCommitteeSize / params.BeaconConfig().SyncCommitteeSubnetCount
+	start := req.SubnetId * subcommitteeSize
+	if uint64(len(committee.Pubkeys)) < start+subcommitteeSize {
+		return nil, status.Errorf(codes.Internal, "Sync committee has %d members, wanted %d", len(committee.Pubkeys), params.BeaconConfig().SyncCommitteeSize)
+	}
+	subcommittee := committee.Pubkeys[start : start+subcommitteeSize]
+
+	bits := prysmv2.NewSyncCommitteeAggregationBits()
+	sigs := make([]bls.Signature, 0, len(msgs))
+	for _, msg := range msgs {
+		if !bytes.Equal(msg.BlockRoot, headRoot) {
+			continue
+		}
+		// A malformed message in the pool must not prevent the aggregation of the others.
+		if uint64(msg.ValidatorIndex) >= uint64(st.NumValidators()) {
+			continue
+		}
+		sig, err := bls.SignatureFromBytes(msg.Signature)
+		if err != nil {
+			log.WithError(err).WithField("validatorIndex", msg.ValidatorIndex).Debug("Skipping sync committee message with invalid signature")
+			continue
+		}
+		pubKey := st.PubkeyAtIndex(msg.ValidatorIndex)
+		for i, k := range subcommittee {
+			if !bytes.Equal(k, pubKey[:]) {
+				continue
+			}
+			// A validator appearing more than once in the subcommittee contributes its
+			// signature once for every position it holds.
+			bits.SetBitAt(uint64(i), true)
+			sigs = append(sigs, sig)
+		}
+	}
+	if len(sigs) == 0 {
+		return nil, status.Errorf(codes.NotFound, "Could not find sync committee messages for slot and subnet in pool")
+	}
+
+	return &prysmv2.SyncCommitteeContribution{
+		Slot:              req.Slot,
+		BlockRoot:         headRoot,
+		SubcommitteeIndex: req.SubnetId,
+		AggregationBits:   bits.Bytes(),
+		Signature:         bls.AggregateSignatures(sigs).Marshal(),
+	}, nil
+}
+
+// SubmitSignedContributionAndProof saves the contribution of a signed contribution and proof
+// in the sync committee pool, where it is available to block proposers, and broadcasts the
+// signed contribution and proof to the network.
+func (vs *Server) SubmitSignedContributionAndProof(
+	ctx context.Context, s *prysmv2.SignedContributionAndProof,
+) (*emptypb.Empty, error) {
+	ctx, span := trace.StartSpan(ctx, "SyncCommitteeServer.SubmitSignedContributionAndProof")
+	defer span.End()
+
+	if s == nil || s.Message == nil || s.Message.Contribution == nil {
+		return nil, status.Error(codes.InvalidArgument, "Malformed signed contribution and proof")
+	}
+	if err := vs.SyncCommitteePool.SaveSyncCommitteeContribution(s.Message.Contribution); err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not save sync committee contribution: %v", err)
+	}
+	if err := vs.P2P.Broadcast(ctx, s); err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not broadcast signed contribution and proof: %v", err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// syncCommitteeAtSlot returns the sync committee of the state which is responsible for the given
+// slot. Only the current and the next sync committee periods of the state can be served.
+func syncCommitteeAtSlot(st iface.BeaconState, slot types.Slot) (*pbp2p.SyncCommittee, error) {
+	if st.Version() != version.Altair {
+		return nil, errors.New("head state is not an Altair state")
+	}
+	epochsPerPeriod := params.BeaconConfig().EpochsPerSyncCommitteePeriod
+	statePeriod := helpers.CurrentEpoch(st) / epochsPerPeriod
+	switch helpers.SlotToEpoch(slot) / epochsPerPeriod {
+	case statePeriod:
+		return st.CurrentSyncCommittee()
+	case statePeriod + 1:
+		return st.NextSyncCommittee()
+	default:
+		return nil, errors.Errorf("slot %d is outside of the current and next sync committee periods", slot)
+	}
+}
+
+// syncCommitteeMembersAtEpoch returns the public keys of the sync committee members responsible for
+// the given epoch. Before Altair there is no sync committee, in which case the result is empty.
+func syncCommitteeMembersAtEpoch(st iface.BeaconState, epoch types.Epoch) (map[[48]byte]bool, error) {
+	members := make(map[[48]byte]bool)
+	if st.Version() != version.Altair {
+		return members, nil
+	}
+	startSlot, err := helpers.StartSlot(epoch)
+	if err != nil {
+		return nil, err
+	}
+	committee, err := syncCommitteeAtSlot(st, startSlot)
+	if err != nil {
+		return nil, err
+	}
+	for _, k := range committee.Pubkeys {
+		members[bytesutil.ToBytes48(k)] = true
+	}
+	return members, nil
+}
+
+// syncSubcommitteeIndices returns the indices of the subcommittees in which the given public
+// key appears. A public key can appear more than once in a sync committee.
+func syncSubcommitteeIndices(committee *pbp2p.SyncCommittee, pubKey []byte) []uint64 {
+	subcommitteeSize := params.BeaconConfig().SyncCommitteeSize / params.BeaconConfig().SyncCommitteeSubnetCount
+	indices := make([]uint64, 0)
+	for i, k := range committee.Pubkeys {
+		if !bytes.Equal(k, pubKey) {
+			continue
+		}
+		index := uint64(i) / subcommitteeSize
+		if len(indices) == 0 || indices[len(indices)-1] != index {
+			indices = append(indices, index)
+		}
+	}
+	return indices
+}
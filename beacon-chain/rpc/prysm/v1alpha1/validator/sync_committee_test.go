@@ -0,0 +1,295 @@
+package validator
+
+import (
+	"context"
+	"testing"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/go-bitfield"
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/operations/synccommittee"
+	mockp2p "github.com/prysmaticlabs/prysm/beacon-chain/p2p/testing"
+	iface "github.com/prysmaticlabs/prysm/beacon-chain/state/interface"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/v1"
+	stateAltair "github.com/prysmaticlabs/prysm/beacon-chain/state/v2"
+	mockSync "github.com/prysmaticlabs/prysm/beacon-chain/sync/initial-sync/testing"
+	pbp2p "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
+	prysmv2 "github.com/prysmaticlabs/prysm/proto/prysm/v2"
+	"github.com/prysmaticlabs/prysm/shared/bls"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// syncCommitteeTestState returns an Altair state with the given number of validators, whose
+// current and next sync committees are made of the validator public keys in round robin order.
+func syncCommitteeTestState(t *testing.T, validatorCount uint64) iface.BeaconState {
+	validators := make([]*ethpb.Validator, validatorCount)
+	for i := range validators {
+		validators[i] = &ethpb.Validator{PublicKey: pubKey(uint64(i))}
+	}
+	pubKeys := make([][]byte, params.BeaconConfig().SyncCommitteeSize)
+	for i := range pubKeys {
+		pubKeys[i] = pubKey(uint64(i) % validatorCount)
+	}
+	committee := &pbp2p.SyncCommittee{
+		Pubkeys:         pubKeys,
+		AggregatePubkey: make([]byte, params.BeaconConfig().BLSPubkeyLength),
+	}
+	st, err := stateAltair.InitializeFromProto(&pbp2p.BeaconStateAltair{
+		Validators:           validators,
+		RandaoMixes:          make([][]byte, params.BeaconConfig().EpochsPerHistoricalVector),
+		CurrentSyncCommittee: committee,
+		NextSyncCommittee:    committee,
+	})
+	require.NoError(t, err)
+	return st
+}
+
+func TestGetSyncMessageBlockRoot(t *testing.T) {
+	root := bytesutil.PadTo([]byte{'a'}, 32)
+	server := &Server{
+		HeadFetcher: &mock.ChainService{Root: root},
+		SyncChecker: &mockSync.Sync{IsSyncing: false},
+	}
+	res, err := server.GetSyncMessageBlockRoot(context.Background(), &emptypb.Empty{})
+	require.NoError(t, err)
+	assert.DeepEqual(t, root, res.Root)
+
+	server.SyncChecker = &mockSync.Sync{IsSyncing: true}
+	_, err = server.GetSyncMessageBlockRoot(context.Background(), &emptypb.Empty{})
+	assert.ErrorContains(t, "Syncing to latest head", err)
+}
+
+func TestSubmitSyncMessage(t *testing.T) {
+	ctx := context.Background()
+	keys := make([]bls.SecretKey, 2)
+	validators := make([]*ethpb.Validator, len(keys)+1)
+	for i := range keys {
+		priv, err := bls.RandKey()
+		require.NoError(t, err)
+		keys[i] = priv
+		validators[i] = &ethpb.Validator{PublicKey: priv.PublicKey().Marshal()}
+	}
+	// The last validator is not a member of the sync committee.
+	validators[len(keys)] = &ethpb.Validator{PublicKey: pubKey(100)}
+	pubKeys := make([][]byte, params.BeaconConfig().SyncCommitteeSize)
+	for i := range pubKeys {
+		pubKeys[i] = validators[i%len(keys)].PublicKey
+	}
+	committee := &pbp2p.SyncCommittee{
+		Pubkeys:         pubKeys,
+		AggregatePubkey: make([]byte, params.BeaconConfig().BLSPubkeyLength),
+	}
+	st, err := stateAltair.InitializeFromProto(&pbp2p.BeaconStateAltair{
+		Fork: &pbp2p.Fork{
+			PreviousVersion: params.BeaconConfig().GenesisForkVersion,
+			CurrentVersion:  params.BeaconConfig().GenesisForkVersion,
+		},
+		GenesisValidatorsRoot: make([]byte, 32),
+		Validators:            validators,
+		RandaoMixes:           make([][]byte, params.BeaconConfig().EpochsPerHistoricalVector),
+		CurrentSyncCommittee:  committee,
+		NextSyncCommittee:     committee,
+	})
+	require.NoError(t, err)
+	pool := synccommittee.NewStore()
+	broadcaster := &mockp2p.MockBroadcaster{}
+	server := &Server{
+		HeadFetcher:       &mock.ChainService{State: st},
+		SyncCommitteePool: pool,
+		P2P:               broadcaster,
+	}
+
+	_, err = server.SubmitSyncMessage(ctx, &prysmv2.SyncCommitteeMessage{Slot: 1})
+	assert.ErrorContains(t, "Malformed sync committee message", err)
+
+	signedMessage := func(index types.ValidatorIndex, priv bls.SecretKey) *prysmv2.SyncCommitteeMessage {
+		blockRoot := types.SSZBytes(bytesutil.PadTo([]byte{'a'}, 32))
+		sig, err := helpers.ComputeDomainAndSign(st, 0, &blockRoot, params.BeaconConfig().DomainSyncCommittee, priv)
+		require.NoError(t, err)
+		return &prysmv2.SyncCommitteeMessage{
+			Slot:           1,
+			ValidatorIndex: index,
+			BlockRoot:      blockRoot,
+			Signature:      sig,
+		}
+	}
+
+	_, err = server.SubmitSyncMessage(ctx, signedMessage(types.ValidatorIndex(len(validators)), keys[0]))
+	assert.ErrorContains(t, "Unknown validator index", err)
+	_, err = server.SubmitSyncMessage(ctx, signedMessage(types.ValidatorIndex(len(keys)), keys[0]))
+	assert.ErrorContains(t, "is not a member of the sync committee", err)
+	_, err = server.SubmitSyncMessage(ctx, signedMessage(1, keys[0]))
+	assert.ErrorContains(t, "Invalid sync committee message signature", err)
+	saved, err := pool.SyncCommitteeMessages(1)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(saved))
+	assert.Equal(t, false, broadcaster.BroadcastCalled)
+
+	msg := signedMessage(1, keys[1])
+	_, err = server.SubmitSyncMessage(ctx, msg)
+	require.NoError(t, err)
+	saved, err = pool.SyncCommitteeMessages(1)
+	require.NoError(t, err)
+	assert.DeepSSZEqual(t, []*prysmv2.SyncCommitteeMessage{msg}, saved)
+	// The validator holds positions in every subcommittee, so the message is broadcast on every subnet.
+	require.Equal(t, int(params.BeaconConfig().SyncCommitteeSubnetCount), len(broadcaster.BroadcastMessages))
+	for _, broadcast := range broadcaster.BroadcastMessages {
+		assert.DeepSSZEqual(t, msg, broadcast)
+	}
+}
+
+func TestGetSyncSubcommitteeIndex(t *testing.T) {
+	subcommitteeSize := params.BeaconConfig().SyncCommitteeSize / params.BeaconConfig().SyncCommitteeSubnetCount
+	st := syncCommitteeTestState(t, subcommitteeSize+1)
+	server := &Server{HeadFetcher: &mock.ChainService{State: st}}
+
+	// Validator 0 appears once in every subcommittee.
+	res, err := server.GetSyncSubcommitteeIndex(context.Background(), &prysmv2.SyncSubcommitteeIndexRequest{
+		PublicKey: pubKey(0),
+	})
+	require.NoError(t, err)
+	assert.DeepEqual(t, []uint64{0, 1, 2, 3}, res.Indices)
+
+	res, err = server.GetSyncSubcommitteeIndex(context.Background(), &prysmv2.SyncSubcommitteeIndexRequest{
+		PublicKey: pubKey(subcommitteeSize + 10),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(res.Indices))
+
+	farSlot := params.BeaconConfig().SlotsPerEpoch.Mul(uint64(2 * params.BeaconConfig().EpochsPerSyncCommitteePeriod))
+	_, err = server.GetSyncSubcommitteeIndex(context.Background(), &prysmv2.SyncSubcommitteeIndexRequest{
+		PublicKey: pubKey(0),
+		Slot:      farSlot,
+	})
+	assert.ErrorContains(t, "outside of the current and next sync committee periods", err)
+}
+
+func TestGetSyncSubcommitteeIndex_Phase0State(t *testing.T) {
+	st, err := v1.InitializeFromProto(&pbp2p.BeaconState{})
+	require.NoError(t, err)
+	server := &Server{HeadFetcher: &mock.ChainService{State: st}}
+	_, err = server.GetSyncSubcommitteeIndex(context.Background(), &prysmv2.SyncSubcommitteeIndexRequest{
+		PublicKey: pubKey(0),
+	})
+	assert.ErrorContains(t, "head state is not an Altair state", err)
+}
+
+func TestGetSyncCommitteeContribution(t *testing.T) {
+	subcommitteeSize := params.BeaconConfig().SyncCommitteeSize / params.BeaconConfig().SyncCommitteeSubnetCount
+	st := syncCommitteeTestState(t, params.BeaconConfig().SyncCommitteeSize)
+	headRoot := bytesutil.PadTo([]byte{'a'}, 32)
+	pool := synccommittee.NewStore()
+	server := &Server{
+		HeadFetcher:       &mock.ChainService{State: st, Root: headRoot},
+		SyncChecker:       &mockSync.Sync{IsSyncing: false},
+		SyncCommitteePool: pool,
+	}
+	req := &prysmv2.SyncCommitteeContributionRequest{Slot: 1, SubnetId: 1}
+
+	_, err := server.GetSyncCommitteeContribution(context.Background(), req)
+	assert.ErrorContains(t, "Could not find sync committee messages", err)
+
+	// Validators of subcommittee 1 voting for the head, one of subcommittee 0 and one
+	// voting for a different block.
+	msgs := []struct {
+		index types.ValidatorIndex
+		root  []byte
+	}{
+		{index: types.ValidatorIndex(subcommitteeSize), root: headRoot},
+		{index: types.ValidatorIndex(subcommitteeSize + 3), root: headRoot},
+		{index: 0, root: headRoot},
+		{index: types.ValidatorIndex(subcommitteeSize + 4), root: bytesutil.PadTo([]byte{'b'}, 32)},
+	}
+	sigs := make([]bls.Signature, 0)
+	for i, m := range msgs {
+		priv, err := bls.RandKey()
+		require.NoError(t, err)
+		sig := priv.Sign(m.root)
+		require.NoError(t, pool.SaveSyncCommitteeMessage(&prysmv2.SyncCommitteeMessage{
+			Slot:           1,
+			ValidatorIndex: m.index,
+			BlockRoot:      m.root,
+			Signature:      sig.Marshal(),
+		}))
+		if i < 2 {
+			sigs = append(sigs, sig)
+		}
+	}
+
+	// Pooled messages with an undecodable signature or an unknown validator are skipped.
+	require.NoError(t, pool.SaveSyncCommitteeMessage(&prysmv2.SyncCommitteeMessage{
+		Slot:           1,
+		ValidatorIndex: types.ValidatorIndex(subcommitteeSize + 5),
+		BlockRoot:      headRoot,
+		Signature:      make([]byte, params.BeaconConfig().BLSSignatureLength),
+	}))
+	require.NoError(t, pool.SaveSyncCommitteeMessage(&prysmv2.SyncCommitteeMessage{
+		Slot:           1,
+		ValidatorIndex: types.ValidatorIndex(params.BeaconConfig().SyncCommitteeSize),
+		BlockRoot:      headRoot,
+		Signature:      sigs[0].Marshal(),
+	}))
+
+	contribution, err := server.GetSyncCommitteeContribution(context.Background(), req)
+	require.NoError(t, err)
+	wantBits := prysmv2.NewSyncCommitteeAggregationBits()
+	wantBits.SetBitAt(0, true)
+	wantBits.SetBitAt(3, true)
+	assert.DeepEqual(t, bitfield.Bitvector128(wantBits.Bytes()), contribution.AggregationBits)
+	assert.Equal(t, uint64(1), contribution.SubcommitteeIndex)
+	assert.DeepEqual(t, headRoot, contribution.BlockRoot)
+	assert.DeepEqual(t, bls.AggregateSignatures(sigs).Marshal(), contribution.Signature)
+
+	_, err = server.GetSyncCommitteeContribution(context.Background(), &prysmv2.SyncCommitteeContributionRequest{
+		SubnetId: params.BeaconConfig().SyncCommitteeSubnetCount,
+	})
+	assert.ErrorContains(t, "Invalid subnet", err)
+}
+
+func TestSubmitSignedContributionAndProof(t *testing.T) {
+	pool := synccommittee.NewStore()
+	broadcaster := &mockp2p.MockBroadcaster{}
+	server := &Server{SyncCommitteePool: pool, P2P: broadcaster}
+
+	_, err := server.SubmitSignedContributionAndProof(context.Background(), &prysmv2.SignedContributionAndProof{})
+	assert.ErrorContains(t, "Malformed signed contribution and proof", err)
+
+	contribution := &prysmv2.SyncCommitteeContribution{
+		Slot:              1,
+		SubcommitteeIndex: 2,
+		BlockRoot:         make([]byte, 32),
+		AggregationBits:   bitfield.NewBitvector128(),
+		Signature:         make([]byte, params.BeaconConfig().BLSSignatureLength),
+	}
+	signed := &prysmv2.SignedContributionAndProof{
+		Message: &prysmv2.ContributionAndProof{Contribution: contribution},
+	}
+	_, err = server.SubmitSignedContributionAndProof(context.Background(), signed)
+	require.NoError(t, err)
+	saved, err := pool.SyncCommitteeContributions(1)
+	require.NoError(t, err)
+	assert.DeepSSZEqual(t, []*prysmv2.SyncCommitteeContribution{contribution}, saved)
+	require.Equal(t, 1, len(broadcaster.BroadcastMessages))
+	assert.DeepSSZEqual(t, signed, broadcaster.BroadcastMessages[0])
+}
+
+func TestSyncCommitteeMembersAtEpoch(t *testing.T) {
+	st := syncCommitteeTestState(t, 8)
+	members, err := syncCommitteeMembersAtEpoch(st, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 8, len(members))
+	assert.Equal(t, true, members[bytesutil.ToBytes48(pubKey(7))])
+	assert.Equal(t, false, members[bytesutil.ToBytes48(pubKey(8))])
+
+	phase0State, err := v1.InitializeFromProto(&pbp2p.BeaconState{})
+	require.NoError(t, err)
+	members, err = syncCommitteeMembersAtEpoch(phase0State, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(members))
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/db"
 	"github.com/prysmaticlabs/prysm/beacon-chain/operations/attestations"
 	"github.com/prysmaticlabs/prysm/beacon-chain/operations/slashings"
+	"github.com/prysmaticlabs/prysm/beacon-chain/operations/synccommittee"
 	"github.com/prysmaticlabs/prysm/beacon-chain/operations/voluntaryexits"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
 	"github.com/prysmaticlabs/prysm/beacon-chain/powchain"
@@ -32,6 +33,7 @@ import (
 	beaconv1alpha1 "github.com/prysmaticlabs/prysm/beacon-chain/rpc/prysm/v1alpha1/beacon"
 	debugv1alpha1 "github.com/prysmaticlabs/prysm/beacon-chain/rpc/prysm/v1alpha1/debug"
 	nodev1alpha1 "github.com/prysmaticlabs/prysm/beacon-chain/rpc/prysm/v1alpha1/node"
+	slasherv1alpha1 "github.com/prysmaticlabs/prysm/beacon-chain/rpc/prysm/v1alpha1/slasher"
 	validatorv1alpha1 "github.com/prysmaticlabs/prysm/beacon-chain/rpc/prysm/v1alpha1/validator"
 	"github.com/prysmaticlabs/prysm/beacon-chain/rpc/statefetcher"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state/stategen"
@@ -40,6 +42,7 @@ import (
 	pbrpc "github.com/prysmaticlabs/prysm/proto/beacon/rpc/v1"
 	ethpbv1 "github.com/prysmaticlabs/prysm/proto/eth/v1"
 	ethpbv1alpha1 "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
+	prysmv2 "github.com/prysmaticlabs/prysm/proto/prysm/v2"
 	"github.com/prysmaticlabs/prysm/shared/featureconfig"
 	"github.com/prysmaticlabs/prysm/shared/logutil"
 	"github.com/prysmaticlabs/prysm/shared/params"
@@ -81,6 +84,7 @@ type Config struct {
 	BeaconMonitoringHost    string
 	BeaconMonitoringPort    int
 	BeaconDB                db.HeadAccessDatabase
+	SlasherDB               db.SlasherDatabase
 	ChainInfoFetcher        blockchain.ChainInfoFetcher
 	HeadFetcher             blockchain.HeadFetcher
 	CanonicalFetcher        blockchain.CanonicalFetcher
@@ -93,15 +97,19 @@ type Config struct {
 	GenesisTimeFetcher      blockchain.TimeFetcher
 	GenesisFetcher          blockchain.GenesisFetcher
 	EnableDebugRPCEndpoints bool
+	EnablePeerAdmin         bool
 	MockEth1Votes           bool
 	EnableVanguardNode      bool // vanguard: vanguard chain enable flag
 	AttestationsPool        attestations.Pool
 	ExitPool                voluntaryexits.PoolManager
 	SlashingsPool           slashings.PoolManager
+	SyncCommitteePool       synccommittee.Pool
 	SyncService             chainSync.Checker
 	Broadcaster             p2p.Broadcaster
 	PeersFetcher            p2p.PeersProvider
 	PeerManager             p2p.PeerManager
+	PeerAdmin               p2p.PeerAdmin
+	GossipScoreInspector    p2p.GossipScoreInspector
 	MetadataProvider        p2p.MetadataProvider
 	DepositFetcher          depositcache.DepositFetcher
 	PendingDepositFetcher   depositcache.PendingDepositsFetcher
@@ -113,6 +121,8 @@ type Config struct {
 
 	// Vanguard un-confirmed cached block fetcher
 	PendingQueueFetcher blockchain.PendingQueueFetcher
+	// Vanguard orchestrator confirmations, shown in the fork choice tree of the debug API
+	OrcConfirmationFetcher blockchain.OrcConfirmationFetcher
 }
 
 // NewService instantiates a new RPC service instance that will
@@ -206,6 +216,7 @@ func (s *Service) Start() {
 		Eth1BlockFetcher:       s.cfg.POWChainService,
 		PendingDepositsFetcher: s.cfg.PendingDepositFetcher,
 		SlashingsPool:          s.cfg.SlashingsPool,
+		SyncCommitteePool:      s.cfg.SyncCommitteePool,
 		StateGen:               s.cfg.StateGen,
 
 		// vanguard: initiate pending queue fetcher
@@ -221,6 +232,9 @@ func (s *Service) Start() {
 		GenesisTimeFetcher:   s.cfg.GenesisTimeFetcher,
 		PeersFetcher:         s.cfg.PeersFetcher,
 		PeerManager:          s.cfg.PeerManager,
+		PeerAdmin:            s.cfg.PeerAdmin,
+		EnablePeerAdmin:      s.cfg.EnablePeerAdmin,
+		GossipScoreInspector: s.cfg.GossipScoreInspector,
 		GenesisFetcher:       s.cfg.GenesisFetcher,
 		BeaconMonitoringHost: s.cfg.BeaconMonitoringHost,
 		BeaconMonitoringPort: s.cfg.BeaconMonitoringPort,
@@ -299,8 +313,9 @@ func (s *Service) Start() {
 			PeersFetcher:       s.cfg.PeersFetcher,
 		}
 		debugServerV1 := &debug.Server{
-			BeaconDB:    s.cfg.BeaconDB,
-			HeadFetcher: s.cfg.HeadFetcher,
+			BeaconDB:               s.cfg.BeaconDB,
+			HeadFetcher:            s.cfg.HeadFetcher,
+			OrcConfirmationFetcher: s.cfg.OrcConfirmationFetcher,
 			StateFetcher: &statefetcher.StateProvider{
 				BeaconDB:           s.cfg.BeaconDB,
 				ChainInfoFetcher:   s.cfg.ChainInfoFetcher,
@@ -311,7 +326,14 @@ func (s *Service) Start() {
 		pbrpc.RegisterDebugServer(s.grpcServer, debugServer)
 		ethpbv1.RegisterBeaconDebugServer(s.grpcServer, debugServerV1)
 	}
+	if s.cfg.SlasherDB != nil {
+		log.Info("Enabled slasher gRPC endpoints")
+		pbrpc.RegisterSlasherServer(s.grpcServer, &slasherv1alpha1.Server{
+			SlasherDB: s.cfg.SlasherDB,
+		})
+	}
 	ethpbv1alpha1.RegisterBeaconNodeValidatorServer(s.grpcServer, validatorServer)
+	prysmv2.RegisterBeaconNodeValidatorAltairServer(s.grpcServer, &validatorv1alpha1.AltairServer{Server: validatorServer})
 
 	// Register reflection service on gRPC server.
 	reflection.Register(s.grpcServer)
@@ -0,0 +1,189 @@
+// Package statediff computes and applies compact differences between two beacon states,
+// which allows archiving a state at every epoch without storing a full state each time.
+package statediff
+
+import (
+	"bytes"
+
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// The validator registry and balances are stored as patches and deltas rather than
+// as changed fields, as they make up most of the size of a state.
+const (
+	validatorsFieldNumber protoreflect.FieldNumber = 4001
+	balancesFieldNumber   protoreflect.FieldNumber = 4002
+)
+
+var (
+	errNilState          = errors.New("nil state")
+	errMissingBase       = errors.New("a base state is required to apply a diff which is not a snapshot")
+	errShrinkingRegistry = errors.New("validator registry and balances cannot shrink")
+	errBaseMismatch      = errors.New("base state does not match the diff")
+)
+
+// Diff describes how to obtain a target state from a base state. A diff computed without
+// a base state is a snapshot, which holds the full target state.
+type Diff struct {
+	// Snapshot is true if the diff does not depend on a base state.
+	Snapshot bool
+	// ChangedFields lists the numbers of the state fields which differ between the base and
+	// target state, other than validators and balances.
+	ChangedFields []protoreflect.FieldNumber
+	// Fields holds the target values of the changed fields.
+	Fields *pb.BeaconState
+	// ValidatorCount is the size of the validator registry of the target state.
+	ValidatorCount uint64
+	// Validators holds the validators which were added or modified in the target state.
+	Validators []*ValidatorPatch
+	// BalanceDeltas holds the change of every validator balance, with balances missing from
+	// the base state counting as zero.
+	BalanceDeltas []int64
+}
+
+// ValidatorPatch is a validator record which differs from the base state.
+type ValidatorPatch struct {
+	Index     types.ValidatorIndex
+	Validator *ethpb.Validator
+}
+
+// Compute returns the diff from the base state to the target state. A nil base state
+// produces a snapshot of the target state. The returned diff shares memory with the target
+// state, which must therefore not be modified while the diff is in use.
+func Compute(base, target *pb.BeaconState) (*Diff, error) {
+	if target == nil {
+		return nil, errNilState
+	}
+	d := &Diff{
+		Snapshot: base == nil,
+		Fields:   &pb.BeaconState{},
+	}
+	if base == nil {
+		base = &pb.BeaconState{}
+	}
+	if len(target.Validators) < len(base.Validators) || len(target.Balances) < len(base.Balances) {
+		return nil, errShrinkingRegistry
+	}
+
+	baseMsg, targetMsg, fieldsMsg := base.ProtoReflect(), target.ProtoReflect(), d.Fields.ProtoReflect()
+	fds := targetMsg.Descriptor().Fields()
+	for i := 0; i < fds.Len(); i++ {
+		fd := fds.Get(i)
+		if fd.Number() == validatorsFieldNumber || fd.Number() == balancesFieldNumber {
+			continue
+		}
+		if fieldEqual(baseMsg, targetMsg, fd) {
+			continue
+		}
+		d.ChangedFields = append(d.ChangedFields, fd.Number())
+		if targetMsg.Has(fd) {
+			fieldsMsg.Set(fd, targetMsg.Get(fd))
+		}
+	}
+
+	d.ValidatorCount = uint64(len(target.Validators))
+	for i, v := range target.Validators {
+		if i < len(base.Validators) && validatorEqual(base.Validators[i], v) {
+			continue
+		}
+		d.Validators = append(d.Validators, &ValidatorPatch{Index: types.ValidatorIndex(i), Validator: v})
+	}
+
+	d.BalanceDeltas = make([]int64, len(target.Balances))
+	for i, b := range target.Balances {
+		var prev uint64
+		if i < len(base.Balances) {
+			prev = base.Balances[i]
+		}
+		d.BalanceDeltas[i] = int64(b - prev)
+	}
+	return d, nil
+}
+
+// Apply applies the diff to the base state, which is modified in place, and returns the
+// target state. The base state is ignored when applying a snapshot. The returned state
+// shares memory with the diff.
+func (d *Diff) Apply(base *pb.BeaconState) (*pb.BeaconState, error) {
+	if d.Snapshot {
+		base = &pb.BeaconState{}
+	}
+	if base == nil {
+		return nil, errMissingBase
+	}
+	if uint64(len(base.Validators)) > d.ValidatorCount || len(base.Balances) > len(d.BalanceDeltas) {
+		return nil, errBaseMismatch
+	}
+
+	baseMsg, fieldsMsg := base.ProtoReflect(), d.Fields.ProtoReflect()
+	fds := baseMsg.Descriptor().Fields()
+	for _, num := range d.ChangedFields {
+		fd := fds.ByNumber(num)
+		if fd == nil || num == validatorsFieldNumber || num == balancesFieldNumber {
+			return nil, errors.Errorf("invalid changed field %d", num)
+		}
+		if fieldsMsg.Has(fd) {
+			baseMsg.Set(fd, fieldsMsg.Get(fd))
+		} else {
+			baseMsg.Clear(fd)
+		}
+	}
+
+	validators := make([]*ethpb.Validator, d.ValidatorCount)
+	copy(validators, base.Validators)
+	for _, p := range d.Validators {
+		if uint64(p.Index) >= d.ValidatorCount {
+			return nil, errors.Errorf("validator patch index %d out of range", p.Index)
+		}
+		validators[p.Index] = p.Validator
+	}
+	for i, v := range validators {
+		if v == nil {
+			return nil, errors.Errorf("missing validator at index %d", i)
+		}
+	}
+	base.Validators = validators
+
+	// Like an SSZ decoded state, a state without balances is left with a nil balance list.
+	if len(d.BalanceDeltas) > 0 {
+		balances := make([]uint64, len(d.BalanceDeltas))
+		copy(balances, base.Balances)
+		for i, delta := range d.BalanceDeltas {
+			balances[i] += uint64(delta)
+		}
+		base.Balances = balances
+	}
+	return base, nil
+}
+
+// fieldEqual returns true if the given field has the same value in both messages.
+func fieldEqual(a, b protoreflect.Message, fd protoreflect.FieldDescriptor) bool {
+	x, y := a.New(), b.New()
+	if a.Has(fd) {
+		x.Set(fd, a.Get(fd))
+	}
+	if b.Has(fd) {
+		y.Set(fd, b.Get(fd))
+	}
+	return proto.Equal(x.Interface(), y.Interface())
+}
+
+// validatorEqual compares validator records field by field, which is much faster than
+// comparing them through reflection for large registries.
+func validatorEqual(a, b *ethpb.Validator) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return bytes.Equal(a.PublicKey, b.PublicKey) &&
+		bytes.Equal(a.WithdrawalCredentials, b.WithdrawalCredentials) &&
+		a.EffectiveBalance == b.EffectiveBalance &&
+		a.Slashed == b.Slashed &&
+		a.ActivationEligibilityEpoch == b.ActivationEligibilityEpoch &&
+		a.ActivationEpoch == b.ActivationEpoch &&
+		a.ExitEpoch == b.ExitEpoch &&
+		a.WithdrawableEpoch == b.WithdrawableEpoch
+}
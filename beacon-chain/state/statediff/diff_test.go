@@ -0,0 +1,107 @@
+package statediff
+
+import (
+	"testing"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	v1 "github.com/prysmaticlabs/prysm/beacon-chain/state/v1"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func testValidator(i uint64) *ethpb.Validator {
+	return &ethpb.Validator{
+		PublicKey:             bytesutil.PadTo(bytesutil.Bytes8(i), 48),
+		WithdrawalCredentials: make([]byte, 32),
+		EffectiveBalance:      params.BeaconConfig().MaxEffectiveBalance,
+		ExitEpoch:             params.BeaconConfig().FarFutureEpoch,
+		WithdrawableEpoch:     params.BeaconConfig().FarFutureEpoch,
+	}
+}
+
+// testStates returns a base state and a target state one epoch later, with a few changed
+// fields, validators and balances.
+func testStates(t testing.TB, validatorCount uint64) (base, target *pb.BeaconState) {
+	st, err := testutil.NewBeaconState()
+	require.NoError(t, err)
+	base, err = v1.ProtobufBeaconState(st.CloneInnerState())
+	require.NoError(t, err)
+	for i := uint64(0); i < validatorCount; i++ {
+		base.Validators = append(base.Validators, testValidator(i))
+		base.Balances = append(base.Balances, params.BeaconConfig().MaxEffectiveBalance)
+	}
+	base.Eth1DataVotes = []*ethpb.Eth1Data{{DepositRoot: make([]byte, 32), BlockHash: make([]byte, 32)}}
+
+	target = proto.Clone(base).(*pb.BeaconState)
+	target.Slot = params.BeaconConfig().SlotsPerEpoch
+	target.BlockRoots[3] = bytesutil.PadTo([]byte{'a'}, 32)
+	target.Eth1DataVotes = nil
+	target.Validators[1].ExitEpoch = 10
+	target.Validators = append(target.Validators, testValidator(validatorCount))
+	target.Balances[0] += 1000
+	target.Balances[2] -= 5000
+	target.Balances = append(target.Balances, 32)
+	return base, target
+}
+
+func TestCompute_Apply(t *testing.T) {
+	base, target := testStates(t, 8)
+	d, err := Compute(base, target)
+	require.NoError(t, err)
+	assert.Equal(t, false, d.Snapshot)
+	assert.Equal(t, 3, len(d.ChangedFields), "Wanted slot, block roots and eth1 data votes to change")
+	assert.Equal(t, uint64(9), d.ValidatorCount)
+	require.Equal(t, 2, len(d.Validators))
+	assert.Equal(t, types.ValidatorIndex(1), d.Validators[0].Index)
+	assert.Equal(t, types.ValidatorIndex(8), d.Validators[1].Index)
+	assert.DeepEqual(t, []int64{1000, 0, -5000, 0, 0, 0, 0, 0, 32}, d.BalanceDeltas)
+
+	got, err := d.Apply(proto.Clone(base).(*pb.BeaconState))
+	require.NoError(t, err)
+	assert.Equal(t, true, proto.Equal(target, got), "Applied diff does not produce the target state")
+}
+
+func TestCompute_Snapshot(t *testing.T) {
+	_, target := testStates(t, 8)
+	d, err := Compute(nil, target)
+	require.NoError(t, err)
+	assert.Equal(t, true, d.Snapshot)
+	assert.Equal(t, 9, len(d.Validators))
+
+	got, err := d.Apply(nil)
+	require.NoError(t, err)
+	assert.Equal(t, true, proto.Equal(target, got), "Applied snapshot does not produce the target state")
+}
+
+func TestCompute_ShrinkingRegistry(t *testing.T) {
+	base, target := testStates(t, 8)
+	_, err := Compute(target, base)
+	assert.ErrorContains(t, errShrinkingRegistry.Error(), err)
+}
+
+func TestApply_Errors(t *testing.T) {
+	base, target := testStates(t, 8)
+	d, err := Compute(base, target)
+	require.NoError(t, err)
+
+	_, err = d.Apply(nil)
+	assert.ErrorContains(t, errMissingBase.Error(), err)
+
+	// The diff was computed against a smaller registry.
+	_, err = d.Apply(proto.Clone(target).(*pb.BeaconState))
+	require.NoError(t, err)
+	bigger := proto.Clone(target).(*pb.BeaconState)
+	bigger.Validators = append(bigger.Validators, testValidator(100))
+	_, err = d.Apply(bigger)
+	assert.ErrorContains(t, errBaseMismatch.Error(), err)
+
+	// A diff which is not a snapshot cannot be applied to an empty registry.
+	_, err = d.Apply(&pb.BeaconState{})
+	assert.ErrorContains(t, "missing validator at index 0", err)
+}
@@ -0,0 +1,173 @@
+package statediff
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+
+	"github.com/golang/snappy"
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// validatorSSZSize is the fixed size of an SSZ encoded validator record.
+const validatorSSZSize = 121
+
+const (
+	diffFlag     byte = 0
+	snapshotFlag byte = 1
+)
+
+var errMalformedDiff = errors.New("malformed state diff")
+
+// Encode serializes the diff. The first byte of the encoding tells whether the diff is a
+// snapshot, the remainder is snappy compressed and laid out as follows:
+//
+//   changed field count, changed field numbers (uvarints)
+//   length of the changed fields, protobuf encoded changed fields
+//   validator count (uvarint)
+//   validator patch count, followed by a uvarint index and an SSZ validator for every patch
+//   balance count, followed by a zigzag varint delta for every balance
+func (d *Diff) Encode() ([]byte, error) {
+	fields, err := proto.Marshal(d.Fields)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not marshal changed fields")
+	}
+	buf := new(bytes.Buffer)
+	buf.Grow(len(fields) + len(d.Validators)*(validatorSSZSize+binary.MaxVarintLen64) + len(d.BalanceDeltas)*binary.MaxVarintLen64)
+	writeUvarint(buf, uint64(len(d.ChangedFields)))
+	for _, num := range d.ChangedFields {
+		writeUvarint(buf, uint64(num))
+	}
+	writeUvarint(buf, uint64(len(fields)))
+	buf.Write(fields)
+	writeUvarint(buf, d.ValidatorCount)
+	writeUvarint(buf, uint64(len(d.Validators)))
+	for _, p := range d.Validators {
+		enc, err := p.Validator.MarshalSSZ()
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not marshal validator %d", p.Index)
+		}
+		writeUvarint(buf, uint64(p.Index))
+		buf.Write(enc)
+	}
+	writeUvarint(buf, uint64(len(d.BalanceDeltas)))
+	for _, delta := range d.BalanceDeltas {
+		writeVarint(buf, delta)
+	}
+
+	flag := diffFlag
+	if d.Snapshot {
+		flag = snapshotFlag
+	}
+	return append([]byte{flag}, snappy.Encode(nil, buf.Bytes())...), nil
+}
+
+// Decode deserializes a diff produced by Encode.
+func Decode(enc []byte) (*Diff, error) {
+	if len(enc) == 0 || (enc[0] != diffFlag && enc[0] != snapshotFlag) {
+		return nil, errMalformedDiff
+	}
+	data, err := snappy.Decode(nil, enc[1:])
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decompress state diff")
+	}
+	r := bytes.NewReader(data)
+	d := &Diff{
+		Snapshot: enc[0] == snapshotFlag,
+		Fields:   &pb.BeaconState{},
+	}
+
+	n, err := readCount(r)
+	if err != nil {
+		return nil, err
+	}
+	d.ChangedFields = make([]protoreflect.FieldNumber, n)
+	for i := range d.ChangedFields {
+		num, err := binary.ReadUvarint(r)
+		if err != nil {
+			return nil, errMalformedDiff
+		}
+		d.ChangedFields[i] = protoreflect.FieldNumber(num)
+	}
+
+	n, err = readCount(r)
+	if err != nil {
+		return nil, err
+	}
+	fields := make([]byte, n)
+	if _, err := io.ReadFull(r, fields); err != nil {
+		return nil, errMalformedDiff
+	}
+	if err := proto.Unmarshal(fields, d.Fields); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal changed fields")
+	}
+
+	if d.ValidatorCount, err = binary.ReadUvarint(r); err != nil {
+		return nil, errMalformedDiff
+	}
+	n, err = readCount(r)
+	if err != nil {
+		return nil, err
+	}
+	d.Validators = make([]*ValidatorPatch, n)
+	validatorEnc := make([]byte, validatorSSZSize)
+	for i := range d.Validators {
+		index, err := binary.ReadUvarint(r)
+		if err != nil {
+			return nil, errMalformedDiff
+		}
+		if _, err := io.ReadFull(r, validatorEnc); err != nil {
+			return nil, errMalformedDiff
+		}
+		v := &ethpb.Validator{}
+		if err := v.UnmarshalSSZ(validatorEnc); err != nil {
+			return nil, errors.Wrap(err, "could not unmarshal validator")
+		}
+		d.Validators[i] = &ValidatorPatch{Index: types.ValidatorIndex(index), Validator: v}
+	}
+
+	n, err = readCount(r)
+	if err != nil {
+		return nil, err
+	}
+	d.BalanceDeltas = make([]int64, n)
+	for i := range d.BalanceDeltas {
+		if d.BalanceDeltas[i], err = binary.ReadVarint(r); err != nil {
+			return nil, errMalformedDiff
+		}
+	}
+	if r.Len() != 0 {
+		return nil, errMalformedDiff
+	}
+	return d, nil
+}
+
+// IsSnapshot returns true if the encoded diff is a snapshot, without decoding it.
+func IsSnapshot(enc []byte) bool {
+	return len(enc) > 0 && enc[0] == snapshotFlag
+}
+
+// readCount reads the length of a list, which cannot exceed the number of remaining bytes
+// as every element takes at least one byte.
+func readCount(r *bytes.Reader) (uint64, error) {
+	n, err := binary.ReadUvarint(r)
+	if err != nil || n > uint64(r.Len()) {
+		return 0, errMalformedDiff
+	}
+	return n, nil
+}
+
+func writeUvarint(buf *bytes.Buffer, x uint64) {
+	var b [binary.MaxVarintLen64]byte
+	buf.Write(b[:binary.PutUvarint(b[:], x)])
+}
+
+func writeVarint(buf *bytes.Buffer, x int64) {
+	var b [binary.MaxVarintLen64]byte
+	buf.Write(b[:binary.PutVarint(b[:], x)])
+}
@@ -0,0 +1,55 @@
+package statediff
+
+import (
+	"testing"
+
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestEncode_Decode(t *testing.T) {
+	base, target := testStates(t, 8)
+	for _, b := range []*pb.BeaconState{base, nil} {
+		d, err := Compute(b, target)
+		require.NoError(t, err)
+		enc, err := d.Encode()
+		require.NoError(t, err)
+		assert.Equal(t, b == nil, IsSnapshot(enc))
+
+		decoded, err := Decode(enc)
+		require.NoError(t, err)
+		assert.Equal(t, d.Snapshot, decoded.Snapshot)
+		assert.DeepEqual(t, d.ChangedFields, decoded.ChangedFields)
+		assert.Equal(t, d.ValidatorCount, decoded.ValidatorCount)
+		assert.DeepEqual(t, d.BalanceDeltas, decoded.BalanceDeltas)
+		require.Equal(t, len(d.Validators), len(decoded.Validators))
+		for i := range d.Validators {
+			assert.Equal(t, d.Validators[i].Index, decoded.Validators[i].Index)
+			assert.DeepSSZEqual(t, d.Validators[i].Validator, decoded.Validators[i].Validator)
+		}
+
+		if b != nil {
+			b = proto.Clone(b).(*pb.BeaconState)
+		}
+		got, err := decoded.Apply(b)
+		require.NoError(t, err)
+		assert.Equal(t, true, proto.Equal(target, got), "Decoded diff does not produce the target state")
+	}
+}
+
+func TestDecode_Malformed(t *testing.T) {
+	base, target := testStates(t, 8)
+	d, err := Compute(base, target)
+	require.NoError(t, err)
+	enc, err := d.Encode()
+	require.NoError(t, err)
+
+	_, err = Decode(nil)
+	assert.ErrorContains(t, errMalformedDiff.Error(), err)
+	_, err = Decode(append([]byte{2}, enc[1:]...))
+	assert.ErrorContains(t, errMalformedDiff.Error(), err)
+	_, err = Decode(enc[:len(enc)/2])
+	assert.NotNil(t, err)
+}
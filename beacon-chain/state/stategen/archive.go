@@ -0,0 +1,215 @@
+package stategen
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	iface "github.com/prysmaticlabs/prysm/beacon-chain/state/interface"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/statediff"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/v1"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/sirupsen/logrus"
+	"go.opencensus.io/trace"
+)
+
+// epochStateArchive tracks the last epoch boundary state saved in the archive, which is
+// the base of the state diff of the following epoch.
+type epochStateArchive struct {
+	lock  sync.Mutex
+	epoch types.Epoch
+	state *pb.BeaconState
+}
+
+// archiveEpochState saves the epoch boundary state of the given epoch in the archive, as a
+// diff against the previous epoch boundary state. A full snapshot of the state is saved
+// every archived point, or when the previous epoch was not archived.
+func (s *State) archiveEpochState(ctx context.Context, epoch types.Epoch) error {
+	ctx, span := trace.StartSpan(ctx, "stateGen.archiveEpochState")
+	defer span.End()
+
+	if s.beaconDB.HasStateDiff(ctx, epoch) {
+		return nil
+	}
+	st, err := s.epochStartState(ctx, epoch)
+	if err != nil {
+		return errors.Wrapf(err, "could not load state of epoch %d", epoch)
+	}
+	target, err := v1.ProtobufBeaconState(st.CloneInnerState())
+	if err != nil {
+		return err
+	}
+	base, err := s.epochStateDiffBase(ctx, epoch)
+	if err != nil {
+		return err
+	}
+	diff, err := statediff.Compute(base, target)
+	if err != nil {
+		return errors.Wrapf(err, "could not compute state diff of epoch %d", epoch)
+	}
+	if err := s.beaconDB.SaveStateDiff(ctx, epoch, diff); err != nil {
+		return err
+	}
+
+	s.epochStateArchive.lock.Lock()
+	s.epochStateArchive.epoch = epoch
+	s.epochStateArchive.state = target
+	s.epochStateArchive.lock.Unlock()
+
+	log.WithFields(logrus.Fields{
+		"epoch":    epoch,
+		"snapshot": diff.Snapshot,
+	}).Debug("Archived epoch state")
+	return nil
+}
+
+// epochStartState returns the state at the start slot of the epoch. The state is taken from the
+// states held by the node when possible, otherwise the blocks of a single epoch are replayed on
+// top of the previously archived epoch state, so that archiving consecutive epochs does not
+// replay the blocks since the last saved state every epoch.
+func (s *State) epochStartState(ctx context.Context, epoch types.Epoch) (iface.BeaconState, error) {
+	startSlot, err := helpers.StartSlot(epoch)
+	if err != nil {
+		return nil, err
+	}
+	if startSlot == 0 {
+		return s.beaconDB.GenesisState(ctx)
+	}
+	cached, ok, err := s.epochBoundaryStateCache.getBySlot(startSlot)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		return cached.state, nil
+	}
+
+	lastValidRoot, lastValidSlot, err := s.lastSavedBlock(ctx, startSlot)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get last valid block for archived state using slot")
+	}
+	if s.isFinalizedRoot(lastValidRoot) && s.finalizedState() != nil {
+		return processSlotsStateGen(ctx, s.finalizedState(), startSlot)
+	}
+	if s.hotStateCache.has(lastValidRoot) || s.beaconDB.HasState(ctx, lastValidRoot) {
+		st, err := s.loadStateByRoot(ctx, lastValidRoot)
+		if err != nil {
+			return nil, err
+		}
+		return processSlotsStateGen(ctx, st, startSlot)
+	}
+
+	s.epochStateArchive.lock.Lock()
+	var base *pb.BeaconState
+	if s.epochStateArchive.state != nil && s.epochStateArchive.epoch+1 == epoch {
+		base = s.epochStateArchive.state
+	}
+	s.epochStateArchive.lock.Unlock()
+	if base == nil {
+		return s.loadStateBySlot(ctx, startSlot)
+	}
+	// The archived state is kept as the base of the next diff, the replay runs on a copy.
+	st, err := v1.InitializeFromProto(base)
+	if err != nil {
+		return nil, err
+	}
+	return s.replayToSlot(ctx, st, startSlot, lastValidRoot, lastValidSlot)
+}
+
+// epochStateDiffBase returns the state the diff of the given epoch is computed against,
+// or nil if the state of the epoch is to be saved as a snapshot.
+func (s *State) epochStateDiffBase(ctx context.Context, epoch types.Epoch) (*pb.BeaconState, error) {
+	if epoch == 0 || epoch%s.epochsPerArchivedSnapshot() == 0 {
+		return nil, nil
+	}
+
+	s.epochStateArchive.lock.Lock()
+	if s.epochStateArchive.state != nil && s.epochStateArchive.epoch+1 == epoch {
+		base := s.epochStateArchive.state
+		s.epochStateArchive.lock.Unlock()
+		return base, nil
+	}
+	s.epochStateArchive.lock.Unlock()
+
+	if !s.beaconDB.HasStateDiff(ctx, epoch-1) {
+		return nil, nil
+	}
+	return s.archivedEpochState(ctx, epoch-1)
+}
+
+// archivedEpochState rebuilds the epoch boundary state of the given epoch by applying the
+// archived diffs to the nearest snapshot.
+func (s *State) archivedEpochState(ctx context.Context, epoch types.Epoch) (*pb.BeaconState, error) {
+	ctx, span := trace.StartSpan(ctx, "stateGen.archivedEpochState")
+	defer span.End()
+
+	diffs, err := s.beaconDB.StateDiffs(ctx, epoch)
+	if err != nil {
+		return nil, err
+	}
+	var st *pb.BeaconState
+	for _, d := range diffs {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		st, err = d.Apply(st)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not apply state diff to rebuild epoch %d", epoch)
+		}
+	}
+	archivedStateDiffsApplied.Observe(float64(len(diffs)))
+	return st, nil
+}
+
+// stateBySlotFromArchive rebuilds the state of the slot from the archived state of its epoch,
+// replaying the blocks between the start of the epoch and the slot.
+func (s *State) stateBySlotFromArchive(ctx context.Context, slot types.Slot) (iface.BeaconState, error) {
+	ctx, span := trace.StartSpan(ctx, "stateGen.stateBySlotFromArchive")
+	defer span.End()
+
+	pbState, err := s.archivedEpochState(ctx, helpers.SlotToEpoch(slot))
+	if err != nil {
+		return nil, err
+	}
+	st, err := v1.InitializeFromProtoUnsafe(pbState)
+	if err != nil {
+		return nil, err
+	}
+	if st.Slot() == slot {
+		return st, nil
+	}
+
+	lastValidRoot, lastValidSlot, err := s.lastSavedBlock(ctx, slot)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get last valid block for archived state using slot")
+	}
+	return s.replayToSlot(ctx, st, slot, lastValidRoot, lastValidSlot)
+}
+
+// replayToSlot advances an archived state to the slot, replaying the blocks after the state up to
+// the last valid block at or before the slot.
+func (s *State) replayToSlot(
+	ctx context.Context, st iface.BeaconState, slot types.Slot, lastValidRoot [32]byte, lastValidSlot types.Slot,
+) (iface.BeaconState, error) {
+	if lastValidSlot <= st.Slot() {
+		return processSlotsStateGen(ctx, st, slot)
+	}
+	blks, err := s.LoadBlocks(ctx, st.Slot()+1, lastValidSlot, lastValidRoot)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not load blocks for archived state using slot")
+	}
+	replayBlockCount.Observe(float64(len(blks)))
+	return s.ReplayBlocks(ctx, st, blks, slot)
+}
+
+// epochsPerArchivedSnapshot returns the number of epochs between two archived snapshots,
+// which follows the archived point interval.
+func (s *State) epochsPerArchivedSnapshot() types.Epoch {
+	epochs := types.Epoch(s.slotsPerArchivedPoint / params.BeaconConfig().SlotsPerEpoch)
+	if epochs == 0 {
+		return 1
+	}
+	return epochs
+}
@@ -0,0 +1,176 @@
+package stategen
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	testDB "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	iface "github.com/prysmaticlabs/prysm/beacon-chain/state/interface"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/proto/eth/v1alpha1/wrapper"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	"google.golang.org/protobuf/proto"
+)
+
+// saveArchiveTestChain saves a block at the start slot of every epoch up to the given one,
+// along with its post state, in which a new validator joins and balances change every epoch.
+func saveArchiveTestChain(t *testing.T, beaconDB db.NoHeadAccessDatabase, epochs types.Epoch) []iface.BeaconState {
+	ctx := context.Background()
+	st, err := testutil.NewBeaconState()
+	require.NoError(t, err)
+	parentRoot := params.BeaconConfig().ZeroHash
+	states := make([]iface.BeaconState, 0, epochs+1)
+	for e := types.Epoch(0); e <= epochs; e++ {
+		slot := params.BeaconConfig().SlotsPerEpoch.Mul(uint64(e))
+		b := testutil.NewBeaconBlock()
+		b.Block.Slot = slot
+		b.Block.ParentRoot = bytesutil.SafeCopyBytes(parentRoot[:])
+		root, err := b.Block.HashTreeRoot()
+		require.NoError(t, err)
+		require.NoError(t, beaconDB.SaveBlock(ctx, wrapper.WrappedPhase0SignedBeaconBlock(b)))
+		if e == 0 {
+			require.NoError(t, beaconDB.SaveGenesisBlockRoot(ctx, root))
+		}
+
+		require.NoError(t, st.SetSlot(slot))
+		require.NoError(t, st.AppendValidator(&ethpb.Validator{
+			PublicKey:             bytesutil.PadTo(bytesutil.Bytes8(uint64(e)), 48),
+			WithdrawalCredentials: make([]byte, 32),
+			ExitEpoch:             params.BeaconConfig().FarFutureEpoch,
+			WithdrawableEpoch:     params.BeaconConfig().FarFutureEpoch,
+		}))
+		require.NoError(t, st.AppendBalance(uint64(e)))
+		require.NoError(t, st.UpdateBalancesAtIndex(0, uint64(e)*1000))
+		require.NoError(t, beaconDB.SaveState(ctx, st, root))
+		states = append(states, st.Copy())
+		parentRoot = root
+	}
+	return states
+}
+
+func TestArchiveEpochState(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := testDB.SetupDB(t)
+	states := saveArchiveTestChain(t, beaconDB, 5)
+
+	service := New(beaconDB)
+	service.epochStateArchive = &epochStateArchive{}
+	service.slotsPerArchivedPoint = params.BeaconConfig().SlotsPerEpoch * 2
+	for e := types.Epoch(1); e <= 4; e++ {
+		require.NoError(t, service.archiveEpochState(ctx, e))
+	}
+
+	// Epoch 1 is a snapshot as there is no previous archived epoch, epochs 2 and 4 are
+	// archived points.
+	for e, wantSnapshot := range map[types.Epoch]bool{1: true, 2: true, 3: false, 4: true} {
+		diffs, err := beaconDB.StateDiffs(ctx, e)
+		require.NoError(t, err)
+		assert.Equal(t, wantSnapshot, diffs[len(diffs)-1].Snapshot, "Unexpected diff type for epoch %d", e)
+
+		st, err := service.archivedEpochState(ctx, e)
+		require.NoError(t, err)
+		assert.Equal(t, true, proto.Equal(states[e].InnerStateUnsafe().(*pb.BeaconState), st), "Wrong state for epoch %d", e)
+	}
+
+	// A new service rebuilds the base of the next diff from the db.
+	service = New(beaconDB)
+	service.epochStateArchive = &epochStateArchive{}
+	service.slotsPerArchivedPoint = params.BeaconConfig().SlotsPerEpoch * 2
+	require.NoError(t, service.archiveEpochState(ctx, 5))
+	diffs, err := beaconDB.StateDiffs(ctx, 5)
+	require.NoError(t, err)
+	assert.Equal(t, 2, len(diffs))
+	st, err := service.archivedEpochState(ctx, 5)
+	require.NoError(t, err)
+	assert.Equal(t, true, proto.Equal(states[5].InnerStateUnsafe().(*pb.BeaconState), st), "Wrong state for epoch 5")
+}
+
+func TestArchiveEpochState_ReplaysFromPreviousArchivedEpoch(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := testDB.SetupDB(t)
+	states := saveArchiveTestChain(t, beaconDB, 1)
+
+	service := New(beaconDB)
+	service.epochStateArchive = &epochStateArchive{}
+	require.NoError(t, service.archiveEpochState(ctx, 1))
+	// Without the saved state of the last block, the state of epoch 2 is derived from the
+	// archived state of epoch 1 rather than replayed from genesis.
+	_, roots, err := beaconDB.BlockRootsBySlot(ctx, params.BeaconConfig().SlotsPerEpoch)
+	require.NoError(t, err)
+	require.NoError(t, beaconDB.DeleteState(ctx, roots[0]))
+	require.NoError(t, service.archiveEpochState(ctx, 2))
+
+	want, err := processSlotsStateGen(ctx, states[1].Copy(), params.BeaconConfig().SlotsPerEpoch.Mul(2))
+	require.NoError(t, err)
+	st, err := service.archivedEpochState(ctx, 2)
+	require.NoError(t, err)
+	assert.DeepSSZEqual(t, want.InnerStateUnsafe(), st)
+	// The archived base of the diff is left untouched by the replay.
+	prev, err := service.archivedEpochState(ctx, 1)
+	require.NoError(t, err)
+	assert.DeepSSZEqual(t, states[1].InnerStateUnsafe(), prev)
+}
+
+func TestStateBySlot_FromArchive(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := testDB.SetupDB(t)
+	states := saveArchiveTestChain(t, beaconDB, 3)
+
+	service := New(beaconDB)
+	service.epochStateArchive = &epochStateArchive{}
+	for e := types.Epoch(1); e <= 3; e++ {
+		require.NoError(t, service.archiveEpochState(ctx, e))
+	}
+	// Remove the saved states, so they can only be rebuilt from the archive.
+	for e := types.Epoch(1); e <= 3; e++ {
+		_, roots, err := beaconDB.BlockRootsBySlot(ctx, params.BeaconConfig().SlotsPerEpoch.Mul(uint64(e)))
+		require.NoError(t, err)
+		require.NoError(t, beaconDB.DeleteState(ctx, roots[0]))
+	}
+
+	startSlot := params.BeaconConfig().SlotsPerEpoch.Mul(2)
+	st, err := service.StateBySlot(ctx, startSlot)
+	require.NoError(t, err)
+	assert.DeepSSZEqual(t, states[2].InnerStateUnsafe(), st.InnerStateUnsafe())
+
+	// Skip slots after the start of the epoch are processed on top of the archived state.
+	st, err = service.StateBySlot(ctx, startSlot+3)
+	require.NoError(t, err)
+	assert.Equal(t, startSlot+3, st.Slot())
+	want, err := processSlotsStateGen(ctx, states[2].Copy(), startSlot+3)
+	require.NoError(t, err)
+	assert.DeepSSZEqual(t, want.InnerStateUnsafe(), st.InnerStateUnsafe())
+}
+
+func TestMigrateToCold_ArchivesEpochStates(t *testing.T) {
+	for _, enabled := range []bool{true, false} {
+		t.Run(fmt.Sprintf("enabled=%v", enabled), func(t *testing.T) {
+			ctx := context.Background()
+			beaconDB := testDB.SetupDB(t)
+			saveArchiveTestChain(t, beaconDB, 3)
+
+			service := New(beaconDB)
+			if enabled {
+				service.epochStateArchive = &epochStateArchive{}
+			}
+			service.finalizedInfo.slot = params.BeaconConfig().SlotsPerEpoch
+			_, roots, err := beaconDB.BlockRootsBySlot(ctx, params.BeaconConfig().SlotsPerEpoch.Mul(3))
+			require.NoError(t, err)
+			require.NoError(t, service.MigrateToCold(ctx, roots[0]))
+
+			assert.Equal(t, false, beaconDB.HasStateDiff(ctx, 0))
+			assert.Equal(t, enabled, beaconDB.HasStateDiff(ctx, 1))
+			assert.Equal(t, enabled, beaconDB.HasStateDiff(ctx, 2))
+			// The finalized epoch is archived by the next migration.
+			assert.Equal(t, false, beaconDB.HasStateDiff(ctx, 3))
+		})
+	}
+}
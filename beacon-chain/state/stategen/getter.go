@@ -5,6 +5,7 @@ import (
 
 	"github.com/pkg/errors"
 	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
 	iface "github.com/prysmaticlabs/prysm/beacon-chain/state/interface"
 	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
@@ -208,6 +209,11 @@ func (s *State) loadStateBySlot(ctx context.Context, slot types.Slot) (iface.Bea
 		return s.beaconDB.GenesisState(ctx)
 	}
 
+	// Rebuild the state from the archive when the epoch of the slot has been archived.
+	if s.epochStateArchive != nil && s.beaconDB.HasStateDiff(ctx, helpers.SlotToEpoch(slot)) {
+		return s.stateBySlotFromArchive(ctx, slot)
+	}
+
 	// Gather the last saved block root and the slot number.
 	lastValidRoot, lastValidSlot, err := s.lastSavedBlock(ctx, slot)
 	if err != nil {
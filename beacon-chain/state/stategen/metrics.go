@@ -13,4 +13,11 @@ var (
 			Buckets: []float64{64, 256, 1024, 2048, 4096},
 		},
 	)
+	archivedStateDiffsApplied = promauto.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "archived_state_diffs_applied_count",
+			Help:    "The number of archived state diffs applied to rebuild an epoch state",
+			Buckets: []float64{1, 4, 16, 64, 256},
+		},
+	)
 )
@@ -5,6 +5,8 @@ import (
 	"encoding/hex"
 	"fmt"
 
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
 	iface "github.com/prysmaticlabs/prysm/beacon-chain/state/interface"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
 	"github.com/sirupsen/logrus"
@@ -38,6 +40,12 @@ func (s *State) MigrateToCold(ctx context.Context, fRoot [32]byte) error {
 			return ctx.Err()
 		}
 
+		if s.epochStateArchive != nil && helpers.IsEpochStart(slot) {
+			if err := s.archiveEpochState(ctx, helpers.SlotToEpoch(slot)); err != nil {
+				return errors.Wrapf(err, "could not archive state of epoch %d", helpers.SlotToEpoch(slot))
+			}
+		}
+
 		if slot%s.slotsPerArchivedPoint == 0 && slot != 0 {
 			cached, exists, err := s.epochBoundaryStateCache.getBySlot(slot)
 			if err != nil {
@@ -11,6 +11,7 @@ import (
 	types "github.com/prysmaticlabs/eth2-types"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db"
 	iface "github.com/prysmaticlabs/prysm/beacon-chain/state/interface"
+	"github.com/prysmaticlabs/prysm/cmd/beacon-chain/flags"
 	ethereum_beacon_p2p_v1 "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
 	"github.com/prysmaticlabs/prysm/proto/interfaces"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
@@ -48,6 +49,7 @@ type State struct {
 	finalizedInfo           *finalizedInfo
 	epochBoundaryStateCache *epochBoundaryState
 	saveHotStateDB          *saveHotStateDbConfig
+	epochStateArchive       *epochStateArchive
 }
 
 // This tracks the config in the event of long non-finality,
@@ -71,7 +73,7 @@ type finalizedInfo struct {
 
 // New returns a new state management object.
 func New(beaconDB db.NoHeadAccessDatabase) *State {
-	return &State{
+	s := &State{
 		beaconDB:                beaconDB,
 		hotStateCache:           newHotStateCache(),
 		finalizedInfo:           &finalizedInfo{slot: 0, root: params.BeaconConfig().ZeroHash},
@@ -81,6 +83,10 @@ func New(beaconDB db.NoHeadAccessDatabase) *State {
 			duration: defaultHotStateDBInterval,
 		},
 	}
+	if flags.Get().ArchiveEpochStates {
+		s.epochStateArchive = &epochStateArchive{}
+	}
+	return s
 }
 
 // Resume resumes a new state management object from previously saved finalized check point in DB.
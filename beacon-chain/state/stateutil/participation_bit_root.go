@@ -0,0 +1,38 @@
+package stateutil
+
+import (
+	"encoding/binary"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/shared/hashutil"
+	"github.com/prysmaticlabs/prysm/shared/htrutils"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+// ParticipationBitsRoot computes the HashTreeRoot merkleization of
+// the Altair epoch participation flags, a byte list bounded by the validator
+// registry limit.
+func ParticipationBitsRoot(bits []byte) ([32]byte, error) {
+	hasher := hashutil.CustomSHA256Hasher()
+	chunkedRoots, err := htrutils.Pack([][]byte{bits})
+	if err != nil {
+		return [32]byte{}, errors.Wrap(err, "could not pack participation bits into chunks")
+	}
+
+	limit := (params.BeaconConfig().ValidatorRegistryLimit + 31) / 32
+	if limit == 0 {
+		if len(bits) == 0 {
+			limit = 1
+		} else {
+			limit = uint64(len(bits))
+		}
+	}
+	bitsRoot, err := htrutils.BitwiseMerkleize(hasher, chunkedRoots, uint64(len(chunkedRoots)), limit)
+	if err != nil {
+		return [32]byte{}, errors.Wrap(err, "could not compute participation bits merkleization")
+	}
+
+	bitsLengthRoot := make([]byte, 32)
+	binary.LittleEndian.PutUint64(bitsLengthRoot, uint64(len(bits)))
+	return htrutils.MixInLength(bitsRoot, bitsLengthRoot), nil
+}
@@ -0,0 +1,59 @@
+package stateutil
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/hashutil"
+	"github.com/prysmaticlabs/prysm/shared/htrutils"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+// SyncCommitteeRoot computes the HashTreeRoot merkleization of a sync committee.
+// The committee size is read from the active beacon config, so the root is
+// correct for both the mainnet and minimal presets. A nil committee is treated
+// as a committee of empty public keys.
+func SyncCommitteeRoot(committee *pb.SyncCommittee) ([32]byte, error) {
+	hasher := hashutil.CustomSHA256Hasher()
+	size := params.BeaconConfig().SyncCommitteeSize
+	if committee == nil {
+		committee = &pb.SyncCommittee{}
+	}
+	if len(committee.Pubkeys) != 0 && uint64(len(committee.Pubkeys)) != size {
+		return [32]byte{}, fmt.Errorf("wrong sync committee size, expected %d, received %d", size, len(committee.Pubkeys))
+	}
+
+	pubKeyRoots := make([][32]byte, size)
+	for i := uint64(0); i < size; i++ {
+		var pubKey []byte
+		if i < uint64(len(committee.Pubkeys)) {
+			pubKey = committee.Pubkeys[i]
+		}
+		root, err := pubKeyRoot(hasher, pubKey)
+		if err != nil {
+			return [32]byte{}, errors.Wrap(err, "could not compute sync committee public key merkleization")
+		}
+		pubKeyRoots[i] = root
+	}
+	pubKeysRoot, err := htrutils.BitwiseMerkleizeArrays(hasher, pubKeyRoots, size, size)
+	if err != nil {
+		return [32]byte{}, errors.Wrap(err, "could not compute sync committee public keys merkleization")
+	}
+	aggregateRoot, err := pubKeyRoot(hasher, committee.AggregatePubkey)
+	if err != nil {
+		return [32]byte{}, errors.Wrap(err, "could not compute sync committee aggregate public key merkleization")
+	}
+	fieldRoots := [][32]byte{pubKeysRoot, aggregateRoot}
+	return htrutils.BitwiseMerkleizeArrays(hasher, fieldRoots, uint64(len(fieldRoots)), uint64(len(fieldRoots)))
+}
+
+func pubKeyRoot(hasher htrutils.HashFn, pubKey []byte) ([32]byte, error) {
+	key := bytesutil.ToBytes48(pubKey)
+	chunks, err := htrutils.Pack([][]byte{key[:]})
+	if err != nil {
+		return [32]byte{}, err
+	}
+	return htrutils.BitwiseMerkleize(hasher, chunks, uint64(len(chunks)), uint64(len(chunks)))
+}
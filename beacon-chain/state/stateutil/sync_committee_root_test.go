@@ -0,0 +1,92 @@
+package stateutil_test
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stateutil"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/hashutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func TestSyncCommitteeRoot(t *testing.T) {
+	pubKeys := make([][]byte, params.BeaconConfig().SyncCommitteeSize)
+	for i := range pubKeys {
+		pubKeys[i] = bytesutil.PadTo(bytesutil.Bytes8(uint64(i)), 48)
+	}
+	aggregate := bytesutil.PadTo([]byte{'a'}, 48)
+	committee := &pb.SyncCommittee{
+		Pubkeys:         pubKeys,
+		AggregatePubkey: aggregate,
+	}
+
+	// Each public key is a 48 byte vector, merkleized on its own before
+	// being placed in the committee vector.
+	leaves := make([][32]byte, len(pubKeys))
+	for i, key := range pubKeys {
+		leaves[i] = hashutil.Hash(bytesutil.PadTo(key, 64))
+	}
+	pubKeysRoot := merkleRoot(leaves)
+	aggregateRoot := hashutil.Hash(bytesutil.PadTo(aggregate, 64))
+	want := hashutil.Hash(append(pubKeysRoot[:], aggregateRoot[:]...))
+
+	got, err := stateutil.SyncCommitteeRoot(committee)
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	_, err = stateutil.SyncCommitteeRoot(&pb.SyncCommittee{Pubkeys: pubKeys[1:]})
+	assert.ErrorContains(t, "wrong sync committee size", err)
+}
+
+func TestSyncCommitteeRoot_Nil(t *testing.T) {
+	empty := &pb.SyncCommittee{
+		Pubkeys:         make([][]byte, params.BeaconConfig().SyncCommitteeSize),
+		AggregatePubkey: make([]byte, 48),
+	}
+	for i := range empty.Pubkeys {
+		empty.Pubkeys[i] = make([]byte, 48)
+	}
+	want, err := stateutil.SyncCommitteeRoot(empty)
+	require.NoError(t, err)
+	got, err := stateutil.SyncCommitteeRoot(nil)
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+}
+
+// merkleRoot hashes a power of two sized list of leaves into a single root.
+func merkleRoot(leaves [][32]byte) [32]byte {
+	for len(leaves) > 1 {
+		next := make([][32]byte, len(leaves)/2)
+		for i := range next {
+			next[i] = hashutil.Hash(append(leaves[2*i][:], leaves[2*i+1][:]...))
+		}
+		leaves = next
+	}
+	return leaves[0]
+}
+
+func TestParticipationBitsRoot(t *testing.T) {
+	bits := []byte{1, 2, 3}
+	// The participation list is limited by the validator registry limit, so a
+	// single chunk is hashed with zero hashes up to the depth of that limit.
+	limit := params.BeaconConfig().ValidatorRegistryLimit / 32
+	root := bytesutil.ToBytes32(bits)
+	zeroHash := [32]byte{}
+	for i := uint64(1); i < limit; i *= 2 {
+		root = hashutil.Hash(append(root[:], zeroHash[:]...))
+		zeroHash = hashutil.Hash(append(zeroHash[:], zeroHash[:]...))
+	}
+	lengthRoot := bytesutil.ToBytes32(bytesutil.Bytes8(uint64(len(bits))))
+	want := hashutil.Hash(append(root[:], lengthRoot[:]...))
+
+	got, err := stateutil.ParticipationBitsRoot(bits)
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	padded, err := stateutil.ParticipationBitsRoot([]byte{1, 2, 3, 0})
+	require.NoError(t, err)
+	assert.NotEqual(t, got, padded, "List length is not mixed into the root")
+}
@@ -0,0 +1,69 @@
+package v2
+
+import (
+	pbp2p "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+)
+
+// currentSyncCommittee of the current sync committee in beacon chain state.
+// This assumes that a lock is already held on BeaconState.
+func (b *BeaconState) currentSyncCommittee() *pbp2p.SyncCommittee {
+	if !b.hasInnerState() {
+		return nil
+	}
+
+	return copySyncCommittee(b.state.CurrentSyncCommittee)
+}
+
+// nextSyncCommittee of the next sync committee in beacon chain state.
+// This assumes that a lock is already held on BeaconState.
+func (b *BeaconState) nextSyncCommittee() *pbp2p.SyncCommittee {
+	if !b.hasInnerState() {
+		return nil
+	}
+
+	return copySyncCommittee(b.state.NextSyncCommittee)
+}
+
+// CurrentSyncCommittee of the current sync committee in beacon chain state.
+func (b *BeaconState) CurrentSyncCommittee() (*pbp2p.SyncCommittee, error) {
+	if !b.hasInnerState() {
+		return nil, nil
+	}
+
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	if b.state.CurrentSyncCommittee == nil {
+		return nil, nil
+	}
+
+	return b.currentSyncCommittee(), nil
+}
+
+// NextSyncCommittee of the next sync committee in beacon chain state.
+func (b *BeaconState) NextSyncCommittee() (*pbp2p.SyncCommittee, error) {
+	if !b.hasInnerState() {
+		return nil, nil
+	}
+
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	if b.state.NextSyncCommittee == nil {
+		return nil, nil
+	}
+
+	return b.nextSyncCommittee(), nil
+}
+
+// copySyncCommittee copies the provided sync committee object.
+func copySyncCommittee(data *pbp2p.SyncCommittee) *pbp2p.SyncCommittee {
+	if data == nil {
+		return nil
+	}
+	return &pbp2p.SyncCommittee{
+		Pubkeys:         bytesutil.Copy2dBytes(data.Pubkeys),
+		AggregatePubkey: bytesutil.SafeCopyBytes(data.AggregatePubkey),
+	}
+}
@@ -0,0 +1,203 @@
+package v2
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stateutil"
+	pbp2p "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/interfaces/version"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func TestInitializeFromProto(t *testing.T) {
+	testState := testStateAltair(t, 16)
+	type test struct {
+		name  string
+		state *pbp2p.BeaconStateAltair
+		error string
+	}
+	initTests := []test{
+		{
+			name:  "nil state",
+			state: nil,
+			error: "received nil state",
+		},
+		{
+			name:  "nil validators",
+			state: &pbp2p.BeaconStateAltair{Slot: 4, Validators: nil},
+		},
+		{
+			name:  "empty state",
+			state: &pbp2p.BeaconStateAltair{},
+		},
+		{
+			name:  "full state",
+			state: testState,
+		},
+	}
+	for _, tt := range initTests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := InitializeFromProto(tt.state)
+			if tt.error != "" {
+				assert.ErrorContains(t, tt.error, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestBeaconState_HashTreeRoot_AltairFields(t *testing.T) {
+	ctx := context.Background()
+	testState := testStateAltair(t, 16)
+	st, err := InitializeFromProto(testState)
+	require.NoError(t, err)
+	assert.Equal(t, version.Altair, st.Version())
+	_, err = st.HashTreeRoot(ctx)
+	require.NoError(t, err)
+
+	// Update every Altair specific field, then compare the incrementally
+	// updated root against the root of a freshly initialized state.
+	require.NoError(t, st.AppendPreviousParticipationBits(3))
+	require.NoError(t, st.AppendCurrentParticipationBits(7))
+	require.NoError(t, st.AppendInactivityScore(9))
+	committee := testSyncCommittee(2)
+	require.NoError(t, st.SetNextSyncCommittee(committee))
+	testState.PreviousEpochParticipation = append(testState.PreviousEpochParticipation, 3)
+	testState.CurrentEpochParticipation = append(testState.CurrentEpochParticipation, 7)
+	testState.InactivityScores = append(testState.InactivityScores, 9)
+	testState.NextSyncCommittee = committee
+
+	fresh, err := InitializeFromProto(testState)
+	require.NoError(t, err)
+	want, err := fresh.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	got, err := st.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, want, got, "Mismatched roots")
+
+	fieldRoots, err := computeFieldRoots(ctx, testState)
+	require.NoError(t, err)
+	syncCommitteeRoot, err := stateutil.SyncCommitteeRoot(committee)
+	require.NoError(t, err)
+	assert.DeepEqual(t, syncCommitteeRoot[:], fieldRoots[nextSyncCommittee])
+}
+
+func TestBeaconState_Copy_AltairFields(t *testing.T) {
+	st, err := InitializeFromProto(testStateAltair(t, 4))
+	require.NoError(t, err)
+	cp, ok := st.Copy().(*BeaconState)
+	require.Equal(t, true, ok)
+
+	require.NoError(t, cp.AppendCurrentParticipationBits(1))
+	require.NoError(t, cp.SetPreviousParticipationBits([]byte{1, 1, 1, 1}))
+	require.NoError(t, cp.SetInactivityScores([]uint64{5, 5, 5, 5}))
+	require.NoError(t, cp.SetCurrentSyncCommittee(testSyncCommittee(3)))
+
+	curr, err := st.CurrentEpochParticipation()
+	require.NoError(t, err)
+	assert.DeepEqual(t, make([]byte, 4), curr)
+	prev, err := st.PreviousEpochParticipation()
+	require.NoError(t, err)
+	assert.DeepEqual(t, make([]byte, 4), prev)
+	scores, err := st.InactivityScores()
+	require.NoError(t, err)
+	assert.DeepEqual(t, make([]uint64, 4), scores)
+	committee, err := st.CurrentSyncCommittee()
+	require.NoError(t, err)
+	assert.DeepEqual(t, testSyncCommittee(1), committee)
+
+	curr, err = cp.CurrentEpochParticipation()
+	require.NoError(t, err)
+	assert.DeepEqual(t, []byte{0, 0, 0, 0, 1}, curr)
+	committee, err = cp.CurrentSyncCommittee()
+	require.NoError(t, err)
+	assert.DeepEqual(t, testSyncCommittee(3), committee)
+}
+
+func TestBeaconState_UnsupportedPhase0Methods(t *testing.T) {
+	st, err := InitializeFromProto(testStateAltair(t, 1))
+	require.NoError(t, err)
+	_, err = st.PreviousEpochAttestations()
+	assert.ErrorContains(t, "not supported", err)
+	_, err = st.CurrentEpochAttestations()
+	assert.ErrorContains(t, "not supported", err)
+	assert.ErrorContains(t, "not supported", st.AppendCurrentEpochAttestations(&pbp2p.PendingAttestation{}))
+	assert.ErrorContains(t, "not supported", st.AppendPreviousEpochAttestations(&pbp2p.PendingAttestation{}))
+	assert.ErrorContains(t, "not supported", st.RotateAttestations())
+	_, err = st.ToProto()
+	assert.ErrorContains(t, "not supported", err)
+}
+
+func testSyncCommittee(seed byte) *pbp2p.SyncCommittee {
+	pubKeys := make([][]byte, params.BeaconConfig().SyncCommitteeSize)
+	for i := range pubKeys {
+		pubKeys[i] = bytesutil.PadTo([]byte{seed, byte(i)}, 48)
+	}
+	return &pbp2p.SyncCommittee{
+		Pubkeys:         pubKeys,
+		AggregatePubkey: bytesutil.PadTo([]byte{seed}, 48),
+	}
+}
+
+func testStateAltair(t *testing.T, numValidators uint64) *pbp2p.BeaconStateAltair {
+	cfg := params.BeaconConfig()
+	roots := func(n uint64) [][]byte {
+		r := make([][]byte, n)
+		for i := range r {
+			r[i] = bytesutil.PadTo(bytesutil.Bytes8(uint64(i)), 32)
+		}
+		return r
+	}
+	validators := make([]*ethpb.Validator, numValidators)
+	balances := make([]uint64, numValidators)
+	for i := range validators {
+		validators[i] = &ethpb.Validator{
+			PublicKey:             bytesutil.PadTo(bytesutil.Bytes8(uint64(i)), 48),
+			WithdrawalCredentials: make([]byte, 32),
+			EffectiveBalance:      cfg.MaxEffectiveBalance,
+			ExitEpoch:             cfg.FarFutureEpoch,
+			WithdrawableEpoch:     cfg.FarFutureEpoch,
+		}
+		balances[i] = cfg.MaxEffectiveBalance
+	}
+	checkpoint := &ethpb.Checkpoint{Root: make([]byte, 32)}
+	st := &pbp2p.BeaconStateAltair{
+		GenesisTime:           1,
+		GenesisValidatorsRoot: bytesutil.PadTo([]byte("genesis"), 32),
+		Slot:                  2,
+		Fork: &pbp2p.Fork{
+			PreviousVersion: cfg.GenesisForkVersion,
+			CurrentVersion:  cfg.AltairForkVersion,
+		},
+		LatestBlockHeader: &ethpb.BeaconBlockHeader{
+			ParentRoot: make([]byte, 32),
+			StateRoot:  make([]byte, 32),
+			BodyRoot:   make([]byte, 32),
+		},
+		BlockRoots:                  roots(uint64(cfg.SlotsPerHistoricalRoot)),
+		StateRoots:                  roots(uint64(cfg.SlotsPerHistoricalRoot)),
+		HistoricalRoots:             roots(2),
+		Eth1Data:                    &ethpb.Eth1Data{DepositRoot: make([]byte, 32), BlockHash: make([]byte, 32)},
+		Eth1DataVotes:               []*ethpb.Eth1Data{},
+		Validators:                  validators,
+		Balances:                    balances,
+		RandaoMixes:                 roots(uint64(cfg.EpochsPerHistoricalVector)),
+		Slashings:                   make([]uint64, cfg.EpochsPerSlashingsVector),
+		PreviousEpochParticipation:  make([]byte, numValidators),
+		CurrentEpochParticipation:   make([]byte, numValidators),
+		JustificationBits:           []byte{0},
+		PreviousJustifiedCheckpoint: checkpoint,
+		CurrentJustifiedCheckpoint:  checkpoint,
+		FinalizedCheckpoint:         checkpoint,
+		InactivityScores:            make([]uint64, numValidators),
+		CurrentSyncCommittee:        testSyncCommittee(1),
+		NextSyncCommittee:           testSyncCommittee(1),
+	}
+	return st
+}
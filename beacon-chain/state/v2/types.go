@@ -4,13 +4,17 @@ import (
 	"sync"
 
 	"github.com/pkg/errors"
+	iface "github.com/prysmaticlabs/prysm/beacon-chain/state/interface"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state/stateutil"
 	pbp2p "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
 	"github.com/prysmaticlabs/prysm/shared/params"
 )
 
+// Ensure type BeaconState below implements BeaconStateAltair interface.
+var _ iface.BeaconStateAltair = (*BeaconState)(nil)
+
 func init() {
-	fieldMap = make(map[fieldIndex]dataType, params.BeaconConfig().BeaconStateFieldCount)
+	fieldMap = make(map[fieldIndex]dataType, params.BeaconConfig().BeaconStateAltairFieldCount)
 
 	// Initialize the fixed sized arrays.
 	fieldMap[blockRoots] = basicArray
@@ -0,0 +1,21 @@
+package v2
+
+import (
+	"github.com/pkg/errors"
+	pbp2p "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+)
+
+// AppendCurrentEpochAttestations is not supported for Altair beacon state.
+func (b *BeaconState) AppendCurrentEpochAttestations(val *pbp2p.PendingAttestation) error {
+	return errors.New("AppendCurrentEpochAttestations is not supported for Altair beacon state")
+}
+
+// AppendPreviousEpochAttestations is not supported for Altair beacon state.
+func (b *BeaconState) AppendPreviousEpochAttestations(val *pbp2p.PendingAttestation) error {
+	return errors.New("AppendPreviousEpochAttestations is not supported for Altair beacon state")
+}
+
+// RotateAttestations is not supported for Altair beacon state.
+func (b *BeaconState) RotateAttestations() error {
+	return errors.New("RotateAttestations is not supported for Altair beacon state")
+}
@@ -0,0 +1,254 @@
+package sync
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kevinms/leakybucket-go"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/cmd/beacon-chain/flags"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/proto/interfaces"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/sirupsen/logrus"
+	"go.opencensus.io/trace"
+)
+
+// backfillRetryPeriod is how long backfill waits before retrying after a failed or
+// impossible (e.g. no suitable peers) batch request.
+var backfillRetryPeriod = time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second
+
+var (
+	errNoBackfillPeers    = errors.New("no peers available to backfill blocks")
+	errBackfillParentRoot = errors.New("backfilled blocks do not form a chain to the lowest known block")
+	errBackfillIncomplete = errors.New("reached slot 0 without finding the parent of the lowest backfilled block")
+)
+
+// backfillStatus tracks the progress of block backfilling. Every block from the
+// origin block down to the lowest backfilled block, at lowestSlot and with parentRoot
+// as its parent root, is present in the db, and blocks are requested from slots below slot.
+type backfillStatus struct {
+	parentRoot [32]byte
+	lowestSlot types.Slot
+	slot       types.Slot
+	// linked is set once the block with parentRoot as its root is found in the db.
+	linked bool
+}
+
+// done returns true once the chain of blocks has been backfilled down to genesis, or
+// down to a block which was already present in the db.
+func (bs *backfillStatus) done() bool {
+	return bs.linked || bs.parentRoot == params.BeaconConfig().ZeroHash
+}
+
+// restart requests blocks again from the lowest backfilled block, after the blocks
+// below it were skipped by peers or failed to link to it.
+func (bs *backfillStatus) restart() {
+	bs.slot = bs.lowestSlot
+}
+
+// backfillBlocks starts a background routine which downloads blocks preceding the
+// origin block of a node that was started from a non-genesis anchor. Nodes started
+// from genesis have nothing to backfill, in which case this is a no-op.
+func (s *Service) backfillBlocks() {
+	status, err := s.loadBackfillStatus(s.ctx)
+	if errors.Is(err, db.ErrNotFoundOriginBlockRoot) {
+		return
+	}
+	if err != nil {
+		log.WithError(err).Error("Could not load block backfill status")
+		return
+	}
+	if status.done() {
+		return
+	}
+	go s.backfill(status)
+}
+
+// backfill requests block batches backwards until the chain of blocks reaches
+// genesis or a block already present in the db.
+func (s *Service) backfill(status *backfillStatus) {
+	// Use the same limits peers enforce on us in their own rate limiter, so
+	// backfill requests are never penalized.
+	allowedBlocksPerSecond := float64(flags.Get().BlockBatchLimit)
+	allowedBlocksBurst := int64(flags.Get().BlockBatchLimitBurstFactor * flags.Get().BlockBatchLimit)
+	limiter := leakybucket.NewCollector(allowedBlocksPerSecond, allowedBlocksBurst, false /* deleteEmptyBuckets */)
+	defer limiter.Free()
+
+	log.WithFields(logrus.Fields{
+		"slot":       status.slot,
+		"parentRoot": fmt.Sprintf("%#x", bytesutil.Trunc(status.parentRoot[:])),
+	}).Info("Starting block backfill")
+	backfillLowestSlot.Set(float64(status.lowestSlot))
+
+	for !status.done() {
+		select {
+		case <-s.ctx.Done():
+			log.Debug("Context closed, exiting block backfill")
+			return
+		default:
+		}
+		if !s.chainStarted.IsSet() || s.cfg.InitialSync.Syncing() {
+			s.waitForBackfillRetry()
+			continue
+		}
+		if err := s.backfillBatch(s.ctx, status, limiter); err != nil {
+			backfillBatchFailures.Inc()
+			log.WithError(err).Debug("Could not backfill blocks")
+			s.waitForBackfillRetry()
+			continue
+		}
+		backfillLowestSlot.Set(float64(status.lowestSlot))
+	}
+	log.Info("Block backfill complete")
+}
+
+// backfillBatch requests a single batch of blocks below the current backfill slot,
+// verifies that it links to the lowest known block and saves it.
+func (s *Service) backfillBatch(ctx context.Context, status *backfillStatus, limiter *leakybucket.Collector) error {
+	ctx, span := trace.StartSpan(ctx, "sync.backfillBatch")
+	defer span.End()
+
+	if status.slot == 0 {
+		// Peers returned empty batches for slots the parent block must be in.
+		status.restart()
+		return errBackfillIncomplete
+	}
+	count := s.backfillBatchSize()
+	if uint64(status.slot) < count {
+		count = uint64(status.slot)
+	}
+	req := &pb.BeaconBlocksByRangeRequest{
+		StartSlot: status.slot - types.Slot(count),
+		Count:     count,
+		Step:      1,
+	}
+
+	pid, err := s.backfillPeer(status, limiter, count)
+	if err != nil {
+		return err
+	}
+	limiter.Add(pid.String(), int64(count))
+
+	reqCtx, cancel := context.WithTimeout(ctx, respTimeout)
+	defer cancel()
+	blks, err := SendBeaconBlocksByRangeRequest(reqCtx, s.cfg.Chain, s.cfg.P2P, pid, req, nil)
+	if err != nil {
+		return errors.Wrapf(err, "could not request blocks from peer %s", pid)
+	}
+	parentRoot, err := verifyBackfillBatch(blks, status.parentRoot)
+	if err != nil {
+		s.cfg.P2P.Peers().Scorers().BadResponsesScorer().Increment(pid)
+		// The blocks may not link because an earlier batch was wrongly returned empty.
+		status.restart()
+		return err
+	}
+	if len(blks) > 0 {
+		if err := s.cfg.DB.SaveBlocks(ctx, blks); err != nil {
+			return errors.Wrap(err, "could not save backfilled blocks")
+		}
+		lowestRoot, err := blks[0].Block().HashTreeRoot()
+		if err != nil {
+			return err
+		}
+		if err := s.cfg.DB.SaveBackfillBlockRoot(ctx, lowestRoot); err != nil {
+			return errors.Wrap(err, "could not save backfill progress")
+		}
+		status.lowestSlot = blks[0].Block().Slot()
+		s.cfg.P2P.Peers().Scorers().BlockProviderScorer().IncrementProcessedBlocks(pid, uint64(len(blks)))
+		backfilledBlocksCount.Add(float64(len(blks)))
+	}
+
+	status.slot = req.StartSlot
+	status.parentRoot = parentRoot
+	// Stop early if the remaining chain is already present in the db.
+	status.linked = s.cfg.DB.HasBlock(ctx, parentRoot)
+	return nil
+}
+
+// backfillPeer selects the best scored block provider which can serve the requested
+// range and still has enough bandwidth left in our rate limiter.
+func (s *Service) backfillPeer(status *backfillStatus, limiter *leakybucket.Collector, count uint64) (peer.ID, error) {
+	epoch := helpers.SlotToEpoch(status.slot)
+	pids := make([]peer.ID, 0)
+	for _, pid := range s.cfg.P2P.Peers().Connected() {
+		chainState, err := s.cfg.P2P.Peers().ChainState(pid)
+		if err != nil || chainState == nil || chainState.FinalizedEpoch < epoch {
+			continue
+		}
+		pids = append(pids, pid)
+	}
+	pids = s.cfg.P2P.Peers().Scorers().BlockProviderScorer().Sorted(pids, nil)
+	for _, pid := range pids {
+		if limiter.Remaining(pid.String()) >= int64(count) {
+			return pid, nil
+		}
+	}
+	return "", errNoBackfillPeers
+}
+
+// loadBackfillStatus restores backfill progress from the db, starting from the
+// origin block if no batch has been saved yet.
+func (s *Service) loadBackfillStatus(ctx context.Context) (*backfillStatus, error) {
+	originRoot, err := s.cfg.DB.OriginBlockRoot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	lowestRoot, err := s.cfg.DB.BackfillBlockRoot(ctx)
+	if errors.Is(err, db.ErrNotFoundBackfillBlockRoot) {
+		lowestRoot = originRoot
+	} else if err != nil {
+		return nil, err
+	}
+	blk, err := s.cfg.DB.Block(ctx, lowestRoot)
+	if err != nil {
+		return nil, err
+	}
+	if blk == nil || blk.IsNil() {
+		return nil, errors.Errorf("lowest backfill block %#x not found in db", lowestRoot)
+	}
+	status := &backfillStatus{
+		parentRoot: bytesutil.ToBytes32(blk.Block().ParentRoot()),
+		lowestSlot: blk.Block().Slot(),
+		slot:       blk.Block().Slot(),
+	}
+	status.linked = s.cfg.DB.HasBlock(ctx, status.parentRoot)
+	return status, nil
+}
+
+// verifyBackfillBatch checks that the blocks, sorted by slot in ascending order, form
+// a chain ending in the block with the given root. The parent root of the lowest
+// block in the batch is returned.
+func verifyBackfillBatch(blks []interfaces.SignedBeaconBlock, root [32]byte) ([32]byte, error) {
+	for i := len(blks) - 1; i >= 0; i-- {
+		if blks[i] == nil || blks[i].IsNil() {
+			return [32]byte{}, ErrInvalidFetchedData
+		}
+		blkRoot, err := blks[i].Block().HashTreeRoot()
+		if err != nil {
+			return [32]byte{}, err
+		}
+		if blkRoot != root {
+			return [32]byte{}, errors.Wrapf(errBackfillParentRoot, "block at slot %d", blks[i].Block().Slot())
+		}
+		root = bytesutil.ToBytes32(blks[i].Block().ParentRoot())
+	}
+	return root, nil
+}
+
+func (s *Service) backfillBatchSize() uint64 {
+	return uint64(flags.Get().BlockBatchLimit)
+}
+
+func (s *Service) waitForBackfillRetry() {
+	select {
+	case <-s.ctx.Done():
+	case <-time.After(backfillRetryPeriod):
+	}
+}
@@ -0,0 +1,256 @@
+package sync
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/p2p/enr"
+	"github.com/kevinms/leakybucket-go"
+	"github.com/libp2p/go-libp2p-core/network"
+	types "github.com/prysmaticlabs/eth2-types"
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	dbtest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers"
+	p2ptest "github.com/prysmaticlabs/prysm/beacon-chain/p2p/testing"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/proto/eth/v1alpha1/wrapper"
+	"github.com/prysmaticlabs/prysm/proto/interfaces"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+// backfillTestChain builds a chain of blocks with one block per slot, starting from genesis.
+func backfillTestChain(t *testing.T, length int) []*ethpb.SignedBeaconBlock {
+	chain := make([]*ethpb.SignedBeaconBlock, 0, length)
+	parentRoot := params.BeaconConfig().ZeroHash[:]
+	for i := 0; i < length; i++ {
+		blk := testutil.NewBeaconBlock()
+		blk.Block.Slot = types.Slot(i)
+		blk.Block.ParentRoot = parentRoot
+		root, err := blk.Block.HashTreeRoot()
+		require.NoError(t, err)
+		parentRoot = root[:]
+		chain = append(chain, blk)
+	}
+	return chain
+}
+
+func wrapBackfillBlocks(blks []*ethpb.SignedBeaconBlock) []interfaces.SignedBeaconBlock {
+	wrapped := make([]interfaces.SignedBeaconBlock, len(blks))
+	for i, blk := range blks {
+		wrapped[i] = wrapper.WrappedPhase0SignedBeaconBlock(blk)
+	}
+	return wrapped
+}
+
+func TestVerifyBackfillBatch(t *testing.T) {
+	chain := backfillTestChain(t, 10)
+	tipRoot, err := chain[9].Block.HashTreeRoot()
+	require.NoError(t, err)
+
+	t.Run("empty batch", func(t *testing.T) {
+		root, err := verifyBackfillBatch(nil, tipRoot)
+		require.NoError(t, err)
+		assert.Equal(t, tipRoot, root)
+	})
+
+	t.Run("valid chain", func(t *testing.T) {
+		root, err := verifyBackfillBatch(wrapBackfillBlocks(chain[5:10]), tipRoot)
+		require.NoError(t, err)
+		assert.DeepEqual(t, chain[5].Block.ParentRoot, root[:])
+	})
+
+	t.Run("does not link to lowest known block", func(t *testing.T) {
+		_, err := verifyBackfillBatch(wrapBackfillBlocks(chain[4:9]), tipRoot)
+		assert.ErrorContains(t, errBackfillParentRoot.Error(), err)
+	})
+
+	t.Run("gap in batch", func(t *testing.T) {
+		blks := append([]*ethpb.SignedBeaconBlock{chain[3]}, chain[5:10]...)
+		_, err := verifyBackfillBatch(wrapBackfillBlocks(blks), tipRoot)
+		assert.ErrorContains(t, errBackfillParentRoot.Error(), err)
+	})
+}
+
+func TestService_loadBackfillStatus(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := dbtest.SetupDB(t)
+	s := &Service{cfg: &Config{DB: beaconDB}}
+
+	_, err := s.loadBackfillStatus(ctx)
+	require.ErrorContains(t, db.ErrNotFoundOriginBlockRoot.Error(), err)
+
+	chain := backfillTestChain(t, 10)
+	originRoot, err := chain[9].Block.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, beaconDB.SaveBlock(ctx, wrapper.WrappedPhase0SignedBeaconBlock(chain[9])))
+	require.NoError(t, beaconDB.SaveOriginBlockRoot(ctx, originRoot))
+
+	status, err := s.loadBackfillStatus(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, types.Slot(9), status.slot)
+	assert.DeepEqual(t, chain[9].Block.ParentRoot, status.parentRoot[:])
+	assert.Equal(t, false, status.done())
+
+	require.NoError(t, beaconDB.SaveBlocks(ctx, wrapBackfillBlocks(chain[6:9])))
+	lowestRoot, err := chain[6].Block.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, beaconDB.SaveBackfillBlockRoot(ctx, lowestRoot))
+
+	status, err = s.loadBackfillStatus(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, types.Slot(6), status.slot)
+	assert.DeepEqual(t, chain[6].Block.ParentRoot, status.parentRoot[:])
+
+	// Once the parent of the lowest block is known, there is nothing left to do.
+	require.NoError(t, beaconDB.SaveBlock(ctx, wrapper.WrappedPhase0SignedBeaconBlock(chain[5])))
+	status, err = s.loadBackfillStatus(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, true, status.done())
+}
+
+func TestService_loadBackfillStatus_FromOrigin(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := dbtest.SetupDB(t)
+	s := &Service{cfg: &Config{DB: beaconDB}}
+	gs, err := testutil.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, beaconDB.SaveGenesisData(ctx, gs))
+
+	chain := backfillTestChain(t, 10)
+	origin := chain[9]
+	bodyRoot, err := origin.Block.Body.HashTreeRoot()
+	require.NoError(t, err)
+	st, err := testutil.NewBeaconState(func(st *pb.BeaconState) error {
+		st.Slot = origin.Block.Slot
+		st.LatestBlockHeader = &ethpb.BeaconBlockHeader{
+			Slot:       origin.Block.Slot,
+			ParentRoot: origin.Block.ParentRoot,
+			StateRoot:  make([]byte, 32),
+			BodyRoot:   bodyRoot[:],
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	stateRoot, err := st.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	origin.Block.StateRoot = stateRoot[:]
+	stateEnc, err := st.MarshalSSZ()
+	require.NoError(t, err)
+	blockEnc, err := origin.MarshalSSZ()
+	require.NoError(t, err)
+	require.NoError(t, beaconDB.SaveOrigin(ctx, bytes.NewReader(stateEnc), bytes.NewReader(blockEnc)))
+
+	status, err := s.loadBackfillStatus(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, types.Slot(9), status.slot)
+	assert.DeepEqual(t, origin.Block.ParentRoot, status.parentRoot[:])
+	assert.Equal(t, false, status.done())
+}
+
+func TestService_backfillBatch(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := dbtest.SetupDB(t)
+	chain := backfillTestChain(t, 100)
+	origin := chain[len(chain)-1]
+	originRoot, err := origin.Block.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, beaconDB.SaveBlock(ctx, wrapper.WrappedPhase0SignedBeaconBlock(origin)))
+	require.NoError(t, beaconDB.SaveOriginBlockRoot(ctx, originRoot))
+
+	p1 := p2ptest.NewTestP2P(t)
+	p2 := p2ptest.NewTestP2P(t)
+	p1.Connect(p2)
+	p1.Peers().Add(new(enr.Record), p2.PeerID(), nil, network.DirOutbound)
+	p1.Peers().SetConnectionState(p2.PeerID(), peers.PeerConnected)
+	p1.Peers().SetChainState(p2.PeerID(), &pb.Status{FinalizedEpoch: 10})
+
+	pcl := fmt.Sprintf("%s/ssz_snappy", p2p.RPCBlocksByRangeTopicV1)
+	p2.SetStreamHandler(pcl, func(stream network.Stream) {
+		defer func() {
+			assert.NoError(t, stream.Close())
+		}()
+		req := &pb.BeaconBlocksByRangeRequest{}
+		assert.NoError(t, p2.Encoding().DecodeWithMaxLength(stream, req))
+		for i := req.StartSlot; i < req.StartSlot.Add(req.Count); i++ {
+			assert.NoError(t, WriteChunk(stream, nil, p2.Encoding(), chain[i]))
+		}
+	})
+
+	s := &Service{
+		cfg: &Config{
+			P2P:   p1,
+			DB:    beaconDB,
+			Chain: &mock.ChainService{},
+		},
+	}
+	status, err := s.loadBackfillStatus(ctx)
+	require.NoError(t, err)
+	limiter := leakybucket.NewCollector(64, 640, false)
+	defer limiter.Free()
+
+	require.NoError(t, s.backfillBatch(ctx, status, limiter))
+	assert.Equal(t, types.Slot(99-64), status.slot)
+	lowestRoot, err := beaconDB.BackfillBlockRoot(ctx)
+	require.NoError(t, err)
+	wantRoot, err := chain[99-64].Block.HashTreeRoot()
+	require.NoError(t, err)
+	assert.Equal(t, wantRoot, lowestRoot)
+
+	require.NoError(t, s.backfillBatch(ctx, status, limiter))
+	assert.Equal(t, true, status.done())
+	for i := 0; i < len(chain); i++ {
+		root, err := chain[i].Block.HashTreeRoot()
+		require.NoError(t, err)
+		assert.Equal(t, true, beaconDB.HasBlock(ctx, root), "Missing block at slot %d", i)
+	}
+}
+
+func TestService_backfillBatch_NoPeers(t *testing.T) {
+	ctx := context.Background()
+	p1 := p2ptest.NewTestP2P(t)
+	s := &Service{cfg: &Config{P2P: p1, DB: dbtest.SetupDB(t)}}
+	limiter := leakybucket.NewCollector(64, 640, false)
+	defer limiter.Free()
+	status := &backfillStatus{slot: 100, parentRoot: [32]byte{'a'}}
+	assert.ErrorContains(t, errNoBackfillPeers.Error(), s.backfillBatch(ctx, status, limiter))
+	assert.Equal(t, types.Slot(100), status.slot)
+}
+
+func TestService_backfillBatch_EmptyBatches(t *testing.T) {
+	ctx := context.Background()
+	p1 := p2ptest.NewTestP2P(t)
+	p2 := p2ptest.NewTestP2P(t)
+	p1.Connect(p2)
+	p1.Peers().Add(new(enr.Record), p2.PeerID(), nil, network.DirOutbound)
+	p1.Peers().SetConnectionState(p2.PeerID(), peers.PeerConnected)
+	p1.Peers().SetChainState(p2.PeerID(), &pb.Status{FinalizedEpoch: 10})
+	pcl := fmt.Sprintf("%s/ssz_snappy", p2p.RPCBlocksByRangeTopicV1)
+	p2.SetStreamHandler(pcl, func(stream network.Stream) {
+		defer func() {
+			assert.NoError(t, stream.Close())
+		}()
+		req := &pb.BeaconBlocksByRangeRequest{}
+		assert.NoError(t, p2.Encoding().DecodeWithMaxLength(stream, req))
+	})
+
+	s := &Service{cfg: &Config{P2P: p1, DB: dbtest.SetupDB(t), Chain: &mock.ChainService{}}}
+	limiter := leakybucket.NewCollector(64, 640, false)
+	defer limiter.Free()
+	status := &backfillStatus{slot: 100, lowestSlot: 100, parentRoot: [32]byte{'a'}}
+	require.NoError(t, s.backfillBatch(ctx, status, limiter))
+	require.NoError(t, s.backfillBatch(ctx, status, limiter))
+	assert.Equal(t, types.Slot(0), status.slot)
+	// Reaching slot 0 without finding the parent block does not complete backfill.
+	assert.Equal(t, false, status.done())
+	assert.ErrorContains(t, errBackfillIncomplete.Error(), s.backfillBatch(ctx, status, limiter))
+	assert.Equal(t, types.Slot(100), status.slot)
+	assert.Equal(t, false, status.done())
+}
@@ -13,6 +13,7 @@ import (
 	types "github.com/prysmaticlabs/eth2-types"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/state"
+	iface "github.com/prysmaticlabs/prysm/beacon-chain/state/interface"
 	"github.com/prysmaticlabs/prysm/proto/interfaces"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
 	"github.com/sirupsen/logrus"
@@ -82,9 +83,27 @@ func (s *Service) syncToFinalizedEpoch(ctx context.Context, genesis time.Time) e
 		return err
 	}
 
-	for data := range queue.fetchedData {
-		s.processFetchedData(ctx, genesis, s.cfg.Chain.HeadSlot(), data)
+	// The signatures of a batch are verified while the state transitions of the next batch are
+	// computed. When no other batch is ready yet, the pending batch is saved right away so that the
+	// head keeps progressing.
+	var pending *pendingBatch
+	for {
+		var data *blocksQueueFetchedData
+		var ok bool
+		select {
+		case data, ok = <-queue.fetchedData:
+		default:
+			// Errors are logged by savePendingBatch.
+			_ = s.savePendingBatch(ctx, pending)
+			pending = nil
+			data, ok = <-queue.fetchedData
+		}
+		if !ok {
+			break
+		}
+		pending = s.processFetchedData(ctx, genesis, pending, data)
 	}
+	_ = s.savePendingBatch(ctx, pending)
 
 	log.WithFields(logrus.Fields{
 		"syncedSlot": s.cfg.Chain.HeadSlot(),
@@ -124,15 +143,70 @@ func (s *Service) syncToNonFinalizedEpoch(ctx context.Context, genesis time.Time
 	return nil
 }
 
-// processFetchedData processes data received from queue.
+// pendingBatch is a block batch whose state transitions have been computed, and whose signatures
+// are verified in the background until it is saved.
+type pendingBatch struct {
+	pid       peer.ID
+	lastRoot  [32]byte
+	postState iface.BeaconState
+	save      func(ctx context.Context) error
+}
+
+// processFetchedData processes data received from queue. The state transitions of the fetched blocks
+// are computed on top of the pending batch, if any, while its signatures are verified. The pending
+// batch is then saved, and the fetched blocks are returned as the new pending batch.
 func (s *Service) processFetchedData(
-	ctx context.Context, genesis time.Time, startSlot types.Slot, data *blocksQueueFetchedData) {
-	defer s.updatePeerScorerStats(data.pid, startSlot)
+	ctx context.Context, genesis time.Time, pending *pendingBatch, data *blocksQueueFetchedData) *pendingBatch {
+	// Only a batch extending the pending batch is transitioned before the pending batch is saved.
+	if pending != nil && (len(data.blocks) == 0 ||
+		!bytes.Equal(data.blocks[0].Block().ParentRoot(), pending.lastRoot[:])) {
+		_ = s.savePendingBatch(ctx, pending)
+		pending = nil
+	}
 
+	var next *pendingBatch
+	transition := func(ctx context.Context, blks []interfaces.SignedBeaconBlock, roots [][32]byte) error {
+		var preState iface.BeaconState
+		if pending != nil {
+			preState = pending.postState
+		}
+		postState, save, err := s.cfg.Chain.TransitionBlockBatch(ctx, preState, blks, roots)
+		if err != nil {
+			return err
+		}
+		next = &pendingBatch{
+			pid:       data.pid,
+			lastRoot:  roots[len(roots)-1],
+			postState: postState,
+			save:      save,
+		}
+		return nil
+	}
 	// Use Batch Block Verify to process and verify batches directly.
-	if err := s.processBatchedBlocks(ctx, genesis, data.blocks, s.cfg.Chain.ReceiveBlockBatch); err != nil {
+	err := s.processBatchedBlocks(ctx, genesis, data.blocks, pending, transition)
+	if saveErr := s.savePendingBatch(ctx, pending); saveErr != nil {
+		// The fetched blocks were transitioned on top of a batch which is not saved.
+		return nil
+	}
+	if err != nil {
 		log.WithError(err).Warn("Batch is not processed")
+		return nil
 	}
+	return next
+}
+
+// savePendingBatch waits for the signatures of the pending batch to be verified, and saves it.
+func (s *Service) savePendingBatch(ctx context.Context, pending *pendingBatch) error {
+	if pending == nil {
+		return nil
+	}
+	defer s.updatePeerScorerStats(pending.pid, s.cfg.Chain.HeadSlot())
+
+	if err := pending.save(ctx); err != nil {
+		log.WithError(err).Warn("Batch is not processed")
+		return err
+	}
+	return nil
 }
 
 // processFetchedData processes data received from queue.
@@ -239,8 +313,10 @@ func (s *Service) processBlock(
 	return blockReceiver(ctx, blk, blkRoot)
 }
 
+// processBatchedBlocks performs basic checks on a batch of incoming blocks, and triggers the batch
+// receiver function. The parent of the batch may also be the last block of the pending batch.
 func (s *Service) processBatchedBlocks(ctx context.Context, genesis time.Time,
-	blks []interfaces.SignedBeaconBlock, bFunc batchBlockReceiverFn) error {
+	blks []interfaces.SignedBeaconBlock, pending *pendingBatch, bFunc batchBlockReceiverFn) error {
 	if len(blks) == 0 {
 		return errors.New("0 blocks provided into method")
 	}
@@ -263,7 +339,8 @@ func (s *Service) processBatchedBlocks(ctx context.Context, genesis time.Time,
 	}
 	s.logBatchSyncStatus(genesis, blks, blkRoot)
 	parentRoot := bytesutil.ToBytes32(firstBlock.Block().ParentRoot())
-	if !s.cfg.DB.HasBlock(ctx, parentRoot) && !s.cfg.Chain.HasInitSyncBlock(parentRoot) {
+	pendingParent := pending != nil && parentRoot == pending.lastRoot
+	if !pendingParent && !s.cfg.DB.HasBlock(ctx, parentRoot) && !s.cfg.Chain.HasInitSyncBlock(parentRoot) {
 		return fmt.Errorf("%w: %#x", errParentDoesNotExist, firstBlock.Block().ParentRoot())
 	}
 	blockRoots := make([][32]byte, len(blks))
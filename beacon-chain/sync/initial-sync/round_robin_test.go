@@ -437,7 +437,7 @@ func TestService_processBlockBatch(t *testing.T) {
 		}
 
 		// Process block normally.
-		err = s.processBatchedBlocks(ctx, genesis, batch, func(
+		err = s.processBatchedBlocks(ctx, genesis, batch, nil, func(
 			ctx context.Context, blks []interfaces.SignedBeaconBlock, blockRoots [][32]byte) error {
 			assert.NoError(t, s.cfg.Chain.ReceiveBlockBatch(ctx, blks, blockRoots))
 			return nil
@@ -445,7 +445,7 @@ func TestService_processBlockBatch(t *testing.T) {
 		assert.NoError(t, err)
 
 		// Duplicate processing should trigger error.
-		err = s.processBatchedBlocks(ctx, genesis, batch, func(
+		err = s.processBatchedBlocks(ctx, genesis, batch, nil, func(
 			ctx context.Context, blocks []interfaces.SignedBeaconBlock, blockRoots [][32]byte) error {
 			return nil
 		})
@@ -461,7 +461,7 @@ func TestService_processBlockBatch(t *testing.T) {
 		}
 
 		// Bad batch should fail because it is non linear
-		err = s.processBatchedBlocks(ctx, genesis, badBatch2, func(
+		err = s.processBatchedBlocks(ctx, genesis, badBatch2, nil, func(
 			ctx context.Context, blks []interfaces.SignedBeaconBlock, blockRoots [][32]byte) error {
 			return nil
 		})
@@ -469,7 +469,7 @@ func TestService_processBlockBatch(t *testing.T) {
 		assert.ErrorContains(t, expectedSubErr, err)
 
 		// Continue normal processing, should proceed w/o errors.
-		err = s.processBatchedBlocks(ctx, genesis, batch2, func(
+		err = s.processBatchedBlocks(ctx, genesis, batch2, nil, func(
 			ctx context.Context, blks []interfaces.SignedBeaconBlock, blockRoots [][32]byte) error {
 			assert.NoError(t, s.cfg.Chain.ReceiveBlockBatch(ctx, blks, blockRoots))
 			return nil
@@ -479,6 +479,70 @@ func TestService_processBlockBatch(t *testing.T) {
 	})
 }
 
+func TestService_processFetchedData_Pipelined(t *testing.T) {
+	beaconDB := dbtest.SetupDB(t)
+	genesisBlk := testutil.NewBeaconBlock()
+	genesisBlkRoot, err := genesisBlk.Block.HashTreeRoot()
+	require.NoError(t, err)
+	err = beaconDB.SaveBlock(context.Background(), wrapper.WrappedPhase0SignedBeaconBlock(genesisBlk))
+	require.NoError(t, err)
+	st, err := testutil.NewBeaconState()
+	require.NoError(t, err)
+	s := NewService(context.Background(), &Config{
+		P2P: p2pt.NewTestP2P(t),
+		DB:  beaconDB,
+		Chain: &mock.ChainService{
+			State: st,
+			Root:  genesisBlkRoot[:],
+			DB:    beaconDB,
+			FinalizedCheckPoint: &eth.Checkpoint{
+				Epoch: 0,
+			},
+		},
+		StateNotifier: &mock.MockStateNotifier{},
+	})
+	ctx := context.Background()
+	genesis := makeGenesisTime(32)
+
+	currBlockRoot := genesisBlkRoot
+	makeBatch := func(start, end types.Slot) []interfaces.SignedBeaconBlock {
+		var batch []interfaces.SignedBeaconBlock
+		for i := start; i < end; i++ {
+			parentRoot := currBlockRoot
+			blk := testutil.NewBeaconBlock()
+			blk.Block.Slot = i
+			blk.Block.ParentRoot = parentRoot[:]
+			currBlockRoot, err = blk.Block.HashTreeRoot()
+			require.NoError(t, err)
+			batch = append(batch, wrapper.WrappedPhase0SignedBeaconBlock(blk))
+		}
+		return batch
+	}
+	batch1 := makeBatch(1, 10)
+	batch2 := makeBatch(10, 20)
+	batch3 := makeBatch(20, 30)
+
+	// The first batch is pending until the next batch is transitioned.
+	pending := s.processFetchedData(ctx, genesis, nil, &blocksQueueFetchedData{blocks: batch1})
+	require.NotNil(t, pending)
+	assert.Equal(t, types.Slot(0), s.cfg.Chain.HeadSlot(), "Unexpected head slot")
+
+	// The next batch is transitioned on top of the pending batch, which is then saved.
+	pending = s.processFetchedData(ctx, genesis, pending, &blocksQueueFetchedData{blocks: batch2})
+	require.NotNil(t, pending)
+	assert.Equal(t, types.Slot(9), s.cfg.Chain.HeadSlot(), "Unexpected head slot")
+
+	// A batch which does not extend the pending batch is processed once the pending batch is saved.
+	pending = s.processFetchedData(ctx, genesis, pending, &blocksQueueFetchedData{blocks: batch3[1:]})
+	assert.Equal(t, true, pending == nil, "Non linear batch should not be pending")
+	assert.Equal(t, types.Slot(19), s.cfg.Chain.HeadSlot(), "Unexpected head slot")
+
+	pending = s.processFetchedData(ctx, genesis, pending, &blocksQueueFetchedData{blocks: batch3})
+	require.NotNil(t, pending)
+	require.NoError(t, s.savePendingBatch(ctx, pending))
+	assert.Equal(t, types.Slot(29), s.cfg.Chain.HeadSlot(), "Unexpected head slot")
+}
+
 func TestService_blockProviderScoring(t *testing.T) {
 	cache.initializeRootCache(makeSequence(1, 640), t)
 
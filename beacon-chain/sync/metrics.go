@@ -48,6 +48,45 @@ var (
 		},
 	)
 
+	backfilledBlocksCount = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "backfill_blocks_saved_total",
+			Help: "Count of blocks saved by the block backfill service.",
+		},
+	)
+	backfillBatchFailures = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "backfill_batch_failures_total",
+			Help: "Count of block backfill batches which failed to be requested, verified or saved.",
+		},
+	)
+	backfillLowestSlot = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "backfill_lowest_slot",
+			Help: "The slot of the lowest block saved by block backfill.",
+		},
+	)
+
+	pendingBlocksQueueDepth = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "pending_blocks_queue_depth",
+			Help: "The number of blocks waiting in the pending blocks queue for their parent.",
+		},
+	)
+	pendingRootsInFlight = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "pending_block_roots_in_flight",
+			Help: "The number of missing parent block roots currently requested from peers.",
+		},
+	)
+	pendingRootTimeToResolve = promauto.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "pending_block_root_resolve_seconds",
+			Help:    "Time between the first request of a missing parent block root and the reception of its block.",
+			Buckets: []float64{0.5, 1, 2, 4, 8, 16, 32, 64, 128},
+		},
+	)
+
 	arrivalBlockPropagationHistogram = promauto.NewHistogram(
 		prometheus.HistogramOpts{
 			Name:    "block_arrival_latency_milliseconds",
@@ -85,8 +124,8 @@ func (s *Service) updateMetrics() {
 
 	// We update all other gossip topics.
 	for topic := range p2p.GossipTopicMappings {
-		// We already updated attestation subnet topics.
-		if strings.Contains(topic, "beacon_attestation") {
+		// We already updated attestation subnet topics, and sync committee subnet topics are not tracked.
+		if strings.Contains(topic, "beacon_attestation") || strings.Contains(topic, "sync_committee_%d") {
 			continue
 		}
 		topic += s.cfg.P2P.Encoding().ProtocolSuffix()
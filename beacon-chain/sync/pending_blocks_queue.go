@@ -59,6 +59,8 @@ func (s *Service) processPendingBlocks(ctx context.Context) error {
 	}
 	slots := s.sortedPendingSlots()
 	var parentRoots [][32]byte
+	pendingBlocksQueueDepth.Set(float64(s.pendingBlocksCount(slots)))
+	s.pendingRoots.prune(time.Now().Add(-pendingBlockExpTime))
 
 	span.AddAttributes(
 		trace.Int64Attribute("numSlots", int64(len(slots))),
@@ -124,6 +126,10 @@ func (s *Service) processPendingBlocks(ctx context.Context) error {
 
 			inDB := s.cfg.DB.HasBlock(ctx, bytesutil.ToBytes32(b.Block().ParentRoot()))
 			hasPeer := len(pids) != 0
+			if inDB {
+				// The parent may have been received by other means than a request.
+				s.pendingRoots.resolve(bytesutil.ToBytes32(b.Block().ParentRoot()), time.Now())
+			}
 
 			// Only request for missing parent block if it's not in DB, not in pending cache
 			// and has peer in the peer list.
@@ -207,15 +213,24 @@ func (s *Service) sendBatchRootRequest(ctx context.Context, roots [][32]byte, ra
 	if len(bestPeers) == 0 {
 		return nil
 	}
-	roots = s.dedupRoots(roots)
-	// Randomly choose a peer to query from our best peers. If that peer cannot return
-	// all the requested blocks, we randomly select another peer.
-	pid := bestPeers[randGen.Int()%len(bestPeers)]
+	// Only request the roots which are neither already requested from another peer, nor
+	// backing off after an unsuccessful request.
+	claimed := s.pendingRoots.claim(s.dedupRoots(roots), int(params.BeaconNetworkConfig().MaxRequestBlocks), time.Now())
+	if len(claimed) == 0 {
+		return nil
+	}
+	defer func() {
+		s.pendingRoots.release(claimed, time.Now())
+	}()
+	roots = claimed
+	// Choose the peers to query from our best peers by their block provider score, the
+	// better providers having a higher chance to be queried first. If a peer cannot return
+	// all the requested blocks, we query the next one.
+	scorer := s.cfg.P2P.Peers().Scorers().BlockProviderScorer()
+	bestPeers = scorer.WeightSorted(randGen, bestPeers, nil)
 	for i := 0; i < numOfTries; i++ {
+		pid := bestPeers[i%len(bestPeers)]
 		req := p2ptypes.BeaconBlockByRootsReq(roots)
-		if len(roots) > int(params.BeaconNetworkConfig().MaxRequestBlocks) {
-			req = roots[:params.BeaconNetworkConfig().MaxRequestBlocks]
-		}
 		if err := s.sendRecentBeaconBlocksRequest(ctx, &req, pid); err != nil {
 			traceutil.AnnotateError(span, err)
 			log.Debugf("Could not send recent block request: %v", err)
@@ -228,13 +243,15 @@ func (s *Service) sendBatchRootRequest(ctx context.Context, roots [][32]byte, ra
 			}
 		}
 		s.pendingQueueLock.RUnlock()
+		if received := len(roots) - len(newRoots); received > 0 {
+			scorer.IncrementProcessedBlocks(pid, uint64(received))
+		}
 		if len(newRoots) == 0 {
 			break
 		}
 		// Choosing a new peer with the leftover set of
 		// roots to request.
 		roots = newRoots
-		pid = bestPeers[randGen.Int()%len(bestPeers)]
 	}
 	return nil
 }
@@ -307,6 +324,7 @@ func (s *Service) clearPendingSlots() {
 	defer s.pendingQueueLock.Unlock()
 	s.slotToPendingBlocks.Flush()
 	s.seenPendingBlocks = make(map[[32]byte]bool)
+	s.pendingRoots.reset()
 }
 
 // Delete block from the list from the pending queue using the slot as key.
@@ -359,9 +377,21 @@ func (s *Service) insertBlockToPendingQueue(slot types.Slot, b interfaces.Signed
 	}
 
 	s.seenPendingBlocks[r] = true
+	s.pendingRoots.resolve(r, time.Now())
 	return nil
 }
 
+// pendingBlocksCount returns the number of blocks in the pending queue for the given slots.
+func (s *Service) pendingBlocksCount(slots []types.Slot) int {
+	s.pendingQueueLock.RLock()
+	defer s.pendingQueueLock.RUnlock()
+	count := 0
+	for _, slot := range slots {
+		count += len(s.pendingBlocksInCache(slot))
+	}
+	return count
+}
+
 // This returns signed beacon blocks given input key from slotToPendingBlocks.
 func (s *Service) pendingBlocksInCache(slot types.Slot) []interfaces.SignedBeaconBlock {
 	k := slotToCacheKey(slot)
@@ -431,6 +431,73 @@ func TestService_BatchRootRequest(t *testing.T) {
 	assert.Equal(t, 4, len(r.seenPendingBlocks), "Incorrect size for seen pending block")
 }
 
+func TestService_BatchRootRequest_InFlightRoots(t *testing.T) {
+	p1 := p2ptest.NewTestP2P(t)
+	p2 := p2ptest.NewTestP2P(t)
+	p1.Connect(p2)
+	assert.Equal(t, 1, len(p1.BHost.Network().Peers()), "Expected peers to be connected")
+
+	r := &Service{
+		cfg: &Config{
+			P2P: p1,
+			Chain: &mock.ChainService{
+				FinalizedCheckPoint: &ethpb.Checkpoint{
+					Epoch: 1,
+					Root:  make([]byte, 32),
+				},
+			},
+		},
+		slotToPendingBlocks: gcache.New(time.Second, 2*time.Second),
+		seenPendingBlocks:   make(map[[32]byte]bool),
+	}
+	p1.Peers().Add(new(enr.Record), p2.PeerID(), nil, network.DirOutbound)
+	p1.Peers().SetConnectionState(p2.PeerID(), peers.PeerConnected)
+	p1.Peers().SetChainState(p2.PeerID(), &pb.Status{FinalizedEpoch: 2})
+
+	b1 := testutil.NewBeaconBlock()
+	b1.Block.Slot = 1
+	b1Root, err := b1.Block.HashTreeRoot()
+	require.NoError(t, err)
+	b2 := testutil.NewBeaconBlock()
+	b2.Block.Slot = 2
+	b2Root, err := b2.Block.HashTreeRoot()
+	require.NoError(t, err)
+	b3 := testutil.NewBeaconBlock()
+	b3.Block.Slot = 3
+	b3Root, err := b3.Block.HashTreeRoot()
+	require.NoError(t, err)
+
+	// b1 is already requested from another peer, and b3 is backing off after an unsuccessful request.
+	require.Equal(t, 2, len(r.pendingRoots.claim([][32]byte{b1Root, b3Root}, 10, time.Now())))
+	r.pendingRoots.release([][32]byte{b3Root}, time.Now())
+
+	pcl := protocol.ID("/eth2/beacon_chain/req/beacon_blocks_by_root/1/ssz_snappy")
+	var wg sync.WaitGroup
+	wg.Add(1)
+	p2.BHost.SetStreamHandler(pcl, func(stream network.Stream) {
+		defer wg.Done()
+		var out p2ptypes.BeaconBlockByRootsReq
+		assert.NoError(t, p2.Encoding().DecodeWithMaxLength(stream, &out))
+		assert.DeepEqual(t, p2ptypes.BeaconBlockByRootsReq{b2Root}, out, "Did not receive expected message")
+		_, err := stream.Write([]byte{responseCodeSuccess})
+		assert.NoError(t, err, "Could not write to stream")
+		_, err = p2.Encoding().EncodeWithMaxLength(stream, b2)
+		assert.NoError(t, err, "Could not send response back")
+		assert.NoError(t, stream.Close())
+	})
+
+	require.NoError(t, r.sendBatchRootRequest(context.Background(), [][32]byte{b1Root, b2Root, b3Root}, rand.NewGenerator()))
+	if testutil.WaitTimeout(&wg, 1*time.Second) {
+		t.Fatal("Did not receive stream within 1 sec")
+	}
+	assert.Equal(t, true, r.seenPendingBlocks[b2Root])
+	assert.Equal(t, uint64(1), p1.Peers().Scorers().BlockProviderScorer().ProcessedBlocks(p2.PeerID()))
+	// The resolved root is not tracked anymore, the others still are.
+	assert.Equal(t, 2, len(r.pendingRoots.roots))
+	_, ok := r.pendingRoots.roots[b2Root]
+	assert.Equal(t, false, ok)
+}
+
 func TestService_AddPeningBlockToQueueOverMax(t *testing.T) {
 	r := &Service{
 		slotToPendingBlocks: gcache.New(time.Second, 2*time.Second),
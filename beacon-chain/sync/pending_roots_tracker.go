@@ -0,0 +1,130 @@
+package sync
+
+import (
+	"sync"
+	"time"
+)
+
+var (
+	// pendingRootBackoff is the time to wait before requesting a missing block root again, after a
+	// first unsuccessful request. It is doubled after every unsuccessful request.
+	pendingRootBackoff = time.Second
+	// pendingRootMaxBackoff caps the time to wait before requesting a missing block root again.
+	pendingRootMaxBackoff = 16 * time.Second
+)
+
+// pendingRootRequest is the request state of a missing block root.
+type pendingRootRequest struct {
+	firstRequested time.Time
+	nextAttempt    time.Time
+	attempts       int
+	inFlight       bool
+}
+
+// pendingRootsTracker tracks the missing block roots requested from peers, so a root is only
+// requested from a single peer at a time, and requested again with an exponential backoff.
+// The zero value is ready to use.
+type pendingRootsTracker struct {
+	lock  sync.Mutex
+	roots map[[32]byte]*pendingRootRequest
+}
+
+// claim returns up to limit of the given roots which are neither in flight nor backing off, and
+// marks them as in flight. The roots returned must be released once requested.
+func (t *pendingRootsTracker) claim(roots [][32]byte, limit int, now time.Time) [][32]byte {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.roots == nil {
+		t.roots = make(map[[32]byte]*pendingRootRequest)
+	}
+
+	claimed := make([][32]byte, 0, len(roots))
+	for _, root := range roots {
+		if len(claimed) >= limit {
+			break
+		}
+		req, ok := t.roots[root]
+		if !ok {
+			req = &pendingRootRequest{firstRequested: now}
+			t.roots[root] = req
+		}
+		if req.inFlight || now.Before(req.nextAttempt) {
+			continue
+		}
+		req.inFlight = true
+		req.attempts++
+		claimed = append(claimed, root)
+	}
+	pendingRootsInFlight.Set(float64(t.inFlightCount()))
+	return claimed
+}
+
+// release marks the given roots as no longer in flight. The roots not resolved in the meantime
+// are backed off before they can be claimed again.
+func (t *pendingRootsTracker) release(roots [][32]byte, now time.Time) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	for _, root := range roots {
+		req, ok := t.roots[root]
+		if !ok {
+			continue
+		}
+		req.inFlight = false
+		backoff := pendingRootBackoff
+		for i := 1; i < req.attempts && backoff < pendingRootMaxBackoff; i++ {
+			backoff *= 2
+		}
+		if backoff > pendingRootMaxBackoff {
+			backoff = pendingRootMaxBackoff
+		}
+		req.nextAttempt = now.Add(backoff)
+	}
+	pendingRootsInFlight.Set(float64(t.inFlightCount()))
+}
+
+// resolve stops tracking the given root, recording the time it took to resolve it.
+func (t *pendingRootsTracker) resolve(root [32]byte, now time.Time) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	req, ok := t.roots[root]
+	if !ok {
+		return
+	}
+	pendingRootTimeToResolve.Observe(now.Sub(req.firstRequested).Seconds())
+	delete(t.roots, root)
+	pendingRootsInFlight.Set(float64(t.inFlightCount()))
+}
+
+// prune stops tracking the roots which were first requested before the given time and are not
+// in flight, so roots never resolved are not tracked forever.
+func (t *pendingRootsTracker) prune(before time.Time) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	for root, req := range t.roots {
+		if !req.inFlight && req.firstRequested.Before(before) {
+			delete(t.roots, root)
+		}
+	}
+}
+
+// reset stops tracking all the roots.
+func (t *pendingRootsTracker) reset() {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.roots = make(map[[32]byte]*pendingRootRequest)
+	pendingRootsInFlight.Set(0)
+}
+
+// Note: this helper is not thread safe.
+func (t *pendingRootsTracker) inFlightCount() int {
+	count := 0
+	for _, req := range t.roots {
+		if req.inFlight {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,70 @@
+package sync
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func TestPendingRootsTracker_ClaimRelease(t *testing.T) {
+	tracker := &pendingRootsTracker{}
+	now := time.Now()
+	r1, r2, r3 := [32]byte{'a'}, [32]byte{'b'}, [32]byte{'c'}
+
+	claimed := tracker.claim([][32]byte{r1, r2}, 10, now)
+	assert.DeepEqual(t, [][32]byte{r1, r2}, claimed)
+	// In flight roots are not claimed twice.
+	claimed = tracker.claim([][32]byte{r1, r2, r3}, 10, now)
+	assert.DeepEqual(t, [][32]byte{r3}, claimed)
+
+	tracker.release([][32]byte{r1, r2, r3}, now)
+	// Released roots back off before they can be claimed again.
+	assert.Equal(t, 0, len(tracker.claim([][32]byte{r1}, 10, now.Add(pendingRootBackoff/2))))
+	claimed = tracker.claim([][32]byte{r1}, 10, now.Add(pendingRootBackoff))
+	assert.DeepEqual(t, [][32]byte{r1}, claimed)
+
+	// The backoff doubles after every attempt.
+	tracker.release(claimed, now)
+	assert.Equal(t, 0, len(tracker.claim([][32]byte{r1}, 10, now.Add(pendingRootBackoff))))
+	assert.Equal(t, 1, len(tracker.claim([][32]byte{r1}, 10, now.Add(2*pendingRootBackoff))))
+
+	// The number of claimed roots is limited.
+	claimed = tracker.claim([][32]byte{r1, r2, r3}, 1, now.Add(time.Hour))
+	assert.DeepEqual(t, [][32]byte{r2}, claimed)
+}
+
+func TestPendingRootsTracker_MaxBackoff(t *testing.T) {
+	tracker := &pendingRootsTracker{}
+	now := time.Now()
+	root := [32]byte{'a'}
+	for i := 0; i < 64; i++ {
+		require.Equal(t, 1, len(tracker.claim([][32]byte{root}, 10, now)))
+		tracker.release([][32]byte{root}, now)
+		now = now.Add(pendingRootMaxBackoff)
+	}
+}
+
+func TestPendingRootsTracker_ResolvePrune(t *testing.T) {
+	tracker := &pendingRootsTracker{}
+	now := time.Now()
+	r1, r2 := [32]byte{'a'}, [32]byte{'b'}
+
+	require.Equal(t, 2, len(tracker.claim([][32]byte{r1, r2}, 10, now)))
+	tracker.resolve(r1, now.Add(time.Second))
+	tracker.release([][32]byte{r1, r2}, now.Add(time.Second))
+	assert.Equal(t, 1, len(tracker.roots))
+
+	// Resolved roots can be requested again right away.
+	assert.Equal(t, 1, len(tracker.claim([][32]byte{r1}, 10, now.Add(time.Second))))
+	tracker.release([][32]byte{r1}, now.Add(time.Second))
+
+	tracker.prune(now.Add(time.Millisecond))
+	assert.Equal(t, 1, len(tracker.roots))
+	_, ok := tracker.roots[r1]
+	assert.Equal(t, true, ok)
+
+	tracker.reset()
+	assert.Equal(t, 0, len(tracker.roots))
+}
@@ -78,6 +78,7 @@ type blockchainService interface {
 	blockchain.FinalizationFetcher
 	blockchain.ForkFetcher
 	blockchain.AttestationReceiver
+	blockchain.SlashingReceiver
 	blockchain.TimeFetcher
 	blockchain.GenesisFetcher
 	blockchain.CanonicalFetcher
@@ -94,6 +95,7 @@ type Service struct {
 	blkRootToPendingAtts             map[[32]byte][]*ethpb.SignedAggregateAttestationAndProof
 	pendingAttsLock                  sync.RWMutex
 	pendingQueueLock                 sync.RWMutex
+	pendingRoots                     pendingRootsTracker
 	chainStarted                     *abool.AtomicBool
 	validateBlockLock                sync.RWMutex
 	rateLimiter                      *limiter
@@ -150,6 +152,7 @@ func (s *Service) Start() {
 	s.processPendingBlocksQueue()
 	s.processPendingAttsQueue()
 	s.maintainPeerStatuses()
+	s.backfillBlocks()
 	if !flags.Get().DisableSync {
 		s.resyncIfBehind()
 	}
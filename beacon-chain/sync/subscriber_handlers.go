@@ -45,6 +45,7 @@ func (s *Service) attesterSlashingSubscriber(ctx context.Context, msg proto.Mess
 			return errors.Wrap(err, "could not insert attester slashing into pool")
 		}
 		s.setAttesterSlashingIndicesSeen(aSlashing.Attestation_1.AttestingIndices, aSlashing.Attestation_2.AttestingIndices)
+		s.cfg.Chain.InsertSlashingsToForkChoiceStore(ctx, []*ethpb.AttesterSlashing{aSlashing})
 	}
 	return nil
 }
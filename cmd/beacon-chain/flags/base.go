@@ -115,11 +115,25 @@ var (
 		Usage: "The slot durations of when an archived state gets saved in the DB.",
 		Value: 2048,
 	}
+	// ArchiveEpochStates enables the archival mode, which stores the beacon state of every finalized epoch
+	// as a diff against the previous epoch.
+	ArchiveEpochStates = &cli.BoolFlag{
+		Name: "archive-epoch-states",
+		Usage: "Stores a diff of the beacon state at every finalized epoch boundary, along with a full state " +
+			"every --slots-per-archive-point slots, so historical states can be served quickly. This requires additional storage.",
+	}
 	// DisableDiscv5 disables running discv5.
 	DisableDiscv5 = &cli.BoolFlag{
 		Name:  "disable-discv5",
 		Usage: "Does not run the discoveryV5 dht.",
 	}
+	// GossipScoreParamsFile specifies a YAML file overriding the gossipsub topic scoring parameters.
+	GossipScoreParamsFile = &cli.StringFlag{
+		Name: "gossip-score-params-file",
+		Usage: "A YAML file overriding the gossipsub scoring parameters of topics, by topic kind " +
+			"(beacon_block, beacon_aggregate_and_proof, beacon_attestation, voluntary_exit, proposer_slashing, attester_slashing). " +
+			"Parameters which are not set keep their default value.",
+	}
 	// BlockBatchLimit specifies the requested block batch size.
 	BlockBatchLimit = &cli.IntFlag{
 		Name:  "block-batch-limit",
@@ -143,6 +157,18 @@ var (
 		Name:  "enable-debug-rpc-endpoints",
 		Usage: "Enables the debug rpc service, containing utility endpoints such as /eth/v1alpha1/beacon/state.",
 	}
+	// EnableSlasherRPCEndpoints serves slashing checks from the slasher database to validator clients.
+	EnableSlasherRPCEndpoints = &cli.BoolFlag{
+		Name: "enable-slasher-rpc-endpoints",
+		Usage: "Opens the slasher database in the data directory and enables the slasher rpc service, " +
+			"allowing validator clients to check whether a block or attestation would be slashable",
+	}
+	// EnablePeerAdminRPCEndpoints serves the node endpoints which ban, trust and disconnect peers.
+	EnablePeerAdminRPCEndpoints = &cli.BoolFlag{
+		Name: "enable-peer-admin-rpc-endpoints",
+		Usage: "Enables the node rpc endpoints which ban, unban, trust and disconnect peers at runtime, " +
+			"such as /eth/v1alpha1/node/peers/ban",
+	}
 	// SubscribeToAllSubnets defines a flag to specify whether to subscribe to all possible attestation subnets or not.
 	SubscribeToAllSubnets = &cli.BoolFlag{
 		Name:  "subscribe-all-subnets",
@@ -182,6 +208,17 @@ var (
 		Usage: "Load a genesis state from ssz file. Testnet genesis files can be found in the " +
 			"eth2-clients/eth2-testnets repository on github.",
 	}
+	// CheckpointStatePath defines a flag to start the beacon chain from a non-genesis anchor state file.
+	CheckpointStatePath = &cli.StringFlag{
+		Name: "checkpoint-state",
+		Usage: "Start the beacon chain from a finalized state ssz file instead of genesis. Requires " +
+			"--checkpoint-block, blocks before the checkpoint are backfilled from peers.",
+	}
+	// CheckpointBlockPath defines a flag for the block file matching the checkpoint state.
+	CheckpointBlockPath = &cli.StringFlag{
+		Name:  "checkpoint-block",
+		Usage: "The ssz file of the block the --checkpoint-state state was derived from.",
+	}
 	// OrcRpcProviderFlag defines a orchestrator node RPC endpoint
 	OrcRpcProviderFlag = &cli.StringFlag{
 		Name:  "orc-rpc-provider",
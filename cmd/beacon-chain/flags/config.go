@@ -9,6 +9,7 @@ import (
 // beacon node.
 type GlobalFlags struct {
 	HeadSync                   bool
+	ArchiveEpochStates         bool
 	DisableSync                bool
 	DisableDiscv5              bool
 	SubscribeToAllSubnets      bool
@@ -44,6 +45,10 @@ func ConfigureGlobalFlags(ctx *cli.Context) {
 		log.Warn("Using Disable Sync flag, using this flag on a live network might lead to adverse consequences.")
 		cfg.DisableSync = true
 	}
+	if ctx.Bool(ArchiveEpochStates.Name) {
+		log.Warn("Archiving the beacon state of every finalized epoch, this requires additional storage.")
+		cfg.ArchiveEpochStates = true
+	}
 	if ctx.Bool(SubscribeToAllSubnets.Name) {
 		log.Warn("Subscribing to All Attestation Subnets")
 		cfg.SubscribeToAllSubnets = true
@@ -47,6 +47,7 @@ var appFlags = []cli.Flag{
 	flags.HeadSync,
 	flags.DisableSync,
 	flags.DisableDiscv5,
+	flags.GossipScoreParamsFile,
 	flags.BlockBatchLimit,
 	flags.BlockBatchLimitBurstFactor,
 	flags.InteropMockEth1DataVotesFlag,
@@ -54,7 +55,10 @@ var appFlags = []cli.Flag{
 	flags.InteropNumValidatorsFlag,
 	flags.InteropGenesisTimeFlag,
 	flags.SlotsPerArchivedPoint,
+	flags.ArchiveEpochStates,
 	flags.EnableDebugRPCEndpoints,
+	flags.EnableSlasherRPCEndpoints,
+	flags.EnablePeerAdminRPCEndpoints,
 	flags.SubscribeToAllSubnets,
 	flags.HistoricalSlasherNode,
 	flags.ChainID,
@@ -62,6 +66,8 @@ var appFlags = []cli.Flag{
 	flags.WeakSubjectivityCheckpt,
 	flags.Eth1HeaderReqLimit,
 	flags.GenesisStatePath,
+	flags.CheckpointStatePath,
+	flags.CheckpointBlockPath,
 	cmd.EnableBackupWebhookFlag,
 	cmd.BackupWebhookOutputDir,
 	cmd.MinimalConfigFlag,
@@ -73,6 +79,7 @@ var appFlags = []cli.Flag{
 	cmd.RelayNode,
 	cmd.P2PUDPPort,
 	cmd.P2PTCPPort,
+	cmd.P2PQUICPort,
 	cmd.P2PIP,
 	cmd.P2PHost,
 	cmd.P2PHostDNS,
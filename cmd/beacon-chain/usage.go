@@ -52,6 +52,7 @@ var appHelpFlagGroups = []flagGroup{
 			cmd.RelayNode,
 			cmd.P2PUDPPort,
 			cmd.P2PTCPPort,
+			cmd.P2PQUICPort,
 			cmd.DataDirFlag,
 			cmd.VerbosityFlag,
 			cmd.EnableTracingFlag,
@@ -111,10 +112,14 @@ var appHelpFlagGroups = []flagGroup{
 			flags.HeadSync,
 			flags.DisableSync,
 			flags.SlotsPerArchivedPoint,
+			flags.ArchiveEpochStates,
 			flags.DisableDiscv5,
+			flags.GossipScoreParamsFile,
 			flags.BlockBatchLimit,
 			flags.BlockBatchLimitBurstFactor,
 			flags.EnableDebugRPCEndpoints,
+			flags.EnableSlasherRPCEndpoints,
+			flags.EnablePeerAdminRPCEndpoints,
 			flags.SubscribeToAllSubnets,
 			flags.HistoricalSlasherNode,
 			flags.ChainID,
@@ -123,6 +128,8 @@ var appHelpFlagGroups = []flagGroup{
 			flags.Eth1HeaderReqLimit,
 			flags.OrcRpcProviderFlag,
 			flags.GenesisStatePath,
+			flags.CheckpointStatePath,
+			flags.CheckpointBlockPath,
 		},
 	},
 	{
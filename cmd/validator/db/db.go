@@ -1,6 +1,7 @@
 package db
 
 import (
+	"github.com/prysmaticlabs/prysm/cmd/validator/flags"
 	"github.com/prysmaticlabs/prysm/shared/cmd"
 	"github.com/prysmaticlabs/prysm/shared/tos"
 	validatordb "github.com/prysmaticlabs/prysm/validator/db"
@@ -31,6 +32,21 @@ var Commands = &cli.Command{
 				return nil
 			},
 		},
+		{
+			Name:        "check",
+			Description: `checks the consistency of the slashing protection history and optionally repairs it into a new database`,
+			Flags: cmd.WrapFlags([]cli.Flag{
+				cmd.DataDirFlag,
+				flags.RepairTargetDirFlag,
+			}),
+			Before: tos.VerifyTosAcceptedOrPrompt,
+			Action: func(cliCtx *cli.Context) error {
+				if err := validatordb.Check(cliCtx); err != nil {
+					log.Fatalf("Could not check database: %v", err)
+				}
+				return nil
+			},
+		},
 		{
 			Name:     "migrate",
 			Category: "db",
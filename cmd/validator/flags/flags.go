@@ -28,10 +28,17 @@ var (
 	}
 	// BeaconRPCProviderFlag defines a beacon node RPC endpoint.
 	BeaconRPCProviderFlag = &cli.StringFlag{
-		Name:  "beacon-rpc-provider",
-		Usage: "Beacon node RPC provider endpoint",
+		Name: "beacon-rpc-provider",
+		Usage: "Beacon node RPC provider endpoint. A comma-separated list of endpoints can be given, " +
+			"in which case requests go to the healthiest beacon node and fail over to another one when it becomes unhealthy",
 		Value: "127.0.0.1:4000",
 	}
+	// BeaconRPCHealthCheckIntervalFlag defines the interval between health checks of the beacon nodes.
+	BeaconRPCHealthCheckIntervalFlag = &cli.DurationFlag{
+		Name:  "beacon-rpc-health-check-interval",
+		Usage: "The amount of time between health checks of the beacon nodes given in --beacon-rpc-provider",
+		Value: 5 * time.Second,
+	}
 	// BeaconRPCGatewayProviderFlag defines a beacon node JSON-RPC endpoint.
 	BeaconRPCGatewayProviderFlag = &cli.StringFlag{
 		Name:  "beacon-rpc-gateway-provider",
@@ -72,11 +79,22 @@ var (
 		Name:  "slasher-tls-cert",
 		Usage: "Certificate for secure slasher gRPC. Pass this and the tls-key flag in order to use gRPC securely.",
 	}
+	// SlasherBeaconNodeAPIFlag uses the slasher API of the beacon node for external slashing protection.
+	SlasherBeaconNodeAPIFlag = &cli.BoolFlag{
+		Name: "slasher-beacon-node-api",
+		Usage: "Uses the slasher API of the beacon node at --beacon-rpc-provider instead of a standalone slasher " +
+			"for external slashing protection. The beacon node only serves it when started with --enable-slasher-rpc-endpoints",
+	}
 	// DisablePenaltyRewardLogFlag defines the ability to not log reward/penalty information during deployment
 	DisablePenaltyRewardLogFlag = &cli.BoolFlag{
 		Name:  "disable-rewards-penalties-logging",
 		Usage: "Disable reward/penalty logging during cluster deployment",
 	}
+	// DisablePerformanceHistoryFlag disables the recording of the validator performance history.
+	DisablePerformanceHistoryFlag = &cli.BoolFlag{
+		Name:  "disable-performance-history",
+		Usage: "Disables the recording of the attestation, proposal and shard performance history in the validator database",
+	}
 	// GraffitiFlag defines the graffiti value included in proposed blocks
 	GraffitiFlag = &cli.StringFlag{
 		Name:  "graffiti",
@@ -88,6 +106,12 @@ var (
 		Usage: "Number of attempts to retry gRPC requests",
 		Value: 5,
 	}
+	// BroadcastSignedMessagesFlag enables the submission of signed messages to all the beacon nodes.
+	BroadcastSignedMessagesFlag = &cli.BoolFlag{
+		Name: "broadcast-signed-messages",
+		Usage: "Submits signed blocks and attestations to all the beacon nodes given in --beacon-rpc-provider in parallel, " +
+			"while duties and attestation data are still fetched from the active beacon node",
+	}
 	// GrpcRetryDelayFlag defines the interval to retry a failed gRPC request.
 	GrpcRetryDelayFlag = &cli.DurationFlag{
 		Name:  "grpc-retry-delay",
@@ -267,10 +291,22 @@ var (
 		Usage: "/path/to/ca.crt for establishing a secure, TLS gRPC connection to a remote signer server",
 		Value: "",
 	}
+	// Web3SignerURLFlag defines the URL of the HTTP API of a Web3Signer server for a web3signer keymanager.
+	Web3SignerURLFlag = &cli.StringFlag{
+		Name:  "web3signer-url",
+		Usage: "URL of the HTTP API of a Web3Signer server for a web3signer keymanager, such as http://localhost:9000",
+		Value: "",
+	}
+	// Web3SignerGenesisValidatorsRootFlag defines the genesis validators root sent in the fork info of Web3Signer sign requests.
+	Web3SignerGenesisValidatorsRootFlag = &cli.StringFlag{
+		Name:  "web3signer-genesis-validators-root",
+		Usage: "Hex encoded genesis validators root of the chain, sent to Web3Signer along with each sign request",
+		Value: "",
+	}
 	// KeymanagerKindFlag defines the kind of keymanager desired by a user during wallet creation.
 	KeymanagerKindFlag = &cli.StringFlag{
 		Name:  "keymanager-kind",
-		Usage: "Kind of keymanager, either imported, derived, or remote, specified during wallet creation",
+		Usage: "Kind of keymanager, either imported, derived, remote or web3signer, specified during wallet creation",
 		Value: "",
 	}
 	// SkipDepositConfirmationFlag skips the y/n confirmation prompt for sending a deposit to the deposit contract.
@@ -292,11 +328,62 @@ var (
 		Usage: "Allows users to specify the output directory to export their slashing protection EIP-3076 standard JSON File",
 		Value: "",
 	}
+	// RepairTargetDirFlag defines the directory to write a repaired validator database into.
+	RepairTargetDirFlag = &cli.StringFlag{
+		Name:  "repair-target-dir",
+		Usage: "Directory to write a repaired copy of the validator database into when integrity issues are found",
+	}
+	// SlashingProtectionDryRunFlag reports the outcome of a slashing protection import without writing it.
+	SlashingProtectionDryRunFlag = &cli.BoolFlag{
+		Name:  "dry-run",
+		Usage: "Prints the signed messages of the slashing protection JSON conflicting with the validator database without importing anything",
+	}
+	// SlashingProtectionMinimalFlag restricts the slashing protection history to the latest watermarks.
+	SlashingProtectionMinimalFlag = &cli.BoolFlag{
+		Name: "minimal",
+		Usage: "Only imports or exports the minimal EIP-3076 slashing protection history, made of the highest signed " +
+			"slot, source and target epochs of each validator key",
+	}
 	// GraffitiFileFlag specifies the file path to load graffiti values.
 	GraffitiFileFlag = &cli.StringFlag{
 		Name:  "graffiti-file",
 		Usage: "The path to a YAML file with graffiti values",
 	}
+	// ProposerConfigFileFlag specifies the file path to load per-validator proposer settings.
+	ProposerConfigFileFlag = &cli.StringFlag{
+		Name: "proposer-config-file",
+		Usage: "The path to a YAML or JSON file setting the graffiti, the Pandora fee recipient and whether " +
+			"proposing is enabled for each validator, reloaded whenever it changes",
+	}
+	// DoppelgangerEpochsFlag defines the number of epochs a key is observed for doppelgangers before performing duties.
+	DoppelgangerEpochsFlag = &cli.Uint64Flag{
+		Name: "doppelganger-epochs",
+		Usage: "The number of epochs a validator key is observed for activity of another instance in the network " +
+			"before performing its duties, when doppelganger protection is enabled",
+		Value: 2,
+	}
+	// PerformancePublicKeysFlag defines a comma-separated list of hex string public keys whose
+	// performance history is shown.
+	PerformancePublicKeysFlag = &cli.StringFlag{
+		Name:  "performance-public-keys",
+		Usage: "Comma-separated list of public key hex strings to show the performance history of, all keys by default",
+		Value: "",
+	}
+	// PerformanceStartEpochFlag defines the first epoch of the performance history shown.
+	PerformanceStartEpochFlag = &cli.Uint64Flag{
+		Name:  "start-epoch",
+		Usage: "The first epoch of the performance history to show",
+	}
+	// PerformanceEndEpochFlag defines the last epoch of the performance history shown.
+	PerformanceEndEpochFlag = &cli.Uint64Flag{
+		Name:  "end-epoch",
+		Usage: "The last epoch of the performance history to show, the latest recorded epoch by default",
+	}
+	// PerformanceShowEpochsFlag shows the performance of every epoch along with the summary of each key.
+	PerformanceShowEpochsFlag = &cli.BoolFlag{
+		Name:  "show-epochs",
+		Usage: "Show the performance of every epoch along with the summary of each validator key",
+	}
 	// EnableDutyCountDown enables more verbose logging for counting down to duty.
 	EnableDutyCountDown = &cli.BoolFlag{
 		Name:  "enable-duty-count-down",
@@ -14,6 +14,7 @@ import (
 	accountcommands "github.com/prysmaticlabs/prysm/cmd/validator/accounts"
 	dbcommands "github.com/prysmaticlabs/prysm/cmd/validator/db"
 	"github.com/prysmaticlabs/prysm/cmd/validator/flags"
+	performancecommands "github.com/prysmaticlabs/prysm/cmd/validator/performance"
 	slashingprotectioncommands "github.com/prysmaticlabs/prysm/cmd/validator/slashing-protection"
 	walletcommands "github.com/prysmaticlabs/prysm/cmd/validator/wallet"
 	"github.com/prysmaticlabs/prysm/shared/cmd"
@@ -48,10 +49,13 @@ func startNode(ctx *cli.Context) error {
 
 var appFlags = []cli.Flag{
 	flags.BeaconRPCProviderFlag,
+	flags.BeaconRPCHealthCheckIntervalFlag,
+	flags.BroadcastSignedMessagesFlag,
 	flags.BeaconRPCGatewayProviderFlag,
 	flags.CertFlag,
 	flags.GraffitiFlag,
 	flags.DisablePenaltyRewardLogFlag,
+	flags.DisablePerformanceHistoryFlag,
 	flags.InteropStartIndex,
 	flags.InteropNumValidators,
 	flags.EnableRPCFlag,
@@ -69,10 +73,13 @@ var appFlags = []cli.Flag{
 	cmd.DisableMonitoringFlag,
 	flags.SlasherRPCProviderFlag,
 	flags.SlasherCertFlag,
+	flags.SlasherBeaconNodeAPIFlag,
 	flags.WalletPasswordFileFlag,
 	flags.WalletDirFlag,
 	flags.EnableWebFlag,
 	flags.GraffitiFileFlag,
+	flags.ProposerConfigFileFlag,
+	flags.DoppelgangerEpochsFlag,
 	flags.EnableDutyCountDown,
 	cmd.BackupWebhookOutputDir,
 	cmd.EnableBackupWebhookFlag,
@@ -121,6 +128,7 @@ func main() {
 		accountcommands.Commands,
 		slashingprotectioncommands.Commands,
 		dbcommands.Commands,
+		performancecommands.Commands,
 	}
 
 	app.Flags = appFlags
@@ -0,0 +1,31 @@
+package performance
+
+import (
+	"github.com/prysmaticlabs/prysm/cmd/validator/flags"
+	"github.com/prysmaticlabs/prysm/shared/cmd"
+	"github.com/prysmaticlabs/prysm/shared/featureconfig"
+	"github.com/prysmaticlabs/prysm/validator/performance"
+	"github.com/urfave/cli/v2"
+)
+
+// Commands for the validator performance history.
+var Commands = &cli.Command{
+	Name:     "performance",
+	Category: "performance",
+	Usage: "shows the performance history of your validator keys recorded in the validator database: attestation " +
+		"correctness and inclusion, balance changes, block proposals and Pandora shard submissions",
+	Flags: cmd.WrapFlags([]cli.Flag{
+		cmd.DataDirFlag,
+		flags.PerformancePublicKeysFlag,
+		flags.PerformanceStartEpochFlag,
+		flags.PerformanceEndEpochFlag,
+		flags.PerformanceShowEpochsFlag,
+	}),
+	Before: func(cliCtx *cli.Context) error {
+		return cmd.LoadFlagsFromConfig(cliCtx, cliCtx.Command.Flags)
+	},
+	Action: func(cliCtx *cli.Context) error {
+		featureconfig.ConfigureValidator(cliCtx)
+		return performance.PerformanceCli(cliCtx)
+	},
+}
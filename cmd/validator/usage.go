@@ -87,10 +87,13 @@ var appHelpFlagGroups = []flagGroup{
 		Name: "validator",
 		Flags: []cli.Flag{
 			flags.BeaconRPCProviderFlag,
+			flags.BeaconRPCHealthCheckIntervalFlag,
+			flags.BroadcastSignedMessagesFlag,
 			flags.BeaconRPCGatewayProviderFlag,
 			flags.CertFlag,
 			flags.EnableWebFlag,
 			flags.DisablePenaltyRewardLogFlag,
+			flags.DisablePerformanceHistoryFlag,
 			flags.GraffitiFlag,
 			flags.EnableRPCFlag,
 			flags.RPCHost,
@@ -103,10 +106,13 @@ var appHelpFlagGroups = []flagGroup{
 			flags.GrpcHeadersFlag,
 			flags.SlasherRPCProviderFlag,
 			flags.SlasherCertFlag,
+			flags.SlasherBeaconNodeAPIFlag,
 			flags.DisableAccountMetricsFlag,
 			flags.WalletDirFlag,
 			flags.WalletPasswordFileFlag,
 			flags.GraffitiFileFlag,
+			flags.ProposerConfigFileFlag,
+			flags.DoppelgangerEpochsFlag,
 			flags.EnableDutyCountDown,
 			pandora.PandoraRpcIpcProviderFlag,
 			pandora.PandoraRpcHttpProviderFlag,
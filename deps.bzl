@@ -458,6 +458,12 @@ def prysm_deps():
         version = "v2.1.1",
     )
 
+    go_repository(
+        name = "com_github_cheekybits_genny",
+        importpath = "github.com/cheekybits/genny",
+        sum = "h1:uGGa4nei+j20rOSeDeP5Of12XVm7TGUd4dJA9RDitfE=",
+        version = "v1.0.0",
+    )
     go_repository(
         name = "com_github_chzyer_logex",
         importpath = "github.com/chzyer/logex",
@@ -860,6 +866,12 @@ def prysm_deps():
         sum = "h1:u8491cBMTQ8ft8aeV+adlcytMZylmA5nnwwkRZjI8vw=",
         version = "v1.3.0",
     )
+    go_repository(
+        name = "com_github_francoispqt_gojay",
+        importpath = "github.com/francoispqt/gojay",
+        sum = "h1:d2m3sFjloqoIUQU3TsHBgj6qg/BVGlTBeHDUmyJnXKk=",
+        version = "v1.2.13",
+    )
     go_repository(
         name = "com_github_franela_goblin",
         importpath = "github.com/franela/goblin",
@@ -2047,6 +2059,12 @@ def prysm_deps():
         version = "v0.4.0",
     )
 
+    go_repository(
+        name = "com_github_libp2p_go_libp2p_quic_transport",
+        importpath = "github.com/libp2p/go-libp2p-quic-transport",
+        sum = "h1:e6eLN55agvemeYzdJTzIylVbLD5PJr0RJANr9HM+FVc=",
+        version = "v0.9.3",
+    )
     go_repository(
         name = "com_github_libp2p_go_libp2p_secio",
         build_file_proto_mode = "disable_global",
@@ -2197,6 +2215,12 @@ def prysm_deps():
         version = "v2.0.3+incompatible",
     )
 
+    go_repository(
+        name = "com_github_lucas_clemente_quic_go",
+        importpath = "github.com/lucas-clemente/quic-go",
+        sum = "h1:eCDQqvGBB+kCTkA0XrAFtNe81FMa0/fn4QSoeAbmiF4=",
+        version = "v0.19.3",
+    )
     go_repository(
         name = "com_github_lunixbochs_vtclean",
         importpath = "github.com/lunixbochs/vtclean",
@@ -2230,6 +2254,18 @@ def prysm_deps():
         version = "v0.7.0",
     )
 
+    go_repository(
+        name = "com_github_marten_seemann_qtls",
+        importpath = "github.com/marten-seemann/qtls",
+        sum = "h1:ECsuYUKalRL240rRD4Ri33ISb7kAQ3qGDlrrl55b2pc=",
+        version = "v0.10.0",
+    )
+    go_repository(
+        name = "com_github_marten_seemann_qtls_go1_15",
+        importpath = "github.com/marten-seemann/qtls-go1-15",
+        sum = "h1:LIH6K34bPVttyXnUWixk0bzH6/N07VxbSabxn5A5gZQ=",
+        version = "v0.1.1",
+    )
     go_repository(
         name = "com_github_mattn_go_colorable",
         importpath = "github.com/mattn/go-colorable",
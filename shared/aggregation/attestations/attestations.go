@@ -21,6 +21,10 @@ const (
 	// This new variant is optimized and relies on Bitlist64 (once fully tested, `max_cover`
 	// strategy will be replaced with this one).
 	OptMaxCoverAggregation AttestationAggregationStrategy = "opt_max_cover"
+
+	// MaxRewardAggregation aggregates attestations like OptMaxCoverAggregation, but block proposers
+	// pack them by the rewards they bring on top of the head state, rather than by their bit count.
+	MaxRewardAggregation AttestationAggregationStrategy = "max_reward"
 )
 
 // AttestationAggregationStrategy defines attestation aggregation strategy.
@@ -57,7 +61,7 @@ func Aggregate(atts []*ethpb.Attestation) ([]*ethpb.Attestation, error) {
 		return NaiveAttestationAggregation(atts)
 	case MaxCoverAggregation:
 		return MaxCoverAttestationAggregation(atts)
-	case OptMaxCoverAggregation:
+	case OptMaxCoverAggregation, MaxRewardAggregation:
 		return optMaxCoverAttestationAggregation(atts)
 	default:
 		return nil, errors.Wrapf(aggregation.ErrInvalidStrategy, "%q", strategy)
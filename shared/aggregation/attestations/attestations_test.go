@@ -253,6 +253,13 @@ func TestAggregateAttestations_Aggregate(t *testing.T) {
 			defer resetCfg()
 			runner()
 		})
+		t.Run(fmt.Sprintf("%s/%s", tt.name, MaxRewardAggregation), func(t *testing.T) {
+			resetCfg := featureconfig.InitWithReset(&featureconfig.Flags{
+				AttestationAggregationStrategy: string(MaxRewardAggregation),
+			})
+			defer resetCfg()
+			runner()
+		})
 	}
 
 	t.Run("invalid strategy", func(t *testing.T) {
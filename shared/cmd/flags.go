@@ -119,6 +119,12 @@ var (
 		Usage: "The port used by libp2p.",
 		Value: 13000,
 	}
+	// P2PQUICPort defines the UDP port to be used by the libp2p QUIC transport.
+	P2PQUICPort = &cli.IntFlag{
+		Name:  "p2p-quic-port",
+		Usage: "The UDP port used by the libp2p QUIC transport, which is only available in beacon nodes built with Go 1.15. QUIC is disabled when set to 0.",
+		Value: 0,
+	}
 	// P2PIP defines the local IP to be used by libp2p.
 	P2PIP = &cli.StringFlag{
 		Name:  "p2p-local-ip",
@@ -287,6 +287,19 @@ func CopyValidator(val *ethpb.Validator) *ethpb.Validator {
 	}
 }
 
+// CopySyncCommitteeMessage copies the provided sync committee message object.
+func CopySyncCommitteeMessage(s *prysmv2.SyncCommitteeMessage) *prysmv2.SyncCommitteeMessage {
+	if s == nil {
+		return nil
+	}
+	return &prysmv2.SyncCommitteeMessage{
+		Slot:           s.Slot,
+		BlockRoot:      bytesutil.SafeCopyBytes(s.BlockRoot),
+		ValidatorIndex: s.ValidatorIndex,
+		Signature:      bytesutil.SafeCopyBytes(s.Signature),
+	}
+}
+
 // CopySyncCommitteeContribution copies the provided sync committee contribution object.
 func CopySyncCommitteeContribution(c *prysmv2.SyncCommitteeContribution) *prysmv2.SyncCommitteeContribution {
 	if c == nil {
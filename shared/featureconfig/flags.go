@@ -47,7 +47,8 @@ var (
 	enableExternalSlasherProtectionFlag = &cli.BoolFlag{
 		Name: "enable-external-slasher-protection",
 		Usage: "Enables the validator to connect to external slasher to prevent it from " +
-			"transmitting a slashable offence over the network.",
+			"transmitting a slashable offence over the network. Uses the slasher API of the beacon node " +
+			"instead of --slasher-rpc-provider when --slasher-beacon-node-api is set.",
 	}
 	disableLookbackFlag = &cli.BoolFlag{
 		Name:  "disable-lookback",
@@ -58,8 +59,9 @@ var (
 		Usage: "Disables displaying logs for newly connected grpc clients",
 	}
 	attestationAggregationStrategy = &cli.StringFlag{
-		Name:  "attestation-aggregation-strategy",
-		Usage: "Which strategy to use when aggregating attestations, one of: naive, max_cover, opt_max_cover.",
+		Name: "attestation-aggregation-strategy",
+		Usage: "Which strategy to use when aggregating attestations, one of: naive, max_cover, opt_max_cover, max_reward. " +
+			"The max_reward strategy packs attestations into proposed blocks by the rewards they bring.",
 		Value: "max_cover",
 	}
 	forceOptMaxCoverAggregationStategy = &cli.BoolFlag{
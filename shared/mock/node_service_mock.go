@@ -37,6 +37,46 @@ func (m *MockNodeClient) EXPECT() *MockNodeClientMockRecorder {
 	return m.recorder
 }
 
+// BanPeer mocks base method
+func (m *MockNodeClient) BanPeer(arg0 context.Context, arg1 *eth.BanPeerRequest, arg2 ...grpc.CallOption) (*emptypb.Empty, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "BanPeer", varargs...)
+	ret0, _ := ret[0].(*emptypb.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BanPeer indicates an expected call of BanPeer
+func (mr *MockNodeClientMockRecorder) BanPeer(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BanPeer", reflect.TypeOf((*MockNodeClient)(nil).BanPeer), varargs...)
+}
+
+// DisconnectPeer mocks base method
+func (m *MockNodeClient) DisconnectPeer(arg0 context.Context, arg1 *eth.PeerRequest, arg2 ...grpc.CallOption) (*emptypb.Empty, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DisconnectPeer", varargs...)
+	ret0, _ := ret[0].(*emptypb.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DisconnectPeer indicates an expected call of DisconnectPeer
+func (mr *MockNodeClientMockRecorder) DisconnectPeer(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisconnectPeer", reflect.TypeOf((*MockNodeClient)(nil).DisconnectPeer), varargs...)
+}
+
 // GetGenesis mocks base method
 func (m *MockNodeClient) GetGenesis(arg0 context.Context, arg1 *emptypb.Empty, arg2 ...grpc.CallOption) (*eth.Genesis, error) {
 	m.ctrl.T.Helper()
@@ -137,6 +177,26 @@ func (mr *MockNodeClientMockRecorder) GetVersion(arg0, arg1 interface{}, arg2 ..
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVersion", reflect.TypeOf((*MockNodeClient)(nil).GetVersion), varargs...)
 }
 
+// ListGossipScores mocks base method
+func (m *MockNodeClient) ListGossipScores(arg0 context.Context, arg1 *eth.GossipScoresRequest, arg2 ...grpc.CallOption) (*eth.GossipScores, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListGossipScores", varargs...)
+	ret0, _ := ret[0].(*eth.GossipScores)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGossipScores indicates an expected call of ListGossipScores
+func (mr *MockNodeClientMockRecorder) ListGossipScores(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGossipScores", reflect.TypeOf((*MockNodeClient)(nil).ListGossipScores), varargs...)
+}
+
 // ListImplementedServices mocks base method
 func (m *MockNodeClient) ListImplementedServices(arg0 context.Context, arg1 *emptypb.Empty, arg2 ...grpc.CallOption) (*eth.ImplementedServices, error) {
 	m.ctrl.T.Helper()
@@ -157,6 +217,26 @@ func (mr *MockNodeClientMockRecorder) ListImplementedServices(arg0, arg1 interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListImplementedServices", reflect.TypeOf((*MockNodeClient)(nil).ListImplementedServices), varargs...)
 }
 
+// ListPeerBans mocks base method
+func (m *MockNodeClient) ListPeerBans(arg0 context.Context, arg1 *emptypb.Empty, arg2 ...grpc.CallOption) (*eth.PeerBans, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListPeerBans", varargs...)
+	ret0, _ := ret[0].(*eth.PeerBans)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPeerBans indicates an expected call of ListPeerBans
+func (mr *MockNodeClientMockRecorder) ListPeerBans(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPeerBans", reflect.TypeOf((*MockNodeClient)(nil).ListPeerBans), varargs...)
+}
+
 // ListPeers mocks base method
 func (m *MockNodeClient) ListPeers(arg0 context.Context, arg1 *emptypb.Empty, arg2 ...grpc.CallOption) (*eth.Peers, error) {
 	m.ctrl.T.Helper()
@@ -176,3 +256,83 @@ func (mr *MockNodeClientMockRecorder) ListPeers(arg0, arg1 interface{}, arg2 ...
 	varargs := append([]interface{}{arg0, arg1}, arg2...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPeers", reflect.TypeOf((*MockNodeClient)(nil).ListPeers), varargs...)
 }
+
+// ListTrustedPeers mocks base method
+func (m *MockNodeClient) ListTrustedPeers(arg0 context.Context, arg1 *emptypb.Empty, arg2 ...grpc.CallOption) (*eth.TrustedPeers, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListTrustedPeers", varargs...)
+	ret0, _ := ret[0].(*eth.TrustedPeers)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTrustedPeers indicates an expected call of ListTrustedPeers
+func (mr *MockNodeClientMockRecorder) ListTrustedPeers(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTrustedPeers", reflect.TypeOf((*MockNodeClient)(nil).ListTrustedPeers), varargs...)
+}
+
+// TrustPeer mocks base method
+func (m *MockNodeClient) TrustPeer(arg0 context.Context, arg1 *eth.TrustPeerRequest, arg2 ...grpc.CallOption) (*emptypb.Empty, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "TrustPeer", varargs...)
+	ret0, _ := ret[0].(*emptypb.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TrustPeer indicates an expected call of TrustPeer
+func (mr *MockNodeClientMockRecorder) TrustPeer(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrustPeer", reflect.TypeOf((*MockNodeClient)(nil).TrustPeer), varargs...)
+}
+
+// UnbanPeer mocks base method
+func (m *MockNodeClient) UnbanPeer(arg0 context.Context, arg1 *eth.UnbanPeerRequest, arg2 ...grpc.CallOption) (*emptypb.Empty, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UnbanPeer", varargs...)
+	ret0, _ := ret[0].(*emptypb.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnbanPeer indicates an expected call of UnbanPeer
+func (mr *MockNodeClientMockRecorder) UnbanPeer(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnbanPeer", reflect.TypeOf((*MockNodeClient)(nil).UnbanPeer), varargs...)
+}
+
+// UntrustPeer mocks base method
+func (m *MockNodeClient) UntrustPeer(arg0 context.Context, arg1 *eth.PeerRequest, arg2 ...grpc.CallOption) (*emptypb.Empty, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UntrustPeer", varargs...)
+	ret0, _ := ret[0].(*emptypb.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UntrustPeer indicates an expected call of UntrustPeer
+func (mr *MockNodeClientMockRecorder) UntrustPeer(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UntrustPeer", reflect.TypeOf((*MockNodeClient)(nil).UntrustPeer), varargs...)
+}
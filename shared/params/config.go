@@ -58,6 +58,8 @@ type BeaconChainConfig struct {
 	Eth1FollowDistance               uint64      `yaml:"ETH1_FOLLOW_DISTANCE" spec:"true"`                // Eth1FollowDistance is the number of eth1.0 blocks to wait before considering a new deposit for voting. This only applies after the chain as been started.
 	SafeSlotsToUpdateJustified       types.Slot  `yaml:"SAFE_SLOTS_TO_UPDATE_JUSTIFIED" spec:"true"`      // SafeSlotsToUpdateJustified is the minimal slots needed to update justified check point.
 	SecondsPerETH1Block              uint64      `yaml:"SECONDS_PER_ETH1_BLOCK" spec:"true"`              // SecondsPerETH1Block is the approximate time for a single eth1 block to be produced.
+	IntervalsPerSlot                 uint64      `yaml:"INTERVALS_PER_SLOT" spec:"true"`                  // IntervalsPerSlot defines the number of fork choice intervals in a slot defined in the fork choice spec.
+	ProposerScoreBoost               uint64      `yaml:"PROPOSER_SCORE_BOOST" spec:"true"`                // ProposerScoreBoost defines the percentage of the committee weight given to a timely proposal in fork choice.
 
 	// Ethereum PoW parameters.
 	DepositChainID         uint64 `yaml:"DEPOSIT_CHAIN_ID" spec:"true"`         // DepositChainID of the eth1 network. This used for replay protection.
@@ -99,20 +101,21 @@ type BeaconChainConfig struct {
 	DomainAggregateAndProof [4]byte `yaml:"DOMAIN_AGGREGATE_AND_PROOF" spec:"true"` // DomainAggregateAndProof defines the BLS signature domain for aggregate and proof.
 
 	// Prysm constants.
-	GweiPerEth                uint64        // GweiPerEth is the amount of gwei corresponding to 1 eth.
-	BLSSecretKeyLength        int           // BLSSecretKeyLength defines the expected length of BLS secret keys in bytes.
-	BLSPubkeyLength           int           // BLSPubkeyLength defines the expected length of BLS public keys in bytes.
-	BLSSignatureLength        int           // BLSSignatureLength defines the expected length of BLS signatures in bytes.
-	DefaultBufferSize         int           // DefaultBufferSize for channels across the Prysm repository.
-	ValidatorPrivkeyFileName  string        // ValidatorPrivKeyFileName specifies the string name of a validator private key file.
-	WithdrawalPrivkeyFileName string        // WithdrawalPrivKeyFileName specifies the string name of a withdrawal private key file.
-	RPCSyncCheck              time.Duration // Number of seconds to query the sync service, to find out if the node is synced or not.
-	EmptySignature            [96]byte      // EmptySignature is used to represent a zeroed out BLS Signature.
-	DefaultPageSize           int           // DefaultPageSize defines the default page size for RPC server request.
-	MaxPeersToSync            int           // MaxPeersToSync describes the limit for number of peers in round robin sync.
-	SlotsPerArchivedPoint     types.Slot    // SlotsPerArchivedPoint defines the number of slots per one archived point.
-	GenesisCountdownInterval  time.Duration // How often to log the countdown until the genesis time is reached.
-	BeaconStateFieldCount     int           // BeaconStateFieldCount defines how many fields are in beacon state.
+	GweiPerEth                  uint64        // GweiPerEth is the amount of gwei corresponding to 1 eth.
+	BLSSecretKeyLength          int           // BLSSecretKeyLength defines the expected length of BLS secret keys in bytes.
+	BLSPubkeyLength             int           // BLSPubkeyLength defines the expected length of BLS public keys in bytes.
+	BLSSignatureLength          int           // BLSSignatureLength defines the expected length of BLS signatures in bytes.
+	DefaultBufferSize           int           // DefaultBufferSize for channels across the Prysm repository.
+	ValidatorPrivkeyFileName    string        // ValidatorPrivKeyFileName specifies the string name of a validator private key file.
+	WithdrawalPrivkeyFileName   string        // WithdrawalPrivKeyFileName specifies the string name of a withdrawal private key file.
+	RPCSyncCheck                time.Duration // Number of seconds to query the sync service, to find out if the node is synced or not.
+	EmptySignature              [96]byte      // EmptySignature is used to represent a zeroed out BLS Signature.
+	DefaultPageSize             int           // DefaultPageSize defines the default page size for RPC server request.
+	MaxPeersToSync              int           // MaxPeersToSync describes the limit for number of peers in round robin sync.
+	SlotsPerArchivedPoint       types.Slot    // SlotsPerArchivedPoint defines the number of slots per one archived point.
+	GenesisCountdownInterval    time.Duration // How often to log the countdown until the genesis time is reached.
+	BeaconStateFieldCount       int           // BeaconStateFieldCount defines how many fields are in beacon state.
+	BeaconStateAltairFieldCount int           // BeaconStateAltairFieldCount defines how many fields are in the Altair beacon state.
 
 	// Slasher constants.
 	WeakSubjectivityPeriod    types.Epoch // WeakSubjectivityPeriod defines the time period expressed in number of epochs were proof of stake network should validate block headers and attestations for slashable events.
@@ -129,4 +132,34 @@ type BeaconChainConfig struct {
 
 	// Weak subjectivity values.
 	SafetyDecay uint64 // SafetyDecay is defined as the loss in the 1/3 consensus safety margin of the casper FFG mechanism.
+
+	// Altair fork values.
+	AltairForkVersion                    []byte      `yaml:"ALTAIR_FORK_VERSION" spec:"true"`                      // AltairForkVersion is used to represent the fork version for Altair.
+	AltairForkEpoch                      types.Epoch `yaml:"ALTAIR_FORK_EPOCH" spec:"true"`                        // AltairForkEpoch is used to represent the assigned fork epoch for Altair.
+	SyncCommitteeSize                    uint64      `yaml:"SYNC_COMMITTEE_SIZE" spec:"true"`                      // SyncCommitteeSize is the number of validators in a sync committee.
+	EpochsPerSyncCommitteePeriod         types.Epoch `yaml:"EPOCHS_PER_SYNC_COMMITTEE_PERIOD" spec:"true"`         // EpochsPerSyncCommitteePeriod defines how many epochs a sync committee serves for.
+	InactivityScoreBias                  uint64      `yaml:"INACTIVITY_SCORE_BIAS" spec:"true"`                    // InactivityScoreBias is the bias applied to inactivity scores of non-participating validators.
+	InactivityScoreRecoveryRate          uint64      `yaml:"INACTIVITY_SCORE_RECOVERY_RATE" spec:"true"`           // InactivityScoreRecoveryRate is the rate at which inactivity scores recover outside of a leak.
+	InactivityPenaltyQuotientAltair      uint64      `yaml:"INACTIVITY_PENALTY_QUOTIENT_ALTAIR" spec:"true"`       // InactivityPenaltyQuotientAltair is the Altair replacement of InactivityPenaltyQuotient.
+	MinSlashingPenaltyQuotientAltair     uint64      `yaml:"MIN_SLASHING_PENALTY_QUOTIENT_ALTAIR" spec:"true"`     // MinSlashingPenaltyQuotientAltair is the Altair replacement of MinSlashingPenaltyQuotient.
+	ProportionalSlashingMultiplierAltair uint64      `yaml:"PROPORTIONAL_SLASHING_MULTIPLIER_ALTAIR" spec:"true"`  // ProportionalSlashingMultiplierAltair is the Altair replacement of ProportionalSlashingMultiplier.
+	MinSyncCommitteeParticipants         uint64      `yaml:"MIN_SYNC_COMMITTEE_PARTICIPANTS" spec:"true"`          // MinSyncCommitteeParticipants is the minimum number of participants for a valid sync aggregate.
+	TargetAggregatorsPerSyncSubcommittee uint64      `yaml:"TARGET_AGGREGATORS_PER_SYNC_SUBCOMMITTEE" spec:"true"` // TargetAggregatorsPerSyncSubcommittee defines the number of aggregators inside one sync subcommittee.
+	SyncCommitteeSubnetCount             uint64      `yaml:"SYNC_COMMITTEE_SUBNET_COUNT" spec:"true"`              // SyncCommitteeSubnetCount defines the number of sync committee subnets.
+
+	// Altair participation flags and reward weights.
+	TimelySourceFlagIndex uint8  `yaml:"TIMELY_SOURCE_FLAG_INDEX" spec:"true"` // TimelySourceFlagIndex is the participation flag index for a timely source vote.
+	TimelyTargetFlagIndex uint8  `yaml:"TIMELY_TARGET_FLAG_INDEX" spec:"true"` // TimelyTargetFlagIndex is the participation flag index for a timely target vote.
+	TimelyHeadFlagIndex   uint8  `yaml:"TIMELY_HEAD_FLAG_INDEX" spec:"true"`   // TimelyHeadFlagIndex is the participation flag index for a timely head vote.
+	TimelySourceWeight    uint64 `yaml:"TIMELY_SOURCE_WEIGHT" spec:"true"`     // TimelySourceWeight is the reward weight of a timely source vote.
+	TimelyTargetWeight    uint64 `yaml:"TIMELY_TARGET_WEIGHT" spec:"true"`     // TimelyTargetWeight is the reward weight of a timely target vote.
+	TimelyHeadWeight      uint64 `yaml:"TIMELY_HEAD_WEIGHT" spec:"true"`       // TimelyHeadWeight is the reward weight of a timely head vote.
+	SyncRewardWeight      uint64 `yaml:"SYNC_REWARD_WEIGHT" spec:"true"`       // SyncRewardWeight is the reward weight of sync committee participation.
+	ProposerWeight        uint64 `yaml:"PROPOSER_WEIGHT" spec:"true"`          // ProposerWeight is the reward weight of block proposals.
+	WeightDenominator     uint64 `yaml:"WEIGHT_DENOMINATOR" spec:"true"`       // WeightDenominator is the sum of all reward weights.
+
+	// Altair BLS domain values.
+	DomainSyncCommittee               [4]byte `yaml:"DOMAIN_SYNC_COMMITTEE" spec:"true"`                 // DomainSyncCommittee defines the BLS signature domain for sync committee messages.
+	DomainSyncCommitteeSelectionProof [4]byte `yaml:"DOMAIN_SYNC_COMMITTEE_SELECTION_PROOF" spec:"true"` // DomainSyncCommitteeSelectionProof defines the BLS signature domain for sync committee selection proofs.
+	DomainContributionAndProof        [4]byte `yaml:"DOMAIN_CONTRIBUTION_AND_PROOF" spec:"true"`         // DomainContributionAndProof defines the BLS signature domain for contribution and proofs.
 }
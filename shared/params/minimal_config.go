@@ -75,6 +75,14 @@ func MinimalSpecConfig() *BeaconChainConfig {
 	minimalConfig.DomainVoluntaryExit = bytesutil.ToBytes4(bytesutil.Bytes4(4))
 	minimalConfig.GenesisForkVersion = []byte{0, 0, 0, 1}
 
+	// Altair
+	minimalConfig.AltairForkVersion = []byte{1, 0, 0, 1}
+	minimalConfig.SyncCommitteeSize = 32
+	minimalConfig.EpochsPerSyncCommitteePeriod = 8
+	minimalConfig.DomainSyncCommittee = bytesutil.ToBytes4(bytesutil.Bytes4(7))
+	minimalConfig.DomainSyncCommitteeSelectionProof = bytesutil.ToBytes4(bytesutil.Bytes4(8))
+	minimalConfig.DomainContributionAndProof = bytesutil.ToBytes4(bytesutil.Bytes4(9))
+
 	minimalConfig.DepositContractTreeDepth = 32
 	minimalConfig.FarFutureEpoch = 1<<64 - 1
 	minimalConfig.FarFutureSlot = 1<<64 - 1
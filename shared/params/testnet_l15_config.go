@@ -74,6 +74,8 @@ func L15Config() *BeaconChainConfig {
 		MinEpochsToInactivityPenalty:     4,
 		Eth1FollowDistance:               2048,
 		SafeSlotsToUpdateJustified:       8,
+		IntervalsPerSlot:                 3,
+		ProposerScoreBoost:               70,
 
 		// Ethereum PoW parameters.
 		DepositChainID:         808081, // Chain ID of eth1 mainnet.
@@ -123,21 +125,22 @@ func L15Config() *BeaconChainConfig {
 		DomainAggregateAndProof: bytesutil.ToBytes4(bytesutil.Bytes4(6)),
 
 		// Prysm constants.
-		GweiPerEth:                1000000000,
-		BLSSecretKeyLength:        32,
-		BLSPubkeyLength:           48,
-		BLSSignatureLength:        96,
-		DefaultBufferSize:         10000,
-		WithdrawalPrivkeyFileName: "/shardwithdrawalkey",
-		ValidatorPrivkeyFileName:  "/validatorprivatekey",
-		RPCSyncCheck:              1,
-		EmptySignature:            [96]byte{},
-		DefaultPageSize:           250,
-		MaxPeersToSync:            15,
-		SlotsPerArchivedPoint:     2048,
-		GenesisCountdownInterval:  time.Minute,
-		ConfigName:                ConfigNames[Mainnet],
-		BeaconStateFieldCount:     21,
+		GweiPerEth:                  1000000000,
+		BLSSecretKeyLength:          32,
+		BLSPubkeyLength:             48,
+		BLSSignatureLength:          96,
+		DefaultBufferSize:           10000,
+		WithdrawalPrivkeyFileName:   "/shardwithdrawalkey",
+		ValidatorPrivkeyFileName:    "/validatorprivatekey",
+		RPCSyncCheck:                1,
+		EmptySignature:              [96]byte{},
+		DefaultPageSize:             250,
+		MaxPeersToSync:              15,
+		SlotsPerArchivedPoint:       2048,
+		GenesisCountdownInterval:    time.Minute,
+		ConfigName:                  ConfigNames[Mainnet],
+		BeaconStateFieldCount:       21,
+		BeaconStateAltairFieldCount: 24,
 
 		// Slasher related values.
 		WeakSubjectivityPeriod:          54000,
@@ -154,6 +157,36 @@ func L15Config() *BeaconChainConfig {
 		ForkVersionSchedule: map[types.Epoch][]byte{
 			// Any further forks must be specified here by their epoch number.
 		},
+
+		// Altair fork values.
+		AltairForkVersion:                    []byte{1, 0, 0, 0},
+		AltairForkEpoch:                      1<<64 - 1, // Set to FarFutureEpoch until Altair is scheduled.
+		SyncCommitteeSize:                    512,
+		EpochsPerSyncCommitteePeriod:         256,
+		InactivityScoreBias:                  4,
+		InactivityScoreRecoveryRate:          16,
+		InactivityPenaltyQuotientAltair:      3 * 1 << 24,
+		MinSlashingPenaltyQuotientAltair:     64,
+		ProportionalSlashingMultiplierAltair: 2,
+		MinSyncCommitteeParticipants:         1,
+		TargetAggregatorsPerSyncSubcommittee: 16,
+		SyncCommitteeSubnetCount:             4,
+
+		// Altair participation flags and reward weights.
+		TimelySourceFlagIndex: 0,
+		TimelyTargetFlagIndex: 1,
+		TimelyHeadFlagIndex:   2,
+		TimelySourceWeight:    14,
+		TimelyTargetWeight:    26,
+		TimelyHeadWeight:      14,
+		SyncRewardWeight:      2,
+		ProposerWeight:        8,
+		WeightDenominator:     64,
+
+		// Altair BLS domain values.
+		DomainSyncCommittee:               bytesutil.ToBytes4(bytesutil.Bytes4(7)),
+		DomainSyncCommitteeSelectionProof: bytesutil.ToBytes4(bytesutil.Bytes4(8)),
+		DomainContributionAndProof:        bytesutil.ToBytes4(bytesutil.Bytes4(9)),
 	}
 	return cfg
 }
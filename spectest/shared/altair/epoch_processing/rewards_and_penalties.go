@@ -0,0 +1,30 @@
+package epoch_processing
+
+import (
+	"path"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/altair"
+	iface "github.com/prysmaticlabs/prysm/beacon-chain/state/interface"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	"github.com/prysmaticlabs/prysm/spectest/utils"
+)
+
+// RunRewardsAndPenaltiesTests executes "epoch_processing/rewards_and_penalties" tests.
+func RunRewardsAndPenaltiesTests(t *testing.T, config string) {
+	require.NoError(t, utils.SetConfig(t, config))
+
+	testFolders, testsFolderPath := utils.TestFolders(t, config, "altair", "epoch_processing/rewards_and_penalties/pyspec_tests")
+	for _, folder := range testFolders {
+		t.Run(folder.Name(), func(t *testing.T) {
+			folderPath := path.Join(testsFolderPath, folder.Name())
+			RunEpochOperationTest(t, folderPath, processRewardsAndPenaltiesWrapper)
+		})
+	}
+}
+
+func processRewardsAndPenaltiesWrapper(t *testing.T, state iface.BeaconStateAltair) (iface.BeaconStateAltair, error) {
+	state, err := altair.ProcessRewardsAndPenalties(state)
+	require.NoError(t, err, "Could not process rewards and penalties")
+	return state, nil
+}
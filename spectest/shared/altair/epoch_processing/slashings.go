@@ -0,0 +1,30 @@
+package epoch_processing
+
+import (
+	"path"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/altair"
+	iface "github.com/prysmaticlabs/prysm/beacon-chain/state/interface"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	"github.com/prysmaticlabs/prysm/spectest/utils"
+)
+
+// RunSlashingsTests executes "epoch_processing/slashings" tests.
+func RunSlashingsTests(t *testing.T, config string) {
+	require.NoError(t, utils.SetConfig(t, config))
+
+	testFolders, testsFolderPath := utils.TestFolders(t, config, "altair", "epoch_processing/slashings/pyspec_tests")
+	for _, folder := range testFolders {
+		t.Run(folder.Name(), func(t *testing.T) {
+			folderPath := path.Join(testsFolderPath, folder.Name())
+			RunEpochOperationTest(t, folderPath, processSlashingsWrapper)
+		})
+	}
+}
+
+func processSlashingsWrapper(t *testing.T, state iface.BeaconStateAltair) (iface.BeaconStateAltair, error) {
+	state, err := altair.ProcessSlashings(state)
+	require.NoError(t, err, "Could not process slashings")
+	return state, nil
+}
@@ -0,0 +1,57 @@
+package fork
+
+import (
+	"context"
+	"path"
+	"testing"
+
+	"github.com/golang/snappy"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/altair"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/v1"
+	stateAltair "github.com/prysmaticlabs/prysm/beacon-chain/state/v2"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	"github.com/prysmaticlabs/prysm/spectest/utils"
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/d4l3k/messagediff.v1"
+)
+
+// RunUpgradeToAltair executes "fork/upgrade_to_altair" tests.
+func RunUpgradeToAltair(t *testing.T, config string) {
+	require.NoError(t, utils.SetConfig(t, config))
+
+	testFolders, testsFolderPath := utils.TestFolders(t, config, "altair", "fork/fork/pyspec_tests")
+	for _, folder := range testFolders {
+		t.Run(folder.Name(), func(t *testing.T) {
+			folderPath := path.Join(testsFolderPath, folder.Name())
+
+			preStateFile, err := testutil.BazelFileBytes(path.Join(folderPath, "pre.ssz_snappy"))
+			require.NoError(t, err)
+			preStateSSZ, err := snappy.Decode(nil /* dst */, preStateFile)
+			require.NoError(t, err, "Failed to decompress")
+			preStateBase := &pb.BeaconState{}
+			require.NoError(t, preStateBase.UnmarshalSSZ(preStateSSZ), "Failed to unmarshal")
+			preState, err := v1.InitializeFromProto(preStateBase)
+			require.NoError(t, err)
+
+			postState, err := altair.UpgradeToAltair(context.Background(), preState)
+			require.NoError(t, err)
+			postStateFromFunction, err := stateAltair.ProtobufBeaconState(postState.InnerStateUnsafe())
+			require.NoError(t, err)
+
+			postStateFile, err := testutil.BazelFileBytes(path.Join(folderPath, "post.ssz_snappy"))
+			require.NoError(t, err)
+			postStateSSZ, err := snappy.Decode(nil /* dst */, postStateFile)
+			require.NoError(t, err, "Failed to decompress")
+			postStateFromFile := &pb.BeaconStateAltair{}
+			require.NoError(t, postStateFromFile.UnmarshalSSZ(postStateSSZ), "Failed to unmarshal")
+
+			if !proto.Equal(postStateFromFile, postStateFromFunction) {
+				diff, _ := messagediff.PrettyDiff(postStateFromFile, postStateFromFunction)
+				t.Log(diff)
+				t.Fatal("Post state does not match expected")
+			}
+		})
+	}
+}
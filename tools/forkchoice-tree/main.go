@@ -0,0 +1,96 @@
+/**
+ * Fork choice tree renderer
+ *
+ * Fetches the fork choice tree of a beacon node from its gRPC debug API, enabled with
+ * --enable-debug-rpc-endpoints, and renders it as JSON or in the Graphviz DOT language.
+ * Each block is annotated with its weight, justified and finalized epochs, Pandora shard
+ * block number and orchestrator confirmation status. Blocks rejected by the orchestrator
+ * are drawn in red next to the block they were built on.
+ *
+ * Example: forkchoice-tree --endpoint 127.0.0.1:4000 --output tree.dot && dot -Tsvg tree.dot > tree.svg
+ * A tree saved as JSON can be rendered again with: forkchoice-tree --input tree.json
+ */
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/rpc/eth/v1/debug"
+	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+var (
+	endpoint = flag.String("endpoint", "127.0.0.1:4000", "gRPC address of the beacon node.")
+	input    = flag.String("input", "", "Path to a fork choice tree saved as JSON, rendered instead of fetching the tree of a beacon node.")
+	format   = flag.String("format", "dot", "Output format, dot or json.")
+	output   = flag.String("output", "", "Path to the output file. The tree is written to the standard output by default.")
+)
+
+var log = logrus.WithField("prefix", "forkchoice_tree")
+
+func main() {
+	flag.Parse()
+	if *format != "dot" && *format != "json" {
+		log.Fatalf("Unknown format %s, expected dot or json", *format)
+	}
+
+	tree, err := loadTree()
+	if err != nil {
+		log.WithError(err).Fatal("Could not load fork choice tree")
+	}
+
+	var out []byte
+	if *format == "dot" {
+		out = []byte(debug.ForkChoiceTreeDot(tree))
+	} else {
+		out, err = protojson.MarshalOptions{Multiline: true, Indent: "  "}.Marshal(tree)
+		if err != nil {
+			log.WithError(err).Fatal("Could not marshal fork choice tree")
+		}
+	}
+
+	if *output == "" {
+		fmt.Println(string(out))
+		return
+	}
+	if err := ioutil.WriteFile(*output, out, params.BeaconIoConfig().ReadWritePermissions); err != nil {
+		log.WithError(err).Fatal("Could not write fork choice tree")
+	}
+}
+
+func loadTree() (*ethpb.ForkChoiceTree, error) {
+	tree := &ethpb.ForkChoiceTree{}
+	if *input != "" {
+		enc, err := ioutil.ReadFile(*input)
+		if err != nil {
+			return nil, err
+		}
+		return tree, protojson.Unmarshal(enc, tree)
+	}
+
+	conn, err := grpc.Dial(*endpoint, grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			log.WithError(err).Error("Could not close gRPC connection")
+		}
+	}()
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	resp, err := ethpb.NewBeaconDebugClient(conn).GetForkChoiceTree(ctx, &emptypb.Empty{})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/prysmaticlabs/prysm/shared/fileutil"
 	"github.com/prysmaticlabs/prysm/shared/promptutil"
 	"github.com/prysmaticlabs/prysm/validator/keymanager/remote"
+	"github.com/prysmaticlabs/prysm/validator/keymanager/web3signer"
 	"github.com/urfave/cli/v2"
 )
 
@@ -141,6 +142,38 @@ func InputRemoteKeymanagerConfig(cliCtx *cli.Context) (*remote.KeymanagerOpts, e
 	return newCfg, nil
 }
 
+// InputWeb3SignerKeymanagerConfig via the cli.
+func InputWeb3SignerKeymanagerConfig(cliCtx *cli.Context) (*web3signer.KeymanagerOpts, error) {
+	baseURL := cliCtx.String(flags.Web3SignerURLFlag.Name)
+	genesisValidatorsRoot := cliCtx.String(flags.Web3SignerGenesisValidatorsRootFlag.Name)
+	log.Info("Input desired configuration")
+	var err error
+	if baseURL == "" {
+		baseURL, err = promptutil.ValidatePrompt(
+			os.Stdin,
+			"Web3Signer URL (such as http://localhost:9000)",
+			promptutil.NotEmpty)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if genesisValidatorsRoot == "" {
+		genesisValidatorsRoot, err = promptutil.ValidatePrompt(
+			os.Stdin,
+			"Hex encoded genesis validators root of the chain (such as 0x0470...)",
+			promptutil.NotEmpty)
+		if err != nil {
+			return nil, err
+		}
+	}
+	newCfg := &web3signer.KeymanagerOpts{
+		BaseURL:               strings.TrimSpace(baseURL),
+		GenesisValidatorsRoot: strings.TrimSpace(genesisValidatorsRoot),
+	}
+	fmt.Printf("%s\n", newCfg)
+	return newCfg, nil
+}
+
 func validateCertPath(input string) error {
 	if input == "" {
 		return errors.New("crt path cannot be empty")
@@ -8,6 +8,7 @@ import (
 	"github.com/prysmaticlabs/prysm/validator/accounts/wallet"
 	"github.com/prysmaticlabs/prysm/validator/keymanager"
 	"github.com/prysmaticlabs/prysm/validator/keymanager/remote"
+	"github.com/prysmaticlabs/prysm/validator/keymanager/web3signer"
 	"github.com/urfave/cli/v2"
 )
 
@@ -49,6 +50,30 @@ func EditWalletConfigurationCli(cliCtx *cli.Context) error {
 		if err := w.WriteKeymanagerConfigToDisk(cliCtx.Context, encodedCfg); err != nil {
 			return errors.Wrap(err, "could not write config to disk")
 		}
+	case keymanager.Web3Signer:
+		enc, err := w.ReadKeymanagerConfigFromDisk(cliCtx.Context)
+		if err != nil {
+			return errors.Wrap(err, "could not read config")
+		}
+		opts, err := web3signer.UnmarshalOptionsFile(enc)
+		if err != nil {
+			return errors.Wrap(err, "could not unmarshal config")
+		}
+		log.Info("Current configuration")
+		// Prints the current configuration to stdout.
+		fmt.Println(opts)
+		newCfg, err := prompt.InputWeb3SignerKeymanagerConfig(cliCtx)
+		if err != nil {
+			return errors.Wrap(err, "could not get keymanager config")
+		}
+		newCfg.PollingIntervalSeconds = opts.PollingIntervalSeconds
+		encodedCfg, err := web3signer.MarshalOptionsFile(cliCtx.Context, newCfg)
+		if err != nil {
+			return errors.Wrap(err, "could not marshal config file")
+		}
+		if err := w.WriteKeymanagerConfigToDisk(cliCtx.Context, encodedCfg); err != nil {
+			return errors.Wrap(err, "could not write config to disk")
+		}
 	default:
 		return fmt.Errorf(errKeymanagerNotSupported, w.KeymanagerKind())
 	}
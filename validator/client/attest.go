@@ -98,6 +98,9 @@ func (v *validator) SubmitAttestation(ctx context.Context, slot types.Slot, pubK
 		return
 	}
 
+	// The signing lock is held until the attestation is saved in the slashing protection history.
+	v.signingLock.RLock()
+	defer v.signingLock.RUnlock()
 	sig, _, err := v.signAtt(ctx, pubKey, data)
 	if err != nil {
 		log.WithError(err).Error("Could not sign attestation")
@@ -143,7 +146,7 @@ func (v *validator) SubmitAttestation(ctx context.Context, slot types.Slot, pubK
 		traceutil.AnnotateError(span, err)
 		return
 	}
-	attResp, err := v.validatorClient.ProposeAttestation(ctx, attestation)
+	attResp, err := v.signedMessageClient().ProposeAttestation(ctx, attestation)
 	if err != nil {
 		log.WithError(err).Error("Could not submit attestation to beacon node")
 		if v.emitAccountMetrics {
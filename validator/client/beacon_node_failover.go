@@ -0,0 +1,331 @@
+package client
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	grpc_retry "github.com/grpc-ecosystem/go-grpc-middleware/retry"
+	types "github.com/prysmaticlabs/eth2-types"
+	ethpbv1 "github.com/prysmaticlabs/prysm/proto/eth/v1"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const (
+	// Interval between health checks when none is configured.
+	defaultHealthCheckInterval = 5 * time.Second
+	// Time given to a beacon node to answer a health check.
+	healthCheckTimeout = 3 * time.Second
+	// Number of consecutive failed requests after which the active beacon node is considered broken.
+	maxConsecutiveFailures = 3
+	// Number of health checks during which a broken beacon node is considered unavailable.
+	penalizedHealthChecks = 5
+	// Number of slots the head of the active beacon node may lag behind another healthy node.
+	maxHeadSlotLag = 2
+)
+
+// beaconNodeHealth is the health of a beacon node, as seen by its last health check.
+type beaconNodeHealth int
+
+const (
+	beaconNodeUnavailable beaconNodeHealth = iota
+	beaconNodeSyncing
+	beaconNodeHealthy
+)
+
+// String returns the name of the health status.
+func (h beaconNodeHealth) String() string {
+	switch h {
+	case beaconNodeSyncing:
+		return "syncing"
+	case beaconNodeHealthy:
+		return "healthy"
+	default:
+		return "unavailable"
+	}
+}
+
+type beaconNode struct {
+	endpoint       string
+	conn           grpc.ClientConnInterface
+	health         beaconNodeHealth
+	headSlot       types.Slot
+	failures       uint64
+	penalizedUntil time.Time
+	streams        map[uint64]context.CancelFunc
+}
+
+// failoverConn is a gRPC client connection to one or several beacon nodes. Every request is sent
+// to the active beacon node, which is chosen by health checks on the GetHealth and GetSyncStatus
+// endpoints. The active node is kept as long as no other node is healthier, and is dropped when
+// its requests keep failing even though it reports itself healthy. Streams opened on a node are
+// canceled when switching away from it, so that their consumers subscribe again to the new node.
+type failoverConn struct {
+	nodes         []*beaconNode
+	checkInterval time.Duration
+	lock          sync.Mutex
+	active        int
+	nextStreamID  uint64
+}
+
+func newFailoverConn(endpoints []string, conns []grpc.ClientConnInterface, checkInterval time.Duration) *failoverConn {
+	nodes := make([]*beaconNode, len(conns))
+	for i, conn := range conns {
+		nodes[i] = &beaconNode{
+			endpoint: endpoints[i],
+			conn:     conn,
+			health:   beaconNodeHealthy,
+			streams:  make(map[uint64]context.CancelFunc),
+		}
+	}
+	if checkInterval <= 0 {
+		checkInterval = defaultHealthCheckInterval
+	}
+	f := &failoverConn{
+		nodes:         nodes,
+		checkInterval: checkInterval,
+	}
+	f.updateMetrics()
+	return f
+}
+
+// Invoke sends a unary request to the active beacon node.
+func (f *failoverConn) Invoke(ctx context.Context, method string, args, reply interface{}, opts ...grpc.CallOption) error {
+	idx, conn := f.activeConn()
+	err := conn.Invoke(ctx, method, args, reply, opts...)
+	f.reportResult(ctx, idx, err)
+	return err
+}
+
+// NewStream opens a stream on the active beacon node. The stream is canceled when the active node changes.
+func (f *failoverConn) NewStream(
+	ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption,
+) (grpc.ClientStream, error) {
+	idx, conn := f.activeConn()
+	ctx, cancel := context.WithCancel(ctx)
+	id := f.trackStream(idx, cancel)
+	var once sync.Once
+	done := func() {
+		once.Do(func() {
+			cancel()
+			f.untrackStream(idx, id)
+		})
+	}
+	stream, err := conn.NewStream(ctx, desc, method, opts...)
+	f.reportResult(ctx, idx, err)
+	if err != nil {
+		done()
+		return nil, err
+	}
+	return &failoverStream{ClientStream: stream, done: done}, nil
+}
+
+// Close closes the connections to all the beacon nodes.
+func (f *failoverConn) Close() error {
+	var firstErr error
+	for _, node := range f.nodes {
+		closer, ok := node.conn.(io.Closer)
+		if !ok {
+			continue
+		}
+		if err := closer.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// run checks the health of the beacon nodes at every interval until the context is canceled.
+func (f *failoverConn) run(ctx context.Context) {
+	ticker := time.NewTicker(f.checkInterval)
+	defer ticker.Stop()
+	for {
+		f.checkHealth(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// checkHealth probes every beacon node and switches to the healthiest one when the active node is
+// not healthy anymore.
+func (f *failoverConn) checkHealth(ctx context.Context) {
+	type probe struct {
+		health   beaconNodeHealth
+		headSlot types.Slot
+	}
+	probes := make([]probe, len(f.nodes))
+	var wg sync.WaitGroup
+	for i, node := range f.nodes {
+		wg.Add(1)
+		go func(i int, conn grpc.ClientConnInterface) {
+			defer wg.Done()
+			probes[i].health, probes[i].headSlot = probeBeaconNode(ctx, conn)
+		}(i, node.conn)
+	}
+	wg.Wait()
+
+	f.lock.Lock()
+	for i, node := range f.nodes {
+		if node.health != probes[i].health {
+			log.WithFields(logrus.Fields{
+				"endpoint": node.endpoint,
+				"health":   probes[i].health,
+			}).Info("Beacon node health changed")
+		}
+		node.health = probes[i].health
+		node.headSlot = probes[i].headSlot
+	}
+	f.lock.Unlock()
+	f.selectActive()
+}
+
+func probeBeaconNode(ctx context.Context, conn grpc.ClientConnInterface) (beaconNodeHealth, types.Slot) {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+	client := ethpbv1.NewBeaconNodeClient(conn)
+	if _, err := client.GetHealth(ctx, &emptypb.Empty{}, grpc_retry.Disable()); err != nil {
+		return beaconNodeUnavailable, 0
+	}
+	resp, err := client.GetSyncStatus(ctx, &emptypb.Empty{}, grpc_retry.Disable())
+	if err != nil || resp.Data == nil {
+		return beaconNodeUnavailable, 0
+	}
+	if resp.Data.IsSyncing {
+		return beaconNodeSyncing, resp.Data.HeadSlot
+	}
+	return beaconNodeHealthy, resp.Data.HeadSlot
+}
+
+// selectActive switches to the healthiest beacon node if it is healthier than the active one.
+func (f *failoverConn) selectActive() {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	now := time.Now()
+	best := f.active
+	for i := range f.nodes {
+		if healthier(f.nodes[i], f.nodes[best], now) {
+			best = i
+		}
+	}
+	if best != f.active {
+		previous := f.nodes[f.active]
+		log.WithFields(logrus.Fields{
+			"previous":       previous.endpoint,
+			"previousHealth": previous.healthAt(now),
+			"endpoint":       f.nodes[best].endpoint,
+		}).Warn("Switching to another beacon node")
+		for id, cancel := range previous.streams {
+			cancel()
+			delete(previous.streams, id)
+		}
+		f.active = best
+		ValidatorBeaconNodeSwitchesCounter.Inc()
+	}
+	f.updateMetrics()
+}
+
+// healthier returns whether beacon node a is healthier than beacon node b. Healthy nodes are only
+// considered healthier than each other when their heads are further apart than maxHeadSlotLag.
+func healthier(a, b *beaconNode, now time.Time) bool {
+	ha, hb := a.healthAt(now), b.healthAt(now)
+	if ha != hb {
+		return ha > hb
+	}
+	return ha == beaconNodeHealthy && a.headSlot > b.headSlot+maxHeadSlotLag
+}
+
+// healthAt returns the health of the beacon node, which is unavailable while it is penalized for
+// failing requests.
+func (n *beaconNode) healthAt(now time.Time) beaconNodeHealth {
+	if now.Before(n.penalizedUntil) {
+		return beaconNodeUnavailable
+	}
+	return n.health
+}
+
+// reportResult tracks the consecutive failures of the requests sent to a beacon node, and switches
+// away from the node when there are too many of them.
+func (f *failoverConn) reportResult(ctx context.Context, idx int, err error) {
+	if err != nil && ctx.Err() != nil {
+		// The request was canceled or timed out by the caller.
+		return
+	}
+	switch status.Code(err) {
+	case codes.OK:
+		f.lock.Lock()
+		f.nodes[idx].failures = 0
+		f.lock.Unlock()
+		return
+	case codes.Unavailable, codes.Internal, codes.Unknown, codes.DeadlineExceeded:
+	default:
+		return
+	}
+	f.lock.Lock()
+	node := f.nodes[idx]
+	node.failures++
+	if node.failures < maxConsecutiveFailures {
+		f.lock.Unlock()
+		return
+	}
+	node.failures = 0
+	node.penalizedUntil = time.Now().Add(penalizedHealthChecks * f.checkInterval)
+	f.lock.Unlock()
+	log.WithError(err).WithField("endpoint", node.endpoint).Warn("Beacon node is failing requests")
+	f.selectActive()
+}
+
+func (f *failoverConn) activeConn() (int, grpc.ClientConnInterface) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return f.active, f.nodes[f.active].conn
+}
+
+func (f *failoverConn) trackStream(idx int, cancel context.CancelFunc) uint64 {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.nextStreamID++
+	f.nodes[idx].streams[f.nextStreamID] = cancel
+	return f.nextStreamID
+}
+
+func (f *failoverConn) untrackStream(idx int, id uint64) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	delete(f.nodes[idx].streams, id)
+}
+
+// updateMetrics must be called with the lock held.
+func (f *failoverConn) updateMetrics() {
+	now := time.Now()
+	for i, node := range f.nodes {
+		active := float64(0)
+		if i == f.active {
+			active = 1
+		}
+		ValidatorBeaconNodeActiveGaugeVec.WithLabelValues(node.endpoint).Set(active)
+		ValidatorBeaconNodeHealthGaugeVec.WithLabelValues(node.endpoint).Set(float64(node.healthAt(now)))
+	}
+}
+
+// failoverStream releases the resources of a stream once it has ended.
+type failoverStream struct {
+	grpc.ClientStream
+	done func()
+}
+
+// RecvMsg receives a message from the stream, and releases the stream when it has ended.
+func (s *failoverStream) RecvMsg(m interface{}) error {
+	err := s.ClientStream.RecvMsg(m)
+	if err != nil {
+		s.done()
+	}
+	return err
+}
@@ -0,0 +1,150 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	ethpbv1 "github.com/prysmaticlabs/prysm/proto/eth/v1"
+	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type fakeBeaconNodeConn struct {
+	lock      sync.Mutex
+	healthErr error
+	syncing   bool
+	headSlot  types.Slot
+	callErr   error
+	calls     int
+	attRoot   []byte
+}
+
+func (c *fakeBeaconNodeConn) Invoke(_ context.Context, method string, _, reply interface{}, _ ...grpc.CallOption) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	switch method {
+	case "/ethereum.eth.v1.BeaconNode/GetHealth":
+		return c.healthErr
+	case "/ethereum.eth.v1.BeaconNode/GetSyncStatus":
+		reply.(*ethpbv1.SyncingResponse).Data = &ethpbv1.SyncInfo{HeadSlot: c.headSlot, IsSyncing: c.syncing}
+		return c.healthErr
+	}
+	c.calls++
+	if resp, ok := reply.(*ethpb.AttestResponse); ok && c.callErr == nil {
+		resp.AttestationDataRoot = c.attRoot
+	}
+	return c.callErr
+}
+
+func (c *fakeBeaconNodeConn) NewStream(ctx context.Context, _ *grpc.StreamDesc, _ string, _ ...grpc.CallOption) (grpc.ClientStream, error) {
+	return &fakeClientStream{ctx: ctx}, nil
+}
+
+func (c *fakeBeaconNodeConn) set(f func(c *fakeBeaconNodeConn)) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	f(c)
+}
+
+// fakeClientStream blocks on receiving until its context is done.
+type fakeClientStream struct {
+	grpc.ClientStream
+	ctx context.Context
+}
+
+func (s *fakeClientStream) RecvMsg(_ interface{}) error {
+	<-s.ctx.Done()
+	return status.Error(codes.Canceled, s.ctx.Err().Error())
+}
+
+func newTestFailoverConn(conns ...*fakeBeaconNodeConn) *failoverConn {
+	endpoints := make([]string, len(conns))
+	ifaces := make([]grpc.ClientConnInterface, len(conns))
+	for i, conn := range conns {
+		endpoints[i] = string(rune('a' + i))
+		ifaces[i] = conn
+	}
+	return newFailoverConn(endpoints, ifaces, time.Second)
+}
+
+func TestFailoverConn_SticksToHealthiestNode(t *testing.T) {
+	ctx := context.Background()
+	first := &fakeBeaconNodeConn{syncing: true}
+	second := &fakeBeaconNodeConn{headSlot: 10}
+	third := &fakeBeaconNodeConn{headSlot: 11}
+	f := newTestFailoverConn(first, second, third)
+
+	f.checkHealth(ctx)
+	assert.Equal(t, 1, f.active, "Expected the first healthy node to be active")
+
+	// A healthy node slightly ahead is not worth switching.
+	third.set(func(c *fakeBeaconNodeConn) { c.headSlot = 12 })
+	f.checkHealth(ctx)
+	assert.Equal(t, 1, f.active)
+
+	// A healthy node far ahead is.
+	third.set(func(c *fakeBeaconNodeConn) { c.headSlot = 20 })
+	f.checkHealth(ctx)
+	assert.Equal(t, 2, f.active)
+
+	third.set(func(c *fakeBeaconNodeConn) { c.healthErr = status.Error(codes.Unavailable, "down") })
+	f.checkHealth(ctx)
+	assert.Equal(t, 1, f.active)
+
+	// Requests go to the active node.
+	_, err := ethpb.NewNodeClient(f).GetSyncStatus(ctx, &emptypb.Empty{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, second.calls)
+	assert.Equal(t, 0, first.calls)
+}
+
+func TestFailoverConn_SwitchesAwayFromFailingNode(t *testing.T) {
+	ctx := context.Background()
+	first := &fakeBeaconNodeConn{callErr: status.Error(codes.Internal, "broken")}
+	second := &fakeBeaconNodeConn{}
+	f := newTestFailoverConn(first, second)
+	f.checkHealth(ctx)
+	require.Equal(t, 0, f.active)
+
+	client := ethpb.NewNodeClient(f)
+	for i := 0; i < maxConsecutiveFailures; i++ {
+		_, err := client.GetSyncStatus(ctx, &emptypb.Empty{})
+		require.ErrorContains(t, "broken", err)
+	}
+	assert.Equal(t, 1, f.active)
+
+	// The failing node stays penalized even though it reports itself healthy.
+	f.checkHealth(ctx)
+	assert.Equal(t, 1, f.active)
+	_, err := client.GetSyncStatus(ctx, &emptypb.Empty{})
+	require.NoError(t, err)
+	assert.Equal(t, maxConsecutiveFailures, first.calls)
+	assert.Equal(t, 1, second.calls)
+}
+
+func TestFailoverConn_CancelsStreamsOnSwitch(t *testing.T) {
+	ctx := context.Background()
+	first := &fakeBeaconNodeConn{}
+	second := &fakeBeaconNodeConn{}
+	f := newTestFailoverConn(first, second)
+
+	stream, err := f.NewStream(ctx, &grpc.StreamDesc{ServerStreams: true}, "/test/Stream")
+	require.NoError(t, err)
+	assert.Equal(t, 1, len(f.nodes[0].streams))
+
+	first.set(func(c *fakeBeaconNodeConn) { c.healthErr = status.Error(codes.Unavailable, "down") })
+	f.checkHealth(ctx)
+	require.Equal(t, 1, f.active)
+	assert.Equal(t, 0, len(f.nodes[0].streams))
+
+	err = stream.RecvMsg(nil)
+	require.Equal(t, codes.Canceled, status.Code(err))
+}
@@ -0,0 +1,92 @@
+package client
+
+import (
+	"context"
+	"path"
+	"sync"
+
+	grpc_retry "github.com/grpc-ecosystem/go-grpc-middleware/retry"
+	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+// broadcastConn is a gRPC client connection sending every request to all the beacon nodes of a
+// failover connection in parallel. It is used to submit signed messages, so that they reach the
+// network through the gossip of every beacon node. The response of the active beacon node is
+// returned when it accepted the request, else the response of any other node which did.
+type broadcastConn struct {
+	*failoverConn
+}
+
+// Invoke sends a unary request to all the beacon nodes and waits for their responses. Requests to
+// the beacon nodes other than the active one are not retried, so that an unavailable node does
+// not delay the submission.
+func (b *broadcastConn) Invoke(ctx context.Context, method string, args, reply interface{}, opts ...grpc.CallOption) error {
+	primary, nodes := b.snapshot()
+	replies := make([]interface{}, len(nodes))
+	errs := make([]error, len(nodes))
+	var wg sync.WaitGroup
+	for i, node := range nodes {
+		callOpts := opts
+		replies[i] = reply
+		if i != primary {
+			callOpts = append(append([]grpc.CallOption{}, opts...), grpc_retry.Disable())
+			replies[i] = newReply(reply)
+		}
+		wg.Add(1)
+		go func(i int, conn grpc.ClientConnInterface, callOpts []grpc.CallOption) {
+			defer wg.Done()
+			errs[i] = conn.Invoke(ctx, method, args, replies[i], callOpts...)
+		}(i, node.conn, callOpts)
+	}
+	wg.Wait()
+	b.reportResult(ctx, primary, errs[primary])
+
+	methodName := path.Base(method)
+	for i, node := range nodes {
+		result := "accepted"
+		if errs[i] != nil {
+			result = "failed"
+			log.WithError(errs[i]).WithField("endpoint", node.endpoint).WithField("method", methodName).Debug(
+				"Beacon node failed to accept broadcast request")
+		}
+		ValidatorBroadcastSubmissionsCounterVec.WithLabelValues(node.endpoint, methodName, result).Inc()
+	}
+	if errs[primary] == nil {
+		return nil
+	}
+	for i, node := range nodes {
+		if errs[i] != nil {
+			continue
+		}
+		log.WithError(errs[primary]).WithField("endpoint", node.endpoint).WithField("method", methodName).Warn(
+			"Active beacon node failed to accept broadcast request, using the response of another beacon node")
+		proto.Merge(reply.(proto.Message), replies[i].(proto.Message))
+		return nil
+	}
+	return errs[primary]
+}
+
+// snapshot returns the index of the active beacon node along with all the beacon nodes.
+func (f *failoverConn) snapshot() (int, []*beaconNode) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	nodes := make([]*beaconNode, len(f.nodes))
+	copy(nodes, f.nodes)
+	return f.active, nodes
+}
+
+// newReply returns an empty message of the same type as the given reply.
+func newReply(reply interface{}) interface{} {
+	return reply.(proto.Message).ProtoReflect().New().Interface()
+}
+
+// signedMessageClient returns the client to submit signed messages with, which broadcasts them to
+// all the beacon nodes when enabled.
+func (v *validator) signedMessageClient() ethpb.BeaconNodeValidatorClient {
+	if v.broadcastClient != nil {
+		return v.broadcastClient
+	}
+	return v.validatorClient
+}
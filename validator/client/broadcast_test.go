@@ -0,0 +1,47 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestBroadcastConn_SubmitsToAllBeaconNodes(t *testing.T) {
+	ctx := context.Background()
+	first := &fakeBeaconNodeConn{attRoot: []byte{1}}
+	second := &fakeBeaconNodeConn{attRoot: []byte{2}}
+	third := &fakeBeaconNodeConn{callErr: status.Error(codes.Unavailable, "down")}
+	f := newTestFailoverConn(first, second, third)
+	f.checkHealth(ctx)
+	client := ethpb.NewBeaconNodeValidatorClient(&broadcastConn{f})
+
+	resp, err := client.ProposeAttestation(ctx, &ethpb.Attestation{})
+	require.NoError(t, err)
+	assert.DeepEqual(t, []byte{1}, resp.AttestationDataRoot, "Expected the response of the active beacon node")
+	assert.Equal(t, 1, first.calls)
+	assert.Equal(t, 1, second.calls)
+	assert.Equal(t, 1, third.calls)
+
+	// The response of another beacon node is used when the active one fails.
+	first.set(func(c *fakeBeaconNodeConn) { c.callErr = status.Error(codes.Internal, "broken") })
+	resp, err = client.ProposeAttestation(ctx, &ethpb.Attestation{})
+	require.NoError(t, err)
+	assert.DeepEqual(t, []byte{2}, resp.AttestationDataRoot)
+
+	second.set(func(c *fakeBeaconNodeConn) { c.callErr = status.Error(codes.Internal, "broken") })
+	_, err = client.ProposeAttestation(ctx, &ethpb.Attestation{})
+	assert.ErrorContains(t, "broken", err)
+}
+
+func TestValidator_SignedMessageClient(t *testing.T) {
+	f := newTestFailoverConn(&fakeBeaconNodeConn{})
+	v := &validator{validatorClient: ethpb.NewBeaconNodeValidatorClient(f)}
+	assert.Equal(t, v.validatorClient, v.signedMessageClient())
+	v.broadcastClient = ethpb.NewBeaconNodeValidatorClient(&broadcastConn{f})
+	assert.Equal(t, v.broadcastClient, v.signedMessageClient())
+}
@@ -0,0 +1,218 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
+	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/slotutil"
+	"github.com/prysmaticlabs/prysm/validator/client/iface"
+	"go.opencensus.io/trace"
+)
+
+// doppelgangerTracker keeps the doppelganger status of the validating keys. A key is observed
+// for a window of epochs before performing its duties, and a key found active from another
+// instance is quarantined, while the other keys of the client keep working.
+type doppelgangerTracker struct {
+	windowEpochs uint64
+	lock         sync.RWMutex
+	keys         map[[48]byte]*doppelgangerKey
+}
+
+type doppelgangerKey struct {
+	status         iface.DoppelgangerStatus
+	observedEpochs map[types.Epoch]bool
+}
+
+func newDoppelgangerTracker(windowEpochs uint64) *doppelgangerTracker {
+	if windowEpochs == 0 {
+		windowEpochs = 1
+	}
+	return &doppelgangerTracker{
+		windowEpochs: windowEpochs,
+		keys:         make(map[[48]byte]*doppelgangerKey),
+	}
+}
+
+// setKeys starts the check of the keys which are not tracked yet, and stops tracking the keys
+// which are no longer validating, so that a key imported again is checked again.
+func (d *doppelgangerTracker) setKeys(pubKeys [][48]byte) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	validating := make(map[[48]byte]bool, len(pubKeys))
+	for _, pubKey := range pubKeys {
+		validating[pubKey] = true
+		if _, ok := d.keys[pubKey]; ok {
+			continue
+		}
+		d.keys[pubKey] = &doppelgangerKey{
+			status:         iface.DoppelgangerChecking,
+			observedEpochs: make(map[types.Epoch]bool),
+		}
+		ValidatorDoppelgangerStatusGaugeVec.WithLabelValues(fmt.Sprintf("%#x", pubKey)).Set(float64(iface.DoppelgangerChecking))
+	}
+	for pubKey := range d.keys {
+		if !validating[pubKey] {
+			delete(d.keys, pubKey)
+			ValidatorDoppelgangerStatusGaugeVec.DeleteLabelValues(fmt.Sprintf("%#x", pubKey))
+		}
+	}
+}
+
+// checkingKeys returns the keys which are still in their observation window.
+func (d *doppelgangerTracker) checkingKeys() [][48]byte {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	pubKeys := make([][48]byte, 0)
+	for pubKey, k := range d.keys {
+		if k.status == iface.DoppelgangerChecking {
+			pubKeys = append(pubKeys, pubKey)
+		}
+	}
+	return pubKeys
+}
+
+// observe records the result of the check of a key in an epoch and returns the resulting status
+// of the key. A key is clean once it was observed without duplicate for the whole window.
+func (d *doppelgangerTracker) observe(pubKey [48]byte, epoch types.Epoch, duplicate bool) iface.DoppelgangerStatus {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	k, ok := d.keys[pubKey]
+	if !ok || k.status != iface.DoppelgangerChecking {
+		// Keys removed meanwhile or already decided are left untouched.
+		if ok {
+			return k.status
+		}
+		return iface.DoppelgangerChecking
+	}
+	if duplicate {
+		k.status = iface.DoppelgangerDetected
+	} else {
+		k.observedEpochs[epoch] = true
+		if uint64(len(k.observedEpochs)) >= d.windowEpochs {
+			k.status = iface.DoppelgangerClean
+		}
+	}
+	ValidatorDoppelgangerStatusGaugeVec.WithLabelValues(fmt.Sprintf("%#x", pubKey)).Set(float64(k.status))
+	return k.status
+}
+
+// isClean returns whether a key passed its doppelganger check. All keys are clean when
+// doppelganger protection is disabled.
+func (d *doppelgangerTracker) isClean(pubKey [48]byte) bool {
+	if d == nil {
+		return true
+	}
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	k, ok := d.keys[pubKey]
+	return ok && k.status == iface.DoppelgangerClean
+}
+
+func (d *doppelgangerTracker) statuses() map[[48]byte]*iface.DoppelgangerKeyStatus {
+	statuses := make(map[[48]byte]*iface.DoppelgangerKeyStatus)
+	if d == nil {
+		return statuses
+	}
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	for pubKey, k := range d.keys {
+		statuses[pubKey] = &iface.DoppelgangerKeyStatus{
+			Status:         k.status,
+			ObservedEpochs: uint64(len(k.observedEpochs)),
+		}
+	}
+	return statuses
+}
+
+// CheckDoppelGanger checks the validating keys which are in their observation window for
+// activity of another instance in the network, from the participation in the head state of the
+// beacon node. It is run on startup and at the start of each epoch: keys found active elsewhere
+// are quarantined, and keys observed clean for the whole window start performing their duties.
+func (v *validator) CheckDoppelGanger(ctx context.Context) error {
+	ctx, span := trace.StartSpan(ctx, "validator.CheckDoppelGanger")
+	defer span.End()
+
+	if v.doppelganger == nil {
+		return nil
+	}
+	pubkeys, err := v.keyManager.FetchValidatingPublicKeys(ctx)
+	if err != nil {
+		return err
+	}
+	v.doppelganger.setKeys(pubkeys)
+	checkingKeys := v.doppelganger.checkingKeys()
+	// Exit early if no key is being checked.
+	if len(checkingKeys) == 0 {
+		return nil
+	}
+	log.WithField("keys", len(checkingKeys)).Info("Running doppelganger check")
+	req := &ethpb.DoppelGangerRequest{ValidatorRequests: []*ethpb.DoppelGangerRequest_ValidatorRequest{}}
+	for _, pkey := range checkingKeys {
+		attRec, err := v.db.AttestationHistoryForPubKey(ctx, pkey)
+		if err != nil {
+			return err
+		}
+		if len(attRec) == 0 {
+			// If no history exists we simply send in a zero
+			// value for the request epoch and root.
+			req.ValidatorRequests = append(req.ValidatorRequests,
+				&ethpb.DoppelGangerRequest_ValidatorRequest{
+					PublicKey:  pkey[:],
+					Epoch:      0,
+					SignedRoot: make([]byte, 32),
+				})
+			continue
+		}
+		r := retrieveLatestRecord(attRec)
+		if pkey != r.PubKey {
+			return errors.New("attestation record mismatched public key")
+		}
+		req.ValidatorRequests = append(req.ValidatorRequests,
+			&ethpb.DoppelGangerRequest_ValidatorRequest{
+				PublicKey:  r.PubKey[:],
+				Epoch:      r.Target,
+				SignedRoot: r.SigningRoot[:],
+			})
+	}
+	resp, err := v.validatorClient.CheckDoppelGanger(ctx, req)
+	if err != nil {
+		return err
+	}
+	// If nothing is returned by the beacon node, we return an
+	// error as it is unsafe for us to proceed.
+	if resp == nil || resp.Responses == nil {
+		return errors.New("beacon node returned no response for doppelganger check")
+	}
+	duplicates := make(map[[48]byte]bool, len(resp.Responses))
+	for _, valRes := range resp.Responses {
+		if valRes.DuplicateExists {
+			duplicates[bytesutil.ToBytes48(valRes.PublicKey)] = true
+		}
+	}
+	// Keys unknown to the beacon node are left out of the response, as no other instance
+	// can be attesting with them yet.
+	epoch := slotutil.EpochsSinceGenesis(time.Unix(int64(v.genesisTime), 0))
+	for _, pkey := range checkingKeys {
+		fmtKey := fmt.Sprintf("%#x", pkey)
+		switch v.doppelganger.observe(pkey, epoch, duplicates[pkey]) {
+		case iface.DoppelgangerDetected:
+			log.WithField("pubKey", fmtKey).Error("Duplicate instance exists in the network for validator key, " +
+				"quarantining it. Stop the other instance and restart or re-import the key to check it again")
+		case iface.DoppelgangerClean:
+			log.WithField("pubKey", fmtKey).Info("No doppelganger found for validator key, starting its duties")
+		}
+	}
+	return nil
+}
+
+// DoppelgangerStatuses returns the doppelganger status of the validating keys, which is empty
+// when doppelganger protection is disabled.
+func (v *validator) DoppelgangerStatuses() map[[48]byte]*iface.DoppelgangerKeyStatus {
+	return v.doppelganger.statuses()
+}
@@ -0,0 +1,129 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	types "github.com/prysmaticlabs/eth2-types"
+	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/bls"
+	"github.com/prysmaticlabs/prysm/shared/mock"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	"github.com/prysmaticlabs/prysm/shared/timeutils"
+	"github.com/prysmaticlabs/prysm/validator/client/iface"
+	dbTest "github.com/prysmaticlabs/prysm/validator/db/testing"
+	"google.golang.org/grpc"
+)
+
+func TestDoppelgangerTracker_Window(t *testing.T) {
+	d := newDoppelgangerTracker(2)
+	clean, dup := [48]byte{1}, [48]byte{2}
+	d.setKeys([][48]byte{clean, dup})
+	assert.Equal(t, 2, len(d.checkingKeys()))
+	assert.Equal(t, false, d.isClean(clean))
+
+	assert.Equal(t, iface.DoppelgangerChecking, d.observe(clean, 1, false))
+	// Observing the same epoch twice does not shorten the window.
+	assert.Equal(t, iface.DoppelgangerChecking, d.observe(clean, 1, false))
+	assert.Equal(t, iface.DoppelgangerChecking, d.observe(dup, 1, false))
+	assert.Equal(t, iface.DoppelgangerClean, d.observe(clean, 2, false))
+	assert.Equal(t, iface.DoppelgangerDetected, d.observe(dup, 2, true))
+	assert.Equal(t, true, d.isClean(clean))
+	assert.Equal(t, false, d.isClean(dup))
+	assert.Equal(t, 0, len(d.checkingKeys()))
+
+	// A decided key is not observed anymore.
+	assert.Equal(t, iface.DoppelgangerDetected, d.observe(dup, 3, false))
+	assert.DeepEqual(t, map[[48]byte]*iface.DoppelgangerKeyStatus{
+		clean: {Status: iface.DoppelgangerClean, ObservedEpochs: 2},
+		dup:   {Status: iface.DoppelgangerDetected, ObservedEpochs: 1},
+	}, d.statuses())
+}
+
+func TestDoppelgangerTracker_ReimportedKeyIsCheckedAgain(t *testing.T) {
+	d := newDoppelgangerTracker(1)
+	pubKey := [48]byte{1}
+	d.setKeys([][48]byte{pubKey})
+	assert.Equal(t, iface.DoppelgangerDetected, d.observe(pubKey, 1, true))
+
+	// Reloading the same keys keeps their status.
+	d.setKeys([][48]byte{pubKey})
+	assert.Equal(t, iface.DoppelgangerDetected, d.statuses()[pubKey].Status)
+
+	d.setKeys([][48]byte{})
+	assert.Equal(t, 0, len(d.statuses()))
+	d.setKeys([][48]byte{pubKey})
+	assert.Equal(t, iface.DoppelgangerChecking, d.statuses()[pubKey].Status)
+	assert.Equal(t, iface.DoppelgangerClean, d.observe(pubKey, 2, false))
+}
+
+func TestDoppelgangerTracker_Disabled(t *testing.T) {
+	var d *doppelgangerTracker
+	assert.Equal(t, true, d.isClean([48]byte{1}))
+	assert.Equal(t, 0, len(d.statuses()))
+}
+
+func TestValidator_CheckDoppelGanger_QuarantinesKeys(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := mock.NewMockBeaconNodeValidatorClient(ctrl)
+	clean, dup, unknown := [48]byte{1}, [48]byte{2}, [48]byte{3}
+	keys := [][48]byte{clean, dup, unknown}
+	km := &mockKeymanager{keysMap: map[[48]byte]bls.SecretKey{clean: nil, dup: nil, unknown: nil}}
+	v := &validator{
+		validatorClient: client,
+		keyManager:      km,
+		db:              dbTest.SetupDB(t, keys),
+		genesisTime:     uint64(timeutils.Now().Unix()),
+		doppelganger:    newDoppelgangerTracker(2),
+		duties: &ethpb.DutiesResponse{
+			Duties: []*ethpb.DutiesResponse_Duty{
+				{PublicKey: clean[:], ProposerSlots: []types.Slot{1}},
+				{PublicKey: dup[:], ProposerSlots: []types.Slot{1}},
+			},
+		},
+	}
+	// The unknown key is left out of the response by the beacon node.
+	client.EXPECT().CheckDoppelGanger(gomock.Any(), gomock.Any()).Return(&ethpb.DoppelGangerResponse{
+		Responses: []*ethpb.DoppelGangerResponse_ValidatorResponse{
+			{PublicKey: clean[:], DuplicateExists: false},
+			{PublicKey: dup[:], DuplicateExists: true},
+		},
+	}, nil)
+	require.NoError(t, v.CheckDoppelGanger(context.Background()))
+	statuses := v.DoppelgangerStatuses()
+	assert.Equal(t, iface.DoppelgangerChecking, statuses[clean].Status)
+	assert.Equal(t, iface.DoppelgangerDetected, statuses[dup].Status)
+	assert.Equal(t, iface.DoppelgangerChecking, statuses[unknown].Status)
+
+	// Keys in their observation window do not perform duties.
+	roles, err := v.RolesAt(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(roles))
+
+	// Complete the window in a later epoch, only the keys still being checked are requested.
+	v.genesisTime -= params.BeaconConfig().SecondsPerSlot * uint64(params.BeaconConfig().SlotsPerEpoch)
+	client.EXPECT().CheckDoppelGanger(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, req *ethpb.DoppelGangerRequest, _ ...grpc.CallOption) (*ethpb.DoppelGangerResponse, error) {
+			assert.Equal(t, 2, len(req.ValidatorRequests))
+			return &ethpb.DoppelGangerResponse{
+				Responses: []*ethpb.DoppelGangerResponse_ValidatorResponse{
+					{PublicKey: clean[:], DuplicateExists: false},
+				},
+			}, nil
+		})
+	require.NoError(t, v.CheckDoppelGanger(context.Background()))
+	statuses = v.DoppelgangerStatuses()
+	assert.Equal(t, iface.DoppelgangerClean, statuses[clean].Status)
+	assert.Equal(t, iface.DoppelgangerDetected, statuses[dup].Status)
+	assert.Equal(t, iface.DoppelgangerClean, statuses[unknown].Status)
+
+	// Only the clean key proposes, the other one stays quarantined.
+	roles, err = v.RolesAt(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, len(roles))
+	assert.DeepEqual(t, []iface.ValidatorRole{iface.RoleProposer}, roles[clean])
+}
@@ -24,8 +24,45 @@ const (
 	RoleProposer
 	// RoleAggregator means that the validator should submit an aggregation and proof.
 	RoleAggregator
+	// RoleSyncCommittee means that the validator should submit a sync committee message.
+	RoleSyncCommittee
+	// RoleSyncCommitteeAggregator means that the validator should aggregate sync committee messages and submit a sync committee contribution.
+	RoleSyncCommitteeAggregator
 )
 
+// DoppelgangerStatus defines the state of the doppelganger check of a validating key.
+type DoppelgangerStatus int8
+
+const (
+	// DoppelgangerChecking means that the key is observed for activity of another instance and does not perform duties yet.
+	DoppelgangerChecking DoppelgangerStatus = iota
+	// DoppelgangerClean means that no other instance of the key was found and the key performs its duties.
+	DoppelgangerClean
+	// DoppelgangerDetected means that another instance of the key is active in the network and the key is quarantined.
+	DoppelgangerDetected
+)
+
+// String returns the name of the doppelganger status.
+func (s DoppelgangerStatus) String() string {
+	switch s {
+	case DoppelgangerChecking:
+		return "checking"
+	case DoppelgangerClean:
+		return "clean"
+	case DoppelgangerDetected:
+		return "detected"
+	default:
+		return "unknown"
+	}
+}
+
+// DoppelgangerKeyStatus is the doppelganger status of a validating key, along with the number
+// of epochs it was observed without activity of another instance.
+type DoppelgangerKeyStatus struct {
+	Status         DoppelgangerStatus
+	ObservedEpochs uint64
+}
+
 // Validator interface defines the primary methods of a validator client.
 type Validator interface {
 	Done()
@@ -42,6 +79,8 @@ type Validator interface {
 	SubmitAttestation(ctx context.Context, slot types.Slot, pubKey [48]byte)
 	ProposeBlock(ctx context.Context, slot types.Slot, pubKey [48]byte)
 	SubmitAggregateAndProof(ctx context.Context, slot types.Slot, pubKey [48]byte)
+	SubmitSyncCommitteeMessage(ctx context.Context, slot types.Slot, pubKey [48]byte)
+	SubmitSignedContributionAndProof(ctx context.Context, slot types.Slot, pubKey [48]byte)
 	LogAttestationsSubmitted()
 	LogNextDutyTimeLeft(slot types.Slot) error
 	UpdateDomainDataCaches(ctx context.Context, slot types.Slot)
@@ -51,4 +90,6 @@ type Validator interface {
 	ReceiveBlocks(ctx context.Context, connectionErrorChannel chan<- error)
 	HandleKeyReload(ctx context.Context, newKeys [][48]byte) (bool, error)
 	CheckDoppelGanger(ctx context.Context) error
+	DoppelgangerStatuses() map[[48]byte]*DoppelgangerKeyStatus
+	WaitForInFlightSigning()
 }
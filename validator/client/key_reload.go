@@ -13,6 +13,11 @@ func (v *validator) HandleKeyReload(ctx context.Context, newKeys [][48]byte) (an
 	ctx, span := trace.StartSpan(ctx, "validator.HandleKeyReload")
 	defer span.End()
 
+	// Newly imported keys are checked for doppelgangers before performing duties.
+	if v.doppelganger != nil {
+		v.doppelganger.setKeys(newKeys)
+	}
+
 	statusRequestKeys := make([][]byte, len(newKeys))
 	for i := range newKeys {
 		statusRequestKeys[i] = newKeys[i][:]
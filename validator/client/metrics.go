@@ -26,6 +26,60 @@ var (
 			"pubkey",
 		},
 	)
+	// ValidatorDoppelgangerStatusGaugeVec used to track the doppelganger check of validators by public key.
+	ValidatorDoppelgangerStatusGaugeVec = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "validator",
+			Name:      "doppelganger_status",
+			Help:      "validator doppelganger statuses: 0 CHECKING, 1 CLEAN, 2 DETECTED",
+		},
+		[]string{
+			"pubkey",
+		},
+	)
+	// ValidatorBeaconNodeActiveGaugeVec used to track which beacon node the validator client sends its requests to.
+	ValidatorBeaconNodeActiveGaugeVec = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "validator",
+			Name:      "beacon_node_active",
+			Help:      "1 for the beacon node currently used by the validator client, 0 for the others",
+		},
+		[]string{
+			"endpoint",
+		},
+	)
+	// ValidatorBeaconNodeHealthGaugeVec used to track the health of the beacon nodes by endpoint.
+	ValidatorBeaconNodeHealthGaugeVec = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "validator",
+			Name:      "beacon_node_health",
+			Help:      "beacon node health: 0 UNAVAILABLE, 1 SYNCING, 2 HEALTHY",
+		},
+		[]string{
+			"endpoint",
+		},
+	)
+	// ValidatorBeaconNodeSwitchesCounter used to count the switches from a beacon node to another.
+	ValidatorBeaconNodeSwitchesCounter = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "validator",
+			Name:      "beacon_node_switches_total",
+			Help:      "Number of times the validator client switched to another beacon node",
+		},
+	)
+	// ValidatorBroadcastSubmissionsCounterVec used to count the signed messages broadcast to each beacon node.
+	ValidatorBroadcastSubmissionsCounterVec = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "validator",
+			Name:      "broadcast_submissions_total",
+			Help:      "Number of signed messages broadcast to a beacon node, by result: accepted or failed",
+		},
+		[]string{
+			"endpoint",
+			"method",
+			"result",
+		},
+	)
 	// ValidatorAggSuccessVec used to count successful aggregations.
 	ValidatorAggSuccessVec = promauto.NewCounterVec(
 		prometheus.CounterOpts{
@@ -199,13 +253,14 @@ var (
 // LogValidatorGainsAndLosses logs important metrics related to this validator client's
 // responsibilities throughout the beacon chain's lifecycle. It logs absolute accrued rewards
 // and penalties over time, percentage gain/loss, and gives the end user a better idea
-// of how the validator performs with respect to the rest.
+// of how the validator performs with respect to the rest. The summary of each key is also
+// recorded in the performance history of the validator DB.
 func (v *validator) LogValidatorGainsAndLosses(ctx context.Context, slot types.Slot) error {
 	if !helpers.IsEpochEnd(slot) || slot <= params.BeaconConfig().SlotsPerEpoch {
 		// Do nothing unless we are at the end of the epoch, and not in the first epoch.
 		return nil
 	}
-	if !v.logValidatorBalances {
+	if !v.logValidatorBalances && !v.recordPerformance {
 		return nil
 	}
 
@@ -225,13 +280,6 @@ func (v *validator) LogValidatorGainsAndLosses(ctx context.Context, slot types.S
 		return err
 	}
 
-	if v.emitAccountMetrics {
-		for _, missingPubKey := range resp.MissingValidators {
-			fmtKey := fmt.Sprintf("%#x", missingPubKey)
-			ValidatorBalancesGaugeVec.WithLabelValues(fmtKey).Set(0)
-		}
-	}
-
 	prevEpoch := types.Epoch(0)
 	if slot >= params.BeaconConfig().SlotsPerEpoch {
 		prevEpoch = types.Epoch(slot/params.BeaconConfig().SlotsPerEpoch) - 1
@@ -239,6 +287,21 @@ func (v *validator) LogValidatorGainsAndLosses(ctx context.Context, slot types.S
 			v.voteStats.startEpoch = prevEpoch
 		}
 	}
+	// The performance history is recorded even when balance logging is disabled.
+	if v.recordPerformance {
+		v.recordAttestationPerformance(ctx, prevEpoch, resp)
+	}
+	if !v.logValidatorBalances {
+		return nil
+	}
+
+	if v.emitAccountMetrics {
+		for _, missingPubKey := range resp.MissingValidators {
+			fmtKey := fmt.Sprintf("%#x", missingPubKey)
+			ValidatorBalancesGaugeVec.WithLabelValues(fmtKey).Set(0)
+		}
+	}
+
 	gweiPerEth := float64(params.BeaconConfig().GweiPerEth)
 	v.prevBalanceLock.Lock()
 	for i, pubKey := range resp.PublicKeys {
@@ -39,6 +39,8 @@ var (
 	errInvalidParentHash = errors.New("invalid parent hash")
 
 	errInvalidBlockNumber = errors.New("invalid block number")
+	// errInvalidCoinbase is returned if the coinbase of the header is not the configured fee recipient
+	errInvalidCoinbase = errors.New("invalid coinbase")
 )
 
 // processPandoraShardHeader method does the following tasks:
@@ -64,8 +66,14 @@ func (v *validator) processPandoraShardHeader(
 		latestPandoraBlkNum = beaconBlk.Body.PandoraShard[0].BlockNumber
 	}
 
+	// Fee recipient of the pandora block, from the proposer config file when specified
+	var feeRecipient common.Address
+	if v.proposerConfig != nil {
+		feeRecipient = v.proposerConfig.Settings(pubKey).FeeRecipient
+	}
+
 	// Request for pandora chain header
-	header, headerHash, extraData, err := v.pandoraService.GetShardBlockHeader(ctx, latestPandoraHash, latestPandoraBlkNum+1, uint64(slot), uint64(epoch))
+	header, headerHash, extraData, err := v.pandoraService.GetShardBlockHeader(ctx, latestPandoraHash, latestPandoraBlkNum+1, uint64(slot), uint64(epoch), feeRecipient)
 	if err != nil {
 		log.WithField("blockSlot", slot).
 			WithField("fmtKey", fmtKey).
@@ -77,7 +85,7 @@ func (v *validator) processPandoraShardHeader(
 	}
 
 	// Validate pandora chain header hash, extraData fields
-	if err := v.verifyPandoraShardHeader(slot, epoch, header, headerHash, extraData, latestPandoraHash, latestPandoraBlkNum); err != nil {
+	if err := v.verifyPandoraShardHeader(slot, epoch, header, headerHash, extraData, latestPandoraHash, latestPandoraBlkNum, feeRecipient); err != nil {
 		log.WithField("blockSlot", slot).
 			WithField("fmtKey", fmtKey).
 			WithError(err).Error("Failed to validate pandora block header")
@@ -90,7 +98,8 @@ func (v *validator) processPandoraShardHeader(
 		PublicKey:       pubKey[:],
 		SigningRoot:     headerHash[:],
 		SignatureDomain: nil,
-		Object:          nil,
+		SigningSlot:     slot,
+		Object:          &validatorpb.SignRequest_PandoraHeaderHash{PandoraHeaderHash: headerHash[:]},
 	})
 
 	if err != nil {
@@ -170,6 +179,7 @@ func (v *validator) verifyPandoraShardHeader(
 	extraData *pandora.ExtraData,
 	canonicalHash common.Hash,
 	canonicalBlockNum uint64,
+	feeRecipient common.Address,
 ) error {
 
 	// verify parent hash and block number
@@ -185,6 +195,15 @@ func (v *validator) verifyPandoraShardHeader(
 		}
 	}
 
+	// verify coinbase, the fee recipient of the proposer config file when one is specified
+	if feeRecipient != (common.Address{}) && header.Coinbase != feeRecipient {
+		log.WithError(errInvalidCoinbase).
+			WithField("coinbase", header.Coinbase.Hex()).
+			WithField("feeRecipient", feeRecipient.Hex()).
+			Error("invalid coinbase from pandora chain")
+		return errInvalidCoinbase
+	}
+
 	// verify header hash
 	if sealHash(header) != headerHash {
 		log.WithError(errInvalidHeaderHash).Error("invalid header hash from pandora chain")
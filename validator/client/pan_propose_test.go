@@ -35,27 +35,35 @@ func TestVerifyPandoraShardHeader(t *testing.T) {
 	headerHash := sealHash(header)
 
 	// Checks all the validations
-	err := validator.verifyPandoraShardHeader(blk.Block.Slot, epoch, header, headerHash, extraData, emptyRootHash, canonicalBlkNum)
+	err := validator.verifyPandoraShardHeader(blk.Block.Slot, epoch, header, headerHash, extraData, emptyRootHash, canonicalBlkNum, common.Address{})
 	require.NoError(t, err, "Should pass without any error")
+	err = validator.verifyPandoraShardHeader(blk.Block.Slot, epoch, header, headerHash, extraData, emptyRootHash, canonicalBlkNum, header.Coinbase)
+	require.NoError(t, err, "Should pass when the coinbase is the fee recipient")
+
+	// Should get an `errInvalidCoinbase` error
+	want := "invalid coinbase"
+	feeRecipient := common.HexToAddress("0x046Fb65722E7b2455012BFEBf6177F1D2e9738D9")
+	err = validator.verifyPandoraShardHeader(blk.Block.Slot, epoch, header, headerHash, extraData, emptyRootHash, canonicalBlkNum, feeRecipient)
+	require.ErrorContains(t, want, err, "Should get an errInvalidCoinbase error")
 
 	// Should get an `errInvalidHeaderHash` error
 	header.Time = uint64(14265167)
-	want := "invalid header hash"
-	err = validator.verifyPandoraShardHeader(blk.Block.Slot, epoch, header, headerHash, extraData, emptyRootHash, canonicalBlkNum)
+	want = "invalid header hash"
+	err = validator.verifyPandoraShardHeader(blk.Block.Slot, epoch, header, headerHash, extraData, emptyRootHash, canonicalBlkNum, common.Address{})
 	require.ErrorContains(t, want, err, "Should get an errInvalidHeaderHash error")
 
 	// Should get an `errInvalidSlot` error
 	header.Time = uint64(1426516743)
 	blk.Block.Slot = 90
 	want = "invalid slot"
-	err = validator.verifyPandoraShardHeader(blk.Block.Slot, epoch, header, headerHash, extraData, emptyRootHash, canonicalBlkNum)
+	err = validator.verifyPandoraShardHeader(blk.Block.Slot, epoch, header, headerHash, extraData, emptyRootHash, canonicalBlkNum, common.Address{})
 	require.ErrorContains(t, want, err, "Should get an errInvalidSlot error")
 
 	// Should get an `errInvalidEpoch` error
 	blk.Block.Slot = 98
 	epoch = 2
 	want = "invalid epoch"
-	err = validator.verifyPandoraShardHeader(blk.Block.Slot, epoch, header, headerHash, extraData, emptyRootHash, canonicalBlkNum)
+	err = validator.verifyPandoraShardHeader(blk.Block.Slot, epoch, header, headerHash, extraData, emptyRootHash, canonicalBlkNum, common.Address{})
 	require.ErrorContains(t, want, err, "Should get an errInvalidEpoch error")
 }
 
@@ -95,6 +103,7 @@ func TestProcessPandoraShardHeader(t *testing.T) {
 		gomock.Any(), // next block number
 		gomock.Any(), // slot
 		gomock.Any(), // epoch
+		gomock.Any(), // fee recipient
 	).Return(header, headerHash, extraData, nil) // nil - error
 
 	m.pandoraService.EXPECT().SubmitShardBlockHeader(
@@ -115,6 +124,7 @@ func TestProcessPandoraShardHeader(t *testing.T) {
 		gomock.Any(), // next block number
 		gomock.Any(), // slot
 		gomock.Any(), // epoch
+		gomock.Any(), // fee recipient
 	).Return(nil, common.Hash{}, nil, ErrRlpDecoding)
 	err = validator.processPandoraShardHeader(context.Background(), beaconBlkWithShard.Block, beaconBlkWithShard.Block.Slot, epoch, pubKey)
 	require.ErrorContains(t, "rlp: input contains more than one value", err)
@@ -151,6 +161,7 @@ func TestValidator_ProposeBlock_Failed_WhenSubmitShardInfoFails(t *testing.T) {
 		gomock.Any(), // next block number
 		gomock.Any(), // slot
 		gomock.Any(), // epoch
+		gomock.Any(), // fee recipient
 	).Return(header, headerHash, extraData, nil) // nil - error
 
 	m.pandoraService.EXPECT().SubmitShardBlockHeader(
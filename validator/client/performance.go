@@ -0,0 +1,68 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/validator/db/kv"
+)
+
+// recordAttestationPerformance saves the attestation summary and balance change of the validating
+// keys for an epoch in the performance history of the validator DB.
+func (v *validator) recordAttestationPerformance(ctx context.Context, epoch types.Epoch, resp *ethpb.ValidatorPerformanceResponse) {
+	for i, pubKey := range resp.PublicKeys {
+		err := v.db.UpdateEpochPerformance(ctx, bytesutil.ToBytes48(pubKey), epoch, func(p *kv.EpochPerformance) {
+			p.AttestationRecorded = true
+			p.CorrectlyVotedSource = resp.CorrectlyVotedSource[i]
+			p.CorrectlyVotedTarget = resp.CorrectlyVotedTarget[i]
+			p.CorrectlyVotedHead = resp.CorrectlyVotedHead[i]
+			p.InclusionSlot = resp.InclusionSlots[i]
+			p.InclusionDistance = resp.InclusionDistances[i]
+			p.BalanceBefore = resp.BalancesBeforeEpochTransition[i]
+			p.BalanceAfter = resp.BalancesAfterEpochTransition[i]
+		})
+		if err != nil {
+			log.WithError(err).WithField("pubKey", fmt.Sprintf("%#x", bytesutil.Trunc(pubKey))).Error(
+				"Could not save attestation performance")
+		}
+	}
+}
+
+// recordProposal saves a block proposal of a validating key, made or missed, in the performance
+// history of the validator DB.
+func (v *validator) recordProposal(ctx context.Context, pubKey [48]byte, slot types.Slot, proposed bool) {
+	v.updateSlotPerformance(ctx, pubKey, slot, func(p *kv.EpochPerformance) {
+		if proposed {
+			p.ProposedSlots = append(p.ProposedSlots, slot)
+		} else {
+			p.MissedSlots = append(p.MissedSlots, slot)
+		}
+	})
+}
+
+// recordShardSubmission saves the outcome of the Pandora shard header submission of a proposal
+// in the performance history of the validator DB.
+func (v *validator) recordShardSubmission(ctx context.Context, pubKey [48]byte, slot types.Slot, submitted bool) {
+	v.updateSlotPerformance(ctx, pubKey, slot, func(p *kv.EpochPerformance) {
+		if submitted {
+			p.ShardSubmittedSlots = append(p.ShardSubmittedSlots, slot)
+		} else {
+			p.ShardFailedSlots = append(p.ShardFailedSlots, slot)
+		}
+	})
+}
+
+func (v *validator) updateSlotPerformance(ctx context.Context, pubKey [48]byte, slot types.Slot, update func(p *kv.EpochPerformance)) {
+	if !v.recordPerformance {
+		return
+	}
+	epoch := types.Epoch(slot / params.BeaconConfig().SlotsPerEpoch)
+	if err := v.db.UpdateEpochPerformance(ctx, pubKey, epoch, update); err != nil {
+		log.WithError(err).WithField("pubKey", fmt.Sprintf("%#x", bytesutil.Trunc(pubKey[:]))).WithField("slot", slot).Error(
+			"Could not save proposal performance")
+	}
+}
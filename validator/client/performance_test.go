@@ -0,0 +1,71 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	dbTest "github.com/prysmaticlabs/prysm/validator/db/testing"
+)
+
+func TestValidator_RecordPerformance(t *testing.T) {
+	ctx := context.Background()
+	pubKey1, pubKey2 := [48]byte{1}, [48]byte{2}
+	v := &validator{db: dbTest.SetupDB(t, [][48]byte{pubKey1, pubKey2}), recordPerformance: true}
+
+	v.recordAttestationPerformance(ctx, 2, &ethpb.ValidatorPerformanceResponse{
+		PublicKeys:                    [][]byte{pubKey1[:], pubKey2[:]},
+		CorrectlyVotedSource:          []bool{true, false},
+		CorrectlyVotedTarget:          []bool{true, false},
+		CorrectlyVotedHead:            []bool{false, false},
+		InclusionSlots:                []types.Slot{65, types.Slot(^uint64(0))},
+		InclusionDistances:            []types.Slot{1, 0},
+		BalancesBeforeEpochTransition: []uint64{32000000000, 32000000000},
+		BalancesAfterEpochTransition:  []uint64{32000001000, 31999999000},
+	})
+	slot := params.BeaconConfig().SlotsPerEpoch.Mul(2) + 3
+	v.recordProposal(ctx, pubKey1, slot, true)
+	v.recordShardSubmission(ctx, pubKey1, slot, true)
+	v.recordProposal(ctx, pubKey2, slot+1, false)
+	v.recordShardSubmission(ctx, pubKey2, slot+1, false)
+
+	records, err := v.db.EpochPerformanceForPubKey(ctx, pubKey1, 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(records))
+	assert.Equal(t, types.Epoch(2), records[0].Epoch)
+	assert.Equal(t, true, records[0].AttestationIncluded())
+	assert.Equal(t, true, records[0].CorrectlyVotedTarget)
+	assert.Equal(t, types.Slot(1), records[0].InclusionDistance)
+	assert.Equal(t, int64(1000), records[0].BalanceDelta())
+	assert.DeepEqual(t, []types.Slot{slot}, records[0].ProposedSlots)
+	assert.DeepEqual(t, []types.Slot{slot}, records[0].ShardSubmittedSlots)
+
+	records, err = v.db.EpochPerformanceForPubKey(ctx, pubKey2, 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(records))
+	assert.Equal(t, false, records[0].AttestationIncluded())
+	assert.Equal(t, int64(-1000), records[0].BalanceDelta())
+	assert.DeepEqual(t, []types.Slot{slot + 1}, records[0].MissedSlots)
+	assert.DeepEqual(t, []types.Slot{slot + 1}, records[0].ShardFailedSlots)
+}
+
+func TestValidator_RecordPerformance_Disabled(t *testing.T) {
+	ctx := context.Background()
+	pubKey := [48]byte{1}
+	v := &validator{db: dbTest.SetupDB(t, [][48]byte{pubKey})}
+
+	slot := params.BeaconConfig().SlotsPerEpoch.Mul(2) + 3
+	v.recordProposal(ctx, pubKey, slot, true)
+	v.recordShardSubmission(ctx, pubKey, slot, false)
+
+	records, err := v.db.EpochPerformanceForPubKey(ctx, pubKey, 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(records))
+
+	// Neither the key manager nor the beacon client is needed when nothing uses the validator performance.
+	require.NoError(t, v.LogValidatorGainsAndLosses(ctx, params.BeaconConfig().SlotsPerEpoch.Mul(2)-1))
+}
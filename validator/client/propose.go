@@ -39,6 +39,11 @@ func (v *validator) ProposeBlock(ctx context.Context, slot types.Slot, pubKey [4
 		log.Debug("Assigned to genesis slot, skipping proposal")
 		return
 	}
+	if v.proposerConfig != nil && !v.proposerConfig.Settings(pubKey).Enabled {
+		log.WithField("pubKey", fmt.Sprintf("%#x", bytesutil.Trunc(pubKey[:]))).WithField("slot", slot).Warn(
+			"Proposing is disabled for this validator in the proposer config file, skipping proposal")
+		return
+	}
 	lock := mputil.NewMultilock(fmt.Sprint(iface.RoleProposer), string(pubKey[:]))
 	lock.Lock()
 	defer lock.Unlock()
@@ -46,6 +51,12 @@ func (v *validator) ProposeBlock(ctx context.Context, slot types.Slot, pubKey [4
 	defer span.End()
 	fmtKey := fmt.Sprintf("%#x", pubKey[:])
 
+	// Every scheduled proposal is recorded in the performance history, either made or missed.
+	proposed := false
+	defer func() {
+		v.recordProposal(ctx, pubKey, slot, proposed)
+	}()
+
 	span.AddAttributes(trace.StringAttribute("validator", fmt.Sprintf("%#x", pubKey)))
 	log := log.WithField("pubKey", fmt.Sprintf("%#x", bytesutil.Trunc(pubKey[:])))
 
@@ -85,13 +96,18 @@ func (v *validator) ProposeBlock(ctx context.Context, slot types.Slot, pubKey [4
 	// Vanguard: If VanguardNetwork flag is enabled then this if state will be executed
 	if v.enableVanguardNode {
 		// Vanguard: processPandoraShardHeader method process the block header from pandora chain
-		if err := v.processPandoraShardHeader(ctx, b, slot, epoch, pubKey); err != nil {
+		err := v.processPandoraShardHeader(ctx, b, slot, epoch, pubKey)
+		v.recordShardSubmission(ctx, pubKey, slot, err == nil)
+		if err != nil {
 			log.WithField("blockSlot", slot).WithError(err).Error("Failed to process pandora sharding info")
 			return
 		}
 	}
 
-	// Sign returned block from beacon node
+	// Sign returned block from beacon node. The signing lock is held until the block is saved in
+	// the slashing protection history.
+	v.signingLock.RLock()
+	defer v.signingLock.RUnlock()
 	sig, domain, err := v.signBlock(ctx, pubKey, epoch, b)
 	if err != nil {
 		log.WithError(err).Error("Failed to sign block")
@@ -129,7 +145,7 @@ func (v *validator) ProposeBlock(ctx context.Context, slot types.Slot, pubKey [4
 	}
 
 	// Propose and broadcast block via beacon node
-	blkResp, err := v.validatorClient.ProposeBlock(ctx, blk)
+	blkResp, err := v.signedMessageClient().ProposeBlock(ctx, blk)
 	if err != nil {
 		log.WithError(err).Error("Failed to propose block")
 		if v.emitAccountMetrics {
@@ -153,6 +169,7 @@ func (v *validator) ProposeBlock(ctx context.Context, slot types.Slot, pubKey [4
 		"graffiti":        string(b.Body.Graffiti),
 	}).Info("Submitted new block")
 
+	proposed = true
 	if v.emitAccountMetrics {
 		ValidatorProposeSuccessVec.WithLabelValues(fmtKey).Inc()
 	}
@@ -293,9 +310,17 @@ func signVoluntaryExit(
 	return sig.Marshal(), nil
 }
 
-// Gets the graffiti from cli or file for the validator public key.
+// Gets the graffiti from the proposer config file, cli or graffiti file for the validator public key.
 func (v *validator) getGraffiti(ctx context.Context, pubKey [48]byte) ([]byte, error) {
-	// When specified, default graffiti from the command line takes the first priority.
+	// When specified, the graffiti of the validator or the default graffiti of the proposer config
+	// file takes the first priority.
+	if v.proposerConfig != nil {
+		if g := v.proposerConfig.Settings(pubKey).Graffiti; len(g) != 0 {
+			return g, nil
+		}
+	}
+
+	// When specified, default graffiti from the command line takes the second priority.
 	if len(v.graffiti) != 0 {
 		return v.graffiti, nil
 	}
@@ -304,7 +329,7 @@ func (v *validator) getGraffiti(ctx context.Context, pubKey [48]byte) ([]byte, e
 		return nil, errors.New("graffitiStruct can't be nil")
 	}
 
-	// When specified, individual validator specified graffiti takes the second priority.
+	// When specified, individual validator specified graffiti takes the third priority.
 	idx, err := v.validatorClient.ValidatorIndex(ctx, &ethpb.ValidatorIndexRequest{PublicKey: pubKey[:]})
 	if err != nil {
 		return []byte{}, err
@@ -314,7 +339,7 @@ func (v *validator) getGraffiti(ctx context.Context, pubKey [48]byte) ([]byte, e
 		return []byte(g), nil
 	}
 
-	// When specified, a graffiti from the ordered list in the file take third priority.
+	// When specified, a graffiti from the ordered list in the file take fourth priority.
 	if v.graffitiOrderedIndex < uint64(len(v.graffitiStruct.Ordered)) {
 		graffiti := v.graffitiStruct.Ordered[v.graffitiOrderedIndex]
 		v.graffitiOrderedIndex = v.graffitiOrderedIndex + 1
@@ -325,7 +350,7 @@ func (v *validator) getGraffiti(ctx context.Context, pubKey [48]byte) ([]byte, e
 		return []byte(graffiti), nil
 	}
 
-	// When specified, a graffiti from the random list in the file take fourth priority.
+	// When specified, a graffiti from the random list in the file take fifth priority.
 	if len(v.graffitiStruct.Random) != 0 {
 		r := rand.NewGenerator()
 		r.Seed(time.Now().Unix())
@@ -4,7 +4,11 @@ import (
 	"context"
 	"encoding/hex"
 	"errors"
+	"fmt"
 	"github.com/prysmaticlabs/prysm/shared/van_mock"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -23,6 +27,7 @@ import (
 	"github.com/prysmaticlabs/prysm/shared/testutil/require"
 	testing2 "github.com/prysmaticlabs/prysm/validator/db/testing"
 	"github.com/prysmaticlabs/prysm/validator/graffiti"
+	proposerconfig "github.com/prysmaticlabs/prysm/validator/proposer-config"
 	logTest "github.com/sirupsen/logrus/hooks/test"
 	grpc "google.golang.org/grpc"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -34,6 +39,7 @@ type mocks struct {
 	signExitFunc      func(context.Context, *validatorpb.SignRequest) (bls.Signature, error)
 	pandoraService    *van_mock.MockPandoraService
 	beaconChainClient *mock.MockBeaconChainClient
+	altairClient      *mock.MockBeaconNodeValidatorAltairClient
 }
 
 type mockSignature struct{}
@@ -72,6 +78,7 @@ func setup(t *testing.T) (*validator, *mocks, bls.SecretKey, func()) {
 		},
 		pandoraService:    van_mock.NewMockPandoraService(ctrl),
 		beaconChainClient: mock.NewMockBeaconChainClient(ctrl),
+		altairClient:      mock.NewMockBeaconNodeValidatorAltairClient(ctrl),
 	}
 
 	aggregatedSlotCommitteeIDCache, err := lru.New(int(params.BeaconConfig().MaxCommitteesPerSlot))
@@ -91,6 +98,7 @@ func setup(t *testing.T) (*validator, *mocks, bls.SecretKey, func()) {
 		aggregatedSlotCommitteeIDCache: aggregatedSlotCommitteeIDCache,
 		pandoraService:                 m.pandoraService,
 		beaconClient:                   m.beaconChainClient,
+		altairClient:                   m.altairClient,
 	}
 
 	return validator, m, validatorKey, ctrl.Finish
@@ -107,6 +115,27 @@ func TestProposeBlock_DoesNotProposeGenesisBlock(t *testing.T) {
 	require.LogsContain(t, hook, "Assigned to genesis slot, skipping proposal")
 }
 
+func newProposerConfig(t *testing.T, content string) *proposerconfig.Store {
+	path := filepath.Join(t.TempDir(), "proposer-config.yaml")
+	require.NoError(t, ioutil.WriteFile(path, []byte(content), os.ModePerm))
+	s, err := proposerconfig.NewStore(path)
+	require.NoError(t, err)
+	return s
+}
+
+func TestProposeBlock_DisabledInProposerConfig(t *testing.T) {
+	hook := logTest.NewGlobal()
+	validator, _, validatorKey, finish := setup(t)
+	defer finish()
+	pubKey := [48]byte{}
+	copy(pubKey[:], validatorKey.PublicKey().Marshal())
+	validator.proposerConfig = newProposerConfig(t, fmt.Sprintf("proposers: {\"%#x\": {enabled: false}}", pubKey))
+
+	// No call to the beacon node is expected.
+	validator.ProposeBlock(context.Background(), 1, pubKey)
+	require.LogsContain(t, hook, "Proposing is disabled for this validator in the proposer config file")
+}
+
 func TestProposeBlock_DomainDataFailed(t *testing.T) {
 	hook := logTest.NewGlobal()
 	validator, m, validatorKey, finish := setup(t)
@@ -164,6 +193,7 @@ func TestProposeBlock_ProposeBlockFailed(t *testing.T) {
 	hook := logTest.NewGlobal()
 	validator, m, validatorKey, finish := setup(t)
 	defer finish()
+	validator.recordPerformance = true
 	pubKey := [48]byte{}
 	copy(pubKey[:], validatorKey.PublicKey().Marshal())
 
@@ -189,6 +219,11 @@ func TestProposeBlock_ProposeBlockFailed(t *testing.T) {
 
 	validator.ProposeBlock(context.Background(), 1, pubKey)
 	require.LogsContain(t, hook, "Failed to propose block")
+
+	records, err := validator.db.EpochPerformanceForPubKey(context.Background(), pubKey, 0, 0)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(records))
+	assert.DeepEqual(t, []types.Slot{1}, records[0].MissedSlots)
 }
 
 func TestProposeBlock_BlocksDoubleProposal(t *testing.T) {
@@ -755,6 +790,27 @@ func TestGetGraffiti_Ok(t *testing.T) {
 	}
 }
 
+func TestGetGraffiti_ProposerConfig(t *testing.T) {
+	pubKey := [48]byte{'a'}
+	v := &validator{
+		graffiti: []byte{'b'},
+		proposerConfig: newProposerConfig(t, fmt.Sprintf(`default: {graffiti: "c"}
+proposers: {"%#x": {graffiti: "d"}}`, pubKey)),
+	}
+	got, err := v.getGraffiti(context.Background(), pubKey)
+	require.NoError(t, err)
+	require.DeepEqual(t, []byte{'d'}, got)
+
+	got, err = v.getGraffiti(context.Background(), [48]byte{'e'})
+	require.NoError(t, err)
+	require.DeepEqual(t, []byte{'c'}, got)
+
+	v.proposerConfig = newProposerConfig(t, `default: {enabled: true}`)
+	got, err = v.getGraffiti(context.Background(), pubKey)
+	require.NoError(t, err)
+	require.DeepEqual(t, []byte{'b'}, got)
+}
+
 func TestGetGraffitiOrdered_Ok(t *testing.T) {
 	pubKey := [48]byte{'a'}
 	valDB := testing2.SetupDB(t, [][48]byte{pubKey})
@@ -84,13 +84,14 @@ func run(ctx context.Context, v iface.Validator) {
 		if err != nil {
 			log.Fatalf("Could not wait for validator activation: %v", err)
 		}
+		// Keys stay quarantined until they pass the check, which is run again at every epoch.
 		err = v.CheckDoppelGanger(ctx)
 		if isConnectionError(err) {
 			log.Warnf("Could not wait for checking doppelganger: %v", err)
 			continue
 		}
 		if err != nil {
-			log.Fatalf("Could not succeed with doppelganger check: %v", err)
+			log.WithError(err).Error("Could not succeed with doppelganger check")
 		}
 		headSlot, err = v.CanonicalHeadSlot(ctx)
 		if isConnectionError(err) {
@@ -175,6 +176,15 @@ func run(ctx context.Context, v iface.Validator) {
 				continue
 			}
 
+			// Keep observing the keys which are in their doppelganger check window.
+			if helpers.IsEpochStart(slot) {
+				go func() {
+					if err := v.CheckDoppelGanger(ctx); err != nil {
+						log.WithError(err).Error("Could not succeed with doppelganger check")
+					}
+				}()
+			}
+
 			// Start fetching domain data for the next epoch.
 			if helpers.IsEpochEnd(slot) {
 				go v.UpdateDomainDataCaches(ctx, slot+1)
@@ -200,6 +210,10 @@ func run(ctx context.Context, v iface.Validator) {
 							v.ProposeBlock(slotCtx, slot, pubKey)
 						case iface.RoleAggregator:
 							v.SubmitAggregateAndProof(slotCtx, slot, pubKey)
+						case iface.RoleSyncCommittee:
+							v.SubmitSyncCommitteeMessage(slotCtx, slot, pubKey)
+						case iface.RoleSyncCommitteeAggregator:
+							v.SubmitSignedContributionAndProof(slotCtx, slot, pubKey)
 						case iface.RoleUnknown:
 							log.WithField("pubKey", fmt.Sprintf("%#x", bytesutil.Trunc(pubKey[:]))).Trace("No active roles, doing nothing")
 						default:
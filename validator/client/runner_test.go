@@ -193,6 +193,26 @@ func TestBothProposesAndAttests_NextSlot(t *testing.T) {
 	assert.Equal(t, uint64(slot), v.ProposeBlockArg1, "ProposeBlock was called with wrong arg")
 }
 
+func TestSyncCommitteeDuties_NextSlot(t *testing.T) {
+	v := &testutil.FakeValidator{Keymanager: &mockKeymanager{accountsChangedFeed: &event.Feed{}}}
+	ctx, cancel := context.WithCancel(context.Background())
+
+	slot := types.Slot(55)
+	ticker := make(chan types.Slot)
+	v.NextSlotRet = ticker
+	v.RolesAtRet = []iface.ValidatorRole{iface.RoleSyncCommittee, iface.RoleSyncCommitteeAggregator}
+	go func() {
+		ticker <- slot
+
+		cancel()
+	}()
+	timer := time.NewTimer(200 * time.Millisecond)
+	run(ctx, v)
+	<-timer.C
+	require.Equal(t, true, v.SubmitSyncCommitteeMessageCalled, "SubmitSyncCommitteeMessage(%d) was not called", slot)
+	require.Equal(t, true, v.SubmitSignedContributionAndProofCalled, "SubmitSignedContributionAndProof(%d) was not called", slot)
+}
+
 func TestAllValidatorsAreExited_NextSlot(t *testing.T) {
 	v := &testutil.FakeValidator{Keymanager: &mockKeymanager{accountsChangedFeed: &event.Feed{}}}
 	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), testutil.AllValidatorsAreExitedCtxKey, true))
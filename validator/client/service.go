@@ -17,8 +17,10 @@ import (
 	"github.com/pkg/errors"
 	types "github.com/prysmaticlabs/eth2-types"
 	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
+	prysmv2 "github.com/prysmaticlabs/prysm/proto/prysm/v2"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
 	"github.com/prysmaticlabs/prysm/shared/event"
+	"github.com/prysmaticlabs/prysm/shared/featureconfig"
 	"github.com/prysmaticlabs/prysm/shared/grpcutils"
 	"github.com/prysmaticlabs/prysm/shared/params"
 	accountsiface "github.com/prysmaticlabs/prysm/validator/accounts/iface"
@@ -29,6 +31,7 @@ import (
 	"github.com/prysmaticlabs/prysm/validator/keymanager"
 	"github.com/prysmaticlabs/prysm/validator/keymanager/imported"
 	"github.com/prysmaticlabs/prysm/validator/pandora"
+	proposerconfig "github.com/prysmaticlabs/prysm/validator/proposer-config"
 	slashingiface "github.com/prysmaticlabs/prysm/validator/slashing-protection/iface"
 	"go.opencensus.io/plugin/ocgrpc"
 	"google.golang.org/grpc"
@@ -54,9 +57,12 @@ type ValidatorService struct {
 	useWeb                bool
 	emitAccountMetrics    bool
 	logValidatorBalances  bool
+	recordPerformance     bool
 	logDutyCountDown      bool
 	enableVanguardNode    bool // Vanguard: enableVanguardNode is needed for vanguard chain
-	conn                  *grpc.ClientConn
+	conn                  *failoverConn
+	healthCheckInterval   time.Duration
+	broadcastSignedMsgs   bool
 	grpcRetryDelay        time.Duration
 	grpcRetries           uint
 	maxCallRecvMsgSize    int
@@ -73,6 +79,8 @@ type ValidatorService struct {
 	grpcHeaders           []string
 	graffiti              []byte
 	graffitiStruct        *graffiti.Graffiti
+	proposerConfig        *proposerconfig.Store
+	doppelgangerEpochs    uint64
 	pandoraService        pandora.PandoraService // Vanguard: Pandora service is needed for vanguard chain
 }
 
@@ -80,12 +88,15 @@ type ValidatorService struct {
 type Config struct {
 	UseWeb                     bool
 	LogValidatorBalances       bool
+	RecordPerformance          bool
 	EmitAccountMetrics         bool
 	LogDutyCountDown           bool
 	EnableVanguardNode         bool // Vanguard: Boolean flag for checking vanguard chain
 	WalletInitializedFeed      *event.Feed
 	GrpcRetriesFlag            uint
 	GrpcRetryDelay             time.Duration
+	HealthCheckInterval        time.Duration
+	BroadcastSignedMessages    bool
 	GrpcMaxCallRecvMsgSizeFlag int
 	Protector                  slashingiface.Protector
 	Endpoint                   string
@@ -97,6 +108,8 @@ type Config struct {
 	DataDir                    string
 	GrpcHeadersFlag            string
 	GraffitiStruct             *graffiti.Graffiti
+	ProposerConfig             *proposerconfig.Store
+	DoppelgangerEpochs         uint64
 	PandoraService             pandora.PandoraService // Vanguard: Pandora service is needed for vanguard chain
 
 }
@@ -114,10 +127,13 @@ func NewValidatorService(ctx context.Context, cfg *Config) (*ValidatorService, e
 		graffiti:              []byte(cfg.GraffitiFlag),
 		keyManager:            cfg.KeyManager,
 		logValidatorBalances:  cfg.LogValidatorBalances,
+		recordPerformance:     cfg.RecordPerformance,
 		emitAccountMetrics:    cfg.EmitAccountMetrics,
 		maxCallRecvMsgSize:    cfg.GrpcMaxCallRecvMsgSizeFlag,
 		grpcRetries:           cfg.GrpcRetriesFlag,
 		grpcRetryDelay:        cfg.GrpcRetryDelay,
+		healthCheckInterval:   cfg.HealthCheckInterval,
+		broadcastSignedMsgs:   cfg.BroadcastSignedMessages,
 		grpcHeaders:           strings.Split(cfg.GrpcHeadersFlag, ","),
 		protector:             cfg.Protector,
 		validator:             cfg.Validator,
@@ -125,6 +141,8 @@ func NewValidatorService(ctx context.Context, cfg *Config) (*ValidatorService, e
 		walletInitializedFeed: cfg.WalletInitializedFeed,
 		useWeb:                cfg.UseWeb,
 		graffitiStruct:        cfg.GraffitiStruct,
+		proposerConfig:        cfg.ProposerConfig,
+		doppelgangerEpochs:    cfg.DoppelgangerEpochs,
 		logDutyCountDown:      cfg.LogDutyCountDown,
 		pandoraService:        cfg.PandoraService,
 		enableVanguardNode:    cfg.EnableVanguardNode,
@@ -134,42 +152,29 @@ func NewValidatorService(ctx context.Context, cfg *Config) (*ValidatorService, e
 // Start the validator service. Launches the main go routine for the validator
 // client.
 func (v *ValidatorService) Start() {
-	beaconRpcAddr, protocol, err := rpcutil.ResolveRpcAddressAndProtocol(v.endpoint, "")
-	if err != nil {
-		log.Errorf("Could not ResolveRpcAddressAndProtocol in Start() %s: %v", beaconRpcAddr, err)
-	}
-
-	dialOpts := ConstructDialOptions(
-		v.maxCallRecvMsgSize,
-		v.withCert,
-		v.grpcRetries,
-		v.grpcRetryDelay,
-	)
-	if dialOpts == nil {
-		return
-	}
-
-	if "unix" == protocol {
-		dialer := func(addr string, t time.Duration) (net.Conn, error) {
-			return net.Dial(protocol, addr)
-		}
-
-		dialOpts = append(dialOpts, grpc.WithDialer(dialer))
-		dialOpts = append(dialOpts, grpc.WithInsecure())
-	}
-
 	v.ctx = grpcutils.AppendHeaders(v.ctx, v.grpcHeaders)
 
-	conn, err := grpc.DialContext(v.ctx, beaconRpcAddr, dialOpts...)
-	if err != nil {
-		log.Errorf("Could not dial endpoint: %s, %v", beaconRpcAddr, err)
-		return
+	// Every beacon node listed in the endpoint gets its own connection, so that the
+	// validator client can fail over from one to another depending on their health.
+	endpoints := strings.Split(v.endpoint, ",")
+	conns := make([]grpc.ClientConnInterface, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		conn, err := v.dialBeaconNode(endpoint)
+		if err != nil {
+			log.Errorf("Could not dial endpoint: %s, %v", endpoint, err)
+			return
+		}
+		if conn == nil {
+			return
+		}
+		conns = append(conns, conn)
 	}
 	if v.withCert != "" {
 		log.Info("Established secure gRPC connection")
 	}
 
-	v.conn = conn
+	v.conn = newFailoverConn(endpoints, conns, v.healthCheckInterval)
+	go v.conn.run(v.ctx)
 	cache, err := ristretto.NewCache(&ristretto.Config{
 		NumCounters: 1920, // number of keys to track.
 		MaxCost:     192,  // maximum cost of cache, 1 item = 1 cost.
@@ -201,14 +206,27 @@ func (v *ValidatorService) Start() {
 		return
 	}
 
+	var broadcastClient ethpb.BeaconNodeValidatorClient
+	if v.broadcastSignedMsgs {
+		broadcastClient = ethpb.NewBeaconNodeValidatorClient(&broadcastConn{v.conn})
+	}
+
+	var doppelganger *doppelgangerTracker
+	if featureconfig.Get().EnableDoppelGanger {
+		doppelganger = newDoppelgangerTracker(v.doppelgangerEpochs)
+	}
+
 	v.validator = &validator{
 		db:                             v.db,
 		validatorClient:                ethpb.NewBeaconNodeValidatorClient(v.conn),
+		broadcastClient:                broadcastClient,
+		altairClient:                   prysmv2.NewBeaconNodeValidatorAltairClient(v.conn),
 		beaconClient:                   ethpb.NewBeaconChainClient(v.conn),
 		node:                           ethpb.NewNodeClient(v.conn),
 		keyManager:                     v.keyManager,
 		graffiti:                       v.graffiti,
 		logValidatorBalances:           v.logValidatorBalances,
+		recordPerformance:              v.recordPerformance,
 		emitAccountMetrics:             v.emitAccountMetrics,
 		startBalances:                  make(map[[48]byte]uint64),
 		prevBalance:                    make(map[[48]byte]uint64),
@@ -222,6 +240,8 @@ func (v *ValidatorService) Start() {
 		blockFeed:                      new(event.Feed),
 		graffitiStruct:                 v.graffitiStruct,
 		graffitiOrderedIndex:           graffitiOrderedIndex,
+		proposerConfig:                 v.proposerConfig,
+		doppelganger:                   doppelganger,
 		eipImportBlacklistedPublicKeys: slashablePublicKeys,
 		logDutyCountDown:               v.logDutyCountDown,
 		// vanguard: initialization for vanguard validator chain
@@ -230,6 +250,56 @@ func (v *ValidatorService) Start() {
 	}
 	go run(v.ctx, v.validator)
 	go v.recheckKeys(v.ctx)
+	if v.proposerConfig != nil {
+		go v.proposerConfig.Run(v.ctx)
+	}
+}
+
+// dialBeaconNode opens a gRPC connection to a beacon node endpoint. It returns a nil connection
+// when the dial options cannot be constructed, which is logged.
+func (v *ValidatorService) dialBeaconNode(endpoint string) (*grpc.ClientConn, error) {
+	beaconRpcAddr, protocol, err := rpcutil.ResolveRpcAddressAndProtocol(endpoint, "")
+	if err != nil {
+		log.Errorf("Could not ResolveRpcAddressAndProtocol in Start() %s: %v", beaconRpcAddr, err)
+	}
+
+	dialOpts := ConstructDialOptions(
+		v.maxCallRecvMsgSize,
+		v.withCert,
+		v.grpcRetries,
+		v.grpcRetryDelay,
+	)
+	if dialOpts == nil {
+		return nil, nil
+	}
+
+	if "unix" == protocol {
+		dialer := func(addr string, t time.Duration) (net.Conn, error) {
+			return net.Dial(protocol, addr)
+		}
+
+		dialOpts = append(dialOpts, grpc.WithDialer(dialer))
+		dialOpts = append(dialOpts, grpc.WithInsecure())
+	}
+
+	return grpc.DialContext(v.ctx, beaconRpcAddr, dialOpts...)
+}
+
+// DoppelgangerStatuses returns the doppelganger status of the validating keys.
+func (v *ValidatorService) DoppelgangerStatuses() map[[48]byte]*iface.DoppelgangerKeyStatus {
+	if v.validator == nil {
+		return make(map[[48]byte]*iface.DoppelgangerKeyStatus)
+	}
+	return v.validator.DoppelgangerStatuses()
+}
+
+// WaitForInFlightSigning waits for the signatures being made with the validating keys to be
+// saved in the slashing protection history.
+func (v *ValidatorService) WaitForInFlightSigning() {
+	if v.validator == nil {
+		return
+	}
+	v.validator.WaitForInFlightSigning()
 }
 
 // Stop the validator service.
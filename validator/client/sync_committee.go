@@ -0,0 +1,273 @@
+package client
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	prysmv2 "github.com/prysmaticlabs/prysm/proto/prysm/v2"
+	validatorpb "github.com/prysmaticlabs/prysm/proto/validator/accounts/v2"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/hashutil"
+	"github.com/prysmaticlabs/prysm/shared/mathutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/sirupsen/logrus"
+	"go.opencensus.io/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// SubmitSyncCommitteeMessage signs the head block root of the beacon node with the validator's
+// key and submits it as the validator's sync committee message for the slot.
+func (v *validator) SubmitSyncCommitteeMessage(ctx context.Context, slot types.Slot, pubKey [48]byte) {
+	ctx, span := trace.StartSpan(ctx, "validator.SubmitSyncCommitteeMessage")
+	defer span.End()
+	span.AddAttributes(trace.StringAttribute("validator", fmt.Sprintf("%#x", pubKey)))
+
+	// Like attestations, sync committee messages are produced once the block of the slot
+	// has been received, or one third into the slot, whichever comes first.
+	v.waitOneThirdOrValidBlock(ctx, slot)
+
+	duty, err := v.duty(pubKey)
+	if err != nil {
+		log.WithError(err).Error("Could not fetch validator assignment")
+		return
+	}
+
+	res, err := v.altairClient.GetSyncMessageBlockRoot(ctx, &emptypb.Empty{})
+	if err != nil {
+		log.WithError(err).Error("Could not request sync message block root to sign")
+		return
+	}
+
+	d, err := v.domainData(ctx, helpers.SlotToEpoch(slot), params.BeaconConfig().DomainSyncCommittee[:])
+	if err != nil {
+		log.WithError(err).Error("Could not get sync committee domain data")
+		return
+	}
+	blockRoot := types.SSZBytes(res.Root)
+	r, err := helpers.ComputeSigningRoot(&blockRoot, d.SignatureDomain)
+	if err != nil {
+		log.WithError(err).Error("Could not get sync committee message signing root")
+		return
+	}
+	sig, err := v.keyManager.Sign(ctx, &validatorpb.SignRequest{
+		PublicKey:       pubKey[:],
+		SigningRoot:     r[:],
+		SignatureDomain: d.SignatureDomain,
+		SigningSlot:     slot,
+		Object:          &validatorpb.SignRequest_SyncMessageBlockRoot{SyncMessageBlockRoot: res.Root},
+	})
+	if err != nil {
+		log.WithError(err).Error("Could not sign sync committee message")
+		return
+	}
+
+	msg := &prysmv2.SyncCommitteeMessage{
+		Slot:           slot,
+		BlockRoot:      res.Root,
+		ValidatorIndex: duty.ValidatorIndex,
+		Signature:      sig.Marshal(),
+	}
+	if _, err := v.altairClient.SubmitSyncMessage(ctx, msg); err != nil {
+		log.WithError(err).Error("Could not submit sync committee message")
+		return
+	}
+
+	log.WithFields(logrus.Fields{
+		"slot":           msg.Slot,
+		"blockRoot":      fmt.Sprintf("%#x", bytesutil.Trunc(msg.BlockRoot)),
+		"validatorIndex": msg.ValidatorIndex,
+	}).Info("Submitted new sync message")
+}
+
+// SubmitSignedContributionAndProof submits a signed sync committee contribution and proof for
+// every sync subcommittee the validator has been selected to aggregate at the slot.
+func (v *validator) SubmitSignedContributionAndProof(ctx context.Context, slot types.Slot, pubKey [48]byte) {
+	ctx, span := trace.StartSpan(ctx, "validator.SubmitSignedContributionAndProof")
+	defer span.End()
+	span.AddAttributes(trace.StringAttribute("validator", fmt.Sprintf("%#x", pubKey)))
+
+	duty, err := v.duty(pubKey)
+	if err != nil {
+		log.WithError(err).Error("Could not fetch validator assignment")
+		return
+	}
+
+	indexRes, err := v.altairClient.GetSyncSubcommitteeIndex(ctx, &prysmv2.SyncSubcommitteeIndexRequest{
+		PublicKey: pubKey[:],
+		Slot:      slot,
+	})
+	if err != nil {
+		log.WithError(err).Error("Could not get sync subcommittee index")
+		return
+	}
+	if len(indexRes.Indices) == 0 {
+		log.Debug("Empty subcommittee index list, do nothing")
+		return
+	}
+
+	selectionProofs, err := v.syncCommitteeSelectionProofs(ctx, slot, pubKey, indexRes.Indices)
+	if err != nil {
+		log.WithError(err).Error("Could not get selection proofs")
+		return
+	}
+
+	// As specified in spec, an aggregator should wait until two thirds of the way through slot
+	// to broadcast the best contribution to the global contribution channel.
+	v.waitToSlotTwoThirds(ctx, slot)
+
+	for i, index := range indexRes.Indices {
+		if !isSyncCommitteeAggregator(selectionProofs[i]) {
+			continue
+		}
+		contribution, err := v.altairClient.GetSyncCommitteeContribution(ctx, &prysmv2.SyncCommitteeContributionRequest{
+			Slot:      slot,
+			PublicKey: pubKey[:],
+			SubnetId:  index,
+		})
+		if err != nil {
+			if s, ok := status.FromError(err); ok && s.Code() == codes.NotFound {
+				log.WithField("slot", slot).WithError(err).Warn("No sync committee messages to aggregate")
+			} else {
+				log.WithField("slot", slot).WithError(err).Error("Could not get sync committee contribution")
+			}
+			continue
+		}
+
+		contributionAndProof := &prysmv2.ContributionAndProof{
+			AggregatorIndex: duty.ValidatorIndex,
+			Contribution:    contribution,
+			SelectionProof:  selectionProofs[i],
+		}
+		sig, err := v.signContributionAndProof(ctx, pubKey, contributionAndProof)
+		if err != nil {
+			log.WithError(err).Error("Could not sign contribution and proof")
+			return
+		}
+
+		if _, err := v.altairClient.SubmitSignedContributionAndProof(ctx, &prysmv2.SignedContributionAndProof{
+			Message:   contributionAndProof,
+			Signature: sig,
+		}); err != nil {
+			log.WithError(err).Error("Could not submit signed contribution and proof")
+			return
+		}
+
+		log.WithFields(logrus.Fields{
+			"slot":              contribution.Slot,
+			"blockRoot":         fmt.Sprintf("%#x", bytesutil.Trunc(contribution.BlockRoot)),
+			"subcommitteeIndex": contribution.SubcommitteeIndex,
+			"aggregatorIndex":   contributionAndProof.AggregatorIndex,
+			"bitsCount":         contribution.AggregationBits.Count(),
+		}).Info("Submitted new sync contribution and proof")
+	}
+}
+
+// isSyncCommitteeAggregator checks if the validator is selected to aggregate sync committee
+// messages of any of its sync subcommittees at the given slot.
+func (v *validator) isSyncCommitteeAggregator(ctx context.Context, slot types.Slot, pubKey [48]byte) (bool, error) {
+	res, err := v.altairClient.GetSyncSubcommitteeIndex(ctx, &prysmv2.SyncSubcommitteeIndexRequest{
+		PublicKey: pubKey[:],
+		Slot:      slot,
+	})
+	if err != nil {
+		return false, err
+	}
+	selectionProofs, err := v.syncCommitteeSelectionProofs(ctx, slot, pubKey, res.Indices)
+	if err != nil {
+		return false, err
+	}
+	for _, proof := range selectionProofs {
+		if isSyncCommitteeAggregator(proof) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// syncCommitteeSelectionProofs signs the sync aggregator selection data of every given
+// subcommittee index at the slot.
+//
+// Spec code:
+// def get_sync_committee_selection_proof(state: BeaconState,
+//                                        slot: Slot,
+//                                        subcommittee_index: uint64,
+//                                        privkey: int) -> BLSSignature:
+//    domain = get_domain(state, DOMAIN_SYNC_COMMITTEE_SELECTION_PROOF, compute_epoch_at_slot(slot))
+//    signing_data = SyncAggregatorSelectionData(
+//        slot=slot,
+//        subcommittee_index=subcommittee_index,
+//    )
+//    signing_root = compute_signing_root(signing_data, domain)
+//    return bls.Sign(privkey, signing_root)
+func (v *validator) syncCommitteeSelectionProofs(ctx context.Context, slot types.Slot, pubKey [48]byte, indices []uint64) ([][]byte, error) {
+	d, err := v.domainData(ctx, helpers.SlotToEpoch(slot), params.BeaconConfig().DomainSyncCommitteeSelectionProof[:])
+	if err != nil {
+		return nil, err
+	}
+	proofs := make([][]byte, len(indices))
+	for i, index := range indices {
+		data := &pb.SyncAggregatorSelectionData{
+			Slot:              slot,
+			SubcommitteeIndex: index,
+		}
+		r, err := helpers.ComputeSigningRoot(data, d.SignatureDomain)
+		if err != nil {
+			return nil, err
+		}
+		sig, err := v.keyManager.Sign(ctx, &validatorpb.SignRequest{
+			PublicKey:       pubKey[:],
+			SigningRoot:     r[:],
+			SignatureDomain: d.SignatureDomain,
+			SigningSlot:     slot,
+			Object:          &validatorpb.SignRequest_SyncAggregatorSelectionData{SyncAggregatorSelectionData: data},
+		})
+		if err != nil {
+			return nil, err
+		}
+		proofs[i] = sig.Marshal()
+	}
+	return proofs, nil
+}
+
+// This returns the signature of validator signing over the contribution and proof object.
+func (v *validator) signContributionAndProof(ctx context.Context, pubKey [48]byte, c *prysmv2.ContributionAndProof) ([]byte, error) {
+	d, err := v.domainData(ctx, helpers.SlotToEpoch(c.Contribution.Slot), params.BeaconConfig().DomainContributionAndProof[:])
+	if err != nil {
+		return nil, err
+	}
+	r, err := helpers.ComputeSigningRoot(c, d.SignatureDomain)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := v.keyManager.Sign(ctx, &validatorpb.SignRequest{
+		PublicKey:       pubKey[:],
+		SigningRoot:     r[:],
+		SignatureDomain: d.SignatureDomain,
+		SigningSlot:     c.Contribution.Slot,
+		Object:          &validatorpb.SignRequest_ContributionAndProof{ContributionAndProof: c},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sig.Marshal(), nil
+}
+
+// isSyncCommitteeAggregator returns true if the selection proof selects its signer as an
+// aggregator of the sync subcommittee.
+//
+// Spec code:
+// def is_sync_committee_aggregator(signature: BLSSignature) -> bool:
+//    modulo = max(1, SYNC_COMMITTEE_SIZE // SYNC_COMMITTEE_SUBNET_COUNT // TARGET_AGGREGATORS_PER_SYNC_SUBCOMMITTEE)
+//    return bytes_to_uint64(hash(signature)[0:8]) % modulo == 0
+func isSyncCommitteeAggregator(sig []byte) bool {
+	cfg := params.BeaconConfig()
+	modulo := mathutil.Max(1, cfg.SyncCommitteeSize/cfg.SyncCommitteeSubnetCount/cfg.TargetAggregatorsPerSyncSubcommittee)
+	h := hashutil.Hash(sig)
+	return binary.LittleEndian.Uint64(h[:8])%modulo == 0
+}
@@ -0,0 +1,245 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	types "github.com/prysmaticlabs/eth2-types"
+	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
+	prysmv2 "github.com/prysmaticlabs/prysm/proto/prysm/v2"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+)
+
+func TestSubmitSyncCommitteeMessage_ValidatorDutiesRequestFailure(t *testing.T) {
+	hook := logTest.NewGlobal()
+	validator, _, validatorKey, finish := setup(t)
+	validator.duties = &ethpb.DutiesResponse{Duties: []*ethpb.DutiesResponse_Duty{}}
+	defer finish()
+
+	pubKey := [48]byte{}
+	copy(pubKey[:], validatorKey.PublicKey().Marshal())
+	validator.SubmitSyncCommitteeMessage(context.Background(), 1, pubKey)
+	require.LogsContain(t, hook, "Could not fetch validator assignment")
+}
+
+func TestSubmitSyncCommitteeMessage_BadDomainData(t *testing.T) {
+	hook := logTest.NewGlobal()
+	validator, m, validatorKey, finish := setup(t)
+	defer finish()
+	validator.duties = &ethpb.DutiesResponse{
+		Duties: []*ethpb.DutiesResponse_Duty{
+			{
+				PublicKey:      validatorKey.PublicKey().Marshal(),
+				ValidatorIndex: 7,
+			},
+		},
+	}
+
+	r := []byte{'a'}
+	m.altairClient.EXPECT().GetSyncMessageBlockRoot(
+		gomock.Any(), // ctx
+		gomock.Any(), // empty
+	).Return(&prysmv2.SyncMessageBlockRootResponse{Root: r}, nil)
+
+	m.validatorClient.EXPECT().DomainData(
+		gomock.Any(), // ctx
+		gomock.Any(), // epoch
+	).Return(nil, errors.New("uh oh"))
+
+	pubKey := [48]byte{}
+	copy(pubKey[:], validatorKey.PublicKey().Marshal())
+	validator.SubmitSyncCommitteeMessage(context.Background(), 1, pubKey)
+	require.LogsContain(t, hook, "Could not get sync committee domain data")
+}
+
+func TestSubmitSyncCommitteeMessage_CouldNotSubmit(t *testing.T) {
+	hook := logTest.NewGlobal()
+	validator, m, validatorKey, finish := setup(t)
+	defer finish()
+	validator.duties = &ethpb.DutiesResponse{
+		Duties: []*ethpb.DutiesResponse_Duty{
+			{
+				PublicKey:      validatorKey.PublicKey().Marshal(),
+				ValidatorIndex: 7,
+			},
+		},
+	}
+
+	r := bytesutil.PadTo([]byte{'a'}, 32)
+	m.altairClient.EXPECT().GetSyncMessageBlockRoot(
+		gomock.Any(), // ctx
+		gomock.Any(), // empty
+	).Return(&prysmv2.SyncMessageBlockRootResponse{Root: r}, nil)
+
+	m.validatorClient.EXPECT().DomainData(
+		gomock.Any(), // ctx
+		gomock.Any(), // epoch
+	).Return(&ethpb.DomainResponse{SignatureDomain: make([]byte, 32)}, nil)
+
+	m.altairClient.EXPECT().SubmitSyncMessage(
+		gomock.Any(), // ctx
+		gomock.AssignableToTypeOf(&prysmv2.SyncCommitteeMessage{}),
+	).Return(nil, errors.New("uh oh"))
+
+	pubKey := [48]byte{}
+	copy(pubKey[:], validatorKey.PublicKey().Marshal())
+	validator.SubmitSyncCommitteeMessage(context.Background(), 1, pubKey)
+	require.LogsContain(t, hook, "Could not submit sync committee message")
+}
+
+func TestSubmitSyncCommitteeMessage_OK(t *testing.T) {
+	hook := logTest.NewGlobal()
+	validator, m, validatorKey, finish := setup(t)
+	defer finish()
+	validator.duties = &ethpb.DutiesResponse{
+		Duties: []*ethpb.DutiesResponse_Duty{
+			{
+				PublicKey:      validatorKey.PublicKey().Marshal(),
+				ValidatorIndex: 7,
+			},
+		},
+	}
+
+	r := bytesutil.PadTo([]byte{'a'}, 32)
+	m.altairClient.EXPECT().GetSyncMessageBlockRoot(
+		gomock.Any(), // ctx
+		gomock.Any(), // empty
+	).Return(&prysmv2.SyncMessageBlockRootResponse{Root: r}, nil)
+
+	m.validatorClient.EXPECT().DomainData(
+		gomock.Any(), // ctx
+		&ethpb.DomainRequest{Epoch: 0, Domain: params.BeaconConfig().DomainSyncCommittee[:]},
+	).Return(&ethpb.DomainResponse{SignatureDomain: make([]byte, 32)}, nil)
+
+	var generatedMsg *prysmv2.SyncCommitteeMessage
+	m.altairClient.EXPECT().SubmitSyncMessage(
+		gomock.Any(), // ctx
+		gomock.AssignableToTypeOf(&prysmv2.SyncCommitteeMessage{}),
+	).Do(func(_ context.Context, msg *prysmv2.SyncCommitteeMessage) {
+		generatedMsg = msg
+	}).Return(nil, nil)
+
+	pubKey := [48]byte{}
+	copy(pubKey[:], validatorKey.PublicKey().Marshal())
+	validator.SubmitSyncCommitteeMessage(context.Background(), 1, pubKey)
+	require.LogsContain(t, hook, "Submitted new sync message")
+	assert.Equal(t, types.Slot(1), generatedMsg.Slot)
+	assert.Equal(t, types.ValidatorIndex(7), generatedMsg.ValidatorIndex)
+	assert.DeepEqual(t, r, generatedMsg.BlockRoot)
+}
+
+func TestSubmitSignedContributionAndProof_NotInSyncCommittee(t *testing.T) {
+	hook := logTest.NewGlobal()
+	validator, m, validatorKey, finish := setup(t)
+	defer finish()
+	validator.duties = &ethpb.DutiesResponse{
+		Duties: []*ethpb.DutiesResponse_Duty{
+			{
+				PublicKey:      validatorKey.PublicKey().Marshal(),
+				ValidatorIndex: 7,
+			},
+		},
+	}
+
+	m.altairClient.EXPECT().GetSyncSubcommitteeIndex(
+		gomock.Any(), // ctx
+		gomock.Any(), // request
+	).Return(&prysmv2.SyncSubcommitteeIndexResponse{}, nil)
+
+	pubKey := [48]byte{}
+	copy(pubKey[:], validatorKey.PublicKey().Marshal())
+	validator.SubmitSignedContributionAndProof(context.Background(), 1, pubKey)
+	require.LogsContain(t, hook, "Empty subcommittee index list, do nothing")
+}
+
+func TestSubmitSignedContributionAndProof_OK(t *testing.T) {
+	// Every selection proof selects an aggregator with a single target aggregator per
+	// subcommittee member.
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.TargetAggregatorsPerSyncSubcommittee = cfg.SyncCommitteeSize
+	params.OverrideBeaconConfig(cfg)
+
+	hook := logTest.NewGlobal()
+	validator, m, validatorKey, finish := setup(t)
+	defer finish()
+	validator.duties = &ethpb.DutiesResponse{
+		Duties: []*ethpb.DutiesResponse_Duty{
+			{
+				PublicKey:      validatorKey.PublicKey().Marshal(),
+				ValidatorIndex: 7,
+			},
+		},
+	}
+
+	m.altairClient.EXPECT().GetSyncSubcommitteeIndex(
+		gomock.Any(), // ctx
+		gomock.Any(), // request
+	).Return(&prysmv2.SyncSubcommitteeIndexResponse{Indices: []uint64{1}}, nil)
+
+	m.validatorClient.EXPECT().DomainData(
+		gomock.Any(), // ctx
+		&ethpb.DomainRequest{Epoch: 0, Domain: params.BeaconConfig().DomainSyncCommitteeSelectionProof[:]},
+	).Return(&ethpb.DomainResponse{SignatureDomain: make([]byte, 32)}, nil)
+
+	aggBits := prysmv2.NewSyncCommitteeAggregationBits()
+	aggBits.SetBitAt(0, true)
+	contribution := &prysmv2.SyncCommitteeContribution{
+		Slot:              1,
+		BlockRoot:         bytesutil.PadTo([]byte{'a'}, 32),
+		SubcommitteeIndex: 1,
+		AggregationBits:   aggBits.Bytes(),
+		Signature:         make([]byte, 96),
+	}
+	m.altairClient.EXPECT().GetSyncCommitteeContribution(
+		gomock.Any(), // ctx
+		&prysmv2.SyncCommitteeContributionRequest{
+			Slot:      1,
+			PublicKey: validatorKey.PublicKey().Marshal(),
+			SubnetId:  1,
+		},
+	).Return(contribution, nil)
+
+	m.validatorClient.EXPECT().DomainData(
+		gomock.Any(), // ctx
+		&ethpb.DomainRequest{Epoch: 0, Domain: params.BeaconConfig().DomainContributionAndProof[:]},
+	).Return(&ethpb.DomainResponse{SignatureDomain: make([]byte, 32)}, nil)
+
+	var submitted *prysmv2.SignedContributionAndProof
+	m.altairClient.EXPECT().SubmitSignedContributionAndProof(
+		gomock.Any(), // ctx
+		gomock.AssignableToTypeOf(&prysmv2.SignedContributionAndProof{}),
+	).Do(func(_ context.Context, s *prysmv2.SignedContributionAndProof) {
+		submitted = s
+	}).Return(nil, nil)
+
+	pubKey := [48]byte{}
+	copy(pubKey[:], validatorKey.PublicKey().Marshal())
+	validator.SubmitSignedContributionAndProof(context.Background(), 1, pubKey)
+	require.LogsContain(t, hook, "Submitted new sync contribution and proof")
+	assert.Equal(t, types.ValidatorIndex(7), submitted.Message.AggregatorIndex)
+	assert.DeepEqual(t, contribution, submitted.Message.Contribution)
+	assert.Equal(t, 96, len(submitted.Message.SelectionProof))
+	assert.Equal(t, 96, len(submitted.Signature))
+}
+
+func TestIsSyncCommitteeAggregator(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.TargetAggregatorsPerSyncSubcommittee = cfg.SyncCommitteeSize
+	params.OverrideBeaconConfig(cfg)
+	assert.Equal(t, true, isSyncCommitteeAggregator(make([]byte, 96)))
+
+	// hash(0x00 * 96)[:8] is not a multiple of a large modulo.
+	cfg.TargetAggregatorsPerSyncSubcommittee = 1
+	cfg.SyncCommitteeSize = 1 << 40
+	cfg.SyncCommitteeSubnetCount = 1
+	params.OverrideBeaconConfig(cfg)
+	assert.Equal(t, false, isSyncCommitteeAggregator(make([]byte, 96)))
+}
@@ -21,6 +21,7 @@ import (
 	types "github.com/prysmaticlabs/eth2-types"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
 	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
+	prysmv2 "github.com/prysmaticlabs/prysm/proto/prysm/v2"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
 	"github.com/prysmaticlabs/prysm/shared/event"
 	"github.com/prysmaticlabs/prysm/shared/featureconfig"
@@ -34,6 +35,7 @@ import (
 	"github.com/prysmaticlabs/prysm/validator/db/kv"
 	"github.com/prysmaticlabs/prysm/validator/graffiti"
 	"github.com/prysmaticlabs/prysm/validator/keymanager"
+	proposerconfig "github.com/prysmaticlabs/prysm/validator/proposer-config"
 	slashingiface "github.com/prysmaticlabs/prysm/validator/slashing-protection/iface"
 	"github.com/sirupsen/logrus"
 	"go.opencensus.io/trace"
@@ -56,6 +58,7 @@ var (
 
 type validator struct {
 	logValidatorBalances               bool
+	recordPerformance                  bool
 	useWeb                             bool
 	emitAccountMetrics                 bool
 	logDutyCountDown                   bool
@@ -65,6 +68,7 @@ type validator struct {
 	aggregatedSlotCommitteeIDCacheLock sync.Mutex
 	prevBalanceLock                    sync.RWMutex
 	slashableKeysLock                  sync.RWMutex
+	signingLock                        sync.RWMutex
 	walletInitializedFeed              *event.Feed
 	blockFeed                          *event.Feed
 	genesisTime                        uint64
@@ -80,12 +84,16 @@ type validator struct {
 	keyManager                         keymanager.IKeymanager
 	beaconClient                       ethpb.BeaconChainClient
 	validatorClient                    ethpb.BeaconNodeValidatorClient
+	broadcastClient                    ethpb.BeaconNodeValidatorClient
+	altairClient                       prysmv2.BeaconNodeValidatorAltairClient
 	protector                          slashingiface.Protector
 	db                                 vdb.Database
 	graffiti                           []byte
 	voteStats                          voteStats
 	graffitiStruct                     *graffiti.Graffiti
 	graffitiOrderedIndex               uint64
+	proposerConfig                     *proposerconfig.Store
+	doppelganger                       *doppelgangerTracker
 	eipImportBlacklistedPublicKeys     map[[48]byte]bool
 	pandoraService                     pandora.PandoraService // Vanguard: Pandora service is needed for vanguard chain
 }
@@ -373,74 +381,6 @@ func (v *validator) SlotDeadline(slot types.Slot) time.Time {
 	return time.Unix(int64(v.genesisTime), 0 /*ns*/).Add(secs * time.Second)
 }
 
-// CheckDoppelGanger checks if the current actively provided keys have
-// any duplicates active in the network.
-func (v *validator) CheckDoppelGanger(ctx context.Context) error {
-	if !featureconfig.Get().EnableDoppelGanger {
-		return nil
-	}
-	pubkeys, err := v.keyManager.FetchValidatingPublicKeys(ctx)
-	if err != nil {
-		return err
-	}
-	log.WithField("keys", len(pubkeys)).Info("Running doppelganger check")
-	// Exit early if no validating pub keys are found.
-	if len(pubkeys) == 0 {
-		return nil
-	}
-	req := &ethpb.DoppelGangerRequest{ValidatorRequests: []*ethpb.DoppelGangerRequest_ValidatorRequest{}}
-	for _, pkey := range pubkeys {
-		attRec, err := v.db.AttestationHistoryForPubKey(ctx, pkey)
-		if err != nil {
-			return err
-		}
-		if len(attRec) == 0 {
-			// If no history exists we simply send in a zero
-			// value for the request epoch and root.
-			req.ValidatorRequests = append(req.ValidatorRequests,
-				&ethpb.DoppelGangerRequest_ValidatorRequest{
-					PublicKey:  pkey[:],
-					Epoch:      0,
-					SignedRoot: make([]byte, 32),
-				})
-			continue
-		}
-		r := retrieveLatestRecord(attRec)
-		if pkey != r.PubKey {
-			return errors.New("attestation record mismatched public key")
-		}
-		req.ValidatorRequests = append(req.ValidatorRequests,
-			&ethpb.DoppelGangerRequest_ValidatorRequest{
-				PublicKey:  r.PubKey[:],
-				Epoch:      r.Target,
-				SignedRoot: r.SigningRoot[:],
-			})
-	}
-	resp, err := v.validatorClient.CheckDoppelGanger(ctx, req)
-	if err != nil {
-		return err
-	}
-	// If nothing is returned by the beacon node, we return an
-	// error as it is unsafe for us to proceed.
-	if resp == nil || resp.Responses == nil || len(resp.Responses) == 0 {
-		return errors.New("beacon node returned 0 responses for doppelganger check")
-	}
-	return buildDuplicateError(resp.Responses)
-}
-
-func buildDuplicateError(respones []*ethpb.DoppelGangerResponse_ValidatorResponse) error {
-	duplicates := make([][]byte, 0)
-	for _, valRes := range respones {
-		if valRes.DuplicateExists {
-			duplicates = append(duplicates, valRes.PublicKey)
-		}
-	}
-	if len(duplicates) == 0 {
-		return nil
-	}
-	return errors.Errorf("Duplicate instances exists in the network for validator keys: %#x", duplicates)
-}
-
 // Ensures that the latest attestion history is retrieved.
 func retrieveLatestRecord(recs []*kv.AttestationRecord) *kv.AttestationRecord {
 	if len(recs) == 0 {
@@ -605,6 +545,10 @@ func (v *validator) RolesAt(ctx context.Context, slot types.Slot) (map[[48]byte]
 		if duty == nil {
 			continue
 		}
+		// Keys which did not pass their doppelganger check do not perform any duty.
+		if !v.doppelganger.isClean(bytesutil.ToBytes48(duty.PublicKey)) {
+			continue
+		}
 		if len(duty.ProposerSlots) > 0 {
 			for _, proposerSlot := range duty.ProposerSlots {
 				if proposerSlot != 0 && proposerSlot == slot {
@@ -625,6 +569,19 @@ func (v *validator) RolesAt(ctx context.Context, slot types.Slot) (map[[48]byte]
 			}
 
 		}
+		if duty.IsSyncCommittee {
+			roles = append(roles, iface.RoleSyncCommittee)
+
+			// The aggregator check requests the sync subcommittee of the key from the beacon
+			// node, a failure must not prevent the other duties of the slot.
+			aggregator, err := v.isSyncCommitteeAggregator(ctx, slot, bytesutil.ToBytes48(duty.PublicKey))
+			if err != nil {
+				log.WithError(err).WithField("pubKey", fmt.Sprintf("%#x", bytesutil.Trunc(duty.PublicKey))).
+					Error("Could not check if a validator is a sync committee aggregator")
+			} else if aggregator {
+				roles = append(roles, iface.RoleSyncCommitteeAggregator)
+			}
+		}
 		if len(roles) == 0 {
 			roles = append(roles, iface.RoleUnknown)
 		}
@@ -670,6 +627,9 @@ func (v *validator) UpdateDomainDataCaches(ctx context.Context, slot types.Slot)
 		params.BeaconConfig().DomainBeaconProposer[:],
 		params.BeaconConfig().DomainSelectionProof[:],
 		params.BeaconConfig().DomainAggregateAndProof[:],
+		params.BeaconConfig().DomainSyncCommittee[:],
+		params.BeaconConfig().DomainSyncCommitteeSelectionProof[:],
+		params.BeaconConfig().DomainContributionAndProof[:],
 	} {
 		_, err := v.domainData(ctx, helpers.SlotToEpoch(slot), d)
 		if err != nil {
@@ -814,3 +774,11 @@ type voteStats struct {
 	correctHeads          uint64
 	totalHeads            uint64
 }
+
+// WaitForInFlightSigning waits for the attestations and blocks being signed to be saved in the
+// slashing protection history. Duties hold the signing lock from signing until the signature
+// is saved, so that a key deleted in the meantime is exported with its full history.
+func (v *validator) WaitForInFlightSigning() {
+	v.signingLock.Lock()
+	defer v.signingLock.Unlock()
+}
@@ -16,7 +16,6 @@ import (
 	"github.com/prysmaticlabs/prysm/shared/bls"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
 	"github.com/prysmaticlabs/prysm/shared/event"
-	"github.com/prysmaticlabs/prysm/shared/featureconfig"
 	"github.com/prysmaticlabs/prysm/shared/mock"
 	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/prysmaticlabs/prysm/shared/testutil"
@@ -656,6 +655,41 @@ func TestRolesAt_OK(t *testing.T) {
 	assert.Equal(t, iface.RoleAttester, roleMap[bytesutil.ToBytes48(validatorKey.PublicKey().Marshal())][0])
 }
 
+func TestRolesAt_SyncCommitteeAggregatorError(t *testing.T) {
+	v, m, validatorKey, finish := setup(t)
+	defer finish()
+
+	v.duties = &ethpb.DutiesResponse{
+		Duties: []*ethpb.DutiesResponse_Duty{
+			{
+				CommitteeIndex:  1,
+				AttesterSlot:    1,
+				ProposerSlots:   []types.Slot{1},
+				IsSyncCommittee: true,
+				PublicKey:       validatorKey.PublicKey().Marshal(),
+			},
+		},
+	}
+
+	m.validatorClient.EXPECT().DomainData(
+		gomock.Any(), // ctx
+		gomock.Any(), // epoch
+	).Return(&ethpb.DomainResponse{SignatureDomain: make([]byte, 32)}, nil /*err*/)
+	m.altairClient.EXPECT().GetSyncSubcommitteeIndex(
+		gomock.Any(), // ctx
+		gomock.Any(), // request
+	).Return(nil, errors.New("bad"))
+
+	roleMap, err := v.RolesAt(context.Background(), 1)
+	require.NoError(t, err)
+
+	roles := roleMap[bytesutil.ToBytes48(validatorKey.PublicKey().Marshal())]
+	require.Equal(t, true, len(roles) >= 3)
+	assert.Equal(t, iface.RoleProposer, roles[0])
+	assert.Equal(t, iface.RoleAttester, roles[1])
+	assert.Equal(t, iface.RoleSyncCommittee, roles[len(roles)-1])
+}
+
 func TestRolesAt_DoesNotAssignProposer_Slot0(t *testing.T) {
 	v, m, validatorKey, finish := setup(t)
 	defer finish()
@@ -994,14 +1028,11 @@ func (m *doppelGangerRequestMatcher) String() string {
 func TestValidator_CheckDoppelGanger(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
-	flgs := featureconfig.Get()
-	flgs.EnableDoppelGanger = true
-	reset := featureconfig.InitWithReset(flgs)
-	defer reset()
 	tests := []struct {
 		name            string
 		validatorSetter func(t *testing.T) *validator
 		err             string
+		detected        int
 	}{
 		{
 			name: "no doppelganger",
@@ -1026,6 +1057,7 @@ func TestValidator_CheckDoppelGanger(t *testing.T) {
 					validatorClient: client,
 					keyManager:      km,
 					db:              db,
+					doppelganger:    newDoppelgangerTracker(1),
 				}
 				client.EXPECT().CheckDoppelGanger(
 					gomock.Any(),                     // ctx
@@ -1034,6 +1066,7 @@ func TestValidator_CheckDoppelGanger(t *testing.T) {
 
 				return v
 			},
+			err: "beacon node returned no response for doppelganger check",
 		},
 		{
 			name: "multiple doppelganger exists",
@@ -1060,6 +1093,7 @@ func TestValidator_CheckDoppelGanger(t *testing.T) {
 					validatorClient: client,
 					keyManager:      km,
 					db:              db,
+					doppelganger:    newDoppelgangerTracker(1),
 				}
 				client.EXPECT().CheckDoppelGanger(
 					gomock.Any(),                     // ctx
@@ -1067,7 +1101,7 @@ func TestValidator_CheckDoppelGanger(t *testing.T) {
 				).Return(resp, nil /*err*/)
 				return v
 			},
-			err: "Duplicate instances exists in the network for validator keys",
+			detected: 4,
 		},
 		{
 			name: "single doppelganger exists",
@@ -1094,6 +1128,7 @@ func TestValidator_CheckDoppelGanger(t *testing.T) {
 					validatorClient: client,
 					keyManager:      km,
 					db:              db,
+					doppelganger:    newDoppelgangerTracker(1),
 				}
 				client.EXPECT().CheckDoppelGanger(
 					gomock.Any(),                     // ctx
@@ -1101,7 +1136,7 @@ func TestValidator_CheckDoppelGanger(t *testing.T) {
 				).Return(resp, nil /*err*/)
 				return v
 			},
-			err: "Duplicate instances exists in the network for validator keys",
+			detected: 2,
 		},
 		{
 			name: "multiple attestations saved",
@@ -1133,6 +1168,7 @@ func TestValidator_CheckDoppelGanger(t *testing.T) {
 					validatorClient: client,
 					keyManager:      km,
 					db:              db,
+					doppelganger:    newDoppelgangerTracker(1),
 				}
 				client.EXPECT().CheckDoppelGanger(
 					gomock.Any(),                     // ctx
@@ -1140,7 +1176,7 @@ func TestValidator_CheckDoppelGanger(t *testing.T) {
 				).Return(resp, nil /*err*/)
 				return v
 			},
-			err: "Duplicate instances exists in the network for validator keys",
+			detected: 4,
 		},
 		{
 			name: "no history exists",
@@ -1161,6 +1197,7 @@ func TestValidator_CheckDoppelGanger(t *testing.T) {
 					validatorClient: client,
 					keyManager:      km,
 					db:              db,
+					doppelganger:    newDoppelgangerTracker(1),
 				}
 				client.EXPECT().CheckDoppelGanger(
 					gomock.Any(), // ctx
@@ -1168,15 +1205,28 @@ func TestValidator_CheckDoppelGanger(t *testing.T) {
 				).Return(resp, nil /*err*/)
 				return v
 			},
-			err: "",
+			detected: 0,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			v := tt.validatorSetter(t)
-			if err := v.CheckDoppelGanger(context.Background()); tt.err != "" {
+			err := v.CheckDoppelGanger(context.Background())
+			if tt.err != "" {
 				assert.ErrorContains(t, tt.err, err)
+				return
+			}
+			require.NoError(t, err)
+			detected := 0
+			for _, s := range v.DoppelgangerStatuses() {
+				if s.Status == iface.DoppelgangerDetected {
+					detected++
+					continue
+				}
+				// Clean keys are observed over a single epoch window.
+				assert.Equal(t, iface.DoppelgangerClean, s.Status)
 			}
+			assert.Equal(t, tt.detected, detected)
 		})
 	}
 }
@@ -1230,3 +1280,25 @@ func createAttestation(source, target types.Epoch) *ethpb.IndexedAttestation {
 		Signature: make([]byte, 96),
 	}
 }
+
+func TestValidator_WaitForInFlightSigning(t *testing.T) {
+	v := &validator{}
+	v.signingLock.RLock()
+	waited := make(chan struct{})
+	go func() {
+		v.WaitForInFlightSigning()
+		close(waited)
+	}()
+
+	select {
+	case <-waited:
+		t.Fatal("Returned while a signature was in flight")
+	case <-time.After(50 * time.Millisecond):
+	}
+	v.signingLock.RUnlock()
+	select {
+	case <-waited:
+	case <-time.After(time.Second):
+		t.Fatal("Did not return once the in flight signature was saved")
+	}
+}
@@ -0,0 +1,62 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"path"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/cmd/validator/flags"
+	"github.com/prysmaticlabs/prysm/shared/cmd"
+	"github.com/prysmaticlabs/prysm/shared/fileutil"
+	"github.com/prysmaticlabs/prysm/validator/db/kv"
+	"github.com/urfave/cli/v2"
+)
+
+// Check the integrity of the slashing protection history of a validator database, and optionally
+// write a repaired copy of it into a new database.
+func Check(cliCtx *cli.Context) error {
+	dataDir := cliCtx.String(cmd.DataDirFlag.Name)
+
+	if !fileutil.FileExists(path.Join(dataDir, kv.ProtectionDbFileName)) {
+		return errors.New("No validator db found at path, nothing to check")
+	}
+
+	ctx := context.Background()
+	log.Info("Opening DB")
+	validatorDB, err := kv.NewKVStore(ctx, dataDir, &kv.Config{})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := validatorDB.Close(); err != nil {
+			log.WithError(err).Error("Could not close validator DB")
+		}
+	}()
+	log.Info("Checking slashing protection history")
+	issues, err := validatorDB.CheckIntegrity(ctx)
+	if err != nil {
+		return err
+	}
+	if len(issues) == 0 {
+		log.Info("No integrity issues found")
+		return nil
+	}
+	for _, issue := range issues {
+		log.WithField("repairable", issue.Repairable).Warn(issue.String())
+	}
+
+	targetDir := cliCtx.String(flags.RepairTargetDirFlag.Name)
+	if targetDir == "" {
+		return fmt.Errorf(
+			"found %d integrity issues, use --%s to write a repaired database", len(issues), flags.RepairTargetDirFlag.Name,
+		)
+	}
+	log.WithField("issues", len(issues)).Info("Repairing database")
+	if err := validatorDB.RepairInto(ctx, targetDir); err != nil {
+		return errors.Wrap(err, "could not repair database")
+	}
+	log.WithField("path", targetDir).Info(
+		"Repaired database written, replace the validator database with it once the validator client is stopped")
+	return nil
+}
@@ -35,6 +35,7 @@ type ValidatorDB interface {
 	ProposalHistoryForSlot(ctx context.Context, publicKey [48]byte, slot types.Slot) ([32]byte, bool, error)
 	SaveProposalHistoryForSlot(ctx context.Context, pubKey [48]byte, slot types.Slot, signingRoot []byte) error
 	ProposedPublicKeys(ctx context.Context) ([][48]byte, error)
+	RaiseLowestSignedProposal(ctx context.Context, publicKey [48]byte, slot types.Slot) error
 
 	// Attester protection related methods.
 	// Methods to store and read blacklisted public keys from EIP-3076
@@ -44,6 +45,7 @@ type ValidatorDB interface {
 	SigningRootAtTargetEpoch(ctx context.Context, publicKey [48]byte, target types.Epoch) ([32]byte, error)
 	LowestSignedTargetEpoch(ctx context.Context, publicKey [48]byte) (types.Epoch, bool, error)
 	LowestSignedSourceEpoch(ctx context.Context, publicKey [48]byte) (types.Epoch, bool, error)
+	RaiseLowestSignedEpochs(ctx context.Context, publicKey [48]byte, source, target types.Epoch) error
 	AttestedPublicKeys(ctx context.Context) ([][48]byte, error)
 	CheckSlashableAttestation(
 		ctx context.Context, pubKey [48]byte, signingRoot [32]byte, att *ethpb.IndexedAttestation,
@@ -58,6 +60,11 @@ type ValidatorDB interface {
 		ctx context.Context, pubKey [48]byte,
 	) ([]*kv.AttestationRecord, error)
 
+	// Validator performance history related methods.
+	UpdateEpochPerformance(ctx context.Context, pubKey [48]byte, epoch types.Epoch, update func(p *kv.EpochPerformance)) error
+	EpochPerformanceForPubKey(ctx context.Context, pubKey [48]byte, start, end types.Epoch) ([]*kv.EpochPerformance, error)
+	PerformancePublicKeys(ctx context.Context) ([][48]byte, error)
+
 	// Graffiti ordered index related methods
 	SaveGraffitiOrderedIndex(ctx context.Context, index uint64) error
 	GraffitiOrderedIndex(ctx context.Context, fileHash [32]byte) (uint64, error)
@@ -104,6 +104,9 @@ func (s *Store) AttestationHistoryForPubKey(ctx context.Context, pubKey [48]byte
 		}
 		signingRootsBucket := pkBucket.Bucket(attestationSigningRootsBucket)
 		sourceEpochsBucket := pkBucket.Bucket(attestationSourceEpochsBucket)
+		if sourceEpochsBucket == nil {
+			return nil
+		}
 
 		return sourceEpochsBucket.ForEach(func(sourceBytes, targetEpochsList []byte) error {
 			targetEpochs := make([]types.Epoch, 0)
@@ -576,3 +579,31 @@ func (s *Store) LowestSignedTargetEpoch(ctx context.Context, publicKey [48]byte)
 	})
 	return lowestSignedTargetEpoch, exists, err
 }
+
+// RaiseLowestSignedEpochs raises the lowest signed source and target epochs of a validator public key
+// to the given epochs when they are lower, so that no attestation older than the given epochs can
+// be signed. It is used when merging imported slashing protection history with the one in the database.
+func (s *Store) RaiseLowestSignedEpochs(ctx context.Context, publicKey [48]byte, source, target types.Epoch) error {
+	ctx, span := trace.StartSpan(ctx, "Validator.RaiseLowestSignedEpochs")
+	defer span.End()
+
+	return s.update(func(tx *bolt.Tx) error {
+		for _, watermark := range []struct {
+			bucket []byte
+			epoch  types.Epoch
+		}{
+			{bucket: lowestSignedSourceBucket, epoch: source},
+			{bucket: lowestSignedTargetBucket, epoch: target},
+		} {
+			bucket := tx.Bucket(watermark.bucket)
+			existing := bucket.Get(publicKey[:])
+			if len(existing) >= 8 && bytesutil.BytesToEpochBigEndian(existing) >= watermark.epoch {
+				continue
+			}
+			if err := bucket.Put(publicKey[:], bytesutil.EpochToBytesBigEndian(watermark.epoch)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -48,6 +48,23 @@ var blockedBuckets = [][]byte{
 	attestationSigningRootsBucket,
 	attestationSourceEpochsBucket,
 	attestationTargetEpochsBucket,
+	performanceBucket,
+}
+
+// schemaBuckets are the top level buckets of the database schema.
+var schemaBuckets = [][]byte{
+	genesisInfoBucket,
+	deprecatedAttestationHistoryBucket,
+	historicProposalsBucket,
+	lowestSignedSourceBucket,
+	lowestSignedTargetBucket,
+	lowestSignedProposalsBucket,
+	highestSignedProposalsBucket,
+	slashablePublicKeysBucket,
+	pubKeysBucket,
+	migrationsBucket,
+	graffitiBucket,
+	performanceBucket,
 }
 
 // Config represents store's config object.
@@ -137,20 +154,7 @@ func NewKVStore(ctx context.Context, dirPath string, config *Config) (*Store, er
 	}
 
 	if err := kv.db.Update(func(tx *bolt.Tx) error {
-		return createBuckets(
-			tx,
-			genesisInfoBucket,
-			deprecatedAttestationHistoryBucket,
-			historicProposalsBucket,
-			lowestSignedSourceBucket,
-			lowestSignedTargetBucket,
-			lowestSignedProposalsBucket,
-			highestSignedProposalsBucket,
-			slashablePublicKeysBucket,
-			pubKeysBucket,
-			migrationsBucket,
-			graffitiBucket,
-		)
+		return createBuckets(tx, schemaBuckets...)
 	}); err != nil {
 		return nil, err
 	}
@@ -163,10 +167,13 @@ func NewKVStore(ctx context.Context, dirPath string, config *Config) (*Store, er
 	}
 
 	if featureconfig.Get().EnableSlashingProtectionPruning {
-		// Prune attesting records older than the current weak subjectivity period.
+		// Prune attesting and performance records older than the current weak subjectivity period.
 		if err := kv.PruneAttestations(ctx); err != nil {
 			return nil, errors.Wrap(err, "could not prune old attestations from DB")
 		}
+		if err := kv.PruneEpochPerformance(ctx); err != nil {
+			return nil, errors.Wrap(err, "could not prune old performance records from DB")
+		}
 	}
 
 	// Batch save attestation records for slashing protection at timed
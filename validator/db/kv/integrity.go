@@ -0,0 +1,549 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/fileutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// IntegrityIssue is an inconsistency found in the slashing protection history of the database.
+type IntegrityIssue struct {
+	// PubKey is the validator key whose history is inconsistent, zero for database wide issues.
+	PubKey [48]byte
+	// Bucket is the name of the bucket holding the inconsistent data.
+	Bucket      string
+	Description string
+	// Repairable is whether the issue is fixed in the database written by RepairInto.
+	Repairable bool
+}
+
+// String describes the issue along with the key and bucket it was found in.
+func (i *IntegrityIssue) String() string {
+	if i.PubKey == ([48]byte{}) {
+		return fmt.Sprintf("%s: %s", i.Bucket, i.Description)
+	}
+	return fmt.Sprintf("%#x in %s: %s", bytesutil.Trunc(i.PubKey[:]), i.Bucket, i.Description)
+}
+
+// integrityReport collects the issues found while reading the database.
+type integrityReport struct {
+	issues []*IntegrityIssue
+}
+
+func (r *integrityReport) add(pubKey, bucket []byte, repairable bool, format string, args ...interface{}) {
+	r.issues = append(r.issues, &IntegrityIssue{
+		PubKey:      bytesutil.ToBytes48(pubKey),
+		Bucket:      string(bucket),
+		Description: fmt.Sprintf(format, args...),
+		Repairable:  repairable,
+	})
+}
+
+// attestingPair is a source and target epoch pair attested to by a public key.
+type attestingPair struct {
+	source, target types.Epoch
+}
+
+// attestingHistory is the attesting history of a public key, as read from both its source epochs
+// and target epochs buckets along with the signing roots of its target epochs.
+type attestingHistory struct {
+	inSources    map[attestingPair]bool
+	inTargets    map[attestingPair]bool
+	signingRoots map[types.Epoch][]byte
+}
+
+// pairs returns the attested pairs found in either of the source and target epochs buckets,
+// sorted by source then target epoch.
+func (h *attestingHistory) pairs() []attestingPair {
+	pairs := make([]attestingPair, 0, len(h.inSources))
+	for p := range h.inSources {
+		pairs = append(pairs, p)
+	}
+	for p := range h.inTargets {
+		if !h.inSources[p] {
+			pairs = append(pairs, p)
+		}
+	}
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i].source != pairs[j].source {
+			return pairs[i].source < pairs[j].source
+		}
+		return pairs[i].target < pairs[j].target
+	})
+	return pairs
+}
+
+// CheckIntegrity verifies the consistency of the slashing protection history in the database. The
+// source and target epochs buckets of each public key must hold the same well formed attestations
+// with target epochs not lower than their source, the lowest and highest signed watermarks must be
+// recorded for every key with a history, the lowest ones not above the highest signed epoch or slot
+// and the highest one not below it, and no data must be left in buckets made obsolete by migrations.
+func (s *Store) CheckIntegrity(ctx context.Context) ([]*IntegrityIssue, error) {
+	ctx, span := trace.StartSpan(ctx, "Validator.CheckIntegrity")
+	defer span.End()
+	report := &integrityReport{}
+	err := s.view(func(tx *bolt.Tx) error {
+		checkTopLevelBuckets(tx, report)
+		targetsMigrated := migrationCompletedIn(tx, migrationSourceTargetEpochsBucketKey)
+		err := forEachPubKeyBucket(tx, pubKeysBucket, report, func(pubKey []byte, pkBucket *bolt.Bucket) error {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			pairs := readAttestingHistory(pubKey, pkBucket, targetsMigrated, report).pairs()
+			for _, p := range pairs {
+				if p.target < p.source {
+					report.add(pubKey, attestationSourceEpochsBucket, false,
+						"target epoch %d is lower than its source epoch %d", p.target, p.source)
+				}
+			}
+			if len(pairs) == 0 {
+				return nil
+			}
+			highestSource, highestTarget := highestEpochs(pairs)
+			checkLowestWatermark(tx, pubKey, lowestSignedSourceBucket, uint64(highestSource), report)
+			checkLowestWatermark(tx, pubKey, lowestSignedTargetBucket, uint64(highestTarget), report)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		return forEachPubKeyBucket(tx, historicProposalsBucket, report, func(pubKey []byte, bkt *bolt.Bucket) error {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			proposals := readProposalHistory(pubKey, bkt, report)
+			if len(proposals) == 0 {
+				return nil
+			}
+			highestSlot := highestProposedSlot(proposals)
+			checkLowestWatermark(tx, pubKey, lowestSignedProposalsBucket, uint64(highestSlot), report)
+			highest, ok := readWatermark(tx.Bucket(highestSignedProposalsBucket), pubKey)
+			if !ok {
+				report.add(pubKey, highestSignedProposalsBucket, true, "no valid watermark recorded for the proposal history")
+			} else if highest < uint64(highestSlot) {
+				report.add(pubKey, highestSignedProposalsBucket, true,
+					"watermark %d is lower than the proposed slot %d", highest, highestSlot)
+			}
+			return nil
+		})
+	})
+	return report.issues, err
+}
+
+// RepairInto writes the slashing protection history of the database into a new database in the
+// given directory, leaving out the data found to be malformed, orphaned or unknown by
+// CheckIntegrity. The source and target epochs buckets of each key are rebuilt from the union of
+// their attestations, and the watermarks are recomputed from the history where they are missing
+// or do not cover it. The attesting history of the database must have been migrated to the optimized format.
+func (s *Store) RepairInto(ctx context.Context, dirPath string) error {
+	ctx, span := trace.StartSpan(ctx, "Validator.RepairInto")
+	defer span.End()
+	datafile := filepath.Join(dirPath, ProtectionDbFileName)
+	if fileutil.FileExists(datafile) {
+		return fmt.Errorf("a validator database already exists at path %s", dirPath)
+	}
+	hasDir, err := fileutil.HasDir(dirPath)
+	if err != nil {
+		return err
+	}
+	if !hasDir {
+		if err := fileutil.MkdirAll(dirPath); err != nil {
+			return err
+		}
+	}
+	repaired, err := bolt.Open(datafile, params.BeaconIoConfig().ReadWritePermissions, &bolt.Options{
+		Timeout: params.BeaconIoConfig().BoltTimeout,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "could not create database at path %s", dirPath)
+	}
+	err = s.view(func(tx *bolt.Tx) error {
+		if !migrationCompletedIn(tx, migrationOptimalAttesterProtectionKey) &&
+			tx.Bucket(deprecatedAttestationHistoryBucket).Stats().KeyN > 0 {
+			return errors.New("attesting history has not been migrated, run the up migrations before repairing")
+		}
+		return repaired.Update(func(dst *bolt.Tx) error {
+			if err := createBuckets(dst, schemaBuckets...); err != nil {
+				return err
+			}
+			return repairTx(ctx, tx, dst)
+		})
+	})
+	if closeErr := repaired.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		// Do not leave a partially repaired database behind.
+		if rmErr := os.Remove(datafile); rmErr != nil {
+			log.WithError(rmErr).Error("Could not remove partially repaired database")
+		}
+		return err
+	}
+	return nil
+}
+
+func repairTx(ctx context.Context, src, dst *bolt.Tx) error {
+	// Buckets which are not part of the slashing protection history are copied as is.
+	for _, name := range [][]byte{genesisInfoBucket, slashablePublicKeysBucket, graffitiBucket, performanceBucket} {
+		if err := copyBucket(src.Bucket(name), dst.Bucket(name)); err != nil {
+			return err
+		}
+	}
+	migrations := dst.Bucket(migrationsBucket)
+	for _, key := range [][]byte{migrationOptimalAttesterProtectionKey, migrationSourceTargetEpochsBucketKey} {
+		if err := migrations.Put(key, migrationCompleted); err != nil {
+			return err
+		}
+	}
+	// Well formed watermarks are kept, even for keys without history, and only recomputed below when
+	// they do not cover the history.
+	for _, name := range [][]byte{
+		lowestSignedSourceBucket, lowestSignedTargetBucket, lowestSignedProposalsBucket, highestSignedProposalsBucket,
+	} {
+		err := src.Bucket(name).ForEach(func(pubKey, v []byte) error {
+			if len(pubKey) != 48 || len(v) != 8 {
+				return nil
+			}
+			return dst.Bucket(name).Put(pubKey, v)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	// The issues were already reported by CheckIntegrity, and are ignored while repairing.
+	report := &integrityReport{}
+	err := forEachPubKeyBucket(src, pubKeysBucket, report, func(pubKey []byte, pkBucket *bolt.Bucket) error {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return writeAttestingHistory(dst, pubKey, readAttestingHistory(pubKey, pkBucket, true, report))
+	})
+	if err != nil {
+		return err
+	}
+	return forEachPubKeyBucket(src, historicProposalsBucket, report, func(pubKey []byte, bkt *bolt.Bucket) error {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return writeProposalHistory(dst, pubKey, readProposalHistory(pubKey, bkt, report))
+	})
+}
+
+// checkTopLevelBuckets reports the buckets which are not part of the schema, and the attesting
+// history left in the deprecated bucket.
+func checkTopLevelBuckets(tx *bolt.Tx, report *integrityReport) {
+	_ = tx.ForEach(func(name []byte, _ *bolt.Bucket) error {
+		for _, known := range schemaBuckets {
+			if bytes.Equal(name, known) {
+				return nil
+			}
+		}
+		report.add(nil, name, true, "bucket is not part of the database schema")
+		return nil
+	})
+	deprecatedKeys := tx.Bucket(deprecatedAttestationHistoryBucket).Stats().KeyN
+	if deprecatedKeys == 0 {
+		return
+	}
+	if migrationCompletedIn(tx, migrationOptimalAttesterProtectionKey) {
+		report.add(nil, deprecatedAttestationHistoryBucket, true,
+			"%d entries are left over from the migration to the optimized attesting history", deprecatedKeys)
+		return
+	}
+	report.add(nil, deprecatedAttestationHistoryBucket, false,
+		"%d entries have not been migrated to the optimized attesting history, run the up migrations", deprecatedKeys)
+}
+
+func migrationCompletedIn(tx *bolt.Tx, key []byte) bool {
+	return bytes.Equal(tx.Bucket(migrationsBucket).Get(key), migrationCompleted)
+}
+
+// forEachPubKeyBucket calls fn with the nested bucket of each public key in the given bucket, and
+// reports the entries which are not public key buckets.
+func forEachPubKeyBucket(
+	tx *bolt.Tx, name []byte, report *integrityReport, fn func(pubKey []byte, bkt *bolt.Bucket) error,
+) error {
+	bkt := tx.Bucket(name)
+	return bkt.ForEach(func(k, v []byte) error {
+		if v != nil || len(k) != 48 {
+			report.add(nil, name, true, "orphaned entry with key %#x is not a public key bucket", bytesutil.Trunc(k))
+			return nil
+		}
+		return fn(k, bkt.Bucket(k))
+	})
+}
+
+// readAttestingHistory reads the attesting history of a public key, reporting malformed entries,
+// orphaned signing roots and attestations missing from one of the source and target epochs
+// buckets. A missing target epochs bucket is only reported once it has been migrated to.
+func readAttestingHistory(
+	pubKey []byte, pkBucket *bolt.Bucket, targetsMigrated bool, report *integrityReport,
+) *attestingHistory {
+	h := &attestingHistory{
+		inSources:    make(map[attestingPair]bool),
+		inTargets:    make(map[attestingPair]bool),
+		signingRoots: make(map[types.Epoch][]byte),
+	}
+	sourceBucket := pkBucket.Bucket(attestationSourceEpochsBucket)
+	targetBucket := pkBucket.Bucket(attestationTargetEpochsBucket)
+	readEpochLists(pubKey, sourceBucket, attestationSourceEpochsBucket, report, func(source, target types.Epoch) bool {
+		return addPair(h.inSources, attestingPair{source: source, target: target})
+	})
+	readEpochLists(pubKey, targetBucket, attestationTargetEpochsBucket, report, func(target, source types.Epoch) bool {
+		return addPair(h.inTargets, attestingPair{source: source, target: target})
+	})
+	pairs := h.pairs()
+	if targetBucket == nil && sourceBucket != nil {
+		if targetsMigrated && len(pairs) > 0 {
+			report.add(pubKey, attestationTargetEpochsBucket, true, "bucket is missing")
+		}
+	} else {
+		for _, p := range pairs {
+			if !h.inSources[p] {
+				report.add(pubKey, attestationSourceEpochsBucket, true,
+					"attestation with source epoch %d and target epoch %d is missing", p.source, p.target)
+			}
+			if !h.inTargets[p] {
+				report.add(pubKey, attestationTargetEpochsBucket, true,
+					"attestation with source epoch %d and target epoch %d is missing", p.source, p.target)
+			}
+		}
+	}
+
+	rootsBucket := pkBucket.Bucket(attestationSigningRootsBucket)
+	if rootsBucket == nil {
+		return h
+	}
+	targets := make(map[types.Epoch]bool, len(pairs))
+	for _, p := range pairs {
+		targets[p.target] = true
+	}
+	_ = rootsBucket.ForEach(func(k, v []byte) error {
+		if len(k) != 8 || len(v) != 32 {
+			report.add(pubKey, attestationSigningRootsBucket, true, "malformed entry with key %#x", k)
+			return nil
+		}
+		target := bytesutil.BytesToEpochBigEndian(k)
+		if !targets[target] {
+			report.add(pubKey, attestationSigningRootsBucket, true,
+				"signing root of target epoch %d has no attestation", target)
+			return nil
+		}
+		h.signingRoots[target] = bytesutil.SafeCopyBytes(v)
+		return nil
+	})
+	return h
+}
+
+// readEpochLists reads a bucket mapping epochs to lists of epochs, calling add with each pair of
+// epochs. add returns false when the pair was already read.
+func readEpochLists(
+	pubKey []byte, bkt *bolt.Bucket, name []byte, report *integrityReport, add func(key, value types.Epoch) bool,
+) {
+	if bkt == nil {
+		return
+	}
+	_ = bkt.ForEach(func(k, v []byte) error {
+		if len(k) != 8 || len(v) == 0 {
+			report.add(pubKey, name, true, "malformed entry with key %#x", k)
+			return nil
+		}
+		key := bytesutil.BytesToEpochBigEndian(k)
+		if len(v)%8 != 0 {
+			report.add(pubKey, name, true, "epochs recorded for epoch %d are truncated", key)
+		}
+		for i := 0; i+8 <= len(v); i += 8 {
+			value := bytesutil.BytesToEpochBigEndian(v[i : i+8])
+			if !add(key, value) {
+				report.add(pubKey, name, true, "epoch %d is recorded several times for epoch %d", value, key)
+			}
+		}
+		return nil
+	})
+}
+
+func addPair(pairs map[attestingPair]bool, p attestingPair) bool {
+	if pairs[p] {
+		return false
+	}
+	pairs[p] = true
+	return true
+}
+
+// readProposalHistory returns the signing roots by slot of the blocks proposed by a public key,
+// reporting malformed entries.
+func readProposalHistory(pubKey []byte, bkt *bolt.Bucket, report *integrityReport) map[types.Slot][]byte {
+	proposals := make(map[types.Slot][]byte)
+	_ = bkt.ForEach(func(k, v []byte) error {
+		if len(k) != 8 || v == nil {
+			report.add(pubKey, historicProposalsBucket, true, "malformed entry with key %#x", k)
+			return nil
+		}
+		proposals[bytesutil.BytesToSlotBigEndian(k)] = bytesutil.SafeCopyBytes(v)
+		return nil
+	})
+	return proposals
+}
+
+func highestProposedSlot(proposals map[types.Slot][]byte) types.Slot {
+	var highest types.Slot
+	for slot := range proposals {
+		if slot > highest {
+			highest = slot
+		}
+	}
+	return highest
+}
+
+func lowestProposedSlot(proposals map[types.Slot][]byte) types.Slot {
+	lowest := types.Slot(^uint64(0))
+	for slot := range proposals {
+		if slot < lowest {
+			lowest = slot
+		}
+	}
+	return lowest
+}
+
+// readWatermark returns the watermark of a public key, and whether a well formed one is recorded.
+func readWatermark(bkt *bolt.Bucket, pubKey []byte) (uint64, bool) {
+	enc := bkt.Get(pubKey)
+	if len(enc) != 8 {
+		return 0, false
+	}
+	return bytesutil.BytesToUint64BigEndian(enc), true
+}
+
+// highestEpochs returns the highest source and target epochs of a non empty attesting history.
+func highestEpochs(pairs []attestingPair) (types.Epoch, types.Epoch) {
+	var source, target types.Epoch
+	for _, p := range pairs {
+		if p.source > source {
+			source = p.source
+		}
+		if p.target > target {
+			target = p.target
+		}
+	}
+	return source, target
+}
+
+// checkLowestWatermark reports a lowest watermark which is missing or above the highest epoch or
+// slot signed in the history of a public key. A watermark below the history is left alone, as
+// pruning the history does not raise it.
+func checkLowestWatermark(tx *bolt.Tx, pubKey, name []byte, highest uint64, report *integrityReport) {
+	lowest, ok := readWatermark(tx.Bucket(name), pubKey)
+	if !ok {
+		report.add(pubKey, name, true, "no valid watermark recorded for the signing history")
+	} else if lowest > highest {
+		report.add(pubKey, name, true, "watermark %d is above the highest signed %d of the history", lowest, highest)
+	}
+}
+
+// recordLowestWatermark recomputes the lowest watermark of a public key from its history when none
+// is recorded or the recorded one is above the highest signed epoch or slot.
+func recordLowestWatermark(bkt *bolt.Bucket, pubKey []byte, lowest, highest uint64) error {
+	if current, ok := readWatermark(bkt, pubKey); ok && current <= highest {
+		return nil
+	}
+	return bkt.Put(pubKey, bytesutil.Uint64ToBytesBigEndian(lowest))
+}
+
+func writeAttestingHistory(dst *bolt.Tx, pubKey []byte, h *attestingHistory) error {
+	pairs := h.pairs()
+	if len(pairs) == 0 {
+		return nil
+	}
+	pkBucket, err := dst.Bucket(pubKeysBucket).CreateBucket(pubKey)
+	if err != nil {
+		return err
+	}
+	buckets := make([]*bolt.Bucket, 3)
+	for i, name := range [][]byte{attestationSourceEpochsBucket, attestationTargetEpochsBucket, attestationSigningRootsBucket} {
+		if buckets[i], err = pkBucket.CreateBucket(name); err != nil {
+			return err
+		}
+	}
+	sourceBucket, targetBucket, rootsBucket := buckets[0], buckets[1], buckets[2]
+	targetsBySource := make(map[types.Epoch][]byte)
+	sourcesByTarget := make(map[types.Epoch][]byte)
+	lowestSource, lowestTarget := pairs[0].source, pairs[0].target
+	highestSource, highestTarget := highestEpochs(pairs)
+	for _, p := range pairs {
+		targetsBySource[p.source] = append(targetsBySource[p.source], bytesutil.EpochToBytesBigEndian(p.target)...)
+		sourcesByTarget[p.target] = append(sourcesByTarget[p.target], bytesutil.EpochToBytesBigEndian(p.source)...)
+		if p.target < lowestTarget {
+			lowestTarget = p.target
+		}
+	}
+	for source, targets := range targetsBySource {
+		if err := sourceBucket.Put(bytesutil.EpochToBytesBigEndian(source), targets); err != nil {
+			return err
+		}
+	}
+	for target, sources := range sourcesByTarget {
+		if err := targetBucket.Put(bytesutil.EpochToBytesBigEndian(target), sources); err != nil {
+			return err
+		}
+	}
+	for target, root := range h.signingRoots {
+		if err := rootsBucket.Put(bytesutil.EpochToBytesBigEndian(target), root); err != nil {
+			return err
+		}
+	}
+	err = recordLowestWatermark(dst.Bucket(lowestSignedSourceBucket), pubKey, uint64(lowestSource), uint64(highestSource))
+	if err != nil {
+		return err
+	}
+	return recordLowestWatermark(dst.Bucket(lowestSignedTargetBucket), pubKey, uint64(lowestTarget), uint64(highestTarget))
+}
+
+func writeProposalHistory(dst *bolt.Tx, pubKey []byte, proposals map[types.Slot][]byte) error {
+	bkt, err := dst.Bucket(historicProposalsBucket).CreateBucket(pubKey)
+	if err != nil {
+		return err
+	}
+	if len(proposals) == 0 {
+		return nil
+	}
+	for slot, root := range proposals {
+		if err := bkt.Put(bytesutil.SlotToBytesBigEndian(slot), root); err != nil {
+			return err
+		}
+	}
+	lowest, highest := uint64(lowestProposedSlot(proposals)), uint64(highestProposedSlot(proposals))
+	if err := recordLowestWatermark(dst.Bucket(lowestSignedProposalsBucket), pubKey, lowest, highest); err != nil {
+		return err
+	}
+	highestBucket := dst.Bucket(highestSignedProposalsBucket)
+	if current, ok := readWatermark(highestBucket, pubKey); ok && current >= highest {
+		return nil
+	}
+	return highestBucket.Put(pubKey, bytesutil.Uint64ToBytesBigEndian(highest))
+}
+
+// copyBucket copies the entries and nested buckets of a bucket into another one.
+func copyBucket(src, dst *bolt.Bucket) error {
+	return src.ForEach(func(k, v []byte) error {
+		if v != nil {
+			return dst.Put(k, v)
+		}
+		nested, err := dst.CreateBucketIfNotExists(k)
+		if err != nil {
+			return err
+		}
+		return copyBucket(src.Bucket(k), nested)
+	})
+}
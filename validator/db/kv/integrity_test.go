@@ -0,0 +1,175 @@
+package kv
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/fileutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	bolt "go.etcd.io/bbolt"
+)
+
+func setupIntegrityDB(t *testing.T, pubKey [48]byte) *Store {
+	ctx := context.Background()
+	validatorDB := setupDB(t, [][48]byte{pubKey})
+	require.NoError(t, validatorDB.update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(migrationsBucket)
+		if err := bkt.Put(migrationOptimalAttesterProtectionKey, migrationCompleted); err != nil {
+			return err
+		}
+		return bkt.Put(migrationSourceTargetEpochsBucketKey, migrationCompleted)
+	}))
+	require.NoError(t, validatorDB.saveAttestationRecords(ctx, []*AttestationRecord{
+		{PubKey: pubKey, Source: 1, Target: 2, SigningRoot: [32]byte{1}},
+		{PubKey: pubKey, Source: 2, Target: 3, SigningRoot: [32]byte{2}},
+		{PubKey: pubKey, Source: 2, Target: 4, SigningRoot: [32]byte{3}},
+	}))
+	require.NoError(t, validatorDB.SaveProposalHistoryForSlot(ctx, pubKey, 10, bytesutil.PadTo([]byte{1}, 32)))
+	require.NoError(t, validatorDB.SaveProposalHistoryForSlot(ctx, pubKey, 12, bytesutil.PadTo([]byte{2}, 32)))
+	return validatorDB
+}
+
+func TestStore_CheckIntegrity_Consistent(t *testing.T) {
+	validatorDB := setupIntegrityDB(t, [48]byte{1})
+	issues, err := validatorDB.CheckIntegrity(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(issues), "Unexpected issues %v", issues)
+}
+
+func TestStore_CheckIntegrity_RepairInto(t *testing.T) {
+	ctx := context.Background()
+	pubKey := [48]byte{1}
+	validatorDB := setupIntegrityDB(t, pubKey)
+	require.NoError(t, validatorDB.update(func(tx *bolt.Tx) error {
+		pkBucket := tx.Bucket(pubKeysBucket).Bucket(pubKey[:])
+		// The attestation (2, 4) is only left in the source epochs bucket.
+		if err := pkBucket.Bucket(attestationTargetEpochsBucket).Delete(bytesutil.EpochToBytesBigEndian(4)); err != nil {
+			return err
+		}
+		if err := pkBucket.Bucket(attestationSigningRootsBucket).Put(
+			bytesutil.EpochToBytesBigEndian(9), make([]byte, 32),
+		); err != nil {
+			return err
+		}
+		if err := tx.Bucket(lowestSignedSourceBucket).Delete(pubKey[:]); err != nil {
+			return err
+		}
+		if err := tx.Bucket(highestSignedProposalsBucket).Put(pubKey[:], bytesutil.SlotToBytesBigEndian(11)); err != nil {
+			return err
+		}
+		if err := tx.Bucket(deprecatedAttestationHistoryBucket).Put(pubKey[:], []byte{1}); err != nil {
+			return err
+		}
+		_, err := tx.CreateBucket([]byte("unknown-bucket"))
+		return err
+	}))
+
+	issues, err := validatorDB.CheckIntegrity(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 6, len(issues), "Unexpected issues %v", issues)
+	for _, issue := range issues {
+		assert.Equal(t, true, issue.Repairable, issue.String())
+	}
+
+	dir := t.TempDir()
+	require.NoError(t, validatorDB.RepairInto(ctx, dir))
+	require.ErrorContains(t, "already exists", validatorDB.RepairInto(ctx, dir))
+	boltDB, err := bolt.Open(filepath.Join(dir, ProtectionDbFileName), params.BeaconIoConfig().ReadWritePermissions, nil)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, boltDB.Close())
+	}()
+	repaired := &Store{db: boltDB}
+	issues, err = repaired.CheckIntegrity(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(issues), "Unexpected issues %v", issues)
+
+	history, err := repaired.AttestationHistoryForPubKey(ctx, pubKey)
+	require.NoError(t, err)
+	assert.Equal(t, 3, len(history))
+	kind, err := repaired.CheckSlashableAttestation(ctx, pubKey, [32]byte{9}, createAttestation(3, 4))
+	require.ErrorContains(t, "double vote", err)
+	assert.Equal(t, DoubleVote, kind)
+	source, ok, err := repaired.LowestSignedSourceEpoch(ctx, pubKey)
+	require.NoError(t, err)
+	require.Equal(t, true, ok)
+	assert.Equal(t, types.Epoch(1), source)
+	highest, _, err := repaired.HighestSignedProposal(ctx, pubKey)
+	require.NoError(t, err)
+	assert.Equal(t, types.Slot(12), highest)
+}
+
+func TestStore_CheckIntegrity_RepairInto_LowestWatermarks(t *testing.T) {
+	ctx := context.Background()
+	pubKey := [48]byte{1}
+	validatorDB := setupIntegrityDB(t, pubKey)
+	require.NoError(t, validatorDB.update(func(tx *bolt.Tx) error {
+		// The lowest watermarks are all above the highest signed epoch or slot.
+		if err := tx.Bucket(lowestSignedSourceBucket).Put(pubKey[:], bytesutil.EpochToBytesBigEndian(3)); err != nil {
+			return err
+		}
+		if err := tx.Bucket(lowestSignedTargetBucket).Put(pubKey[:], bytesutil.EpochToBytesBigEndian(100)); err != nil {
+			return err
+		}
+		return tx.Bucket(lowestSignedProposalsBucket).Put(pubKey[:], bytesutil.SlotToBytesBigEndian(13))
+	}))
+
+	issues, err := validatorDB.CheckIntegrity(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(issues), "Unexpected issues %v", issues)
+	for _, issue := range issues {
+		assert.Equal(t, true, issue.Repairable, issue.String())
+		assert.Equal(t, true, strings.Contains(issue.Description, "above the highest signed"), issue.String())
+	}
+
+	dir := t.TempDir()
+	require.NoError(t, validatorDB.RepairInto(ctx, dir))
+	boltDB, err := bolt.Open(filepath.Join(dir, ProtectionDbFileName), params.BeaconIoConfig().ReadWritePermissions, nil)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, boltDB.Close())
+	}()
+	repaired := &Store{db: boltDB}
+	issues, err = repaired.CheckIntegrity(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(issues), "Unexpected issues %v", issues)
+
+	source, ok, err := repaired.LowestSignedSourceEpoch(ctx, pubKey)
+	require.NoError(t, err)
+	require.Equal(t, true, ok)
+	assert.Equal(t, types.Epoch(1), source)
+	target, ok, err := repaired.LowestSignedTargetEpoch(ctx, pubKey)
+	require.NoError(t, err)
+	require.Equal(t, true, ok)
+	assert.Equal(t, types.Epoch(2), target)
+	slot, ok, err := repaired.LowestSignedProposal(ctx, pubKey)
+	require.NoError(t, err)
+	require.Equal(t, true, ok)
+	assert.Equal(t, types.Slot(10), slot)
+}
+
+func TestStore_CheckIntegrity_UnrepairableIssues(t *testing.T) {
+	ctx := context.Background()
+	pubKey := [48]byte{1}
+	validatorDB := setupIntegrityDB(t, pubKey)
+	require.NoError(t, validatorDB.update(func(tx *bolt.Tx) error {
+		if err := tx.Bucket(migrationsBucket).Delete(migrationOptimalAttesterProtectionKey); err != nil {
+			return err
+		}
+		return tx.Bucket(deprecatedAttestationHistoryBucket).Put(pubKey[:], []byte{1})
+	}))
+	issues, err := validatorDB.CheckIntegrity(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(issues))
+	assert.Equal(t, false, issues[0].Repairable)
+
+	dir := t.TempDir()
+	require.ErrorContains(t, "not been migrated", validatorDB.RepairInto(ctx, dir))
+	assert.Equal(t, false, fileutil.FileExists(filepath.Join(dir, ProtectionDbFileName)), "Partial database should be removed")
+}
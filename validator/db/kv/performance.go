@@ -0,0 +1,194 @@
+package kv
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// EpochPerformance is the performance of a validating key in an epoch: the correctness and
+// inclusion of its attestation, its balance change over the epoch transition, its block
+// proposals and the Pandora shard headers it submitted when proposing.
+type EpochPerformance struct {
+	Epoch                types.Epoch  `json:"epoch"`
+	AttestationRecorded  bool         `json:"attestation_recorded"`
+	CorrectlyVotedSource bool         `json:"correctly_voted_source"`
+	CorrectlyVotedTarget bool         `json:"correctly_voted_target"`
+	CorrectlyVotedHead   bool         `json:"correctly_voted_head"`
+	InclusionSlot        types.Slot   `json:"inclusion_slot"`
+	InclusionDistance    types.Slot   `json:"inclusion_distance"`
+	BalanceBefore        uint64       `json:"balance_before"`
+	BalanceAfter         uint64       `json:"balance_after"`
+	ProposedSlots        []types.Slot `json:"proposed_slots,omitempty"`
+	MissedSlots          []types.Slot `json:"missed_slots,omitempty"`
+	ShardSubmittedSlots  []types.Slot `json:"shard_submitted_slots,omitempty"`
+	ShardFailedSlots     []types.Slot `json:"shard_failed_slots,omitempty"`
+}
+
+// BalanceDelta returns the balance change of the key over the epoch transition, in Gwei.
+func (p *EpochPerformance) BalanceDelta() int64 {
+	return int64(p.BalanceAfter) - int64(p.BalanceBefore)
+}
+
+// AttestationIncluded returns whether the attestation of the key for the epoch was included on chain.
+func (p *EpochPerformance) AttestationIncluded() bool {
+	return p.AttestationRecorded && uint64(p.InclusionSlot) != ^uint64(0)
+}
+
+// UpdateEpochPerformance applies the given update to the performance record of a public key for an
+// epoch, creating the record when it does not exist yet.
+func (s *Store) UpdateEpochPerformance(
+	ctx context.Context, pubKey [48]byte, epoch types.Epoch, update func(p *EpochPerformance),
+) error {
+	ctx, span := trace.StartSpan(ctx, "Validator.UpdateEpochPerformance")
+	defer span.End()
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bkt, err := tx.Bucket(performanceBucket).CreateBucketIfNotExists(pubKey[:])
+		if err != nil {
+			return err
+		}
+		key := bytesutil.EpochToBytesBigEndian(epoch)
+		p := &EpochPerformance{Epoch: epoch}
+		if enc := bkt.Get(key); enc != nil {
+			if err := json.Unmarshal(enc, p); err != nil {
+				return errors.Wrapf(err, "could not unmarshal performance of epoch %d", epoch)
+			}
+		}
+		update(p)
+		p.Epoch = epoch
+		enc, err := json.Marshal(p)
+		if err != nil {
+			return err
+		}
+		return bkt.Put(key, enc)
+	})
+}
+
+// EpochPerformanceForPubKey returns the performance records of a public key from the start epoch
+// to the end epoch included, in ascending epoch order.
+func (s *Store) EpochPerformanceForPubKey(
+	ctx context.Context, pubKey [48]byte, start, end types.Epoch,
+) ([]*EpochPerformance, error) {
+	ctx, span := trace.StartSpan(ctx, "Validator.EpochPerformanceForPubKey")
+	defer span.End()
+	records := make([]*EpochPerformance, 0)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(performanceBucket).Bucket(pubKey[:])
+		if bkt == nil {
+			return nil
+		}
+		c := bkt.Cursor()
+		for k, v := c.Seek(bytesutil.EpochToBytesBigEndian(start)); k != nil; k, v = c.Next() {
+			if bytesutil.BytesToEpochBigEndian(k) > end {
+				break
+			}
+			p := &EpochPerformance{}
+			if err := json.Unmarshal(v, p); err != nil {
+				return errors.Wrapf(err, "could not unmarshal performance of epoch %d", bytesutil.BytesToEpochBigEndian(k))
+			}
+			records = append(records, p)
+		}
+		return nil
+	})
+	return records, err
+}
+
+// PerformancePublicKeys returns the public keys which have performance records.
+func (s *Store) PerformancePublicKeys(ctx context.Context) ([][48]byte, error) {
+	ctx, span := trace.StartSpan(ctx, "Validator.PerformancePublicKeys")
+	defer span.End()
+	publicKeys := make([][48]byte, 0)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(performanceBucket).ForEach(func(key []byte, _ []byte) error {
+			publicKeys = append(publicKeys, bytesutil.ToBytes48(key))
+			return nil
+		})
+	})
+	return publicKeys, err
+}
+
+// PruneEpochPerformance deletes, for every public key, the performance records older than the
+// highest recorded epoch of the key minus the slashing protection pruning period, so that
+// performance history is kept for as long as the attestation history of the key.
+func (s *Store) PruneEpochPerformance(ctx context.Context) error {
+	ctx, span := trace.StartSpan(ctx, "Validator.PruneEpochPerformance")
+	defer span.End()
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(performanceBucket)
+		return bkt.ForEach(func(pubKey []byte, _ []byte) error {
+			pkBucket := bkt.Bucket(pubKey)
+			if pkBucket == nil {
+				return nil
+			}
+			highestEpochBytes, _ := pkBucket.Cursor().Last()
+			if highestEpochBytes == nil {
+				return nil
+			}
+			cutoff := pruningEpochCutoff(bytesutil.BytesToEpochBigEndian(highestEpochBytes))
+			// Keys are collected before deleting them as deleting while iterating a cursor skips keys.
+			var staleKeys [][]byte
+			c := pkBucket.Cursor()
+			for k, _ := c.First(); k != nil && bytesutil.BytesToEpochBigEndian(k) < cutoff; k, _ = c.Next() {
+				staleKeys = append(staleKeys, bytesutil.SafeCopyBytes(k))
+			}
+			for _, k := range staleKeys {
+				if err := pkBucket.Delete(k); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	})
+}
+
+// PerformanceSummary aggregates the performance records of a public key over a range of epochs.
+type PerformanceSummary struct {
+	Epochs               uint64 `json:"epochs"`
+	AttestationsIncluded uint64 `json:"attestations_included"`
+	CorrectSources       uint64 `json:"correct_sources"`
+	CorrectTargets       uint64 `json:"correct_targets"`
+	CorrectHeads         uint64 `json:"correct_heads"`
+	TotalDistance        uint64 `json:"total_inclusion_distance"`
+	BalanceDelta         int64  `json:"balance_delta"`
+	Proposed             uint64 `json:"proposed"`
+	Missed               uint64 `json:"missed"`
+	ShardsSubmitted      uint64 `json:"shards_submitted"`
+	ShardsFailed         uint64 `json:"shards_failed"`
+}
+
+// SummarizePerformance aggregates performance records. Only the records holding an attestation
+// summary count as epochs with attestation duties.
+func SummarizePerformance(records []*EpochPerformance) *PerformanceSummary {
+	summary := &PerformanceSummary{}
+	for _, p := range records {
+		summary.Proposed += uint64(len(p.ProposedSlots))
+		summary.Missed += uint64(len(p.MissedSlots))
+		summary.ShardsSubmitted += uint64(len(p.ShardSubmittedSlots))
+		summary.ShardsFailed += uint64(len(p.ShardFailedSlots))
+		if !p.AttestationRecorded {
+			continue
+		}
+		summary.Epochs++
+		summary.BalanceDelta += p.BalanceDelta()
+		if !p.AttestationIncluded() {
+			continue
+		}
+		summary.AttestationsIncluded++
+		summary.TotalDistance += uint64(p.InclusionDistance)
+		if p.CorrectlyVotedSource {
+			summary.CorrectSources++
+		}
+		if p.CorrectlyVotedTarget {
+			summary.CorrectTargets++
+		}
+		if p.CorrectlyVotedHead {
+			summary.CorrectHeads++
+		}
+	}
+	return summary
+}
@@ -0,0 +1,96 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func TestStore_EpochPerformance(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t, [][48]byte{})
+	pubKey := [48]byte{1}
+
+	records, err := db.EpochPerformanceForPubKey(ctx, pubKey, 0, 100)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(records))
+
+	for epoch := types.Epoch(1); epoch <= 5; epoch++ {
+		require.NoError(t, db.UpdateEpochPerformance(ctx, pubKey, epoch, func(p *EpochPerformance) {
+			p.AttestationRecorded = true
+			p.CorrectlyVotedTarget = true
+			p.InclusionSlot = types.Slot(epoch) * 32
+			p.BalanceBefore = 32000000000
+			p.BalanceAfter = 32000000000 + uint64(epoch)
+		}))
+	}
+	// Updates of the same epoch are merged into a single record.
+	require.NoError(t, db.UpdateEpochPerformance(ctx, pubKey, 3, func(p *EpochPerformance) {
+		p.ProposedSlots = append(p.ProposedSlots, 100)
+	}))
+	require.NoError(t, db.UpdateEpochPerformance(ctx, pubKey, 3, func(p *EpochPerformance) {
+		p.MissedSlots = append(p.MissedSlots, 101)
+	}))
+
+	records, err = db.EpochPerformanceForPubKey(ctx, pubKey, 2, 4)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(records))
+	for i, p := range records {
+		assert.Equal(t, types.Epoch(i+2), p.Epoch)
+		assert.Equal(t, true, p.AttestationIncluded())
+		assert.Equal(t, int64(i+2), p.BalanceDelta())
+	}
+	assert.DeepEqual(t, []types.Slot{100}, records[1].ProposedSlots)
+	assert.DeepEqual(t, []types.Slot{101}, records[1].MissedSlots)
+	assert.Equal(t, true, records[1].CorrectlyVotedTarget)
+
+	keys, err := db.PerformancePublicKeys(ctx)
+	require.NoError(t, err)
+	assert.DeepEqual(t, [][48]byte{pubKey}, keys)
+
+	summary := SummarizePerformance(records)
+	assert.DeepEqual(t, &PerformanceSummary{
+		Epochs:               3,
+		AttestationsIncluded: 3,
+		CorrectTargets:       3,
+		BalanceDelta:         9,
+		Proposed:             1,
+		Missed:               1,
+	}, summary)
+}
+
+func TestStore_PruneEpochPerformance(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t, [][48]byte{})
+	pubKey := [48]byte{1}
+	otherPubKey := [48]byte{2}
+
+	pruningEpochs := params.BeaconConfig().SlashingProtectionPruningEpochs
+	highestEpoch := pruningEpochs + 10
+	for _, epoch := range []types.Epoch{0, 5, 9, 10, 11, highestEpoch} {
+		require.NoError(t, db.UpdateEpochPerformance(ctx, pubKey, epoch, func(p *EpochPerformance) {
+			p.AttestationRecorded = true
+		}))
+	}
+	// Records of a key are pruned relative to the highest epoch of that key only.
+	require.NoError(t, db.UpdateEpochPerformance(ctx, otherPubKey, 5, func(p *EpochPerformance) {
+		p.AttestationRecorded = true
+	}))
+
+	require.NoError(t, db.PruneEpochPerformance(ctx))
+
+	records, err := db.EpochPerformanceForPubKey(ctx, pubKey, 0, highestEpoch)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(records))
+	assert.Equal(t, types.Epoch(10), records[0].Epoch)
+	assert.Equal(t, types.Epoch(11), records[1].Epoch)
+	assert.Equal(t, highestEpoch, records[2].Epoch)
+
+	records, err = db.EpochPerformanceForPubKey(ctx, otherPubKey, 0, highestEpoch)
+	require.NoError(t, err)
+	assert.Equal(t, 1, len(records))
+}
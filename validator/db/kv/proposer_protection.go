@@ -207,3 +207,25 @@ func pruneProposalHistoryBySlot(valBucket *bolt.Bucket, newestSlot types.Slot) e
 	}
 	return nil
 }
+
+// RaiseLowestSignedProposal raises the lowest and highest signed proposal slots of a validator public
+// key to the given slot when they are lower, so that no block older than the given slot can be signed.
+// It is used when merging imported slashing protection history with the one in the database.
+func (s *Store) RaiseLowestSignedProposal(ctx context.Context, publicKey [48]byte, slot types.Slot) error {
+	ctx, span := trace.StartSpan(ctx, "Validator.RaiseLowestSignedProposal")
+	defer span.End()
+
+	return s.update(func(tx *bolt.Tx) error {
+		for _, bucketName := range [][]byte{lowestSignedProposalsBucket, highestSignedProposalsBucket} {
+			bucket := tx.Bucket(bucketName)
+			existing := bucket.Get(publicKey[:])
+			if len(existing) >= 8 && bytesutil.BytesToSlotBigEndian(existing) >= slot {
+				continue
+			}
+			if err := bucket.Put(publicKey[:], bytesutil.SlotToBytesBigEndian(slot)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -34,6 +34,9 @@ var (
 	// Graffiti
 	graffitiBucket = []byte("graffiti")
 
+	// Validator performance history, with a nested bucket per public key keyed by epoch.
+	performanceBucket = []byte("validator-performance-bucket")
+
 	// Graffiti ordered index and hash keys
 	graffitiOrderedIndexKey = []byte("graffiti-ordered-index")
 	graffitiFileHashKey     = []byte("graffiti-file-hash")
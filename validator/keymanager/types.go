@@ -28,7 +28,7 @@ type Keystore struct {
 	Name    string                 `json:"name"`
 }
 
-// Kind defines an enum for either imported, derived, or remote-signing
+// Kind defines an enum for either imported, derived, remote-signing or Web3Signer
 // keystores for Prysm wallets.
 type Kind int
 
@@ -39,6 +39,8 @@ const (
 	Derived
 	// Remote keymanager capable of remote-signing data.
 	Remote
+	// Web3Signer keymanager remote-signing data through the Web3Signer HTTP API.
+	Web3Signer
 )
 
 // String marshals a keymanager kind to a string value.
@@ -50,6 +52,8 @@ func (k Kind) String() string {
 		return "direct"
 	case Remote:
 		return "remote"
+	case Web3Signer:
+		return "web3signer"
 	default:
 		return fmt.Sprintf("%d", int(k))
 	}
@@ -64,6 +68,8 @@ func ParseKind(k string) (Kind, error) {
 		return Imported, nil
 	case "remote":
 		return Remote, nil
+	case "web3signer":
+		return Web3Signer, nil
 	default:
 		return 0, fmt.Errorf("%s is not an allowed keymanager", k)
 	}
@@ -0,0 +1,38 @@
+/*
+Package web3signer defines a keymanager implementation which signs with the keys of a remote
+Web3Signer server through its HTTP API. The public keys are listed with
+
+ GET /api/v1/eth2/publicKeys
+
+and each sign request of the validator is sent to
+
+ POST /api/v1/eth2/sign/{pubkey}
+
+with a typed JSON body holding the signed object, the signing root and the fork info at the
+epoch of the object, letting Web3Signer apply its own slashing protection:
+
+ {
+   "type": "ATTESTATION",
+   "fork_info": {
+     "fork": {"previous_version": "0x00000000", "current_version": "0x00000000", "epoch": "0"},
+     "genesis_validators_root": "0x04700007fabc8282644aed6d1c7c9e21d38a03a0c4ba193f3afe428824b3a673"
+   },
+   "signingRoot": "0x...",
+   "attestation": {"slot": "32", "index": "0", "beacon_block_root": "0x...", "source": {...}, "target": {...}}
+ }
+
+Blocks are sent in full as BLOCK_V2 requests of the PHASE0 or ALTAIR version. Phase 0 block bodies
+holding Pandora shards list them under "pandora_shard": their root, and so the signing root, can
+only be computed by a signer supporting Vanguard. Pandora header hashes are sent as PANDORA_HEADER
+requests holding only the signing root, which also requires a signer supporting Vanguard.
+
+The keymanager can be customized via a keymanageropts.json file
+which requires the following schema:
+
+ {
+   "base_url": "http://localhost:9000", // Web3Signer HTTP API address.
+   "genesis_validators_root": "0x...",  // Genesis validators root of the chain.
+   "polling_interval_seconds": 60       // Interval between two fetches of the public keys.
+ }
+*/
+package web3signer
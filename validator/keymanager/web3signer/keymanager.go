@@ -0,0 +1,296 @@
+package web3signer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/logrusorgru/aurora"
+	"github.com/pkg/errors"
+	validatorpb "github.com/prysmaticlabs/prysm/proto/validator/accounts/v2"
+	"github.com/prysmaticlabs/prysm/shared/bls"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/event"
+	"github.com/prysmaticlabs/prysm/validator/keymanager"
+)
+
+const (
+	// PublicKeysPath is the path of the Web3Signer endpoint listing the available public keys.
+	PublicKeysPath = "/api/v1/eth2/publicKeys"
+	// SignPath is the path of the Web3Signer endpoint signing with a public key, followed by the key.
+	SignPath = "/api/v1/eth2/sign/"
+	// DefaultPollingInterval is the interval between two fetches of the public keys of the signer
+	// when the keymanager listens for changes.
+	DefaultPollingInterval = time.Minute
+	requestTimeout         = 10 * time.Second
+)
+
+var (
+	// ErrSigningFailed defines a failure from Web3Signer when performing a signing operation.
+	ErrSigningFailed = errors.New("signing failed in web3signer")
+	// ErrSigningDenied defines a signing operation denied by the slashing protection of Web3Signer.
+	ErrSigningDenied = errors.New("signing request was denied by web3signer slashing protection")
+	// ErrUnknownPublicKey defines a signing operation for a public key unknown to Web3Signer.
+	ErrUnknownPublicKey = errors.New("public key is not known by web3signer")
+)
+
+// KeymanagerOpts for a Web3Signer keymanager.
+type KeymanagerOpts struct {
+	BaseURL                string `json:"base_url"`
+	GenesisValidatorsRoot  string `json:"genesis_validators_root"`
+	PollingIntervalSeconds uint64 `json:"polling_interval_seconds,omitempty"`
+}
+
+// SetupConfig includes configuration values for initializing a Web3Signer keymanager.
+type SetupConfig struct {
+	Opts             *KeymanagerOpts
+	ListenForChanges bool
+}
+
+// Keymanager implementation using remote signing keys via the Web3Signer HTTP API.
+type Keymanager struct {
+	opts                  *KeymanagerOpts
+	baseURL               string
+	genesisValidatorsRoot []byte
+	client                *http.Client
+	orderedPubKeys        [][48]byte
+	pubKeysLoaded         bool
+	pubKeysLock           sync.Mutex
+	accountsChangedFeed   *event.Feed
+}
+
+// NewKeymanager instantiates a new Web3Signer keymanager from configuration options. When listening
+// for changes, the public keys of the signer are polled until the context is canceled.
+func NewKeymanager(ctx context.Context, cfg *SetupConfig) (*Keymanager, error) {
+	if cfg.Opts == nil {
+		return nil, errors.New("web3signer configuration is missing")
+	}
+	u, err := url.Parse(cfg.Opts.BaseURL)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("invalid web3signer url %q", cfg.Opts.BaseURL)
+	}
+	genesisValidatorsRoot, err := hexutil.Decode(cfg.Opts.GenesisValidatorsRoot)
+	if err != nil || len(genesisValidatorsRoot) != 32 {
+		return nil, fmt.Errorf("invalid genesis validators root %q, expected 32 hex encoded bytes", cfg.Opts.GenesisValidatorsRoot)
+	}
+	km := &Keymanager{
+		opts:                  cfg.Opts,
+		baseURL:               strings.TrimSuffix(cfg.Opts.BaseURL, "/"),
+		genesisValidatorsRoot: genesisValidatorsRoot,
+		client:                &http.Client{Timeout: requestTimeout},
+		orderedPubKeys:        make([][48]byte, 0),
+		accountsChangedFeed:   new(event.Feed),
+	}
+	if cfg.ListenForChanges {
+		interval := DefaultPollingInterval
+		if cfg.Opts.PollingIntervalSeconds > 0 {
+			interval = time.Duration(cfg.Opts.PollingIntervalSeconds) * time.Second
+		}
+		go km.pollPublicKeys(ctx, interval)
+	}
+	return km, nil
+}
+
+// UnmarshalOptionsFile attempts to JSON unmarshal a keymanager
+// options file into a struct.
+func UnmarshalOptionsFile(r io.ReadCloser) (*KeymanagerOpts, error) {
+	enc, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read config")
+	}
+	defer func() {
+		if err := r.Close(); err != nil {
+			log.Errorf("Could not close keymanager config file: %v", err)
+		}
+	}()
+	opts := &KeymanagerOpts{}
+	if err := json.Unmarshal(enc, opts); err != nil {
+		return nil, errors.Wrap(err, "could not JSON unmarshal")
+	}
+	return opts, nil
+}
+
+// MarshalOptionsFile for the keymanager.
+func MarshalOptionsFile(_ context.Context, cfg *KeymanagerOpts) ([]byte, error) {
+	return json.MarshalIndent(cfg, "", "\t")
+}
+
+// String pretty-print of Web3Signer keymanager options.
+func (opts *KeymanagerOpts) String() string {
+	au := aurora.NewAurora(true)
+	lines := []string{
+		fmt.Sprintf("%s: %s", au.BrightMagenta("Web3Signer URL"), opts.BaseURL),
+		fmt.Sprintf("%s: %s", au.BrightMagenta("Genesis validators root"), opts.GenesisValidatorsRoot),
+	}
+	if opts.PollingIntervalSeconds > 0 {
+		lines = append(lines, fmt.Sprintf("%s: %ds", au.BrightMagenta("Public keys polling interval"), opts.PollingIntervalSeconds))
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// KeymanagerOpts for the Web3Signer keymanager.
+func (km *Keymanager) KeymanagerOpts() *KeymanagerOpts {
+	return km.opts
+}
+
+// FetchValidatingPublicKeys fetches the list of public keys available in Web3Signer.
+func (km *Keymanager) FetchValidatingPublicKeys(ctx context.Context) ([][48]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, km.baseURL+PublicKeysPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := km.client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not list public keys from web3signer")
+	}
+	defer closeBody(resp)
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("could not list public keys from web3signer: %s", responseError(resp))
+	}
+	var hexKeys []string
+	if err := json.NewDecoder(resp.Body).Decode(&hexKeys); err != nil {
+		return nil, errors.Wrap(err, "could not decode public keys")
+	}
+	pubKeys := make([][48]byte, len(hexKeys))
+	for i, k := range hexKeys {
+		pubKey, err := hexutil.Decode(k)
+		if err != nil || len(pubKey) != 48 {
+			return nil, fmt.Errorf("invalid public key %q", k)
+		}
+		pubKeys[i] = bytesutil.ToBytes48(pubKey)
+	}
+	return pubKeys, nil
+}
+
+// Sign signs a message for a validator key via a Web3Signer sign request. The signed object is
+// sent along with the signing root and the fork info, for Web3Signer to check it against its
+// slashing protection.
+func (km *Keymanager) Sign(ctx context.Context, req *validatorpb.SignRequest) (bls.Signature, error) {
+	sig, err := km.signature(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return bls.SignatureFromBytes(sig)
+}
+
+func (km *Keymanager) signature(ctx context.Context, req *validatorpb.SignRequest) ([]byte, error) {
+	body, err := signRequestJson(req, km.genesisValidatorsRoot)
+	if err != nil {
+		return nil, err
+	}
+	enc, err := json.Marshal(body)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not marshal sign request")
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, km.baseURL+SignPath+hexString(req.PublicKey), bytes.NewReader(enc))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Accept", "application/json")
+	resp, err := km.client.Do(httpReq)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not send sign request to web3signer")
+	}
+	defer closeBody(resp)
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, ErrUnknownPublicKey
+	case http.StatusPreconditionFailed:
+		return nil, ErrSigningDenied
+	default:
+		return nil, errors.Wrap(ErrSigningFailed, responseError(resp))
+	}
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read sign response")
+	}
+	sigHex := strings.TrimSpace(string(respBody))
+	if strings.HasPrefix(resp.Header.Get("Content-Type"), "application/json") {
+		signResp := &SignResponseJson{}
+		if err := json.Unmarshal(respBody, signResp); err != nil {
+			return nil, errors.Wrap(err, "could not decode sign response")
+		}
+		sigHex = signResp.Signature
+	}
+	sig, err := hexutil.Decode(sigHex)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode signature")
+	}
+	return sig, nil
+}
+
+// SubscribeAccountChanges creates an event subscription for a channel
+// to listen for public key changes at runtime, such as when keys are added to
+// or removed from Web3Signer while the validator process is running.
+func (km *Keymanager) SubscribeAccountChanges(pubKeysChan chan [][48]byte) event.Subscription {
+	return km.accountsChangedFeed.Subscribe(pubKeysChan)
+}
+
+func (km *Keymanager) pollPublicKeys(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := km.reloadPublicKeys(ctx); err != nil {
+			log.WithError(err).Error("Could not reload public keys from web3signer")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// reloadPublicKeys fetches the public keys of the signer, and notifies the subscribers
+// when they differ from the previously fetched keys. The first fetch only records the keys,
+// which the validator already loaded at start.
+func (km *Keymanager) reloadPublicKeys(ctx context.Context) error {
+	pubKeys, err := km.FetchValidatingPublicKeys(ctx)
+	if err != nil {
+		return err
+	}
+	sort.Slice(pubKeys, func(i, j int) bool { return bytes.Compare(pubKeys[i][:], pubKeys[j][:]) == -1 })
+
+	km.pubKeysLock.Lock()
+	defer km.pubKeysLock.Unlock()
+	changed := len(km.orderedPubKeys) != len(pubKeys)
+	for i := 0; !changed && i < len(pubKeys); i++ {
+		changed = km.orderedPubKeys[i] != pubKeys[i]
+	}
+	loaded := km.pubKeysLoaded
+	km.orderedPubKeys = pubKeys
+	km.pubKeysLoaded = true
+	if changed && loaded {
+		log.WithField("numKeys", len(pubKeys)).Info(keymanager.KeysReloaded)
+		km.accountsChangedFeed.Send(pubKeys)
+	}
+	return nil
+}
+
+func responseError(resp *http.Response) string {
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil || len(body) == 0 {
+		return fmt.Sprintf("status %d", resp.StatusCode)
+	}
+	return fmt.Sprintf("status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+}
+
+func closeBody(resp *http.Response) {
+	if err := resp.Body.Close(); err != nil {
+		log.WithError(err).Error("Could not close response body")
+	}
+}
@@ -0,0 +1,414 @@
+package web3signer
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
+	prysmv2 "github.com/prysmaticlabs/prysm/proto/prysm/v2"
+	validatorpb "github.com/prysmaticlabs/prysm/proto/validator/accounts/v2"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+var (
+	testGenesisValidatorsRoot = "0x04700007fabc8282644aed6d1c7c9e21d38a03a0c4ba193f3afe428824b3a673"
+	testSignature             = bytes.Repeat([]byte{0xab}, 96)
+)
+
+func testPublicKeys(n int) [][48]byte {
+	keys := make([][48]byte, n)
+	for i := range keys {
+		keys[i] = bytesutil.ToBytes48(bytes.Repeat([]byte{byte(i + 1)}, 48))
+	}
+	return keys
+}
+
+func setupKeymanager(t *testing.T, server *mockServer, listen bool) *Keymanager {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	km, err := NewKeymanager(ctx, &SetupConfig{
+		Opts: &KeymanagerOpts{
+			BaseURL:                server.URL() + "/",
+			GenesisValidatorsRoot:  testGenesisValidatorsRoot,
+			PollingIntervalSeconds: 1,
+		},
+		ListenForChanges: listen,
+	})
+	require.NoError(t, err)
+	return km
+}
+
+func TestNewKeymanager_InvalidOpts(t *testing.T) {
+	tests := []struct {
+		name   string
+		opts   *KeymanagerOpts
+		errMsg string
+	}{
+		{name: "missing", errMsg: "configuration is missing"},
+		{name: "url", opts: &KeymanagerOpts{BaseURL: "localhost", GenesisValidatorsRoot: testGenesisValidatorsRoot}, errMsg: "invalid web3signer url"},
+		{name: "genesis validators root", opts: &KeymanagerOpts{BaseURL: "http://localhost:9000", GenesisValidatorsRoot: "0x1234"}, errMsg: "invalid genesis validators root"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewKeymanager(context.Background(), &SetupConfig{Opts: tt.opts})
+			assert.ErrorContains(t, tt.errMsg, err)
+		})
+	}
+}
+
+func TestUnmarshalOptionsFile(t *testing.T) {
+	opts := &KeymanagerOpts{BaseURL: "http://localhost:9000", GenesisValidatorsRoot: testGenesisValidatorsRoot, PollingIntervalSeconds: 12}
+	enc, err := MarshalOptionsFile(context.Background(), opts)
+	require.NoError(t, err)
+	got, err := UnmarshalOptionsFile(ioutil.NopCloser(bytes.NewReader(enc)))
+	require.NoError(t, err)
+	assert.DeepEqual(t, opts, got)
+}
+
+func TestKeymanager_FetchValidatingPublicKeys(t *testing.T) {
+	keys := testPublicKeys(3)
+	server := newMockServer(keys, testSignature)
+	defer server.Close()
+	km := setupKeymanager(t, server, false)
+
+	got, err := km.FetchValidatingPublicKeys(context.Background())
+	require.NoError(t, err)
+	assert.DeepEqual(t, keys, got)
+}
+
+func TestKeymanager_Signature(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig()
+	cfg.ForkVersionSchedule = map[types.Epoch][]byte{10: {1, 0, 0, 0}}
+	params.OverrideBeaconConfig(cfg)
+
+	keys := testPublicKeys(1)
+	server := newMockServer(keys, testSignature)
+	defer server.Close()
+	km := setupKeymanager(t, server, false)
+
+	block := testutil.NewBeaconBlock().Block
+	block.Slot = params.BeaconConfig().SlotsPerEpoch.Mul(11)
+	block.Body.Attestations = []*ethpb.Attestation{{
+		AggregationBits: []byte{0x03},
+		Data: &ethpb.AttestationData{
+			Slot:            351,
+			BeaconBlockRoot: make([]byte, 32),
+			Source:          &ethpb.Checkpoint{Epoch: 9, Root: make([]byte, 32)},
+			Target:          &ethpb.Checkpoint{Epoch: 10, Root: make([]byte, 32)},
+		},
+		Signature: make([]byte, 96),
+	}}
+	shardBlock := testutil.NewBeaconBlock().Block
+	shardBlock.Body.PandoraShard = []*ethpb.PandoraShard{{
+		BlockNumber: 7,
+		Hash:        bytes.Repeat([]byte{0x02}, 32),
+		ParentHash:  make([]byte, 32),
+		StateRoot:   make([]byte, 32),
+		TxHash:      make([]byte, 32),
+		ReceiptHash: make([]byte, 32),
+		SealHash:    make([]byte, 32),
+		Signature:   make([]byte, 96),
+	}}
+	altairBlock := &prysmv2.BeaconBlockAltair{
+		Slot:          params.BeaconConfig().SlotsPerEpoch.Mul(11),
+		ProposerIndex: 4,
+		ParentRoot:    make([]byte, 32),
+		StateRoot:     make([]byte, 32),
+		Body: &prysmv2.BeaconBlockBodyAltair{
+			RandaoReveal: make([]byte, 96),
+			Eth1Data:     &ethpb.Eth1Data{DepositRoot: make([]byte, 32), BlockHash: make([]byte, 32), DepositCount: 3},
+			Graffiti:     make([]byte, 32),
+			SyncAggregate: &prysmv2.SyncAggregate{
+				SyncCommitteeBits:      bytes.Repeat([]byte{0xff}, 64),
+				SyncCommitteeSignature: make([]byte, 96),
+			},
+		},
+	}
+	attData := &ethpb.AttestationData{
+		Slot:            3,
+		CommitteeIndex:  2,
+		BeaconBlockRoot: make([]byte, 32),
+		Source:          &ethpb.Checkpoint{Epoch: 0, Root: make([]byte, 32)},
+		Target:          &ethpb.Checkpoint{Epoch: 10, Root: make([]byte, 32)},
+	}
+
+	tests := []struct {
+		name     string
+		req      *validatorpb.SignRequest
+		signType string
+		fork     *ForkJson
+		check    func(t *testing.T, body *SignRequestJson)
+	}{
+		{
+			name:     "block",
+			req:      &validatorpb.SignRequest{Object: &validatorpb.SignRequest_Block{Block: block}},
+			signType: BlockV2SignType,
+			fork:     &ForkJson{PreviousVersion: "0x00000000", CurrentVersion: "0x01000000", Epoch: "10"},
+			check: func(t *testing.T, body *SignRequestJson) {
+				assert.Equal(t, phase0BlockVersion, body.BeaconBlock.Version)
+				assert.Equal(t, "352", body.BeaconBlock.Block.Slot)
+				blockBody := body.BeaconBlock.Block.Body
+				assert.Equal(t, hexString(make([]byte, 96)), blockBody.RandaoReveal)
+				require.Equal(t, 1, len(blockBody.Attestations))
+				assert.Equal(t, "0x03", blockBody.Attestations[0].AggregationBits)
+				assert.Equal(t, "351", blockBody.Attestations[0].Data.Slot)
+				assert.Equal(t, 0, len(blockBody.Deposits))
+				assert.Equal(t, (*SyncAggregateJson)(nil), blockBody.SyncAggregate)
+				assert.Equal(t, 0, len(blockBody.PandoraShards))
+			},
+		},
+		{
+			name:     "block with pandora shards",
+			req:      &validatorpb.SignRequest{Object: &validatorpb.SignRequest_Block{Block: shardBlock}},
+			signType: BlockV2SignType,
+			fork:     &ForkJson{PreviousVersion: "0x00000000", CurrentVersion: "0x00000000", Epoch: "0"},
+			check: func(t *testing.T, body *SignRequestJson) {
+				assert.Equal(t, phase0BlockVersion, body.BeaconBlock.Version)
+				shards := body.BeaconBlock.Block.Body.PandoraShards
+				require.Equal(t, 1, len(shards))
+				assert.Equal(t, "7", shards[0].BlockNumber)
+				assert.Equal(t, hexString(bytes.Repeat([]byte{0x02}, 32)), shards[0].Hash)
+			},
+		},
+		{
+			name:     "altair block",
+			req:      &validatorpb.SignRequest{Object: &validatorpb.SignRequest_BlockV2{BlockV2: altairBlock}},
+			signType: BlockV2SignType,
+			fork:     &ForkJson{PreviousVersion: "0x00000000", CurrentVersion: "0x01000000", Epoch: "10"},
+			check: func(t *testing.T, body *SignRequestJson) {
+				assert.Equal(t, altairBlockVersion, body.BeaconBlock.Version)
+				assert.Equal(t, "4", body.BeaconBlock.Block.ProposerIndex)
+				blockBody := body.BeaconBlock.Block.Body
+				assert.Equal(t, "3", blockBody.Eth1Data.DepositCount)
+				assert.Equal(t, hexString(bytes.Repeat([]byte{0xff}, 64)), blockBody.SyncAggregate.SyncCommitteeBits)
+				assert.Equal(t, 0, len(blockBody.PandoraShards))
+			},
+		},
+		{
+			name:     "attestation",
+			req:      &validatorpb.SignRequest{Object: &validatorpb.SignRequest_AttestationData{AttestationData: attData}},
+			signType: AttestationSignType,
+			fork:     &ForkJson{PreviousVersion: "0x00000000", CurrentVersion: "0x01000000", Epoch: "10"},
+			check: func(t *testing.T, body *SignRequestJson) {
+				assert.Equal(t, "3", body.Attestation.Slot)
+				assert.Equal(t, "2", body.Attestation.CommitteeIndex)
+				assert.Equal(t, "10", body.Attestation.Target.Epoch)
+			},
+		},
+		{
+			name: "aggregate and proof",
+			req: &validatorpb.SignRequest{Object: &validatorpb.SignRequest_AggregateAttestationAndProof{AggregateAttestationAndProof: &ethpb.AggregateAttestationAndProof{
+				AggregatorIndex: 7,
+				Aggregate:       &ethpb.Attestation{AggregationBits: []byte{0b1101}, Data: attData, Signature: testSignature},
+				SelectionProof:  testSignature,
+			}}},
+			signType: AggregateAndProofSignType,
+			fork:     &ForkJson{PreviousVersion: "0x00000000", CurrentVersion: "0x00000000", Epoch: "0"},
+			check: func(t *testing.T, body *SignRequestJson) {
+				assert.Equal(t, "7", body.AggregateAndProof.AggregatorIndex)
+				assert.Equal(t, "0x0d", body.AggregateAndProof.Aggregate.AggregationBits)
+				assert.Equal(t, "3", body.AggregateAndProof.Aggregate.Data.Slot)
+			},
+		},
+		{
+			name:     "aggregation slot",
+			req:      &validatorpb.SignRequest{Object: &validatorpb.SignRequest_Slot{Slot: 5}},
+			signType: AggregationSlotSignType,
+			fork:     &ForkJson{PreviousVersion: "0x00000000", CurrentVersion: "0x00000000", Epoch: "0"},
+			check: func(t *testing.T, body *SignRequestJson) {
+				assert.Equal(t, "5", body.AggregationSlot.Slot)
+			},
+		},
+		{
+			name:     "randao reveal",
+			req:      &validatorpb.SignRequest{Object: &validatorpb.SignRequest_Epoch{Epoch: 12}},
+			signType: RandaoRevealSignType,
+			fork:     &ForkJson{PreviousVersion: "0x00000000", CurrentVersion: "0x01000000", Epoch: "10"},
+			check: func(t *testing.T, body *SignRequestJson) {
+				assert.Equal(t, "12", body.RandaoReveal.Epoch)
+			},
+		},
+		{
+			name:     "voluntary exit",
+			req:      &validatorpb.SignRequest{Object: &validatorpb.SignRequest_Exit{Exit: &ethpb.VoluntaryExit{Epoch: 9, ValidatorIndex: 4}}},
+			signType: VoluntaryExitSignType,
+			fork:     &ForkJson{PreviousVersion: "0x00000000", CurrentVersion: "0x00000000", Epoch: "0"},
+			check: func(t *testing.T, body *SignRequestJson) {
+				assert.Equal(t, "9", body.VoluntaryExit.Epoch)
+				assert.Equal(t, "4", body.VoluntaryExit.ValidatorIndex)
+			},
+		},
+		{
+			name: "sync committee message",
+			req: &validatorpb.SignRequest{
+				SigningSlot: block.Slot,
+				Object:      &validatorpb.SignRequest_SyncMessageBlockRoot{SyncMessageBlockRoot: bytes.Repeat([]byte{0x01}, 32)},
+			},
+			signType: SyncCommitteeMessageSignType,
+			fork:     &ForkJson{PreviousVersion: "0x00000000", CurrentVersion: "0x01000000", Epoch: "10"},
+			check: func(t *testing.T, body *SignRequestJson) {
+				assert.Equal(t, "352", body.SyncCommitteeMessage.Slot)
+				assert.Equal(t, hexString(bytes.Repeat([]byte{0x01}, 32)), body.SyncCommitteeMessage.BeaconBlockRoot)
+			},
+		},
+		{
+			name: "sync committee selection proof",
+			req: &validatorpb.SignRequest{Object: &validatorpb.SignRequest_SyncAggregatorSelectionData{
+				SyncAggregatorSelectionData: &pb.SyncAggregatorSelectionData{Slot: block.Slot, SubcommitteeIndex: 3},
+			}},
+			signType: SyncCommitteeSelectionProofSignType,
+			fork:     &ForkJson{PreviousVersion: "0x00000000", CurrentVersion: "0x01000000", Epoch: "10"},
+			check: func(t *testing.T, body *SignRequestJson) {
+				assert.Equal(t, "352", body.SyncAggregatorSelectionData.Slot)
+				assert.Equal(t, "3", body.SyncAggregatorSelectionData.SubcommitteeIndex)
+			},
+		},
+		{
+			name: "sync committee contribution and proof",
+			req: &validatorpb.SignRequest{Object: &validatorpb.SignRequest_ContributionAndProof{ContributionAndProof: &prysmv2.ContributionAndProof{
+				AggregatorIndex: 6,
+				Contribution: &prysmv2.SyncCommitteeContribution{
+					Slot:              block.Slot,
+					BlockRoot:         make([]byte, 32),
+					SubcommitteeIndex: 1,
+					AggregationBits:   []byte{0b1011},
+					Signature:         testSignature,
+				},
+				SelectionProof: testSignature,
+			}}},
+			signType: SyncCommitteeContributionAndProofSignType,
+			fork:     &ForkJson{PreviousVersion: "0x00000000", CurrentVersion: "0x01000000", Epoch: "10"},
+			check: func(t *testing.T, body *SignRequestJson) {
+				assert.Equal(t, "6", body.ContributionAndProof.AggregatorIndex)
+				assert.Equal(t, hexString(testSignature), body.ContributionAndProof.SelectionProof)
+				assert.Equal(t, "352", body.ContributionAndProof.Contribution.Slot)
+				assert.Equal(t, "1", body.ContributionAndProof.Contribution.SubcommitteeIndex)
+				assert.Equal(t, "0x0b", body.ContributionAndProof.Contribution.AggregationBits)
+			},
+		},
+		{
+			name: "pandora header",
+			req: &validatorpb.SignRequest{
+				SigningSlot: 5,
+				Object:      &validatorpb.SignRequest_PandoraHeaderHash{PandoraHeaderHash: make([]byte, 32)},
+			},
+			signType: PandoraHeaderSignType,
+			fork:     &ForkJson{PreviousVersion: "0x00000000", CurrentVersion: "0x00000000", Epoch: "0"},
+			check: func(t *testing.T, body *SignRequestJson) {
+				assert.Equal(t, (*BeaconBlockJson)(nil), body.BeaconBlock)
+				assert.Equal(t, (*SyncCommitteeMessageJson)(nil), body.SyncCommitteeMessage)
+			},
+		},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signingRoot := bytes.Repeat([]byte{byte(i)}, 32)
+			tt.req.PublicKey = keys[0][:]
+			tt.req.SigningRoot = signingRoot
+			sig, err := km.signature(context.Background(), tt.req)
+			require.NoError(t, err)
+			assert.DeepEqual(t, testSignature, sig)
+
+			requests := server.SignRequests()
+			req := requests[len(requests)-1]
+			assert.Equal(t, hexString(keys[0][:]), req.PublicKey)
+			assert.Equal(t, tt.signType, req.Body.Type)
+			assert.Equal(t, hexString(signingRoot), req.Body.SigningRoot)
+			assert.Equal(t, testGenesisValidatorsRoot, req.Body.ForkInfo.GenesisValidatorsRoot)
+			assert.DeepEqual(t, tt.fork, req.Body.ForkInfo.Fork)
+			tt.check(t, req.Body)
+		})
+	}
+}
+
+func TestKeymanager_Signature_Errors(t *testing.T) {
+	keys := testPublicKeys(1)
+	server := newMockServer(keys, testSignature)
+	defer server.Close()
+	km := setupKeymanager(t, server, false)
+	ctx := context.Background()
+
+	_, err := km.signature(ctx, &validatorpb.SignRequest{PublicKey: keys[0][:], SigningRoot: make([]byte, 32)})
+	assert.ErrorContains(t, ErrUnsupportedSignRequest.Error(), err)
+
+	req := &validatorpb.SignRequest{
+		PublicKey:   keys[0][:],
+		SigningRoot: make([]byte, 32),
+		Object:      &validatorpb.SignRequest_Epoch{Epoch: 1},
+	}
+	unknownKey := testPublicKeys(2)[1]
+	_, err = km.signature(ctx, &validatorpb.SignRequest{PublicKey: unknownKey[:], SigningRoot: req.SigningRoot, Object: req.Object})
+	assert.ErrorContains(t, ErrUnknownPublicKey.Error(), err)
+
+	server.SetSignStatus(http.StatusPreconditionFailed)
+	_, err = km.signature(ctx, req)
+	assert.ErrorContains(t, ErrSigningDenied.Error(), err)
+
+	server.SetSignStatus(http.StatusInternalServerError)
+	_, err = km.signature(ctx, req)
+	assert.ErrorContains(t, ErrSigningFailed.Error(), err)
+}
+
+func TestKeymanager_SubscribeAccountChanges(t *testing.T) {
+	keys := testPublicKeys(3)
+	server := newMockServer(keys[:1], testSignature)
+	defer server.Close()
+	km := setupKeymanager(t, server, false)
+	ctx := context.Background()
+
+	pubKeysChan := make(chan [][48]byte, 1)
+	sub := km.SubscribeAccountChanges(pubKeysChan)
+	defer sub.Unsubscribe()
+
+	// The first fetch records the keys loaded by the validator at start.
+	require.NoError(t, km.reloadPublicKeys(ctx))
+	require.NoError(t, km.reloadPublicKeys(ctx))
+	assert.Equal(t, 0, len(pubKeysChan))
+
+	server.SetPublicKeys([][48]byte{keys[2], keys[0]})
+	require.NoError(t, km.reloadPublicKeys(ctx))
+	require.Equal(t, 1, len(pubKeysChan))
+	assert.DeepEqual(t, [][48]byte{keys[0], keys[2]}, <-pubKeysChan)
+
+	server.SetPublicKeys([][48]byte{keys[1], keys[2]})
+	require.NoError(t, km.reloadPublicKeys(ctx))
+	require.Equal(t, 1, len(pubKeysChan))
+	assert.DeepEqual(t, [][48]byte{keys[1], keys[2]}, <-pubKeysChan)
+}
+
+func TestKeymanager_PollPublicKeys(t *testing.T) {
+	keys := testPublicKeys(2)
+	server := newMockServer(keys[:1], testSignature)
+	defer server.Close()
+	km := setupKeymanager(t, server, true)
+
+	pubKeysChan := make(chan [][48]byte, 1)
+	sub := km.SubscribeAccountChanges(pubKeysChan)
+	defer sub.Unsubscribe()
+
+	// Wait for the keys loaded at start to be recorded before adding a key.
+	for loaded := false; !loaded; {
+		time.Sleep(10 * time.Millisecond)
+		km.pubKeysLock.Lock()
+		loaded = km.pubKeysLoaded
+		km.pubKeysLock.Unlock()
+	}
+	server.SetPublicKeys(keys)
+	select {
+	case got := <-pubKeysChan:
+		assert.DeepEqual(t, keys, got)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Public keys were not reloaded")
+	}
+}
@@ -0,0 +1,181 @@
+package web3signer
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// mockSignRequest is a sign request received by the mock Web3Signer server.
+type mockSignRequest struct {
+	PublicKey string
+	Body      *SignRequestJson
+}
+
+// mockServer is an HTTP stand-in of Web3Signer serving a list of public keys, and answering
+// every well formed sign request of a known key with a fixed signature.
+type mockServer struct {
+	server     *httptest.Server
+	lock       sync.Mutex
+	publicKeys [][48]byte
+	signature  []byte
+	status     int
+	requests   []*mockSignRequest
+}
+
+// newMockServer starts a mock Web3Signer server. It must be closed by the caller.
+func newMockServer(publicKeys [][48]byte, signature []byte) *mockServer {
+	m := &mockServer{publicKeys: publicKeys, signature: signature, status: http.StatusOK}
+	mux := http.NewServeMux()
+	mux.HandleFunc(PublicKeysPath, m.listPublicKeys)
+	mux.HandleFunc(SignPath, m.sign)
+	m.server = httptest.NewServer(mux)
+	return m
+}
+
+// URL of the mock server.
+func (m *mockServer) URL() string {
+	return m.server.URL
+}
+
+// Close shuts the mock server down.
+func (m *mockServer) Close() {
+	m.server.Close()
+}
+
+// SetPublicKeys replaces the public keys served by the mock server.
+func (m *mockServer) SetPublicKeys(publicKeys [][48]byte) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.publicKeys = publicKeys
+}
+
+// SetSignStatus sets the status code of the following sign responses, such as
+// http.StatusPreconditionFailed to deny them as slashable.
+func (m *mockServer) SetSignStatus(status int) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.status = status
+}
+
+// SignRequests returns the sign requests received by the mock server.
+func (m *mockServer) SignRequests() []*mockSignRequest {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return append([]*mockSignRequest{}, m.requests...)
+}
+
+func (m *mockServer) listPublicKeys(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	m.lock.Lock()
+	keys := make([]string, len(m.publicKeys))
+	for i := range m.publicKeys {
+		keys[i] = hexString(m.publicKeys[i][:])
+	}
+	m.lock.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(keys); err != nil {
+		log.WithError(err).Error("Could not write public keys")
+	}
+}
+
+func (m *mockServer) sign(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	body := &SignRequestJson{}
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(body); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := checkSignRequest(body); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	pubKey := strings.TrimPrefix(r.URL.Path, SignPath)
+
+	m.lock.Lock()
+	m.requests = append(m.requests, &mockSignRequest{PublicKey: pubKey, Body: body})
+	known := false
+	for i := range m.publicKeys {
+		known = known || hexString(m.publicKeys[i][:]) == pubKey
+	}
+	status := m.status
+	m.lock.Unlock()
+
+	switch {
+	case !known:
+		http.Error(w, "public key not found", http.StatusNotFound)
+	case status != http.StatusOK:
+		http.Error(w, http.StatusText(status), status)
+	case strings.Contains(r.Header.Get("Accept"), "application/json"):
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(&SignResponseJson{Signature: hexString(m.signature)}); err != nil {
+			log.WithError(err).Error("Could not write signature")
+		}
+	default:
+		w.Header().Set("Content-Type", "text/plain")
+		if _, err := w.Write([]byte(hexString(m.signature))); err != nil {
+			log.WithError(err).Error("Could not write signature")
+		}
+	}
+}
+
+// checkSignRequest rejects the sign requests Web3Signer would not accept: each type of request must
+// hold its object, and blocks of the phase 0 and altair versions must be sent in full.
+func checkSignRequest(body *SignRequestJson) error {
+	if body.ForkInfo == nil || body.SigningRoot == "" {
+		return errors.New("missing fork info or signing root")
+	}
+	hasObject := map[string]bool{
+		BlockV2SignType:                           body.BeaconBlock != nil,
+		AttestationSignType:                       body.Attestation != nil,
+		AggregateAndProofSignType:                 body.AggregateAndProof != nil,
+		AggregationSlotSignType:                   body.AggregationSlot != nil,
+		RandaoRevealSignType:                      body.RandaoReveal != nil,
+		VoluntaryExitSignType:                     body.VoluntaryExit != nil,
+		SyncCommitteeMessageSignType:              body.SyncCommitteeMessage != nil,
+		SyncCommitteeSelectionProofSignType:       body.SyncAggregatorSelectionData != nil,
+		SyncCommitteeContributionAndProofSignType: body.ContributionAndProof != nil,
+		PandoraHeaderSignType:                     true,
+	}
+	ok, known := hasObject[body.Type]
+	if !known {
+		return errors.New("unknown sign request type")
+	}
+	if !ok {
+		return errors.New("missing object of the sign request type")
+	}
+	if body.Type != BlockV2SignType {
+		return nil
+	}
+	block := body.BeaconBlock
+	if block.Version != phase0BlockVersion && block.Version != altairBlockVersion {
+		return errors.New("unsupported block version")
+	}
+	if block.Block == nil || block.Block.Body == nil {
+		return errors.New("missing block")
+	}
+	blockBody := block.Block.Body
+	if blockBody.Eth1Data == nil || blockBody.ProposerSlashings == nil || blockBody.AttesterSlashings == nil ||
+		blockBody.Attestations == nil || blockBody.Deposits == nil || blockBody.VoluntaryExits == nil {
+		return errors.New("missing block body field")
+	}
+	if (block.Version == altairBlockVersion) != (blockBody.SyncAggregate != nil) {
+		return errors.New("sync aggregate must be set in altair blocks only")
+	}
+	if block.Version == altairBlockVersion && blockBody.PandoraShards != nil {
+		return errors.New("pandora shards must be set in phase 0 blocks only")
+	}
+	return nil
+}
@@ -0,0 +1,538 @@
+package web3signer
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
+	validatorpb "github.com/prysmaticlabs/prysm/proto/validator/accounts/v2"
+	"github.com/prysmaticlabs/prysm/shared/p2putils"
+)
+
+// Sign request types of the Web3Signer eth2 signing API.
+const (
+	BlockV2SignType           = "BLOCK_V2"
+	AttestationSignType       = "ATTESTATION"
+	AggregateAndProofSignType = "AGGREGATE_AND_PROOF"
+	AggregationSlotSignType   = "AGGREGATION_SLOT"
+	RandaoRevealSignType      = "RANDAO_REVEAL"
+	VoluntaryExitSignType     = "VOLUNTARY_EXIT"
+
+	SyncCommitteeMessageSignType              = "SYNC_COMMITTEE_MESSAGE"
+	SyncCommitteeSelectionProofSignType       = "SYNC_COMMITTEE_SELECTION_PROOF"
+	SyncCommitteeContributionAndProofSignType = "SYNC_COMMITTEE_CONTRIBUTION_AND_PROOF"
+
+	// PandoraHeaderSignType is the Vanguard request to sign the hash of a Pandora header. It holds
+	// no object, the signing root being the header hash, and is only served by signers supporting
+	// Vanguard.
+	PandoraHeaderSignType = "PANDORA_HEADER"
+)
+
+// Block versions of BLOCK_V2 sign requests.
+const (
+	phase0BlockVersion = "PHASE0"
+	altairBlockVersion = "ALTAIR"
+)
+
+// ErrUnsupportedSignRequest is returned for sign requests without an object Web3Signer can sign.
+var ErrUnsupportedSignRequest = errors.New("sign request object is not supported by web3signer")
+
+// SignRequestJson is the body of a Web3Signer sign request. Only the object matching the
+// type of the request is set.
+type SignRequestJson struct {
+	Type              string                 `json:"type"`
+	ForkInfo          *ForkInfoJson          `json:"fork_info"`
+	SigningRoot       string                 `json:"signingRoot"`
+	BeaconBlock       *BeaconBlockJson       `json:"beacon_block,omitempty"`
+	Attestation       *AttestationDataJson   `json:"attestation,omitempty"`
+	AggregateAndProof *AggregateAndProofJson `json:"aggregate_and_proof,omitempty"`
+	AggregationSlot   *AggregationSlotJson   `json:"aggregation_slot,omitempty"`
+	RandaoReveal      *RandaoRevealJson      `json:"randao_reveal,omitempty"`
+	VoluntaryExit     *VoluntaryExitJson     `json:"voluntary_exit,omitempty"`
+
+	SyncCommitteeMessage        *SyncCommitteeMessageJson        `json:"sync_committee_message,omitempty"`
+	SyncAggregatorSelectionData *SyncAggregatorSelectionDataJson `json:"sync_aggregator_selection_data,omitempty"`
+	ContributionAndProof        *ContributionAndProofJson        `json:"contribution_and_proof,omitempty"`
+}
+
+// SignResponseJson is the body of a Web3Signer sign response sent as JSON.
+type SignResponseJson struct {
+	Signature string `json:"signature"`
+}
+
+// ForkInfoJson is the fork at the epoch of the signed object, with the genesis validators root
+// of the chain.
+type ForkInfoJson struct {
+	Fork                  *ForkJson `json:"fork"`
+	GenesisValidatorsRoot string    `json:"genesis_validators_root"`
+}
+
+// ForkJson --
+type ForkJson struct {
+	PreviousVersion string `json:"previous_version"`
+	CurrentVersion  string `json:"current_version"`
+	Epoch           string `json:"epoch"`
+}
+
+// BeaconBlockJson is a block to sign along with its fork version.
+type BeaconBlockJson struct {
+	Version string     `json:"version"`
+	Block   *BlockJson `json:"block"`
+}
+
+// BlockJson --
+type BlockJson struct {
+	Slot          string               `json:"slot"`
+	ProposerIndex string               `json:"proposer_index"`
+	ParentRoot    string               `json:"parent_root"`
+	StateRoot     string               `json:"state_root"`
+	Body          *BeaconBlockBodyJson `json:"body"`
+}
+
+// BeaconBlockBodyJson is the body of a phase 0 or altair block. The sync aggregate is only set in
+// altair blocks. The Pandora shards are only set in Vanguard phase 0 blocks, whose body root can
+// then only be computed by signers supporting Vanguard.
+type BeaconBlockBodyJson struct {
+	RandaoReveal      string                     `json:"randao_reveal"`
+	Eth1Data          *Eth1DataJson              `json:"eth1_data"`
+	Graffiti          string                     `json:"graffiti"`
+	ProposerSlashings []*ProposerSlashingJson    `json:"proposer_slashings"`
+	AttesterSlashings []*AttesterSlashingJson    `json:"attester_slashings"`
+	Attestations      []*AttestationJson         `json:"attestations"`
+	Deposits          []*DepositJson             `json:"deposits"`
+	VoluntaryExits    []*SignedVoluntaryExitJson `json:"voluntary_exits"`
+	SyncAggregate     *SyncAggregateJson         `json:"sync_aggregate,omitempty"`
+	PandoraShards     []*PandoraShardJson        `json:"pandora_shard,omitempty"`
+}
+
+// Eth1DataJson --
+type Eth1DataJson struct {
+	DepositRoot  string `json:"deposit_root"`
+	DepositCount string `json:"deposit_count"`
+	BlockHash    string `json:"block_hash"`
+}
+
+// ProposerSlashingJson --
+type ProposerSlashingJson struct {
+	SignedHeader1 *SignedBeaconBlockHeaderJson `json:"signed_header_1"`
+	SignedHeader2 *SignedBeaconBlockHeaderJson `json:"signed_header_2"`
+}
+
+// SignedBeaconBlockHeaderJson --
+type SignedBeaconBlockHeaderJson struct {
+	Message   *BeaconBlockHeaderJson `json:"message"`
+	Signature string                 `json:"signature"`
+}
+
+// BeaconBlockHeaderJson --
+type BeaconBlockHeaderJson struct {
+	Slot          string `json:"slot"`
+	ProposerIndex string `json:"proposer_index"`
+	ParentRoot    string `json:"parent_root"`
+	StateRoot     string `json:"state_root"`
+	BodyRoot      string `json:"body_root"`
+}
+
+// AttesterSlashingJson --
+type AttesterSlashingJson struct {
+	Attestation1 *IndexedAttestationJson `json:"attestation_1"`
+	Attestation2 *IndexedAttestationJson `json:"attestation_2"`
+}
+
+// IndexedAttestationJson --
+type IndexedAttestationJson struct {
+	AttestingIndices []string             `json:"attesting_indices"`
+	Data             *AttestationDataJson `json:"data"`
+	Signature        string               `json:"signature"`
+}
+
+// DepositJson --
+type DepositJson struct {
+	Proof []string         `json:"proof"`
+	Data  *DepositDataJson `json:"data"`
+}
+
+// DepositDataJson --
+type DepositDataJson struct {
+	PublicKey             string `json:"pubkey"`
+	WithdrawalCredentials string `json:"withdrawal_credentials"`
+	Amount                string `json:"amount"`
+	Signature             string `json:"signature"`
+}
+
+// SignedVoluntaryExitJson --
+type SignedVoluntaryExitJson struct {
+	Message   *VoluntaryExitJson `json:"message"`
+	Signature string             `json:"signature"`
+}
+
+// SyncAggregateJson --
+type SyncAggregateJson struct {
+	SyncCommitteeBits      string `json:"sync_committee_bits"`
+	SyncCommitteeSignature string `json:"sync_committee_signature"`
+}
+
+// PandoraShardJson --
+type PandoraShardJson struct {
+	BlockNumber string `json:"block_number"`
+	Hash        string `json:"hash"`
+	ParentHash  string `json:"parent_hash"`
+	StateRoot   string `json:"state_root"`
+	TxHash      string `json:"tx_hash"`
+	ReceiptHash string `json:"receipt_hash"`
+	SealHash    string `json:"seal_hash"`
+	Signature   string `json:"signature"`
+}
+
+// AttestationDataJson --
+type AttestationDataJson struct {
+	Slot            string          `json:"slot"`
+	CommitteeIndex  string          `json:"index"`
+	BeaconBlockRoot string          `json:"beacon_block_root"`
+	Source          *CheckpointJson `json:"source"`
+	Target          *CheckpointJson `json:"target"`
+}
+
+// CheckpointJson --
+type CheckpointJson struct {
+	Epoch string `json:"epoch"`
+	Root  string `json:"root"`
+}
+
+// AggregateAndProofJson --
+type AggregateAndProofJson struct {
+	AggregatorIndex string           `json:"aggregator_index"`
+	Aggregate       *AttestationJson `json:"aggregate"`
+	SelectionProof  string           `json:"selection_proof"`
+}
+
+// AttestationJson --
+type AttestationJson struct {
+	AggregationBits string               `json:"aggregation_bits"`
+	Data            *AttestationDataJson `json:"data"`
+	Signature       string               `json:"signature"`
+}
+
+// AggregationSlotJson --
+type AggregationSlotJson struct {
+	Slot string `json:"slot"`
+}
+
+// RandaoRevealJson --
+type RandaoRevealJson struct {
+	Epoch string `json:"epoch"`
+}
+
+// VoluntaryExitJson --
+type VoluntaryExitJson struct {
+	Epoch          string `json:"epoch"`
+	ValidatorIndex string `json:"validator_index"`
+}
+
+// SyncCommitteeMessageJson --
+type SyncCommitteeMessageJson struct {
+	BeaconBlockRoot string `json:"beacon_block_root"`
+	Slot            string `json:"slot"`
+}
+
+// SyncAggregatorSelectionDataJson --
+type SyncAggregatorSelectionDataJson struct {
+	Slot              string `json:"slot"`
+	SubcommitteeIndex string `json:"subcommittee_index"`
+}
+
+// ContributionAndProofJson --
+type ContributionAndProofJson struct {
+	AggregatorIndex string                         `json:"aggregator_index"`
+	SelectionProof  string                         `json:"selection_proof"`
+	Contribution    *SyncCommitteeContributionJson `json:"contribution"`
+}
+
+// SyncCommitteeContributionJson --
+type SyncCommitteeContributionJson struct {
+	Slot              string `json:"slot"`
+	BeaconBlockRoot   string `json:"beacon_block_root"`
+	SubcommitteeIndex string `json:"subcommittee_index"`
+	AggregationBits   string `json:"aggregation_bits"`
+	Signature         string `json:"signature"`
+}
+
+// signRequestJson maps a sign request to the typed body of the matching Web3Signer sign request.
+// The fork info is the fork scheduled at the epoch of the signed object.
+func signRequestJson(req *validatorpb.SignRequest, genesisValidatorsRoot []byte) (*SignRequestJson, error) {
+	var epoch types.Epoch
+	body := &SignRequestJson{SigningRoot: hexString(req.SigningRoot)}
+	switch obj := req.Object.(type) {
+	case *validatorpb.SignRequest_Block:
+		if obj.Block == nil || obj.Block.Body == nil {
+			return nil, errors.New("nil block")
+		}
+		epoch = helpers.SlotToEpoch(obj.Block.Slot)
+		block := blockJson(obj.Block, obj.Block.Body)
+		block.Body.PandoraShards = pandoraShardsJson(obj.Block.Body.PandoraShard)
+		body.Type = BlockV2SignType
+		body.BeaconBlock = &BeaconBlockJson{Version: phase0BlockVersion, Block: block}
+	case *validatorpb.SignRequest_BlockV2:
+		if obj.BlockV2 == nil || obj.BlockV2.Body == nil {
+			return nil, errors.New("nil block")
+		}
+		epoch = helpers.SlotToEpoch(obj.BlockV2.Slot)
+		block := blockJson(obj.BlockV2, obj.BlockV2.Body)
+		syncAggregate := obj.BlockV2.Body.SyncAggregate
+		block.Body.SyncAggregate = &SyncAggregateJson{
+			SyncCommitteeBits:      hexString(syncAggregate.GetSyncCommitteeBits()),
+			SyncCommitteeSignature: hexString(syncAggregate.GetSyncCommitteeSignature()),
+		}
+		body.Type = BlockV2SignType
+		body.BeaconBlock = &BeaconBlockJson{Version: altairBlockVersion, Block: block}
+	case *validatorpb.SignRequest_AttestationData:
+		if obj.AttestationData == nil || obj.AttestationData.Target == nil || obj.AttestationData.Source == nil {
+			return nil, errors.New("nil attestation data")
+		}
+		epoch = obj.AttestationData.Target.Epoch
+		body.Type = AttestationSignType
+		body.Attestation = attestationDataJson(obj.AttestationData)
+	case *validatorpb.SignRequest_AggregateAttestationAndProof:
+		agg := obj.AggregateAttestationAndProof
+		if agg == nil || agg.Aggregate == nil || agg.Aggregate.Data == nil ||
+			agg.Aggregate.Data.Target == nil || agg.Aggregate.Data.Source == nil {
+			return nil, errors.New("nil aggregate attestation and proof")
+		}
+		epoch = helpers.SlotToEpoch(agg.Aggregate.Data.Slot)
+		body.Type = AggregateAndProofSignType
+		body.AggregateAndProof = &AggregateAndProofJson{
+			AggregatorIndex: fmt.Sprintf("%d", agg.AggregatorIndex),
+			Aggregate: &AttestationJson{
+				AggregationBits: hexString(agg.Aggregate.AggregationBits),
+				Data:            attestationDataJson(agg.Aggregate.Data),
+				Signature:       hexString(agg.Aggregate.Signature),
+			},
+			SelectionProof: hexString(agg.SelectionProof),
+		}
+	case *validatorpb.SignRequest_Slot:
+		epoch = helpers.SlotToEpoch(obj.Slot)
+		body.Type = AggregationSlotSignType
+		body.AggregationSlot = &AggregationSlotJson{Slot: fmt.Sprintf("%d", obj.Slot)}
+	case *validatorpb.SignRequest_Epoch:
+		epoch = obj.Epoch
+		body.Type = RandaoRevealSignType
+		body.RandaoReveal = &RandaoRevealJson{Epoch: fmt.Sprintf("%d", obj.Epoch)}
+	case *validatorpb.SignRequest_Exit:
+		if obj.Exit == nil {
+			return nil, errors.New("nil voluntary exit")
+		}
+		epoch = obj.Exit.Epoch
+		body.Type = VoluntaryExitSignType
+		body.VoluntaryExit = &VoluntaryExitJson{
+			Epoch:          fmt.Sprintf("%d", obj.Exit.Epoch),
+			ValidatorIndex: fmt.Sprintf("%d", obj.Exit.ValidatorIndex),
+		}
+	case *validatorpb.SignRequest_SyncMessageBlockRoot:
+		epoch = helpers.SlotToEpoch(req.SigningSlot)
+		body.Type = SyncCommitteeMessageSignType
+		body.SyncCommitteeMessage = &SyncCommitteeMessageJson{
+			BeaconBlockRoot: hexString(obj.SyncMessageBlockRoot),
+			Slot:            fmt.Sprintf("%d", req.SigningSlot),
+		}
+	case *validatorpb.SignRequest_SyncAggregatorSelectionData:
+		if obj.SyncAggregatorSelectionData == nil {
+			return nil, errors.New("nil sync aggregator selection data")
+		}
+		epoch = helpers.SlotToEpoch(obj.SyncAggregatorSelectionData.Slot)
+		body.Type = SyncCommitteeSelectionProofSignType
+		body.SyncAggregatorSelectionData = &SyncAggregatorSelectionDataJson{
+			Slot:              fmt.Sprintf("%d", obj.SyncAggregatorSelectionData.Slot),
+			SubcommitteeIndex: fmt.Sprintf("%d", obj.SyncAggregatorSelectionData.SubcommitteeIndex),
+		}
+	case *validatorpb.SignRequest_ContributionAndProof:
+		c := obj.ContributionAndProof
+		if c == nil || c.Contribution == nil {
+			return nil, errors.New("nil contribution and proof")
+		}
+		epoch = helpers.SlotToEpoch(c.Contribution.Slot)
+		body.Type = SyncCommitteeContributionAndProofSignType
+		body.ContributionAndProof = &ContributionAndProofJson{
+			AggregatorIndex: fmt.Sprintf("%d", c.AggregatorIndex),
+			SelectionProof:  hexString(c.SelectionProof),
+			Contribution: &SyncCommitteeContributionJson{
+				Slot:              fmt.Sprintf("%d", c.Contribution.Slot),
+				BeaconBlockRoot:   hexString(c.Contribution.BlockRoot),
+				SubcommitteeIndex: fmt.Sprintf("%d", c.Contribution.SubcommitteeIndex),
+				AggregationBits:   hexString(c.Contribution.AggregationBits),
+				Signature:         hexString(c.Contribution.Signature),
+			},
+		}
+	case *validatorpb.SignRequest_PandoraHeaderHash:
+		epoch = helpers.SlotToEpoch(req.SigningSlot)
+		body.Type = PandoraHeaderSignType
+	default:
+		return nil, ErrUnsupportedSignRequest
+	}
+
+	fork, err := p2putils.Fork(epoch)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get fork at epoch %d", epoch)
+	}
+	body.ForkInfo = &ForkInfoJson{
+		Fork: &ForkJson{
+			PreviousVersion: hexString(fork.PreviousVersion),
+			CurrentVersion:  hexString(fork.CurrentVersion),
+			Epoch:           fmt.Sprintf("%d", fork.Epoch),
+		},
+		GenesisValidatorsRoot: hexString(genesisValidatorsRoot),
+	}
+	return body, nil
+}
+
+// beaconBlock holds the getters of the fields shared by phase 0 and altair blocks.
+type beaconBlock interface {
+	GetSlot() types.Slot
+	GetProposerIndex() types.ValidatorIndex
+	GetParentRoot() []byte
+	GetStateRoot() []byte
+}
+
+// beaconBlockBody holds the getters of the fields shared by phase 0 and altair block bodies.
+type beaconBlockBody interface {
+	GetRandaoReveal() []byte
+	GetEth1Data() *ethpb.Eth1Data
+	GetGraffiti() []byte
+	GetProposerSlashings() []*ethpb.ProposerSlashing
+	GetAttesterSlashings() []*ethpb.AttesterSlashing
+	GetAttestations() []*ethpb.Attestation
+	GetDeposits() []*ethpb.Deposit
+	GetVoluntaryExits() []*ethpb.SignedVoluntaryExit
+}
+
+// blockJson maps the fields shared by phase 0 and altair blocks, the fields specific to a version
+// being set by the caller.
+func blockJson(block beaconBlock, body beaconBlockBody) *BlockJson {
+	eth1Data := body.GetEth1Data()
+	bodyJson := &BeaconBlockBodyJson{
+		RandaoReveal: hexString(body.GetRandaoReveal()),
+		Eth1Data: &Eth1DataJson{
+			DepositRoot:  hexString(eth1Data.GetDepositRoot()),
+			DepositCount: fmt.Sprintf("%d", eth1Data.GetDepositCount()),
+			BlockHash:    hexString(eth1Data.GetBlockHash()),
+		},
+		Graffiti:          hexString(body.GetGraffiti()),
+		ProposerSlashings: make([]*ProposerSlashingJson, len(body.GetProposerSlashings())),
+		AttesterSlashings: make([]*AttesterSlashingJson, len(body.GetAttesterSlashings())),
+		Attestations:      make([]*AttestationJson, len(body.GetAttestations())),
+		Deposits:          make([]*DepositJson, len(body.GetDeposits())),
+		VoluntaryExits:    make([]*SignedVoluntaryExitJson, len(body.GetVoluntaryExits())),
+	}
+	for i, slashing := range body.GetProposerSlashings() {
+		bodyJson.ProposerSlashings[i] = &ProposerSlashingJson{
+			SignedHeader1: signedBlockHeaderJson(slashing.GetHeader_1()),
+			SignedHeader2: signedBlockHeaderJson(slashing.GetHeader_2()),
+		}
+	}
+	for i, slashing := range body.GetAttesterSlashings() {
+		bodyJson.AttesterSlashings[i] = &AttesterSlashingJson{
+			Attestation1: indexedAttestationJson(slashing.GetAttestation_1()),
+			Attestation2: indexedAttestationJson(slashing.GetAttestation_2()),
+		}
+	}
+	for i, att := range body.GetAttestations() {
+		bodyJson.Attestations[i] = &AttestationJson{
+			AggregationBits: hexString(att.GetAggregationBits()),
+			Data:            attestationDataJson(att.GetData()),
+			Signature:       hexString(att.GetSignature()),
+		}
+	}
+	for i, deposit := range body.GetDeposits() {
+		proof := make([]string, len(deposit.GetProof()))
+		for j, node := range deposit.GetProof() {
+			proof[j] = hexString(node)
+		}
+		data := deposit.GetData()
+		bodyJson.Deposits[i] = &DepositJson{
+			Proof: proof,
+			Data: &DepositDataJson{
+				PublicKey:             hexString(data.GetPublicKey()),
+				WithdrawalCredentials: hexString(data.GetWithdrawalCredentials()),
+				Amount:                fmt.Sprintf("%d", data.GetAmount()),
+				Signature:             hexString(data.GetSignature()),
+			},
+		}
+	}
+	for i, exit := range body.GetVoluntaryExits() {
+		bodyJson.VoluntaryExits[i] = &SignedVoluntaryExitJson{
+			Message: &VoluntaryExitJson{
+				Epoch:          fmt.Sprintf("%d", exit.GetExit().GetEpoch()),
+				ValidatorIndex: fmt.Sprintf("%d", exit.GetExit().GetValidatorIndex()),
+			},
+			Signature: hexString(exit.GetSignature()),
+		}
+	}
+	return &BlockJson{
+		Slot:          fmt.Sprintf("%d", block.GetSlot()),
+		ProposerIndex: fmt.Sprintf("%d", block.GetProposerIndex()),
+		ParentRoot:    hexString(block.GetParentRoot()),
+		StateRoot:     hexString(block.GetStateRoot()),
+		Body:          bodyJson,
+	}
+}
+
+func signedBlockHeaderJson(header *ethpb.SignedBeaconBlockHeader) *SignedBeaconBlockHeaderJson {
+	return &SignedBeaconBlockHeaderJson{
+		Message: &BeaconBlockHeaderJson{
+			Slot:          fmt.Sprintf("%d", header.GetHeader().GetSlot()),
+			ProposerIndex: fmt.Sprintf("%d", header.GetHeader().GetProposerIndex()),
+			ParentRoot:    hexString(header.GetHeader().GetParentRoot()),
+			StateRoot:     hexString(header.GetHeader().GetStateRoot()),
+			BodyRoot:      hexString(header.GetHeader().GetBodyRoot()),
+		},
+		Signature: hexString(header.GetSignature()),
+	}
+}
+
+func indexedAttestationJson(att *ethpb.IndexedAttestation) *IndexedAttestationJson {
+	indices := make([]string, len(att.GetAttestingIndices()))
+	for i, index := range att.GetAttestingIndices() {
+		indices[i] = fmt.Sprintf("%d", index)
+	}
+	return &IndexedAttestationJson{
+		AttestingIndices: indices,
+		Data:             attestationDataJson(att.GetData()),
+		Signature:        hexString(att.GetSignature()),
+	}
+}
+
+func pandoraShardsJson(shards []*ethpb.PandoraShard) []*PandoraShardJson {
+	if len(shards) == 0 {
+		return nil
+	}
+	shardsJson := make([]*PandoraShardJson, len(shards))
+	for i, shard := range shards {
+		shardsJson[i] = &PandoraShardJson{
+			BlockNumber: fmt.Sprintf("%d", shard.GetBlockNumber()),
+			Hash:        hexString(shard.GetHash()),
+			ParentHash:  hexString(shard.GetParentHash()),
+			StateRoot:   hexString(shard.GetStateRoot()),
+			TxHash:      hexString(shard.GetTxHash()),
+			ReceiptHash: hexString(shard.GetReceiptHash()),
+			SealHash:    hexString(shard.GetSealHash()),
+			Signature:   hexString(shard.GetSignature()),
+		}
+	}
+	return shardsJson
+}
+
+func attestationDataJson(data *ethpb.AttestationData) *AttestationDataJson {
+	return &AttestationDataJson{
+		Slot:            fmt.Sprintf("%d", data.GetSlot()),
+		CommitteeIndex:  fmt.Sprintf("%d", data.GetCommitteeIndex()),
+		BeaconBlockRoot: hexString(data.GetBeaconBlockRoot()),
+		Source:          &CheckpointJson{Epoch: fmt.Sprintf("%d", data.GetSource().GetEpoch()), Root: hexString(data.GetSource().GetRoot())},
+		Target:          &CheckpointJson{Epoch: fmt.Sprintf("%d", data.GetTarget().GetEpoch()), Root: hexString(data.GetTarget().GetRoot())},
+	}
+}
+
+func hexString(b []byte) string {
+	return "0x" + hex.EncodeToString(b)
+}
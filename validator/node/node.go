@@ -40,6 +40,7 @@ import (
 	"github.com/prysmaticlabs/prysm/validator/keymanager"
 	"github.com/prysmaticlabs/prysm/validator/keymanager/imported"
 	"github.com/prysmaticlabs/prysm/validator/pandora"
+	proposerconfig "github.com/prysmaticlabs/prysm/validator/proposer-config"
 	"github.com/prysmaticlabs/prysm/validator/rpc"
 	slashingprotection "github.com/prysmaticlabs/prysm/validator/slashing-protection"
 	"github.com/prysmaticlabs/prysm/validator/slashing-protection/iface"
@@ -395,6 +396,7 @@ func (c *ValidatorClient) registerValidatorService(
 	endpoint := c.cliCtx.String(flags.BeaconRPCProviderFlag.Name)
 	dataDir := c.cliCtx.String(cmd.DataDirFlag.Name)
 	logValidatorBalances := !c.cliCtx.Bool(flags.DisablePenaltyRewardLogFlag.Name)
+	recordPerformance := !c.cliCtx.Bool(flags.DisablePerformanceHistoryFlag.Name)
 	emitAccountMetrics := !c.cliCtx.Bool(flags.DisableAccountMetricsFlag.Name)
 	cert := c.cliCtx.String(flags.CertFlag.Name)
 	graffiti := c.cliCtx.String(flags.GraffitiFlag.Name)
@@ -416,6 +418,13 @@ func (c *ValidatorClient) registerValidatorService(
 			log.WithError(err).Warn("Could not parse graffiti file")
 		}
 	}
+	var proposerConfig *proposerconfig.Store
+	if c.cliCtx.IsSet(flags.ProposerConfigFileFlag.Name) {
+		proposerConfig, err = proposerconfig.NewStore(c.cliCtx.String(flags.ProposerConfigFileFlag.Name))
+		if err != nil {
+			return errors.Wrap(err, "could not parse proposer config file")
+		}
+	}
 	// Vanguard: pandora chain service is needed for vanguard chain
 	var pandoraService *pandora.Service
 	if err := c.services.FetchService(&pandoraService); err != nil {
@@ -426,18 +435,23 @@ func (c *ValidatorClient) registerValidatorService(
 		DataDir:                    dataDir,
 		KeyManager:                 keyManager,
 		LogValidatorBalances:       logValidatorBalances,
+		RecordPerformance:          recordPerformance,
 		EmitAccountMetrics:         emitAccountMetrics,
 		CertFlag:                   cert,
 		GraffitiFlag:               g.ParseHexGraffiti(graffiti),
 		GrpcMaxCallRecvMsgSizeFlag: maxCallRecvMsgSize,
 		GrpcRetriesFlag:            grpcRetries,
 		GrpcRetryDelay:             grpcRetryDelay,
+		HealthCheckInterval:        c.cliCtx.Duration(flags.BeaconRPCHealthCheckIntervalFlag.Name),
+		BroadcastSignedMessages:    c.cliCtx.Bool(flags.BroadcastSignedMessagesFlag.Name),
 		GrpcHeadersFlag:            c.cliCtx.String(flags.GrpcHeadersFlag.Name),
 		Protector:                  protector,
 		ValDB:                      c.db,
 		UseWeb:                     c.cliCtx.Bool(flags.EnableWebFlag.Name),
 		WalletInitializedFeed:      c.walletInitialized,
 		GraffitiStruct:             gStruct,
+		ProposerConfig:             proposerConfig,
+		DoppelgangerEpochs:         c.cliCtx.Uint64(flags.DoppelgangerEpochsFlag.Name),
 		LogDutyCountDown:           c.cliCtx.Bool(flags.EnableDutyCountDown.Name),
 		// Vanguard: pandora service and vanguard node flag are needed for vanguard chain
 		PandoraService:     pandoraService,
@@ -451,11 +465,16 @@ func (c *ValidatorClient) registerValidatorService(
 }
 func (c *ValidatorClient) registerSlasherService() error {
 	endpoint := c.cliCtx.String(flags.SlasherRPCProviderFlag.Name)
+	cert := c.cliCtx.String(flags.SlasherCertFlag.Name)
+	beaconNodeAPI := c.cliCtx.Bool(flags.SlasherBeaconNodeAPIFlag.Name)
+	if beaconNodeAPI {
+		endpoint = strings.Split(c.cliCtx.String(flags.BeaconRPCProviderFlag.Name), ",")[0]
+		cert = c.cliCtx.String(flags.CertFlag.Name)
+		log.WithField("endpoint", endpoint).Info("Using the slasher API of the beacon node for external slashing protection")
+	}
 	if endpoint == "" {
 		return errors.New("external slasher feature flag is set but no slasher endpoint is configured")
-
 	}
-	cert := c.cliCtx.String(flags.SlasherCertFlag.Name)
 	maxCallRecvMsgSize := c.cliCtx.Int(cmd.GrpcMaxCallRecvMsgSizeFlag.Name)
 	grpcRetries := c.cliCtx.Uint(flags.GrpcRetriesFlag.Name)
 	grpcRetryDelay := c.cliCtx.Duration(flags.GrpcRetryDelayFlag.Name)
@@ -466,6 +485,7 @@ func (c *ValidatorClient) registerSlasherService() error {
 		GrpcRetriesFlag:            grpcRetries,
 		GrpcRetryDelay:             grpcRetryDelay,
 		GrpcHeadersFlag:            c.cliCtx.String(flags.GrpcHeadersFlag.Name),
+		BeaconNodeAPI:              beaconNodeAPI,
 	})
 	if err != nil {
 		return errors.Wrap(err, "could not initialize slasher service")
@@ -557,8 +577,16 @@ func (c *ValidatorClient) registerRPCGatewayService(cliCtx *cli.Context) error {
 			"text/event-stream", &gwruntime.EventSourceJSONPb{},
 		),
 	)
+	var rpcServer *rpc.Server
+	if err := c.services.FetchService(&rpcServer); err != nil {
+		return err
+	}
+	jsonAPIMux := http.NewServeMux()
+	rpcServer.RegisterKeymanagerAPIHandlers(jsonAPIMux)
 	muxHandler := func(h http.Handler, w http.ResponseWriter, req *http.Request) {
-		if strings.HasPrefix(req.URL.Path, "/api") {
+		if strings.HasPrefix(req.URL.Path, "/eth/v1/") {
+			jsonAPIMux.ServeHTTP(w, req)
+		} else if strings.HasPrefix(req.URL.Path, "/api") {
 			http.StripPrefix("/api", h).ServeHTTP(w, req)
 		} else {
 			web.Handler(w, req)
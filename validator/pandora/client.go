@@ -66,22 +66,29 @@ func (oc *PandoraClient) Close() error {
 //  - result[1], 32 bytes hex encoded receipt hash for transaction proof
 //  - result[2], hex encoded rlp block header
 //  - result[3], hex encoded block number
+// A non zero fee recipient is sent as an extra parameter, for pandora to use it as the coinbase of the block.
 func (oc *PandoraClient) GetShardBlockHeader(
 	ctx context.Context,
 	parentHash common.Hash,
 	nextBlockNumber uint64,
 	slot uint64,
 	epoch uint64,
+	feeRecipient common.Address,
 ) (*ShardBlockHeaderResponse, error) {
 
 	log.WithField("latestPandoraHash", parentHash.Hex()).
 		WithField("nextBlockNumber", nextBlockNumber).
 		WithField("slot", slot).
 		WithField("epoch", epoch).
+		WithField("feeRecipient", feeRecipient.Hex()).
 		Debug("calling pandora chain for new sharding info")
 
+	args := []interface{}{parentHash, nextBlockNumber, slot, epoch}
+	if feeRecipient != (common.Address{}) {
+		args = append(args, feeRecipient)
+	}
 	var response []string
-	if err := oc.c.CallContext(ctx, &response, "eth_getShardingWork", parentHash, nextBlockNumber, slot, epoch); err != nil {
+	if err := oc.c.CallContext(ctx, &response, "eth_getShardingWork", args...); err != nil {
 		return nil, errors.Wrap(err, "Got error when calls to eth_getWork api")
 	}
 
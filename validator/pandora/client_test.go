@@ -2,6 +2,7 @@ package pandora
 
 import (
 	"context"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/prysmaticlabs/prysm/shared/testutil/require"
 	"reflect"
@@ -26,7 +27,7 @@ func TestGetShardBlockHeader_Success(t *testing.T) {
 	inputBlock := getDummyBlock()
 	var response *ShardBlockHeaderResponse
 	response, err = mockedPandoraClient.GetShardBlockHeader(
-		context.Background(), types.EmptyRootHash, 1000, 31, 0)
+		context.Background(), types.EmptyRootHash, 1000, 31, 0, common.Address{})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -40,6 +41,24 @@ func TestGetShardBlockHeader_Success(t *testing.T) {
 	}
 }
 
+// TestGetShardBlockHeader_FeeRecipient method checks that the fee recipient is sent to pandora.
+func TestGetShardBlockHeader_FeeRecipient(t *testing.T) {
+	server := NewMockPandoraServer()
+	defer server.Stop()
+	mockedPandoraClient, err := DialInProcRPCClient(HttpEndpoint)
+	require.NoError(t, err)
+	defer func() {
+		err := mockedPandoraClient.Close()
+		require.NoError(t, err)
+	}()
+
+	feeRecipient := common.HexToAddress("0x1a642f0e3c3af545e7acbd38b07251b3990914f1")
+	response, err := mockedPandoraClient.GetShardBlockHeader(
+		context.Background(), types.EmptyRootHash, 1000, 31, 0, feeRecipient)
+	require.NoError(t, err)
+	require.Equal(t, feeRecipient, response.Header.Coinbase)
+}
+
 // TestSubmitShardBlockHeader_Success method checks `eth_submitWork` api
 func TestSubmitShardBlockHeader_Success(t *testing.T) {
 	// Create a mock server
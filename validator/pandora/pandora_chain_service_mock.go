@@ -92,9 +92,15 @@ func getDummyBlock() *types.Block {
 //   result[1] - 32 bytes hex encoded seed hash used for DAG
 //   result[2] - 32 bytes hex encoded boundary condition ("target"), 2^256/difficulty
 //   result[3] - hex encoded block number
+// The optional fee recipient is used as the coinbase of the returned block.
 func (api *mockPandoraService) GetShardingWork(parentHash common.Hash, blockNumber uint64,
-	slotNumber uint64, epoch uint64) ([4]string, error) {
+	slotNumber uint64, epoch uint64, feeRecipient *common.Address) ([4]string, error) {
 	block := getDummyBlock()
+	if feeRecipient != nil {
+		header := block.Header()
+		header.Coinbase = *feeRecipient
+		block = types.NewBlockWithHeader(header)
+	}
 	var response [4]string
 	rlpHeader, _ := rlp.EncodeToBytes(block.Header())
 
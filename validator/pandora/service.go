@@ -39,9 +39,10 @@ type PandoraExtraDataSig struct {
 // Client defines a subset of methods conformed to by Pandora RPC clients for
 // producing catalyst block and insert pandora block.
 type PandoraService interface {
-	// GetShardBlockHeader gets the new block header and hash of pandora client
+	// GetShardBlockHeader gets the new block header and hash of pandora client, paying the
+	// block fees to the given fee recipient when it is not zero
 	GetShardBlockHeader(ctx context.Context, parentHash common.Hash,
-		nextBlockNumber uint64, slot uint64, epoch uint64) (*eth1Types.Header, common.Hash, *ExtraData, error)
+		nextBlockNumber uint64, slot uint64, epoch uint64, feeRecipient common.Address) (*eth1Types.Header, common.Hash, *ExtraData, error)
 	// SubmitShardBlockHeader submits the header hash and signature of pandora block header
 	SubmitShardBlockHeader(ctx context.Context, blockNonce uint64, headerHash common.Hash, sig [96]byte) (bool, error)
 }
@@ -186,13 +187,14 @@ func (s *Service) GetShardBlockHeader(
 	nextBlockNumber uint64,
 	slot uint64,
 	epoch uint64,
+	feeRecipient common.Address,
 ) (*eth1Types.Header, common.Hash, *ExtraData, error) {
 	if !s.connected {
 		log.WithError(ConnectionError).Error("Pandora chain is not connected")
 		return nil, common.Hash{}, nil, ConnectionError
 	}
 
-	response, err := s.pandoraClient.GetShardBlockHeader(ctx, parentHash, nextBlockNumber, slot, epoch)
+	response, err := s.pandoraClient.GetShardBlockHeader(ctx, parentHash, nextBlockNumber, slot, epoch, feeRecipient)
 	if err != nil {
 		log.WithError(err).Error("Pandora block preparation failed")
 		return nil, common.Hash{}, nil, err
@@ -2,6 +2,7 @@ package pandora
 
 import (
 	"context"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/prysmaticlabs/prysm/shared/testutil/require"
 	logTest "github.com/sirupsen/logrus/hooks/test"
@@ -71,7 +72,7 @@ func TestService_GetShardBlockHeader_Success(t *testing.T) {
 	pandoraService.isRunning = true
 
 	actualHeader, actualHash, actualExtraData, err := pandoraService.GetShardBlockHeader(context.Background(),
-		types.EmptyRootHash, 1000, 31, 0)
+		types.EmptyRootHash, 1000, 31, 0, common.Address{})
 	require.NoError(t, err, "Should not get error when calling GetWork method")
 
 	expectedExtraData, _, err := getDummyEncodedExtraData()
@@ -0,0 +1,119 @@
+package performance
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/logrusorgru/aurora"
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/cmd/validator/flags"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/cmd"
+	"github.com/prysmaticlabs/prysm/shared/fileutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/validator/db/kv"
+	"github.com/urfave/cli/v2"
+)
+
+// PerformanceCli prints the performance history of the validator keys recorded in the validator
+// database, for the keys and the range of epochs selected on the command line.
+func PerformanceCli(cliCtx *cli.Context) error {
+	dataDir := cliCtx.String(cmd.DataDirFlag.Name)
+	found, _, err := fileutil.RecursiveFileFind(kv.ProtectionDbFileName, dataDir)
+	if err != nil {
+		return errors.Wrapf(err, "error finding validator database at path %s", dataDir)
+	}
+	if !found {
+		return fmt.Errorf("validator database not found at path %s", dataDir)
+	}
+	pubKeys, err := ParsePublicKeys(strings.Split(cliCtx.String(flags.PerformancePublicKeysFlag.Name), ","))
+	if err != nil {
+		return err
+	}
+	start := types.Epoch(cliCtx.Uint64(flags.PerformanceStartEpochFlag.Name))
+	end := types.Epoch(^uint64(0))
+	if cliCtx.IsSet(flags.PerformanceEndEpochFlag.Name) {
+		end = types.Epoch(cliCtx.Uint64(flags.PerformanceEndEpochFlag.Name))
+	}
+
+	validatorDB, err := kv.NewKVStore(cliCtx.Context, dataDir, &kv.Config{})
+	if err != nil {
+		return errors.Wrapf(err, "could not access validator database at path %s", dataDir)
+	}
+	defer func() {
+		if err := validatorDB.Close(); err != nil {
+			log.WithError(err).Errorf("Could not close validator DB")
+		}
+	}()
+	performances, err := Query(cliCtx.Context, validatorDB, pubKeys, start, end)
+	if err != nil {
+		return err
+	}
+	if len(performances) == 0 {
+		fmt.Println("No performance history recorded")
+		return nil
+	}
+	for _, p := range performances {
+		printKeyPerformance(p, cliCtx.Bool(flags.PerformanceShowEpochsFlag.Name))
+	}
+	return nil
+}
+
+func printKeyPerformance(p *KeyPerformance, showEpochs bool) {
+	gweiPerEth := float64(params.BeaconConfig().GweiPerEth)
+	s := p.Summary
+	fmt.Printf("%s %#x\n", aurora.BrightGreen("Validator"), bytesutil.Trunc(p.PublicKey[:]))
+	fmt.Printf("  Epochs with attestation duties: %d\n", s.Epochs)
+	if s.Epochs > 0 {
+		fmt.Printf("  Attestations included: %d (%.0f%%)\n", s.AttestationsIncluded, percent(s.AttestationsIncluded, s.Epochs))
+	}
+	if s.AttestationsIncluded > 0 {
+		fmt.Printf("  Correct source/target/head: %.0f%% / %.0f%% / %.0f%%\n",
+			percent(s.CorrectSources, s.AttestationsIncluded),
+			percent(s.CorrectTargets, s.AttestationsIncluded),
+			percent(s.CorrectHeads, s.AttestationsIncluded))
+		fmt.Printf("  Average inclusion distance: %.2f slots\n", float64(s.TotalDistance)/float64(s.AttestationsIncluded))
+	}
+	fmt.Printf("  Balance change: %.9f ETH\n", float64(s.BalanceDelta)/gweiPerEth)
+	fmt.Printf("  Blocks proposed: %d, missed: %d\n", s.Proposed, s.Missed)
+	fmt.Printf("  Pandora shards submitted: %d, failed: %d\n", s.ShardsSubmitted, s.ShardsFailed)
+	if !showEpochs {
+		fmt.Println()
+		return
+	}
+	for _, e := range p.Epochs {
+		line := fmt.Sprintf("    epoch %d:", e.Epoch)
+		if e.AttestationRecorded {
+			if e.AttestationIncluded() {
+				line += fmt.Sprintf(" included at distance %d, source %s, target %s, head %s,",
+					e.InclusionDistance, vote(e.CorrectlyVotedSource), vote(e.CorrectlyVotedTarget), vote(e.CorrectlyVotedHead))
+			} else {
+				line += " attestation not included,"
+			}
+			line += fmt.Sprintf(" balance change %.9f ETH", float64(e.BalanceDelta())/gweiPerEth)
+		}
+		if len(e.ProposedSlots) > 0 {
+			line += fmt.Sprintf(" proposed slots %v", e.ProposedSlots)
+		}
+		if len(e.MissedSlots) > 0 {
+			line += fmt.Sprintf(" missed slots %v", e.MissedSlots)
+		}
+		if len(e.ShardFailedSlots) > 0 {
+			line += fmt.Sprintf(" failed shard submissions %v", e.ShardFailedSlots)
+		}
+		fmt.Println(line)
+	}
+	fmt.Println()
+}
+
+func percent(n, total uint64) float64 {
+	return float64(n) / float64(total) * 100
+}
+
+func vote(correct bool) string {
+	if correct {
+		return "correct"
+	}
+	return "wrong"
+}
@@ -0,0 +1,76 @@
+// Package performance queries the per-key performance history of a validator, recorded in the
+// validator database at every epoch: the correctness and inclusion of attestations, balance
+// changes, block proposals and Pandora shard submissions.
+package performance
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/validator/db"
+	"github.com/prysmaticlabs/prysm/validator/db/kv"
+)
+
+// KeyPerformance is the performance history of a public key over a range of epochs, along with
+// its summary.
+type KeyPerformance struct {
+	PublicKey [48]byte               `json:"-"`
+	Pubkey    string                 `json:"pubkey"`
+	Summary   *kv.PerformanceSummary `json:"summary"`
+	Epochs    []*kv.EpochPerformance `json:"epochs"`
+}
+
+// Query returns the performance history of the given public keys from the start epoch to the end
+// epoch included. The history of every recorded key is returned when no key is given.
+func Query(ctx context.Context, valDB db.Database, pubKeys [][48]byte, start, end types.Epoch) ([]*KeyPerformance, error) {
+	if start > end {
+		return nil, fmt.Errorf("start epoch %d is after end epoch %d", start, end)
+	}
+	if len(pubKeys) == 0 {
+		var err error
+		pubKeys, err = valDB.PerformancePublicKeys(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get public keys with performance history")
+		}
+	}
+	performances := make([]*KeyPerformance, len(pubKeys))
+	for i, pubKey := range pubKeys {
+		records, err := valDB.EpochPerformanceForPubKey(ctx, pubKey, start, end)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not get performance history of %#x", pubKey)
+		}
+		performances[i] = &KeyPerformance{
+			PublicKey: pubKey,
+			Pubkey:    fmt.Sprintf("%#x", pubKey),
+			Summary:   kv.SummarizePerformance(records),
+			Epochs:    records,
+		}
+	}
+	return performances, nil
+}
+
+// ParsePublicKeys decodes hex encoded public keys, with or without 0x prefix.
+func ParsePublicKeys(pubKeyStrings []string) ([][48]byte, error) {
+	pubKeys := make([][48]byte, 0, len(pubKeyStrings))
+	for _, str := range pubKeyStrings {
+		str = strings.TrimSpace(str)
+		if str == "" {
+			continue
+		}
+		pubKeyBytes, err := hex.DecodeString(strings.TrimPrefix(str, "0x"))
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not decode string %s as hex", str)
+		}
+		if len(pubKeyBytes) != 48 {
+			return nil, fmt.Errorf("public key %s is %d bytes long, expected 48", str, len(pubKeyBytes))
+		}
+		var pubKey [48]byte
+		copy(pubKey[:], pubKeyBytes)
+		pubKeys = append(pubKeys, pubKey)
+	}
+	return pubKeys, nil
+}
@@ -0,0 +1,54 @@
+package performance
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	"github.com/prysmaticlabs/prysm/validator/db/kv"
+	dbtest "github.com/prysmaticlabs/prysm/validator/db/testing"
+)
+
+func TestQuery(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.SetupDB(t, [][48]byte{})
+	for _, pubKey := range [][48]byte{{1}, {2}} {
+		for epoch := types.Epoch(0); epoch < 4; epoch++ {
+			require.NoError(t, db.UpdateEpochPerformance(ctx, pubKey, epoch, func(p *kv.EpochPerformance) {
+				p.ProposedSlots = append(p.ProposedSlots, types.Slot(epoch))
+			}))
+		}
+	}
+
+	performances, err := Query(ctx, db, nil, 1, 2)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(performances))
+	for _, p := range performances {
+		assert.Equal(t, 2, len(p.Epochs))
+		assert.Equal(t, uint64(2), p.Summary.Proposed)
+		assert.Equal(t, fmt.Sprintf("%#x", p.PublicKey), p.Pubkey)
+	}
+
+	performances, err = Query(ctx, db, [][48]byte{{2}, {3}}, 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(performances))
+	assert.Equal(t, 4, len(performances[0].Epochs))
+	assert.Equal(t, 0, len(performances[1].Epochs))
+
+	_, err = Query(ctx, db, nil, 2, 1)
+	assert.ErrorContains(t, "is after end epoch", err)
+}
+
+func TestParsePublicKeys(t *testing.T) {
+	pubKeys, err := ParsePublicKeys([]string{fmt.Sprintf("%#x", [48]byte{1}), "", fmt.Sprintf(" %x", [48]byte{2})})
+	require.NoError(t, err)
+	require.DeepEqual(t, [][48]byte{{1}, {2}}, pubKeys)
+
+	_, err = ParsePublicKeys([]string{"0x1234"})
+	assert.ErrorContains(t, "expected 48", err)
+	_, err = ParsePublicKeys([]string{"zz"})
+	assert.ErrorContains(t, "could not decode", err)
+}
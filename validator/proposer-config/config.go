@@ -0,0 +1,139 @@
+// Package proposerconfig defines a per-validator proposer configuration file, setting
+// the graffiti, the Pandora fee recipient and whether proposing is enabled for each
+// validating public key, along with defaults for the keys which are not listed:
+//
+//  default:
+//    graffiti: "Vanguard"
+//    fee_recipient: "0x1a642f0e3c3af545e7acbd38b07251b3990914f1"
+//    enabled: true
+//  proposers:
+//    "0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c":
+//      graffiti: "hex:0x4d72205420776173206865726521"
+//      fee_recipient: "0x50155530FCE8a85ec7055A5F8b2bE214B3DaeFd3"
+//    "0xb89bebc699769726a318c8e9971bd3171297c61aea4a6578a7a4f94b547dcba5bac16a89108b6b6a1fe3695d1a874a0b":
+//      enabled: false
+//
+// The file can be written in YAML or JSON, and graffiti values follow the format of the
+// graffiti file.
+package proposerconfig
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/hashutil"
+	"github.com/prysmaticlabs/prysm/validator/graffiti"
+	"gopkg.in/yaml.v2"
+)
+
+// maxGraffitiLength is the size of the graffiti field of a beacon block body.
+const maxGraffitiLength = 32
+
+// Options are the proposer options of the file, for a single key or as defaults.
+// Unset fields of a key fall back to the defaults.
+type Options struct {
+	Graffiti     string `yaml:"graffiti,omitempty"`
+	FeeRecipient string `yaml:"fee_recipient,omitempty"`
+	Enabled      *bool  `yaml:"enabled,omitempty"`
+}
+
+// File is the content of a proposer configuration file.
+type File struct {
+	Default   *Options            `yaml:"default,omitempty"`
+	Proposers map[string]*Options `yaml:"proposers,omitempty"`
+}
+
+// ProposerSettings are the resolved proposer options of a validating key.
+type ProposerSettings struct {
+	Graffiti     []byte
+	FeeRecipient common.Address
+	Enabled      bool
+}
+
+// Config is a parsed proposer configuration.
+type Config struct {
+	Hash      [32]byte
+	defaults  *ProposerSettings
+	proposers map[[48]byte]*Options
+}
+
+// ParseFile reads and parses a proposer configuration file.
+func ParseFile(path string) (*Config, error) {
+	enc, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(enc)
+}
+
+// Parse parses the content of a proposer configuration file, checking the public keys,
+// fee recipients and graffiti values of every entry.
+func Parse(enc []byte) (*Config, error) {
+	f := &File{}
+	if err := yaml.UnmarshalStrict(enc, f); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal proposer config")
+	}
+	cfg := &Config{
+		Hash:      hashutil.Hash(enc),
+		defaults:  &ProposerSettings{Enabled: true},
+		proposers: make(map[[48]byte]*Options, len(f.Proposers)),
+	}
+	if f.Default != nil {
+		if err := applyOptions(cfg.defaults, f.Default); err != nil {
+			return nil, errors.Wrap(err, "invalid default proposer options")
+		}
+	}
+	for k, opts := range f.Proposers {
+		pubKey, err := hexutil.Decode(k)
+		if err != nil || len(pubKey) != 48 {
+			return nil, fmt.Errorf("invalid public key %q, expected 48 hex encoded bytes", k)
+		}
+		if opts == nil {
+			opts = &Options{}
+		}
+		// Check the options once, for invalid entries to be rejected when parsing.
+		if err := applyOptions(&ProposerSettings{}, opts); err != nil {
+			return nil, errors.Wrapf(err, "invalid proposer options of %s", k)
+		}
+		cfg.proposers[bytesutil.ToBytes48(pubKey)] = opts
+	}
+	return cfg, nil
+}
+
+// Settings returns the proposer settings of a validating key: its own options when
+// listed in the file, completed by the defaults.
+func (c *Config) Settings(pubKey [48]byte) *ProposerSettings {
+	settings := *c.defaults
+	if opts, ok := c.proposers[pubKey]; ok {
+		// Options were checked when parsing.
+		if err := applyOptions(&settings, opts); err != nil {
+			log.WithError(err).Error("Could not apply proposer options")
+		}
+	}
+	return &settings
+}
+
+func applyOptions(settings *ProposerSettings, opts *Options) error {
+	if opts.Graffiti != "" {
+		g := graffiti.ParseHexGraffiti(opts.Graffiti)
+		if len(g) > maxGraffitiLength {
+			return fmt.Errorf("graffiti %q is longer than %d bytes", opts.Graffiti, maxGraffitiLength)
+		}
+		settings.Graffiti = []byte(g)
+	}
+	if opts.FeeRecipient != "" {
+		if !common.IsHexAddress(opts.FeeRecipient) || !strings.HasPrefix(opts.FeeRecipient, "0x") {
+			return fmt.Errorf("invalid fee recipient %q, expected a 0x prefixed hex address", opts.FeeRecipient)
+		}
+		settings.FeeRecipient = common.HexToAddress(opts.FeeRecipient)
+	}
+	if opts.Enabled != nil {
+		settings.Enabled = *opts.Enabled
+	}
+	return nil
+}
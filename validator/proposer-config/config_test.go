@@ -0,0 +1,126 @@
+package proposerconfig
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+const (
+	pubKey1 = "0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c"
+	pubKey2 = "0xb89bebc699769726a318c8e9971bd3171297c61aea4a6578a7a4f94b547dcba5bac16a89108b6b6a1fe3695d1a874a0b"
+)
+
+func toPubKey(t *testing.T, k string) [48]byte {
+	pubKey, err := hexutil.Decode(k)
+	require.NoError(t, err)
+	return bytesutil.ToBytes48(pubKey)
+}
+
+func TestParse_DefaultsAndProposers(t *testing.T) {
+	input := []byte(`default:
+  graffiti: "Vanguard"
+  fee_recipient: "0x1a642f0e3c3af545e7acbd38b07251b3990914f1"
+proposers:
+  "` + pubKey1 + `":
+    graffiti: "hex:0x4d72205420776173206865726521"
+    fee_recipient: "0x50155530FCE8a85ec7055A5F8b2bE214B3DaeFd3"
+  "` + pubKey2 + `":
+    enabled: false`)
+	cfg, err := Parse(input)
+	require.NoError(t, err)
+
+	require.DeepEqual(t, &ProposerSettings{
+		Graffiti:     []byte("Mr T was here!"),
+		FeeRecipient: common.HexToAddress("0x50155530FCE8a85ec7055A5F8b2bE214B3DaeFd3"),
+		Enabled:      true,
+	}, cfg.Settings(toPubKey(t, pubKey1)))
+	require.DeepEqual(t, &ProposerSettings{
+		Graffiti:     []byte("Vanguard"),
+		FeeRecipient: common.HexToAddress("0x1a642f0e3c3af545e7acbd38b07251b3990914f1"),
+		Enabled:      false,
+	}, cfg.Settings(toPubKey(t, pubKey2)))
+	require.DeepEqual(t, &ProposerSettings{
+		Graffiti:     []byte("Vanguard"),
+		FeeRecipient: common.HexToAddress("0x1a642f0e3c3af545e7acbd38b07251b3990914f1"),
+		Enabled:      true,
+	}, cfg.Settings([48]byte{1}))
+}
+
+func TestParse_JSON(t *testing.T) {
+	input := []byte(`{"proposers": {"` + pubKey1 + `": {"enabled": false}}}`)
+	cfg, err := Parse(input)
+	require.NoError(t, err)
+	assert.Equal(t, false, cfg.Settings(toPubKey(t, pubKey1)).Enabled)
+	assert.Equal(t, true, cfg.Settings([48]byte{1}).Enabled)
+	assert.Equal(t, common.Address{}, cfg.Settings([48]byte{1}).FeeRecipient)
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{
+			name:  "unknown field",
+			input: `default: {coinbase: "0x1a642f0e3c3af545e7acbd38b07251b3990914f1"}`,
+			err:   "could not unmarshal proposer config",
+		},
+		{
+			name:  "invalid public key",
+			input: `proposers: {"0x1234": {enabled: false}}`,
+			err:   "invalid public key",
+		},
+		{
+			name:  "invalid fee recipient",
+			input: `default: {fee_recipient: "0x1234"}`,
+			err:   "invalid fee recipient",
+		},
+		{
+			name:  "graffiti too long",
+			input: `proposers: {"` + pubKey1 + `": {graffiti: "this graffiti does not fit in a beacon block"}}`,
+			err:   "is longer than 32 bytes",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.input))
+			require.ErrorContains(t, tt.err, err)
+		})
+	}
+}
+
+func TestStore_Reload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "proposer-config.yaml")
+	require.NoError(t, ioutil.WriteFile(path, []byte(`default: {graffiti: "first"}`), os.ModePerm))
+	s, err := NewStore(path)
+	require.NoError(t, err)
+	assert.DeepEqual(t, []byte("first"), s.Settings([48]byte{}).Graffiti)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go s.Run(ctx)
+	// Give the watcher time to start before changing the file.
+	time.Sleep(100 * time.Millisecond)
+
+	require.NoError(t, ioutil.WriteFile(path, []byte(`default: {graffiti: "second"}`), os.ModePerm))
+	for i := 0; i < 100 && string(s.Settings([48]byte{}).Graffiti) != "second"; i++ {
+		time.Sleep(50 * time.Millisecond)
+	}
+	assert.DeepEqual(t, []byte("second"), s.Settings([48]byte{}).Graffiti)
+
+	// An invalid file keeps the previous configuration.
+	require.NoError(t, ioutil.WriteFile(path, []byte(`default: {fee_recipient: "invalid"}`), os.ModePerm))
+	require.ErrorContains(t, "invalid fee recipient", s.Reload())
+	assert.DeepEqual(t, []byte("second"), s.Settings([48]byte{}).Graffiti)
+}
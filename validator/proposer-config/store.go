@@ -0,0 +1,101 @@
+package proposerconfig
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/prysmaticlabs/prysm/shared/asyncutil"
+)
+
+// reloadDebounceInterval is the time waited after the last change of the file before reloading it,
+// as editors may write a file in several steps.
+const reloadDebounceInterval = time.Second
+
+// Store holds the proposer configuration of a file, reloading it whenever the file changes.
+type Store struct {
+	path string
+	cfg  *Config
+	lock sync.RWMutex
+}
+
+// NewStore parses the proposer configuration file at the given path.
+func NewStore(path string) (*Store, error) {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := ParseFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return &Store{path: path, cfg: cfg}, nil
+}
+
+// Settings returns the proposer settings of a validating key from the latest loaded configuration.
+func (s *Store) Settings(pubKey [48]byte) *ProposerSettings {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.cfg.Settings(pubKey)
+}
+
+// Reload parses the configuration file again. An invalid file is rejected, keeping the
+// previous configuration in use.
+func (s *Store) Reload() error {
+	cfg, err := ParseFile(s.path)
+	if err != nil {
+		return err
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if cfg.Hash == s.cfg.Hash {
+		return nil
+	}
+	s.cfg = cfg
+	log.WithField("path", s.path).Info("Reloaded proposer config file")
+	return nil
+}
+
+// Run listens for changes to the configuration file until the context is canceled, and reloads it
+// on each change. The directory of the file is watched rather than the file itself, for files
+// replaced by editors or configuration tools to be picked up as well.
+func (s *Store) Run(ctx context.Context) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		log.WithError(err).Error("Could not initialize file watcher")
+		return
+	}
+	defer func() {
+		if err := watcher.Close(); err != nil {
+			log.WithError(err).Error("Could not close file watcher")
+		}
+	}()
+	if err := watcher.Add(filepath.Dir(s.path)); err != nil {
+		log.WithError(err).Errorf("Could not add directory of %s to file watcher", s.path)
+		return
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	fileChangesChan := make(chan interface{}, 100)
+	defer close(fileChangesChan)
+
+	go asyncutil.Debounce(ctx, reloadDebounceInterval, fileChangesChan, func(interface{}) {
+		if err := s.Reload(); err != nil {
+			log.WithError(err).Errorf("Could not reload proposer config file %s, keeping the previous configuration", s.path)
+		}
+	})
+	for {
+		select {
+		case event := <-watcher.Events:
+			if filepath.Clean(event.Name) == s.path && event.Op&(fsnotify.Write|fsnotify.Create) != 0 {
+				fileChangesChan <- event
+			}
+		case err := <-watcher.Errors:
+			log.WithError(err).Errorf("Could not watch for file changes for: %s", s.path)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
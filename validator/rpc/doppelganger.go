@@ -0,0 +1,57 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+
+	pb "github.com/prysmaticlabs/prysm/proto/validator/accounts/v2"
+	"github.com/prysmaticlabs/prysm/validator/client/iface"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// ListDoppelgangerStatuses lists the doppelganger status of the validating keys, sorted by public key.
+// The list is empty when doppelganger protection is disabled.
+func (s *Server) ListDoppelgangerStatuses(_ context.Context, _ *emptypb.Empty) (*pb.DoppelgangerStatusesResponse, error) {
+	resp := &pb.DoppelgangerStatusesResponse{Statuses: make([]*pb.DoppelgangerStatus, 0)}
+	if s.validatorService == nil {
+		return resp, nil
+	}
+	statuses := s.validatorService.DoppelgangerStatuses()
+	pubKeys := make([][48]byte, 0, len(statuses))
+	for pubKey := range statuses {
+		pubKeys = append(pubKeys, pubKey)
+	}
+	sort.Slice(pubKeys, func(i, j int) bool {
+		return bytes.Compare(pubKeys[i][:], pubKeys[j][:]) < 0
+	})
+	for _, pubKey := range pubKeys {
+		keyStatus, err := doppelgangerStatus(statuses[pubKey].Status)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		pubKey := pubKey
+		resp.Statuses = append(resp.Statuses, &pb.DoppelgangerStatus{
+			PublicKey:      pubKey[:],
+			Status:         keyStatus,
+			ObservedEpochs: statuses[pubKey].ObservedEpochs,
+		})
+	}
+	return resp, nil
+}
+
+func doppelgangerStatus(s iface.DoppelgangerStatus) (pb.DoppelgangerStatus_Status, error) {
+	switch s {
+	case iface.DoppelgangerChecking:
+		return pb.DoppelgangerStatus_CHECKING, nil
+	case iface.DoppelgangerClean:
+		return pb.DoppelgangerStatus_CLEAN, nil
+	case iface.DoppelgangerDetected:
+		return pb.DoppelgangerStatus_DETECTED, nil
+	default:
+		return 0, fmt.Errorf("unknown doppelganger status %d", s)
+	}
+}
@@ -0,0 +1,40 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+
+	pb "github.com/prysmaticlabs/prysm/proto/validator/accounts/v2"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	"github.com/prysmaticlabs/prysm/validator/client"
+	"github.com/prysmaticlabs/prysm/validator/client/iface"
+	"github.com/prysmaticlabs/prysm/validator/client/testutil"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func TestServer_ListDoppelgangerStatuses(t *testing.T) {
+	ctx := context.Background()
+	s := &Server{}
+	resp, err := s.ListDoppelgangerStatuses(ctx, &emptypb.Empty{})
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(resp.Statuses))
+
+	vs, err := client.NewValidatorService(ctx, &client.Config{
+		Validator: &testutil.FakeValidator{
+			DoppelgangerStatusesRet: map[[48]byte]*iface.DoppelgangerKeyStatus{
+				{2}: {Status: iface.DoppelgangerDetected},
+				{1}: {Status: iface.DoppelgangerClean, ObservedEpochs: 2},
+			},
+		},
+	})
+	require.NoError(t, err)
+	s = &Server{validatorService: vs}
+	resp, err = s.ListDoppelgangerStatuses(ctx, &emptypb.Empty{})
+	require.NoError(t, err)
+	first, second := [48]byte{1}, [48]byte{2}
+	require.DeepEqual(t, []*pb.DoppelgangerStatus{
+		{PublicKey: first[:], Status: pb.DoppelgangerStatus_CLEAN, ObservedEpochs: 2},
+		{PublicKey: second[:], Status: pb.DoppelgangerStatus_DETECTED},
+	}, resp.Statuses)
+}
@@ -3,10 +3,12 @@ package rpc
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"strings"
 	"sync"
 
 	"github.com/form3tech-oss/jwt-go"
+	"github.com/prysmaticlabs/prysm/shared/gateway"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
@@ -81,6 +83,30 @@ func (s *Server) authorize(ctx context.Context) error {
 	return nil
 }
 
+// JWTHandler is an HTTP middleware authorizing incoming requests with the same
+// JWT as the gRPC API, given in a Bearer authorization header.
+func (s *Server) JWTHandler(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		authHeader := r.Header.Get("Authorization")
+		if !strings.HasPrefix(authHeader, "Bearer ") {
+			gateway.WriteError(w, &gateway.DefaultErrorJson{
+				Message: "Invalid auth header, needs Bearer {token}",
+				Code:    http.StatusUnauthorized,
+			}, nil)
+			return
+		}
+		if _, err := jwt.Parse(strings.TrimPrefix(authHeader, "Bearer "), s.validateJWT); err != nil {
+			gateway.WriteError(w, &gateway.DefaultErrorJson{
+				Message: fmt.Sprintf("Could not parse JWT token: %v", err),
+				Code:    http.StatusUnauthorized,
+			}, nil)
+			return
+		}
+		log.Debugf("Request - Method: %s, Path: %s\n", r.Method, r.URL.Path)
+		next(w, r)
+	}
+}
+
 func (s *Server) validateJWT(token *jwt.Token) (interface{}, error) {
 	if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 		return nil, fmt.Errorf("unexpected JWT signing method: %v", token.Header["alg"])
@@ -0,0 +1,342 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/shared/bls"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/gateway"
+	"github.com/prysmaticlabs/prysm/validator/keymanager"
+	"github.com/prysmaticlabs/prysm/validator/keymanager/derived"
+	"github.com/prysmaticlabs/prysm/validator/keymanager/imported"
+	slashing "github.com/prysmaticlabs/prysm/validator/slashing-protection/local/standard-protection-format"
+	"github.com/prysmaticlabs/prysm/validator/slashing-protection/local/standard-protection-format/format"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+)
+
+// KeystoresPath is the path of the standard keymanager API endpoint listing, importing and
+// deleting the keystores of the validator.
+const KeystoresPath = "/eth/v1/keystores"
+
+// Statuses of the keystores imported or deleted through the keymanager API.
+const (
+	KeystoreImported  = "imported"
+	KeystoreDuplicate = "duplicate"
+	KeystoreDeleted   = "deleted"
+	KeystoreNotActive = "not_active"
+	KeystoreNotFound  = "not_found"
+	KeystoreError     = "error"
+)
+
+// ListKeystoresResponseJson is the response of the list keystores endpoint.
+type ListKeystoresResponseJson struct {
+	Data []*KeystoreJson `json:"data"`
+}
+
+// KeystoreJson is a validating key of the validator. Read only keys, held by a remote signer,
+// cannot be deleted through the keymanager API.
+type KeystoreJson struct {
+	ValidatingPubkey string `json:"validating_pubkey"`
+	DerivationPath   string `json:"derivation_path,omitempty"`
+	ReadOnly         bool   `json:"readonly"`
+}
+
+// ImportKeystoresRequestJson is the body of an import keystores request. Each EIP-2335 keystore
+// is decrypted with the password at the same index, and the optional EIP-3076 slashing protection
+// data is imported before the keys.
+type ImportKeystoresRequestJson struct {
+	Keystores          []string `json:"keystores"`
+	Passwords          []string `json:"passwords"`
+	SlashingProtection string   `json:"slashing_protection"`
+}
+
+// DeleteKeystoresRequestJson is the body of a delete keystores request.
+type DeleteKeystoresRequestJson struct {
+	Pubkeys []string `json:"pubkeys"`
+}
+
+// KeystoreStatusJson is the outcome of importing or deleting a keystore.
+type KeystoreStatusJson struct {
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+// ImportKeystoresResponseJson is the response of the import keystores endpoint, with a status for
+// each keystore of the request.
+type ImportKeystoresResponseJson struct {
+	Data []*KeystoreStatusJson `json:"data"`
+}
+
+// DeleteKeystoresResponseJson is the response of the delete keystores endpoint, with a status for
+// each public key of the request and the EIP-3076 slashing protection data of the deleted keys.
+type DeleteKeystoresResponseJson struct {
+	Data               []*KeystoreStatusJson `json:"data"`
+	SlashingProtection string                `json:"slashing_protection"`
+}
+
+// RegisterKeymanagerAPIHandlers registers the keymanager API endpoints in the given mux.
+// Every request must carry a JWT issued by the Login or Signup endpoints.
+func (s *Server) RegisterKeymanagerAPIHandlers(mux *http.ServeMux) {
+	mux.HandleFunc(KeystoresPath, s.JWTHandler(s.keystores))
+}
+
+func (s *Server) keystores(w http.ResponseWriter, r *http.Request) {
+	var resp interface{}
+	var err *gateway.DefaultErrorJson
+	switch r.Method {
+	case http.MethodGet:
+		resp, err = s.ListKeystores(r.Context())
+	case http.MethodPost:
+		req := &ImportKeystoresRequestJson{}
+		if decodeErr := json.NewDecoder(r.Body).Decode(req); decodeErr != nil {
+			err = &gateway.DefaultErrorJson{Message: "could not decode request body: " + decodeErr.Error(), Code: http.StatusBadRequest}
+			break
+		}
+		resp, err = s.ImportKeystoresJson(r.Context(), req)
+	case http.MethodDelete:
+		req := &DeleteKeystoresRequestJson{}
+		if decodeErr := json.NewDecoder(r.Body).Decode(req); decodeErr != nil {
+			err = &gateway.DefaultErrorJson{Message: "could not decode request body: " + decodeErr.Error(), Code: http.StatusBadRequest}
+			break
+		}
+		resp, err = s.DeleteKeystoresJson(r.Context(), req)
+	default:
+		err = &gateway.DefaultErrorJson{Message: "method not allowed", Code: http.StatusMethodNotAllowed}
+	}
+	if err != nil {
+		gateway.WriteError(w, err, nil)
+		return
+	}
+	enc, marshalErr := json.Marshal(resp)
+	if marshalErr != nil {
+		gateway.WriteError(w, gateway.InternalServerErrorWithMessage(marshalErr, "could not marshal response"), nil)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if _, writeErr := w.Write(enc); writeErr != nil {
+		log.WithError(writeErr).Error("Could not write response")
+	}
+}
+
+// ListKeystores lists the validating public keys of the keymanager.
+func (s *Server) ListKeystores(ctx context.Context) (*ListKeystoresResponseJson, *gateway.DefaultErrorJson) {
+	if s.wallet == nil || s.keymanager == nil {
+		return nil, &gateway.DefaultErrorJson{Message: "no wallet initialized", Code: http.StatusBadRequest}
+	}
+	pubKeys, err := s.keymanager.FetchValidatingPublicKeys(ctx)
+	if err != nil {
+		return nil, gateway.InternalServerErrorWithMessage(err, "could not list keystores")
+	}
+	kind := s.wallet.KeymanagerKind()
+	resp := &ListKeystoresResponseJson{Data: make([]*KeystoreJson, len(pubKeys))}
+	for i := range pubKeys {
+		resp.Data[i] = &KeystoreJson{
+			ValidatingPubkey: fmt.Sprintf("%#x", pubKeys[i]),
+			ReadOnly:         kind != keymanager.Imported,
+		}
+		if kind == keymanager.Derived {
+			resp.Data[i].DerivationPath = fmt.Sprintf(derived.ValidatingKeyDerivationPathTemplate, i)
+		}
+	}
+	return resp, nil
+}
+
+// ImportKeystoresJson imports EIP-2335 keystores into an imported keymanager, along with their
+// EIP-3076 slashing protection data. Keystores already held by the keymanager are reported as
+// duplicates, and keystores which cannot be decrypted are reported as errors without failing the
+// others.
+func (s *Server) ImportKeystoresJson(ctx context.Context, req *ImportKeystoresRequestJson) (*ImportKeystoresResponseJson, *gateway.DefaultErrorJson) {
+	km, errJson := s.importedKeymanager()
+	if errJson != nil {
+		return nil, errJson
+	}
+	if len(req.Keystores) != len(req.Passwords) {
+		return nil, &gateway.DefaultErrorJson{
+			Message: fmt.Sprintf("%d keystores and %d passwords provided, expected one password per keystore", len(req.Keystores), len(req.Passwords)),
+			Code:    http.StatusBadRequest,
+		}
+	}
+	if req.SlashingProtection != "" {
+		if s.valDB == nil {
+			return nil, &gateway.DefaultErrorJson{Message: "no validator database to import slashing protection into", Code: http.StatusInternalServerError}
+		}
+		if err := slashing.ImportStandardProtectionJSON(ctx, s.valDB, strings.NewReader(req.SlashingProtection)); err != nil {
+			return nil, &gateway.DefaultErrorJson{Message: "could not import slashing protection: " + err.Error(), Code: http.StatusBadRequest}
+		}
+	}
+
+	existing, err := km.FetchValidatingPublicKeys(ctx)
+	if err != nil {
+		return nil, gateway.InternalServerErrorWithMessage(err, "could not list keystores")
+	}
+	seen := make(map[[48]byte]bool, len(existing))
+	for _, pubKey := range existing {
+		seen[pubKey] = true
+	}
+	decryptor := keystorev4.New()
+	statuses := make([]*KeystoreStatusJson, len(req.Keystores))
+	toImport := make([]int, 0, len(req.Keystores))
+	privKeys := make([][]byte, 0, len(req.Keystores))
+	pubKeys := make([][]byte, 0, len(req.Keystores))
+	for i := range req.Keystores {
+		privKey, pubKey, err := decryptKeystore(decryptor, req.Keystores[i], req.Passwords[i])
+		if err != nil {
+			statuses[i] = &KeystoreStatusJson{Status: KeystoreError, Message: err.Error()}
+			continue
+		}
+		if seen[bytesutil.ToBytes48(pubKey)] {
+			statuses[i] = &KeystoreStatusJson{Status: KeystoreDuplicate}
+			continue
+		}
+		seen[bytesutil.ToBytes48(pubKey)] = true
+		toImport = append(toImport, i)
+		privKeys = append(privKeys, privKey)
+		pubKeys = append(pubKeys, pubKey)
+	}
+	if len(toImport) > 0 {
+		importStatus := &KeystoreStatusJson{Status: KeystoreImported}
+		if err := km.ImportKeypairs(ctx, privKeys, pubKeys); err != nil {
+			importStatus = &KeystoreStatusJson{Status: KeystoreError, Message: "could not import keystore: " + err.Error()}
+		} else {
+			log.WithField("numKeys", len(toImport)).Info("Imported keystores through the keymanager API")
+		}
+		for _, i := range toImport {
+			statuses[i] = importStatus
+		}
+	}
+	return &ImportKeystoresResponseJson{Data: statuses}, nil
+}
+
+// DeleteKeystoresJson deletes keys from an imported keymanager, and returns the EIP-3076
+// slashing protection data of the requested keys for them to be safely imported elsewhere. Keys
+// which are not held by the keymanager but have slashing protection data are reported as not
+// active, their data being exported as well. The slashing protection data is exported once the
+// keys are deleted and the signatures being made with them are saved, so that no signature made
+// with a deleted key is missing from the export.
+func (s *Server) DeleteKeystoresJson(ctx context.Context, req *DeleteKeystoresRequestJson) (*DeleteKeystoresResponseJson, *gateway.DefaultErrorJson) {
+	km, errJson := s.importedKeymanager()
+	if errJson != nil {
+		return nil, errJson
+	}
+	if s.valDB == nil {
+		return nil, &gateway.DefaultErrorJson{Message: "no validator database to export slashing protection from", Code: http.StatusInternalServerError}
+	}
+	requested := make([][48]byte, len(req.Pubkeys))
+	for i, k := range req.Pubkeys {
+		pubKey, err := hex.DecodeString(strings.TrimPrefix(k, "0x"))
+		if err != nil || len(pubKey) != 48 {
+			return nil, &gateway.DefaultErrorJson{Message: fmt.Sprintf("invalid public key %s", k), Code: http.StatusBadRequest}
+		}
+		requested[i] = bytesutil.ToBytes48(pubKey)
+	}
+
+	existing, err := km.FetchValidatingPublicKeys(ctx)
+	if err != nil {
+		return nil, gateway.InternalServerErrorWithMessage(err, "could not list keystores")
+	}
+	active := make(map[[48]byte]bool, len(existing))
+	for _, pubKey := range existing {
+		active[pubKey] = true
+	}
+	statuses := make([]*KeystoreStatusJson, len(requested))
+	for i, pubKey := range requested {
+		if !active[pubKey] {
+			continue
+		}
+		if err := km.DeleteAccounts(ctx, [][]byte{pubKey[:]}); err != nil {
+			statuses[i] = &KeystoreStatusJson{Status: KeystoreError, Message: "could not delete keystore: " + err.Error()}
+			continue
+		}
+		delete(active, pubKey)
+		statuses[i] = &KeystoreStatusJson{Status: KeystoreDeleted}
+	}
+	if s.validatorService != nil {
+		s.validatorService.WaitForInFlightSigning()
+	}
+
+	history, err := slashing.ExportStandardProtectionJSON(ctx, s.valDB)
+	if err != nil {
+		return nil, gateway.InternalServerErrorWithMessage(err, "could not export slashing protection")
+	}
+	historyByPubKey := make(map[string]*format.ProtectionData, len(history.Data))
+	for _, d := range history.Data {
+		historyByPubKey[d.Pubkey] = d
+	}
+	exported := make([]*format.ProtectionData, 0, len(requested))
+	for i, pubKey := range requested {
+		pubKeyHex := fmt.Sprintf("%#x", pubKey)
+		data, hasHistory := historyByPubKey[pubKeyHex]
+		switch {
+		case statuses[i] != nil:
+			if statuses[i].Status == KeystoreError {
+				continue
+			}
+		case hasHistory:
+			statuses[i] = &KeystoreStatusJson{Status: KeystoreNotActive}
+		default:
+			statuses[i] = &KeystoreStatusJson{Status: KeystoreNotFound}
+			continue
+		}
+		if hasHistory {
+			exported = append(exported, data)
+			delete(historyByPubKey, pubKeyHex)
+		}
+	}
+	history.Data = exported
+	enc, err := json.Marshal(history)
+	if err != nil {
+		return nil, gateway.InternalServerErrorWithMessage(err, "could not marshal slashing protection")
+	}
+	return &DeleteKeystoresResponseJson{Data: statuses, SlashingProtection: string(enc)}, nil
+}
+
+func (s *Server) importedKeymanager() (*imported.Keymanager, *gateway.DefaultErrorJson) {
+	if s.wallet == nil || s.keymanager == nil {
+		return nil, &gateway.DefaultErrorJson{Message: "no wallet initialized", Code: http.StatusBadRequest}
+	}
+	km, ok := s.keymanager.(*imported.Keymanager)
+	if !ok {
+		return nil, &gateway.DefaultErrorJson{
+			Message: fmt.Sprintf("keystores cannot be imported nor deleted with a %s keymanager", s.wallet.KeymanagerKind()),
+			Code:    http.StatusBadRequest,
+		}
+	}
+	return km, nil
+}
+
+// decryptKeystore decrypts an EIP-2335 keystore, returning its private and public keys. The public
+// key is derived from the private key, a keystore announcing another public key being rejected.
+func decryptKeystore(decryptor *keystorev4.Encryptor, encoded, password string) ([]byte, []byte, error) {
+	keystore := &keymanager.Keystore{}
+	if err := json.Unmarshal([]byte(encoded), keystore); err != nil {
+		return nil, nil, errors.Wrap(err, "not a valid EIP-2335 keystore")
+	}
+	privKey, err := decryptor.Decrypt(keystore.Crypto, password)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not decrypt keystore")
+	}
+	secretKey, err := bls.SecretKeyFromBytes(privKey)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "not a valid BLS private key")
+	}
+	pubKey := secretKey.PublicKey().Marshal()
+	if keystore.Pubkey == "" {
+		return privKey, pubKey, nil
+	}
+	announced, err := hex.DecodeString(strings.TrimPrefix(keystore.Pubkey, "0x"))
+	if err != nil || len(announced) != 48 {
+		return nil, nil, errors.New("not a valid BLS public key in keystore")
+	}
+	if !bytes.Equal(announced, pubKey) {
+		return nil, nil, fmt.Errorf("keystore public key %#x does not match its private key", announced)
+	}
+	return privKey, pubKey, nil
+}
@@ -0,0 +1,246 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/prysmaticlabs/prysm/shared/bls"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	"github.com/prysmaticlabs/prysm/validator/accounts"
+	"github.com/prysmaticlabs/prysm/validator/accounts/iface"
+	"github.com/prysmaticlabs/prysm/validator/accounts/wallet"
+	dbtest "github.com/prysmaticlabs/prysm/validator/db/testing"
+	"github.com/prysmaticlabs/prysm/validator/keymanager"
+	"github.com/prysmaticlabs/prysm/validator/keymanager/imported"
+	"github.com/prysmaticlabs/prysm/validator/keymanager/remote"
+	"github.com/prysmaticlabs/prysm/validator/slashing-protection/local/standard-protection-format/format"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+)
+
+func setupImportedKeymanagerAPIServer(t *testing.T) *Server {
+	imported.ResetCaches()
+	ctx := context.Background()
+	w, err := accounts.CreateWalletWithKeymanager(ctx, &accounts.CreateWalletConfig{
+		WalletCfg: &wallet.Config{
+			WalletDir:      setupWalletDir(t),
+			KeymanagerKind: keymanager.Imported,
+			WalletPassword: strongPass,
+		},
+		SkipMnemonicConfirm: true,
+	})
+	require.NoError(t, err)
+	km, err := w.InitializeKeymanager(ctx, iface.InitKeymanagerConfig{ListenForChanges: false})
+	require.NoError(t, err)
+	return &Server{
+		wallet:     w,
+		keymanager: km,
+		valDB:      dbtest.SetupDB(t, [][48]byte{}),
+		jwtKey:     []byte("testKey"),
+	}
+}
+
+func TestServer_KeymanagerAPI_Unauthorized(t *testing.T) {
+	s := &Server{jwtKey: []byte("testKey")}
+	mux := http.NewServeMux()
+	s.RegisterKeymanagerAPIHandlers(mux)
+
+	req := httptest.NewRequest(http.MethodGet, KeystoresPath, nil)
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	badServer := &Server{jwtKey: []byte("badTestKey")}
+	token, _, err := badServer.createTokenString()
+	require.NoError(t, err)
+	req = httptest.NewRequest(http.MethodGet, KeystoresPath, nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Equal(t, true, strings.Contains(rec.Body.String(), "signature is invalid"))
+}
+
+func TestServer_KeymanagerAPI_ListKeystores(t *testing.T) {
+	km := remote.NewMock()
+	km.PublicKeys = [][48]byte{{1}, {2}}
+	s := &Server{
+		wallet:     wallet.New(&wallet.Config{KeymanagerKind: keymanager.Remote}),
+		keymanager: &km,
+		jwtKey:     []byte("testKey"),
+	}
+	mux := http.NewServeMux()
+	s.RegisterKeymanagerAPIHandlers(mux)
+
+	token, _, err := s.createTokenString()
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodGet, KeystoresPath, nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	resp := &ListKeystoresResponseJson{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), resp))
+	require.Equal(t, 2, len(resp.Data))
+	assert.Equal(t, fmt.Sprintf("%#x", km.PublicKeys[0]), resp.Data[0].ValidatingPubkey)
+	assert.Equal(t, fmt.Sprintf("%#x", km.PublicKeys[1]), resp.Data[1].ValidatingPubkey)
+	assert.Equal(t, true, resp.Data[0].ReadOnly)
+	assert.Equal(t, "", resp.Data[0].DerivationPath)
+}
+
+func TestServer_KeymanagerAPI_ImportKeystores_FailedPreconditions(t *testing.T) {
+	ctx := context.Background()
+	km := remote.NewMock()
+	s := &Server{
+		wallet:     wallet.New(&wallet.Config{KeymanagerKind: keymanager.Remote}),
+		keymanager: &km,
+	}
+	_, errJson := s.ImportKeystoresJson(ctx, &ImportKeystoresRequestJson{})
+	require.NotNil(t, errJson)
+	assert.Equal(t, http.StatusBadRequest, errJson.Code)
+	assert.Equal(t, true, strings.Contains(errJson.Message, "cannot be imported nor deleted"))
+
+	s = setupImportedKeymanagerAPIServer(t)
+	_, errJson = s.ImportKeystoresJson(ctx, &ImportKeystoresRequestJson{Keystores: []string{"{}"}})
+	require.NotNil(t, errJson)
+	assert.Equal(t, http.StatusBadRequest, errJson.Code)
+	assert.Equal(t, true, strings.Contains(errJson.Message, "expected one password per keystore"))
+
+	_, errJson = s.ImportKeystoresJson(ctx, &ImportKeystoresRequestJson{SlashingProtection: "badjson"})
+	require.NotNil(t, errJson)
+	assert.Equal(t, http.StatusBadRequest, errJson.Code)
+	assert.Equal(t, true, strings.Contains(errJson.Message, "could not import slashing protection"))
+
+	resp, errJson := s.ImportKeystoresJson(ctx, &ImportKeystoresRequestJson{
+		Keystores: []string{"badjson"},
+		Passwords: []string{strongPass},
+	})
+	require.Equal(t, true, errJson == nil)
+	require.Equal(t, 1, len(resp.Data))
+	assert.Equal(t, KeystoreError, resp.Data[0].Status)
+	assert.Equal(t, true, strings.Contains(resp.Data[0].Message, "not a valid EIP-2335 keystore"))
+}
+
+func TestServer_KeymanagerAPI_ImportKeystores_PubkeyMismatch(t *testing.T) {
+	ctx := context.Background()
+	s := setupImportedKeymanagerAPIServer(t)
+
+	privKey, err := bls.RandKey()
+	require.NoError(t, err)
+	otherKey, err := bls.RandKey()
+	require.NoError(t, err)
+	encryptor := keystorev4.New()
+	cryptoFields, err := encryptor.Encrypt(privKey.Marshal(), strongPass)
+	require.NoError(t, err)
+	encoded, err := json.Marshal(&keymanager.Keystore{
+		Crypto:  cryptoFields,
+		ID:      uuid.New().String(),
+		Version: encryptor.Version(),
+		Pubkey:  fmt.Sprintf("%x", otherKey.PublicKey().Marshal()),
+		Name:    encryptor.Name(),
+	})
+	require.NoError(t, err)
+
+	resp, errJson := s.ImportKeystoresJson(ctx, &ImportKeystoresRequestJson{
+		Keystores: []string{string(encoded)},
+		Passwords: []string{strongPass},
+	})
+	require.Equal(t, true, errJson == nil)
+	require.Equal(t, 1, len(resp.Data))
+	assert.Equal(t, KeystoreError, resp.Data[0].Status)
+	assert.Equal(t, true, strings.Contains(resp.Data[0].Message, "does not match its private key"))
+	list, errJson := s.ListKeystores(ctx)
+	require.Equal(t, true, errJson == nil)
+	assert.Equal(t, 0, len(list.Data))
+}
+
+func TestServer_KeymanagerAPI_DeleteKeystores_NotActive(t *testing.T) {
+	ctx := context.Background()
+	s := setupImportedKeymanagerAPIServer(t)
+	inactive := [48]byte{1}
+	unknown := [48]byte{2}
+	require.NoError(t, s.valDB.SaveProposalHistoryForSlot(ctx, inactive, 5, make([]byte, 32)))
+
+	_, errJson := s.DeleteKeystoresJson(ctx, &DeleteKeystoresRequestJson{Pubkeys: []string{"0x1234"}})
+	require.NotNil(t, errJson)
+	assert.Equal(t, http.StatusBadRequest, errJson.Code)
+
+	resp, errJson := s.DeleteKeystoresJson(ctx, &DeleteKeystoresRequestJson{
+		Pubkeys: []string{fmt.Sprintf("%#x", inactive), fmt.Sprintf("%#x", unknown)},
+	})
+	require.Equal(t, true, errJson == nil)
+	require.Equal(t, 2, len(resp.Data))
+	assert.Equal(t, KeystoreNotActive, resp.Data[0].Status)
+	assert.Equal(t, KeystoreNotFound, resp.Data[1].Status)
+
+	exported := &format.EIPSlashingProtectionFormat{}
+	require.NoError(t, json.Unmarshal([]byte(resp.SlashingProtection), exported))
+	require.Equal(t, 1, len(exported.Data))
+	assert.Equal(t, fmt.Sprintf("%#x", inactive), exported.Data[0].Pubkey)
+	require.Equal(t, 1, len(exported.Data[0].SignedBlocks))
+	assert.Equal(t, "5", exported.Data[0].SignedBlocks[0].Slot)
+}
+
+func TestServer_KeymanagerAPI_ImportDeleteKeystores_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+	s := setupImportedKeymanagerAPIServer(t)
+
+	encryptor := keystorev4.New()
+	keystores := make([]string, 2)
+	passwords := make([]string, 2)
+	pubKeys := make([]string, 2)
+	for i := 0; i < len(keystores); i++ {
+		privKey, err := bls.RandKey()
+		require.NoError(t, err)
+		passwords[i] = fmt.Sprintf("%s%d", strongPass, i)
+		cryptoFields, err := encryptor.Encrypt(privKey.Marshal(), passwords[i])
+		require.NoError(t, err)
+		id, err := uuid.NewRandom()
+		require.NoError(t, err)
+		encoded, err := json.Marshal(&keymanager.Keystore{
+			Crypto:  cryptoFields,
+			ID:      id.String(),
+			Version: encryptor.Version(),
+			Pubkey:  fmt.Sprintf("%x", privKey.PublicKey().Marshal()),
+			Name:    encryptor.Name(),
+		})
+		require.NoError(t, err)
+		keystores[i] = string(encoded)
+		pubKeys[i] = fmt.Sprintf("%#x", privKey.PublicKey().Marshal())
+	}
+
+	// The first keystore is imported once, then reported as a duplicate.
+	resp, errJson := s.ImportKeystoresJson(ctx, &ImportKeystoresRequestJson{
+		Keystores: keystores[:1],
+		Passwords: passwords[:1],
+	})
+	require.Equal(t, true, errJson == nil)
+	assert.Equal(t, KeystoreImported, resp.Data[0].Status)
+	resp, errJson = s.ImportKeystoresJson(ctx, &ImportKeystoresRequestJson{
+		Keystores: keystores,
+		Passwords: []string{passwords[0], "wrong"},
+	})
+	require.Equal(t, true, errJson == nil)
+	assert.Equal(t, KeystoreDuplicate, resp.Data[0].Status)
+	assert.Equal(t, KeystoreError, resp.Data[1].Status)
+
+	list, errJson := s.ListKeystores(ctx)
+	require.Equal(t, true, errJson == nil)
+	require.Equal(t, 1, len(list.Data))
+	assert.Equal(t, pubKeys[0], list.Data[0].ValidatingPubkey)
+	assert.Equal(t, false, list.Data[0].ReadOnly)
+
+	deleted, errJson := s.DeleteKeystoresJson(ctx, &DeleteKeystoresRequestJson{Pubkeys: pubKeys[:1]})
+	require.Equal(t, true, errJson == nil)
+	assert.Equal(t, KeystoreDeleted, deleted.Data[0].Status)
+	list, errJson = s.ListKeystores(ctx)
+	require.Equal(t, true, errJson == nil)
+	assert.Equal(t, 0, len(list.Data))
+}
@@ -0,0 +1,87 @@
+package rpc
+
+import (
+	"context"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	pb "github.com/prysmaticlabs/prysm/proto/validator/accounts/v2"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/validator/performance"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ListAccountsPerformance returns the performance history of the requested keys, every key with a
+// recorded history by default, from the start epoch to the end epoch included. The history runs to
+// the latest recorded epoch when no end epoch is given.
+func (s *Server) ListAccountsPerformance(ctx context.Context, req *pb.AccountsPerformanceRequest) (*pb.AccountsPerformanceResponse, error) {
+	if s.valDB == nil {
+		return nil, status.Error(codes.FailedPrecondition, "No validator database")
+	}
+	pubKeys := make([][48]byte, len(req.PublicKeys))
+	for i, pubKey := range req.PublicKeys {
+		if len(pubKey) != 48 {
+			return nil, status.Errorf(codes.InvalidArgument, "Public key %#x is %d bytes long, expected 48", pubKey, len(pubKey))
+		}
+		pubKeys[i] = bytesutil.ToBytes48(pubKey)
+	}
+	start, end := types.Epoch(req.StartEpoch), types.Epoch(req.EndEpoch)
+	if end == 0 {
+		end = types.Epoch(^uint64(0))
+	}
+	if start > end {
+		return nil, status.Errorf(codes.InvalidArgument, "Start epoch %d is after end epoch %d", start, end)
+	}
+	performances, err := performance.Query(ctx, s.valDB, pubKeys, start, end)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get performance history: %v", err)
+	}
+	resp := &pb.AccountsPerformanceResponse{Performances: make([]*pb.AccountPerformance, len(performances))}
+	for i, p := range performances {
+		pubKey := p.PublicKey
+		epochs := make([]*pb.AccountEpochPerformance, len(p.Epochs))
+		for j, e := range p.Epochs {
+			epochs[j] = &pb.AccountEpochPerformance{
+				Epoch:                uint64(e.Epoch),
+				AttestationRecorded:  e.AttestationRecorded,
+				CorrectlyVotedSource: e.CorrectlyVotedSource,
+				CorrectlyVotedTarget: e.CorrectlyVotedTarget,
+				CorrectlyVotedHead:   e.CorrectlyVotedHead,
+				InclusionSlot:        uint64(e.InclusionSlot),
+				InclusionDistance:    uint64(e.InclusionDistance),
+				BalanceBefore:        e.BalanceBefore,
+				BalanceAfter:         e.BalanceAfter,
+				ProposedSlots:        slotsToUint64(e.ProposedSlots),
+				MissedSlots:          slotsToUint64(e.MissedSlots),
+				ShardSubmittedSlots:  slotsToUint64(e.ShardSubmittedSlots),
+				ShardFailedSlots:     slotsToUint64(e.ShardFailedSlots),
+			}
+		}
+		resp.Performances[i] = &pb.AccountPerformance{
+			PublicKey: pubKey[:],
+			Summary: &pb.AccountPerformanceSummary{
+				Epochs:                 p.Summary.Epochs,
+				AttestationsIncluded:   p.Summary.AttestationsIncluded,
+				CorrectSources:         p.Summary.CorrectSources,
+				CorrectTargets:         p.Summary.CorrectTargets,
+				CorrectHeads:           p.Summary.CorrectHeads,
+				TotalInclusionDistance: p.Summary.TotalDistance,
+				BalanceDelta:           p.Summary.BalanceDelta,
+				Proposed:               p.Summary.Proposed,
+				Missed:                 p.Summary.Missed,
+				ShardsSubmitted:        p.Summary.ShardsSubmitted,
+				ShardsFailed:           p.Summary.ShardsFailed,
+			},
+			Epochs: epochs,
+		}
+	}
+	return resp, nil
+}
+
+func slotsToUint64(slots []types.Slot) []uint64 {
+	out := make([]uint64, len(slots))
+	for i, slot := range slots {
+		out[i] = uint64(slot)
+	}
+	return out
+}
@@ -0,0 +1,58 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	pb "github.com/prysmaticlabs/prysm/proto/validator/accounts/v2"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	"github.com/prysmaticlabs/prysm/validator/db/kv"
+	dbtest "github.com/prysmaticlabs/prysm/validator/db/testing"
+)
+
+func TestServer_ListAccountsPerformance(t *testing.T) {
+	ctx := context.Background()
+	valDB := dbtest.SetupDB(t, [][48]byte{})
+	for _, pubKey := range [][48]byte{{1}, {2}} {
+		for epoch := types.Epoch(1); epoch <= 3; epoch++ {
+			require.NoError(t, valDB.UpdateEpochPerformance(ctx, pubKey, epoch, func(p *kv.EpochPerformance) {
+				p.AttestationRecorded = true
+				p.CorrectlyVotedHead = true
+				p.InclusionSlot = 1
+				p.InclusionDistance = 1
+				p.MissedSlots = []types.Slot{types.Slot(epoch) * 32}
+			}))
+		}
+	}
+	s := &Server{valDB: valDB}
+
+	resp, err := s.ListAccountsPerformance(ctx, &pb.AccountsPerformanceRequest{})
+	require.NoError(t, err)
+	require.Equal(t, 2, len(resp.Performances))
+	assert.Equal(t, uint64(3), resp.Performances[0].Summary.Epochs)
+	assert.Equal(t, 3, len(resp.Performances[1].Epochs))
+
+	second := [48]byte{2}
+	resp, err = s.ListAccountsPerformance(ctx, &pb.AccountsPerformanceRequest{
+		PublicKeys: [][]byte{second[:]},
+		StartEpoch: 2,
+		EndEpoch:   2,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(resp.Performances))
+	assert.DeepEqual(t, second[:], resp.Performances[0].PublicKey)
+	require.Equal(t, 1, len(resp.Performances[0].Epochs))
+	assert.Equal(t, uint64(2), resp.Performances[0].Epochs[0].Epoch)
+	assert.DeepEqual(t, []uint64{64}, resp.Performances[0].Epochs[0].MissedSlots)
+	assert.Equal(t, uint64(1), resp.Performances[0].Summary.CorrectHeads)
+	assert.Equal(t, uint64(1), resp.Performances[0].Summary.Missed)
+
+	_, err = s.ListAccountsPerformance(ctx, &pb.AccountsPerformanceRequest{PublicKeys: [][]byte{{0x12, 0x34}}})
+	assert.ErrorContains(t, "expected 48", err)
+	_, err = s.ListAccountsPerformance(ctx, &pb.AccountsPerformanceRequest{StartEpoch: 3, EndEpoch: 2})
+	assert.ErrorContains(t, "is after end epoch", err)
+	_, err = (&Server{}).ListAccountsPerformance(ctx, &pb.AccountsPerformanceRequest{})
+	assert.ErrorContains(t, "No validator database", err)
+}
@@ -60,6 +60,12 @@ func ExportSlashingProtectionJSONCli(cliCtx *cli.Context) error {
 	if err != nil {
 		return errors.Wrap(err, "could not export slashing protection history")
 	}
+	if cliCtx.Bool(flags.SlashingProtectionMinimalFlag.Name) {
+		eipJSON, err = export.MinimalInterchange(eipJSON)
+		if err != nil {
+			return errors.Wrap(err, "could not reduce slashing protection history to the minimal format")
+		}
+	}
 	outputDir, err := prompt.InputDirectory(
 		cliCtx,
 		"Enter your desired output directory for your slashing protection history",
@@ -6,11 +6,13 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/cmd/validator/flags"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
 	"github.com/prysmaticlabs/prysm/shared/cmd"
 	"github.com/prysmaticlabs/prysm/shared/fileutil"
 	"github.com/prysmaticlabs/prysm/validator/accounts/prompt"
 	"github.com/prysmaticlabs/prysm/validator/db/kv"
 	slashingProtectionFormat "github.com/prysmaticlabs/prysm/validator/slashing-protection/local/standard-protection-format"
+	"github.com/sirupsen/logrus"
 	"github.com/urfave/cli/v2"
 )
 
@@ -22,7 +24,8 @@ import (
 // 2. Open the validator database.
 // 3. Read the JSON file from user input.
 // 4. Call the function which actually imports the data from
-// from the standard slashing protection JSON file into our database.
+// from the standard slashing protection JSON file into our database, or
+// only reports its outcome in a dry run.
 func ImportSlashingProtectionCLI(cliCtx *cli.Context) error {
 	var err error
 	dataDir := cliCtx.String(cmd.DataDirFlag.Name)
@@ -66,11 +69,48 @@ func ImportSlashingProtectionCLI(cliCtx *cli.Context) error {
 		return err
 	}
 	buf := bytes.NewBuffer(enc)
-	if err := slashingProtectionFormat.ImportStandardProtectionJSON(
-		cliCtx.Context, valDB, buf,
-	); err != nil {
+	dryRun := cliCtx.Bool(flags.SlashingProtectionDryRunFlag.Name)
+	report, err := slashingProtectionFormat.ImportStandardProtectionJSONWithConfig(
+		cliCtx.Context, valDB, buf, &slashingProtectionFormat.ImportConfig{
+			Minimal: cliCtx.Bool(flags.SlashingProtectionMinimalFlag.Name),
+			DryRun:  dryRun,
+		},
+	)
+	if err != nil {
 		return err
 	}
-	log.Info("Slashing protection JSON successfully imported")
+	if dryRun {
+		printImportReport(report)
+		return nil
+	}
+	for _, conflict := range report.Conflicts {
+		log.WithField("publicKey", fmt.Sprintf("%#x", bytesutil.Trunc(conflict.PubKey[:]))).Warnf(
+			"Skipped conflicting record: %s", conflict.Description)
+	}
+	log.WithFields(logrus.Fields{
+		"importedBlocks":       report.ImportedBlocks,
+		"importedAttestations": report.ImportedAttestations,
+		"conflicts":            len(report.Conflicts),
+	}).Info("Slashing protection JSON successfully imported")
 	return nil
 }
+
+func printImportReport(report *slashingProtectionFormat.ImportReport) {
+	fmt.Println("Dry run, nothing was written to the validator database")
+	fmt.Printf("Blocks to import: %d, already in the database: %d\n", report.ImportedBlocks, report.DuplicateBlocks)
+	fmt.Printf(
+		"Attestations to import: %d, already in the database: %d\n",
+		report.ImportedAttestations, report.DuplicateAttestations,
+	)
+	for _, pubKey := range report.SlashablePublicKeys {
+		fmt.Printf("Slashable history in the JSON file, key would be blocked from signing: %#x\n", pubKey)
+	}
+	if len(report.Conflicts) == 0 {
+		fmt.Println("No conflicts with the validator database")
+		return
+	}
+	fmt.Printf("Conflicts with the validator database, which would be skipped: %d\n", len(report.Conflicts))
+	for _, conflict := range report.Conflicts {
+		fmt.Printf("  %#x: %s\n", conflict.PubKey, conflict.Description)
+	}
+}
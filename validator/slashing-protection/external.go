@@ -9,6 +9,10 @@ import (
 // CheckBlockSafety this function is part of slashing protection for block proposals it performs
 // validation without db update. To be used before the block is signed.
 func (s *Service) CheckBlockSafety(ctx context.Context, blockHeader *ethpb.BeaconBlockHeader) bool {
+	if s.beaconSlasherClient != nil {
+		slashable, err := s.isSlashableBlockOnBeaconNode(ctx, &ethpb.SignedBeaconBlockHeader{Header: blockHeader})
+		return err == nil && !slashable
+	}
 	slashable, err := s.slasherClient.IsSlashableBlockNoUpdate(ctx, blockHeader)
 	if err != nil {
 		log.Errorf("External slashing block protection returned an error: %v", err)
@@ -23,6 +27,13 @@ func (s *Service) CheckBlockSafety(ctx context.Context, blockHeader *ethpb.Beaco
 // CommitBlock this function is part of slashing protection for block proposals it performs
 // validation and db update. To be used after the block is proposed.
 func (s *Service) CommitBlock(ctx context.Context, blockHeader *ethpb.SignedBeaconBlockHeader) (bool, error) {
+	if s.beaconSlasherClient != nil {
+		slashable, err := s.isSlashableBlockOnBeaconNode(ctx, blockHeader)
+		if err != nil {
+			return false, err
+		}
+		return !slashable, nil
+	}
 	ps, err := s.slasherClient.IsSlashableBlock(ctx, blockHeader)
 	if err != nil {
 		log.Errorf("External slashing block protection returned an error: %v", err)
@@ -38,6 +49,9 @@ func (s *Service) CommitBlock(ctx context.Context, blockHeader *ethpb.SignedBeac
 // CheckAttestationSafety implements the slashing protection for attestations without db update.
 // To be used before signing.
 func (s *Service) CheckAttestationSafety(ctx context.Context, attestation *ethpb.IndexedAttestation) bool {
+	if s.beaconSlasherClient != nil {
+		return !s.isSlashableAttestationOnBeaconNode(ctx, attestation)
+	}
 	slashable, err := s.slasherClient.IsSlashableAttestationNoUpdate(ctx, attestation)
 	if err != nil {
 		log.Errorf("External slashing attestation protection returned an error: %v", err)
@@ -52,6 +66,9 @@ func (s *Service) CheckAttestationSafety(ctx context.Context, attestation *ethpb
 // CommitAttestation implements the slashing protection for attestations it performs
 // validation and db update. To be used after the attestation is proposed.
 func (s *Service) CommitAttestation(ctx context.Context, attestation *ethpb.IndexedAttestation) bool {
+	if s.beaconSlasherClient != nil {
+		return !s.isSlashableAttestationOnBeaconNode(ctx, attestation)
+	}
 	as, err := s.slasherClient.IsSlashableAttestation(ctx, attestation)
 	if err != nil {
 		log.Errorf("External slashing attestation protection returned an error: %v", err)
@@ -63,3 +80,34 @@ func (s *Service) CommitAttestation(ctx context.Context, attestation *ethpb.Inde
 	}
 	return true
 }
+
+// isSlashableBlockOnBeaconNode checks a block header against the slasher API of a beacon node.
+// The beacon node does not record the checked block, so the same check is used before and
+// after signing.
+func (s *Service) isSlashableBlockOnBeaconNode(ctx context.Context, blockHeader *ethpb.SignedBeaconBlockHeader) (bool, error) {
+	ps, err := s.beaconSlasherClient.IsSlashableBlock(ctx, blockHeader)
+	if err != nil {
+		log.Errorf("External slashing block protection returned an error: %v", err)
+		return false, err
+	}
+	if ps != nil && ps.ProposerSlashing != nil {
+		log.Warn("External slashing proposal protection found the block to be slashable")
+		return true, nil
+	}
+	return false, nil
+}
+
+// isSlashableAttestationOnBeaconNode treats an attestation as slashable if the beacon node
+// could not be queried.
+func (s *Service) isSlashableAttestationOnBeaconNode(ctx context.Context, attestation *ethpb.IndexedAttestation) bool {
+	as, err := s.beaconSlasherClient.IsSlashableAttestation(ctx, attestation)
+	if err != nil {
+		log.Errorf("External slashing attestation protection returned an error: %v", err)
+		return true
+	}
+	if as != nil && as.AttesterSlashing != nil {
+		log.Warnf("External slashing attestation protection found the attestation to be slashable: %v", as)
+		return true
+	}
+	return false
+}
@@ -89,3 +89,38 @@ func TestService_VerifyBlock(t *testing.T) {
 	s = &Service{slasherClient: mockSlasher.MockSlasher{SlashBlock: false}}
 	assert.Equal(t, true, s.CheckBlockSafety(context.Background(), blk), "Expected verify block to pass verification")
 }
+
+func TestService_BeaconNodeSlasher(t *testing.T) {
+	ctx := context.Background()
+	att := &eth.IndexedAttestation{
+		AttestingIndices: []uint64{1, 2},
+		Data: &eth.AttestationData{
+			BeaconBlockRoot: []byte("great block"),
+			Source:          &eth.Checkpoint{Epoch: 4, Root: []byte("good source")},
+			Target:          &eth.Checkpoint{Epoch: 10, Root: []byte("good target")},
+		},
+	}
+	blk := &eth.SignedBeaconBlockHeader{
+		Header: &eth.BeaconBlockHeader{
+			ParentRoot: bytesutil.PadTo([]byte("parent"), 32),
+			StateRoot:  bytesutil.PadTo([]byte("state"), 32),
+			BodyRoot:   bytesutil.PadTo([]byte("body"), 32),
+		},
+	}
+
+	s := &Service{beaconSlasherClient: mockSlasher.MockBeaconNodeSlasher{SlashAttestation: true, SlashBlock: true}}
+	assert.Equal(t, false, s.CheckAttestationSafety(ctx, att), "Expected verify attestation to fail verification")
+	assert.Equal(t, false, s.CommitAttestation(ctx, att), "Expected commit attestation to fail verification")
+	assert.Equal(t, false, s.CheckBlockSafety(ctx, blk.Header), "Expected verify block to fail verification")
+	slashable, err := s.CommitBlock(ctx, blk)
+	assert.NoError(t, err)
+	assert.Equal(t, false, slashable, "Expected commit block to fail verification")
+
+	s = &Service{beaconSlasherClient: mockSlasher.MockBeaconNodeSlasher{}}
+	assert.Equal(t, true, s.CheckAttestationSafety(ctx, att), "Expected verify attestation to pass verification")
+	assert.Equal(t, true, s.CommitAttestation(ctx, att), "Expected commit attestation to pass verification")
+	assert.Equal(t, true, s.CheckBlockSafety(ctx, blk.Header), "Expected verify block to pass verification")
+	slashable, err = s.CommitBlock(ctx, blk)
+	assert.NoError(t, err)
+	assert.Equal(t, true, slashable, "Expected commit block to pass verification")
+}
@@ -18,38 +18,91 @@ import (
 	"github.com/prysmaticlabs/prysm/validator/slashing-protection/local/standard-protection-format/format"
 )
 
+// ImportConfig configures an import of EIP-3076 slashing protection history.
+type ImportConfig struct {
+	// Minimal reduces the imported history to the minimal interchange format, keeping only the
+	// watermarks of each public key.
+	Minimal bool
+	// DryRun computes the outcome of the import without writing to the database.
+	DryRun bool
+}
+
+// ImportConflict is a signed message of the imported history which conflicts with the history of
+// the same public key in the database, and which is therefore not imported.
+type ImportConflict struct {
+	PubKey      [48]byte
+	Description string
+}
+
+// ImportReport describes the outcome of an import of EIP-3076 slashing protection history.
+type ImportReport struct {
+	// SlashablePublicKeys are the public keys whose imported history is slashable on its own. They
+	// are blacklisted from signing.
+	SlashablePublicKeys [][48]byte
+	// Conflicts are the imported signed messages conflicting with the history in the database.
+	Conflicts []*ImportConflict
+	// ImportedBlocks and ImportedAttestations count the signed messages added to the database.
+	ImportedBlocks       int
+	ImportedAttestations int
+	// DuplicateBlocks and DuplicateAttestations count the signed messages already in the database.
+	DuplicateBlocks       int
+	DuplicateAttestations int
+}
+
 // ImportStandardProtectionJSON takes in EIP-3076 compliant JSON file used for slashing protection
 // by Ethereum validators and imports its data into Prysm's internal representation of slashing
 // protection in the validator client's database. For more information, see the EIP document here:
 // https://eips.ethereum.org/EIPS/eip-3076.
 func ImportStandardProtectionJSON(ctx context.Context, validatorDB db.Database, r io.Reader) error {
+	_, err := ImportStandardProtectionJSONWithConfig(ctx, validatorDB, r, &ImportConfig{})
+	return err
+}
+
+// ImportStandardProtectionJSONWithConfig imports an EIP-3076 compliant JSON file like
+// ImportStandardProtectionJSON, and reports the outcome of the import.
+//
+// The imported history is merged with the history of the database: signed messages already in the
+// database are skipped, and the ones conflicting with it are reported and skipped. For every public
+// key which already had a history, the lowest signed source epoch, target epoch and proposal slot
+// are raised to the maximal ones of the merged history, so that nothing older can be signed.
+func ImportStandardProtectionJSONWithConfig(
+	ctx context.Context, validatorDB db.Database, r io.Reader, cfg *ImportConfig,
+) (*ImportReport, error) {
+	report := &ImportReport{}
 	encodedJSON, err := ioutil.ReadAll(r)
 	if err != nil {
-		return errors.Wrap(err, "could not read slashing protection JSON file")
+		return nil, errors.Wrap(err, "could not read slashing protection JSON file")
 	}
 	interchangeJSON := &format.EIPSlashingProtectionFormat{}
 	if err := json.Unmarshal(encodedJSON, interchangeJSON); err != nil {
-		return errors.Wrap(err, "could not unmarshal slashing protection JSON file")
+		return nil, errors.Wrap(err, "could not unmarshal slashing protection JSON file")
 	}
 	if interchangeJSON.Data == nil {
 		log.Warn("No slashing protection data to import")
-		return nil
+		return report, nil
 	}
 
 	// We validate the `MetadataV0` field of the slashing protection JSON file.
-	if err := validateMetadata(ctx, validatorDB, interchangeJSON); err != nil {
-		return errors.Wrap(err, "slashing protection JSON metadata was incorrect")
+	if err := validateMetadata(ctx, validatorDB, interchangeJSON, cfg.DryRun); err != nil {
+		return nil, errors.Wrap(err, "slashing protection JSON metadata was incorrect")
+	}
+
+	if cfg.Minimal {
+		interchangeJSON, err = MinimalInterchange(interchangeJSON)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not reduce slashing protection JSON to the minimal format")
+		}
 	}
 
 	// We need to handle duplicate public keys in the JSON file, with potentially
 	// different signing histories for both attestations and blocks.
 	signedBlocksByPubKey, err := parseBlocksForUniquePublicKeys(interchangeJSON.Data)
 	if err != nil {
-		return errors.Wrap(err, "could not parse unique entries for blocks by public key")
+		return nil, errors.Wrap(err, "could not parse unique entries for blocks by public key")
 	}
 	signedAttsByPubKey, err := parseAttestationsForUniquePublicKeys(interchangeJSON.Data)
 	if err != nil {
-		return errors.Wrap(err, "could not parse unique entries for attestations by public key")
+		return nil, errors.Wrap(err, "could not parse unique entries for attestations by public key")
 	}
 
 	attestingHistoryByPubKey := make(map[[48]byte][]*kv.AttestationRecord)
@@ -59,7 +112,7 @@ func ImportStandardProtectionJSON(ctx context.Context, validatorDB db.Database,
 		// file into the internal Prysm representation of proposal history.
 		proposalHistory, err := transformSignedBlocks(ctx, signedBlocks)
 		if err != nil {
-			return errors.Wrapf(err, "could not parse signed blocks in JSON file for key %#x", pubKey)
+			return nil, errors.Wrapf(err, "could not parse signed blocks in JSON file for key %#x", pubKey)
 		}
 		proposalHistoryByPubKey[pubKey] = *proposalHistory
 	}
@@ -69,7 +122,7 @@ func ImportStandardProtectionJSON(ctx context.Context, validatorDB db.Database,
 		// file into the internal Prysm representation of attesting history.
 		historicalAtt, err := transformSignedAttestations(pubKey, signedAtts)
 		if err != nil {
-			return errors.Wrapf(err, "could not parse signed attestations in JSON file for key %#x", pubKey)
+			return nil, errors.Wrapf(err, "could not parse signed attestations in JSON file for key %#x", pubKey)
 		}
 		attestingHistoryByPubKey[pubKey] = historicalAtt
 	}
@@ -77,12 +130,7 @@ func ImportStandardProtectionJSON(ctx context.Context, validatorDB db.Database,
 	// We validate and filter out public keys parsed from JSON to ensure we are
 	// not importing those which are slashable with respect to other data within the same JSON.
 	slashableProposerKeys := filterSlashablePubKeysFromBlocks(ctx, proposalHistoryByPubKey)
-	slashableAttesterKeys, err := filterSlashablePubKeysFromAttestations(
-		ctx, validatorDB, attestingHistoryByPubKey,
-	)
-	if err != nil {
-		return errors.Wrap(err, "could not filter slashable attester public keys from JSON data")
-	}
+	slashableAttesterKeys := filterSlashablePubKeysFromAttestations(ctx, attestingHistoryByPubKey)
 
 	slashablePublicKeys := make([][48]byte, 0, len(slashableAttesterKeys)+len(slashableProposerKeys))
 	for _, pubKey := range slashableProposerKeys {
@@ -93,51 +141,91 @@ func ImportStandardProtectionJSON(ctx context.Context, validatorDB db.Database,
 		delete(attestingHistoryByPubKey, pubKey)
 		slashablePublicKeys = append(slashablePublicKeys, pubKey)
 	}
+	report.SlashablePublicKeys = slashablePublicKeys
+
+	// We then merge the histories with the ones in the database.
+	proposalMerges := make(map[[48]byte]*proposalMerge, len(proposalHistoryByPubKey))
+	for pubKey, proposalHistory := range proposalHistoryByPubKey {
+		merge, err := mergeProposals(ctx, validatorDB, pubKey, proposalHistory.Proposals, cfg.Minimal)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not merge signed blocks for key %#x", pubKey)
+		}
+		proposalMerges[pubKey] = merge
+		report.ImportedBlocks += len(merge.proposals)
+		report.DuplicateBlocks += merge.duplicates
+		report.Conflicts = append(report.Conflicts, merge.conflicts...)
+	}
+	attestationMerges := make(map[[48]byte]*attestationMerge, len(attestingHistoryByPubKey))
+	for pubKey, attestations := range attestingHistoryByPubKey {
+		merge, err := mergeAttestations(ctx, validatorDB, pubKey, attestations, cfg.Minimal)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not merge signed attestations for key %#x", pubKey)
+		}
+		attestationMerges[pubKey] = merge
+		report.ImportedAttestations += len(merge.attestations)
+		report.DuplicateAttestations += merge.duplicates
+		report.Conflicts = append(report.Conflicts, merge.conflicts...)
+	}
+	if cfg.DryRun {
+		return report, nil
+	}
 
 	if err := validatorDB.SaveEIPImportBlacklistedPublicKeys(ctx, slashablePublicKeys); err != nil {
-		return errors.Wrap(err, "could not save slashable public keys to database")
+		return nil, errors.Wrap(err, "could not save slashable public keys to database")
 	}
 
 	// We save the histories to disk as atomic operations, ensuring that this only occurs
 	// until after we successfully parse all data from the JSON file. If there is any error
 	// in parsing the JSON proposal and attesting histories, we will not reach this point.
-	for pubKey, proposalHistory := range proposalHistoryByPubKey {
+	for pubKey, merge := range proposalMerges {
 		bar := initializeProgressBar(
-			len(proposalHistory.Proposals),
+			len(merge.proposals),
 			fmt.Sprintf("Importing proposals for validator public key %#x", bytesutil.Trunc(pubKey[:])),
 		)
-		for _, proposal := range proposalHistory.Proposals {
+		for _, proposal := range merge.proposals {
 			if err := bar.Add(1); err != nil {
 				log.WithError(err).Debug("Could not increase progress bar")
 			}
 			if err = validatorDB.SaveProposalHistoryForSlot(ctx, pubKey, proposal.Slot, proposal.SigningRoot); err != nil {
-				return errors.Wrap(err, "could not save proposal history from imported JSON to database")
+				return nil, errors.Wrap(err, "could not save proposal history from imported JSON to database")
+			}
+		}
+		if merge.raiseWatermark {
+			if err := validatorDB.RaiseLowestSignedProposal(ctx, pubKey, merge.highestSlot); err != nil {
+				return nil, errors.Wrap(err, "could not save lowest signed proposal from imported JSON to database")
 			}
 		}
 	}
 	bar := initializeProgressBar(
-		len(attestingHistoryByPubKey),
+		len(attestationMerges),
 		"Importing attesting history for validator public keys",
 	)
-	for pubKey, attestations := range attestingHistoryByPubKey {
+	for pubKey, merge := range attestationMerges {
 		if err := bar.Add(1); err != nil {
 			log.WithError(err).Debug("Could not increase progress bar")
 		}
-		indexedAtts := make([]*ethpb.IndexedAttestation, len(attestations))
-		signingRoots := make([][32]byte, len(attestations))
-		for i, att := range attestations {
+		indexedAtts := make([]*ethpb.IndexedAttestation, len(merge.attestations))
+		signingRoots := make([][32]byte, len(merge.attestations))
+		for i, att := range merge.attestations {
 			indexedAtt := createAttestation(att.Source, att.Target)
 			indexedAtts[i] = indexedAtt
 			signingRoots[i] = att.SigningRoot
 		}
 		if err := validatorDB.SaveAttestationsForPubKey(ctx, pubKey, signingRoots, indexedAtts); err != nil {
-			return errors.Wrap(err, "could not save attestations from imported JSON to database")
+			return nil, errors.Wrap(err, "could not save attestations from imported JSON to database")
+		}
+		if merge.raiseWatermark {
+			if err := validatorDB.RaiseLowestSignedEpochs(ctx, pubKey, merge.highestSource, merge.highestTarget); err != nil {
+				return nil, errors.Wrap(err, "could not save lowest signed epochs from imported JSON to database")
+			}
 		}
 	}
-	return nil
+	return report, nil
 }
 
-func validateMetadata(ctx context.Context, validatorDB db.Database, interchangeJSON *format.EIPSlashingProtectionFormat) error {
+func validateMetadata(
+	ctx context.Context, validatorDB db.Database, interchangeJSON *format.EIPSlashingProtectionFormat, dryRun bool,
+) error {
 	// We need to ensure the version in the metadata field matches the one we support.
 	version := interchangeJSON.Metadata.InterchangeFormatVersion
 	if version != format.InterchangeFormatVersion {
@@ -159,6 +247,9 @@ func validateMetadata(ctx context.Context, validatorDB db.Database, interchangeJ
 		return errors.Wrap(err, "could not retrieve genesis validator root to db")
 	}
 	if dbGvr == nil {
+		if dryRun {
+			return nil
+		}
 		if err = validatorDB.SaveGenesisValidatorsRoot(ctx, gvr[:]); err != nil {
 			return errors.Wrap(err, "could not save genesis validator root to db")
 		}
@@ -260,9 +351,8 @@ func filterSlashablePubKeysFromBlocks(ctx context.Context, historyByPubKey map[[
 
 func filterSlashablePubKeysFromAttestations(
 	ctx context.Context,
-	validatorDB db.Database,
 	signedAttsByPubKey map[[48]byte][]*kv.AttestationRecord,
-) ([][48]byte, error) {
+) [][48]byte {
 	slashablePubKeys := make([][48]byte, 0)
 	// First we need to find attestations that are slashable with respect to other
 	// attestations within the same JSON import.
@@ -293,22 +383,7 @@ func filterSlashablePubKeysFromAttestations(
 			targetEpochsBySource[att.Source] = append(targetEpochsBySource[att.Source], att.Target)
 		}
 	}
-	// Then, we need to find attestations that are slashable with respect to our database.
-	for pubKey, signedAtts := range signedAttsByPubKey {
-		for _, att := range signedAtts {
-			indexedAtt := createAttestation(att.Source, att.Target)
-			slashable, err := validatorDB.CheckSlashableAttestation(ctx, pubKey, att.SigningRoot, indexedAtt)
-			if err != nil {
-				return nil, err
-			}
-			// Malformed data should not prevent us from completing this function.
-			if slashable != kv.NotSlashable {
-				slashablePubKeys = append(slashablePubKeys, pubKey)
-				break
-			}
-		}
-	}
-	return slashablePubKeys, nil
+	return slashablePubKeys
 }
 
 func transformSignedBlocks(ctx context.Context, signedBlocks []*format.SignedBlock) (*kv.ProposalHistoryForPubkey, error) {
@@ -222,7 +222,7 @@ func Test_validateMetadata(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			validatorDB := dbtest.SetupDB(t, nil)
 			ctx := context.Background()
-			if err := validateMetadata(ctx, validatorDB, tt.interchangeJSON); (err != nil) != tt.wantErr {
+			if err := validateMetadata(ctx, validatorDB, tt.interchangeJSON, false); (err != nil) != tt.wantErr {
 				t.Errorf("validateMetadata() error = %v, wantErr %v", err, tt.wantErr)
 			}
 
@@ -278,7 +278,7 @@ func Test_validateMetadataGenesisValidatorRoot(t *testing.T) {
 			validatorDB := dbtest.SetupDB(t, nil)
 			ctx := context.Background()
 			require.NoError(t, validatorDB.SaveGenesisValidatorsRoot(ctx, tt.dbGenesisValidatorRoot))
-			err := validateMetadata(ctx, validatorDB, tt.interchangeJSON)
+			err := validateMetadata(ctx, validatorDB, tt.interchangeJSON, false)
 			if tt.wantErr {
 				require.ErrorContains(t, "genesis validator root doesnt match the one that is stored", err)
 			} else {
@@ -923,9 +923,7 @@ func Test_filterSlashablePubKeysFromAttestations(t *testing.T) {
 	tests := []struct {
 		name                 string
 		previousAttsByPubKey map[[48]byte][]*format.SignedAttestation
-		incomingAttsByPubKey map[[48]byte][]*format.SignedAttestation
 		want                 map[[48]byte]bool
-		wantErr              bool
 	}{
 		{
 			name: "Properly filters out double voting attester keys",
@@ -1041,25 +1039,13 @@ func Test_filterSlashablePubKeysFromAttestations(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			attestingHistoriesByPubKey := make(map[[48]byte][]*kv.AttestationRecord)
-			pubKeys := make([][48]byte, 0)
-			for pubKey := range tt.incomingAttsByPubKey {
-				pubKeys = append(pubKeys, pubKey)
-			}
-			validatorDB := dbtest.SetupDB(t, pubKeys)
-			for pubKey, signedAtts := range tt.incomingAttsByPubKey {
+			for pubKey, signedAtts := range tt.previousAttsByPubKey {
 				attestingHistory, err := transformSignedAttestations(pubKey, signedAtts)
 				require.NoError(t, err)
-				for _, att := range attestingHistory {
-					indexedAtt := createAttestation(att.Source, att.Target)
-					err := validatorDB.SaveAttestationForPubKey(ctx, pubKey, att.SigningRoot, indexedAtt)
-					require.NoError(t, err)
-				}
-			}
-			got, err := filterSlashablePubKeysFromAttestations(ctx, validatorDB, attestingHistoriesByPubKey)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("filterSlashablePubKeysFromAttestations() error = %v, wantErr %v", err, tt.wantErr)
-				return
+				attestingHistoriesByPubKey[pubKey] = attestingHistory
 			}
+			got := filterSlashablePubKeysFromAttestations(ctx, attestingHistoriesByPubKey)
+			require.Equal(t, len(tt.want), len(got))
 			for _, pubKey := range got {
 				ok := tt.want[pubKey]
 				assert.Equal(t, true, ok)
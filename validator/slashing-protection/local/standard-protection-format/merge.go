@@ -0,0 +1,135 @@
+package interchangeformat
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/slashutil"
+	"github.com/prysmaticlabs/prysm/validator/db"
+	"github.com/prysmaticlabs/prysm/validator/db/kv"
+)
+
+// proposalMerge is the outcome of merging imported signed blocks of a public key with its
+// proposal history in the database.
+type proposalMerge struct {
+	proposals      []kv.Proposal
+	duplicates     int
+	conflicts      []*ImportConflict
+	raiseWatermark bool
+	highestSlot    types.Slot
+}
+
+// attestationMerge is the outcome of merging imported signed attestations of a public key with
+// its attesting history in the database.
+type attestationMerge struct {
+	attestations   []*kv.AttestationRecord
+	duplicates     int
+	conflicts      []*ImportConflict
+	raiseWatermark bool
+	highestSource  types.Epoch
+	highestTarget  types.Epoch
+}
+
+// mergeProposals skips the imported blocks already in the database and the ones conflicting with
+// it. The lowest signed proposal must be raised to the highest slot of the merged history when the
+// public key already had a history, or when only the watermarks are imported.
+func mergeProposals(
+	ctx context.Context, validatorDB db.Database, pubKey [48]byte, proposals []kv.Proposal, minimal bool,
+) (*proposalMerge, error) {
+	history, err := validatorDB.ProposalHistoryForPubKey(ctx, pubKey)
+	if err != nil {
+		return nil, err
+	}
+	merge := &proposalMerge{raiseWatermark: minimal || len(history) > 0}
+	signingRootsBySlot := make(map[types.Slot][]byte, len(history))
+	for _, proposal := range history {
+		signingRootsBySlot[proposal.Slot] = proposal.SigningRoot
+		if proposal.Slot > merge.highestSlot {
+			merge.highestSlot = proposal.Slot
+		}
+	}
+	for _, proposal := range proposals {
+		// Conflicting blocks were signed too, so they count in the watermark.
+		if proposal.Slot > merge.highestSlot {
+			merge.highestSlot = proposal.Slot
+		}
+		signingRoot, ok := signingRootsBySlot[proposal.Slot]
+		if !ok {
+			merge.proposals = append(merge.proposals, proposal)
+			signingRootsBySlot[proposal.Slot] = proposal.SigningRoot
+			continue
+		}
+		if !bytes.Equal(signingRoot, params.BeaconConfig().ZeroHash[:]) && bytes.Equal(signingRoot, proposal.SigningRoot) {
+			merge.duplicates++
+			continue
+		}
+		merge.conflicts = append(merge.conflicts, &ImportConflict{
+			PubKey: pubKey,
+			Description: fmt.Sprintf(
+				"block at slot %d with signing root %#x conflicts with the block with signing root %#x in the database",
+				proposal.Slot, proposal.SigningRoot, signingRoot,
+			),
+		})
+	}
+	return merge, nil
+}
+
+// mergeAttestations skips the imported attestations already in the database and the ones which
+// are slashable with respect to it. The lowest signed source and target epochs must be raised to
+// the highest ones of the merged history when the public key already had a history, or when only
+// the watermarks are imported.
+func mergeAttestations(
+	ctx context.Context, validatorDB db.Database, pubKey [48]byte, attestations []*kv.AttestationRecord, minimal bool,
+) (*attestationMerge, error) {
+	history, err := validatorDB.AttestationHistoryForPubKey(ctx, pubKey)
+	if err != nil {
+		return nil, err
+	}
+	type sourceTarget struct {
+		source types.Epoch
+		target types.Epoch
+	}
+	merge := &attestationMerge{raiseWatermark: minimal || len(history) > 0}
+	signingRoots := make(map[sourceTarget][32]byte, len(history))
+	for _, att := range history {
+		signingRoots[sourceTarget{att.Source, att.Target}] = att.SigningRoot
+		merge.updateWatermarks(att)
+	}
+	for _, att := range attestations {
+		// Conflicting attestations were signed too, so they count in the watermarks.
+		merge.updateWatermarks(att)
+		key := sourceTarget{att.Source, att.Target}
+		if signingRoot, ok := signingRoots[key]; ok && !slashutil.SigningRootsDiffer(signingRoot, att.SigningRoot) {
+			merge.duplicates++
+			continue
+		}
+		slashingKind, err := validatorDB.CheckSlashableAttestation(
+			ctx, pubKey, att.SigningRoot, createAttestation(att.Source, att.Target),
+		)
+		if slashingKind != kv.NotSlashable {
+			merge.conflicts = append(merge.conflicts, &ImportConflict{
+				PubKey:      pubKey,
+				Description: fmt.Sprintf("attestation conflicts with the database: %v", err),
+			})
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		merge.attestations = append(merge.attestations, att)
+		signingRoots[key] = att.SigningRoot
+	}
+	return merge, nil
+}
+
+func (m *attestationMerge) updateWatermarks(att *kv.AttestationRecord) {
+	if att.Source > m.highestSource {
+		m.highestSource = att.Source
+	}
+	if att.Target > m.highestTarget {
+		m.highestTarget = att.Target
+	}
+}
@@ -0,0 +1,122 @@
+package interchangeformat
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	dbtest "github.com/prysmaticlabs/prysm/validator/db/testing"
+	"github.com/prysmaticlabs/prysm/validator/slashing-protection/local/standard-protection-format/format"
+)
+
+func mockInterchangeJSON(t *testing.T, data ...*format.ProtectionData) *bytes.Buffer {
+	interchangeJSON := &format.EIPSlashingProtectionFormat{Data: data}
+	interchangeJSON.Metadata.InterchangeFormatVersion = format.InterchangeFormatVersion
+	interchangeJSON.Metadata.GenesisValidatorsRoot = fmt.Sprintf("%#x", [32]byte{})
+	blob, err := json.Marshal(interchangeJSON)
+	require.NoError(t, err)
+	return bytes.NewBuffer(blob)
+}
+
+func TestImportStandardProtectionJSONWithConfig_MergesWithDatabase(t *testing.T) {
+	ctx := context.Background()
+	pubKey := [48]byte{1}
+	validatorDB := dbtest.SetupDB(t, [][48]byte{pubKey})
+	require.NoError(t, validatorDB.SaveAttestationsForPubKey(
+		ctx, pubKey, [][32]byte{{1}, {2}}, []*ethpb.IndexedAttestation{createAttestation(1, 2), createAttestation(2, 3)},
+	))
+	require.NoError(t, validatorDB.SaveProposalHistoryForSlot(ctx, pubKey, 4, bytesutil.PadTo([]byte{4}, 32)))
+	require.NoError(t, validatorDB.SaveProposalHistoryForSlot(ctx, pubKey, 5, bytesutil.PadTo([]byte{5}, 32)))
+	root := func(b byte) string {
+		return fmt.Sprintf("%#x", [32]byte{b})
+	}
+	data := &format.ProtectionData{
+		Pubkey: fmt.Sprintf("%#x", pubKey),
+		SignedBlocks: []*format.SignedBlock{
+			{Slot: "4", SigningRoot: root(8)},
+			{Slot: "5", SigningRoot: root(5)},
+			{Slot: "6", SigningRoot: root(6)},
+		},
+		SignedAttestations: []*format.SignedAttestation{
+			{SourceEpoch: "1", TargetEpoch: "3", SigningRoot: root(9)},
+			{SourceEpoch: "1", TargetEpoch: "2", SigningRoot: root(1)},
+			{SourceEpoch: "3", TargetEpoch: "4", SigningRoot: root(3)},
+		},
+	}
+
+	report, err := ImportStandardProtectionJSONWithConfig(ctx, validatorDB, mockInterchangeJSON(t, data), &ImportConfig{DryRun: true})
+	require.NoError(t, err)
+	assert.Equal(t, 2, len(report.Conflicts))
+	assert.Equal(t, 1, report.ImportedBlocks)
+	assert.Equal(t, 1, report.ImportedAttestations)
+	assert.Equal(t, 1, report.DuplicateAttestations)
+	assert.Equal(t, 0, len(report.SlashablePublicKeys))
+	gvr, err := validatorDB.GenesisValidatorsRoot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(gvr), "Dry run should not write to the database")
+	history, err := validatorDB.AttestationHistoryForPubKey(ctx, pubKey)
+	require.NoError(t, err)
+	assert.Equal(t, 2, len(history))
+
+	_, err = ImportStandardProtectionJSONWithConfig(ctx, validatorDB, mockInterchangeJSON(t, data), &ImportConfig{})
+	require.NoError(t, err)
+	history, err = validatorDB.AttestationHistoryForPubKey(ctx, pubKey)
+	require.NoError(t, err)
+	assert.Equal(t, 3, len(history))
+	signingRoot, err := validatorDB.SigningRootAtTargetEpoch(ctx, pubKey, 3)
+	require.NoError(t, err)
+	assert.Equal(t, [32]byte{2}, signingRoot, "Conflicting attestation should not be imported")
+	source, _, err := validatorDB.LowestSignedSourceEpoch(ctx, pubKey)
+	require.NoError(t, err)
+	assert.Equal(t, types.Epoch(3), source)
+	target, _, err := validatorDB.LowestSignedTargetEpoch(ctx, pubKey)
+	require.NoError(t, err)
+	assert.Equal(t, types.Epoch(4), target)
+	slot, _, err := validatorDB.LowestSignedProposal(ctx, pubKey)
+	require.NoError(t, err)
+	assert.Equal(t, types.Slot(6), slot)
+	blockRoot, _, err := validatorDB.ProposalHistoryForSlot(ctx, pubKey, 4)
+	require.NoError(t, err)
+	assert.Equal(t, [32]byte{4}, blockRoot, "Conflicting block should not be imported")
+}
+
+func TestImportStandardProtectionJSONWithConfig_Minimal(t *testing.T) {
+	ctx := context.Background()
+	pubKey := [48]byte{1}
+	validatorDB := dbtest.SetupDB(t, [][48]byte{pubKey})
+	data := &format.ProtectionData{
+		Pubkey: fmt.Sprintf("%#x", pubKey),
+		SignedBlocks: []*format.SignedBlock{
+			{Slot: "4"},
+			{Slot: "9"},
+		},
+		SignedAttestations: []*format.SignedAttestation{
+			{SourceEpoch: "1", TargetEpoch: "2"},
+			{SourceEpoch: "2", TargetEpoch: "3"},
+			{SourceEpoch: "3", TargetEpoch: "5"},
+		},
+	}
+	report, err := ImportStandardProtectionJSONWithConfig(ctx, validatorDB, mockInterchangeJSON(t, data), &ImportConfig{Minimal: true})
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.ImportedBlocks)
+	assert.Equal(t, 1, report.ImportedAttestations)
+
+	history, err := validatorDB.AttestationHistoryForPubKey(ctx, pubKey)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(history))
+	assert.Equal(t, types.Epoch(3), history[0].Source)
+	assert.Equal(t, types.Epoch(5), history[0].Target)
+	source, _, err := validatorDB.LowestSignedSourceEpoch(ctx, pubKey)
+	require.NoError(t, err)
+	assert.Equal(t, types.Epoch(3), source)
+	slot, _, err := validatorDB.LowestSignedProposal(ctx, pubKey)
+	require.NoError(t, err)
+	assert.Equal(t, types.Slot(9), slot)
+}
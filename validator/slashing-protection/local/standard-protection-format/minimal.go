@@ -0,0 +1,121 @@
+package interchangeformat
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/validator/slashing-protection/local/standard-protection-format/format"
+)
+
+// MinimalInterchange reduces an EIP-3076 slashing protection history to the minimal interchange
+// format, which only keeps the watermarks of each public key: a single block at the highest signed
+// slot, and a single attestation with the highest signed source and target epochs. Signing roots
+// are only kept when a single signed message matches the watermark exactly.
+func MinimalInterchange(interchangeJSON *format.EIPSlashingProtectionFormat) (*format.EIPSlashingProtectionFormat, error) {
+	type watermarks struct {
+		pubKey         string
+		hasBlock       bool
+		slot           types.Slot
+		slotRoots      map[string]bool
+		hasAttestation bool
+		source, target types.Epoch
+		attRoots       map[string]bool
+	}
+	byPubKey := make(map[[48]byte]*watermarks)
+	for _, validatorData := range interchangeJSON.Data {
+		pubKey, err := PubKeyFromHex(validatorData.Pubkey)
+		if err != nil {
+			return nil, fmt.Errorf("%s is not a valid public key: %w", validatorData.Pubkey, err)
+		}
+		w, ok := byPubKey[pubKey]
+		if !ok {
+			w = &watermarks{pubKey: validatorData.Pubkey}
+			byPubKey[pubKey] = w
+		}
+		for _, sBlock := range validatorData.SignedBlocks {
+			if sBlock == nil {
+				continue
+			}
+			slot, err := SlotFromString(sBlock.Slot)
+			if err != nil {
+				return nil, fmt.Errorf("%s is not a valid slot: %w", sBlock.Slot, err)
+			}
+			if !w.hasBlock || slot > w.slot {
+				w.hasBlock, w.slot, w.slotRoots = true, slot, make(map[string]bool)
+			}
+			if slot == w.slot {
+				w.slotRoots[sBlock.SigningRoot] = true
+			}
+		}
+		for _, sAtt := range validatorData.SignedAttestations {
+			if sAtt == nil {
+				continue
+			}
+			source, err := EpochFromString(sAtt.SourceEpoch)
+			if err != nil {
+				return nil, fmt.Errorf("%s is not a valid epoch: %w", sAtt.SourceEpoch, err)
+			}
+			target, err := EpochFromString(sAtt.TargetEpoch)
+			if err != nil {
+				return nil, fmt.Errorf("%s is not a valid epoch: %w", sAtt.TargetEpoch, err)
+			}
+			if !w.hasAttestation || source > w.source || target > w.target {
+				// The roots of the previous watermark do not match the new one anymore.
+				w.attRoots = make(map[string]bool)
+			}
+			if !w.hasAttestation || source > w.source {
+				w.source = source
+			}
+			if !w.hasAttestation || target > w.target {
+				w.target = target
+			}
+			w.hasAttestation = true
+			if source == w.source && target == w.target {
+				w.attRoots[sAtt.SigningRoot] = true
+			}
+		}
+	}
+
+	minimal := &format.EIPSlashingProtectionFormat{
+		Metadata: interchangeJSON.Metadata,
+		Data:     make([]*format.ProtectionData, 0, len(byPubKey)),
+	}
+	for _, w := range byPubKey {
+		data := &format.ProtectionData{
+			Pubkey:             w.pubKey,
+			SignedBlocks:       make([]*format.SignedBlock, 0),
+			SignedAttestations: make([]*format.SignedAttestation, 0),
+		}
+		if w.hasBlock {
+			data.SignedBlocks = append(data.SignedBlocks, &format.SignedBlock{
+				Slot:        fmt.Sprintf("%d", w.slot),
+				SigningRoot: uniqueRoot(w.slotRoots),
+			})
+		}
+		if w.hasAttestation {
+			data.SignedAttestations = append(data.SignedAttestations, &format.SignedAttestation{
+				SourceEpoch: fmt.Sprintf("%d", w.source),
+				TargetEpoch: fmt.Sprintf("%d", w.target),
+				SigningRoot: uniqueRoot(w.attRoots),
+			})
+		}
+		minimal.Data = append(minimal.Data, data)
+	}
+	sort.Slice(minimal.Data, func(i, j int) bool {
+		return strings.Compare(minimal.Data[i].Pubkey, minimal.Data[j].Pubkey) < 0
+	})
+	return minimal, nil
+}
+
+// uniqueRoot returns the signing root of a watermark when a single one was seen, and no root otherwise.
+func uniqueRoot(roots map[string]bool) string {
+	if len(roots) != 1 {
+		return ""
+	}
+	for root := range roots {
+		return root
+	}
+	return ""
+}
@@ -0,0 +1,62 @@
+package interchangeformat
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	"github.com/prysmaticlabs/prysm/validator/slashing-protection/local/standard-protection-format/format"
+)
+
+func TestMinimalInterchange(t *testing.T) {
+	pubKey1 := fmt.Sprintf("%#x", [48]byte{1})
+	pubKey2 := fmt.Sprintf("%#x", [48]byte{2})
+	root := func(b byte) string {
+		return fmt.Sprintf("%#x", [32]byte{b})
+	}
+	interchangeJSON := &format.EIPSlashingProtectionFormat{
+		Data: []*format.ProtectionData{
+			{
+				Pubkey:       pubKey2,
+				SignedBlocks: []*format.SignedBlock{{Slot: "3", SigningRoot: root(3)}},
+				SignedAttestations: []*format.SignedAttestation{
+					{SourceEpoch: "1", TargetEpoch: "2", SigningRoot: root(1)},
+					{SourceEpoch: "2", TargetEpoch: "3", SigningRoot: root(2)},
+				},
+			},
+			{
+				Pubkey: pubKey1,
+				SignedAttestations: []*format.SignedAttestation{
+					{SourceEpoch: "5", TargetEpoch: "6", SigningRoot: root(5)},
+					{SourceEpoch: "4", TargetEpoch: "8", SigningRoot: root(4)},
+				},
+			},
+			{
+				Pubkey: pubKey2,
+				SignedBlocks: []*format.SignedBlock{
+					{Slot: "7", SigningRoot: root(7)},
+					{Slot: "5", SigningRoot: root(5)},
+				},
+			},
+		},
+	}
+	minimal, err := MinimalInterchange(interchangeJSON)
+	require.NoError(t, err)
+	require.DeepEqual(t, []*format.ProtectionData{
+		{
+			Pubkey:             pubKey1,
+			SignedBlocks:       []*format.SignedBlock{},
+			SignedAttestations: []*format.SignedAttestation{{SourceEpoch: "5", TargetEpoch: "8"}},
+		},
+		{
+			Pubkey:             pubKey2,
+			SignedBlocks:       []*format.SignedBlock{{Slot: "7", SigningRoot: root(7)}},
+			SignedAttestations: []*format.SignedAttestation{{SourceEpoch: "2", TargetEpoch: "3", SigningRoot: root(2)}},
+		},
+	}, minimal.Data)
+
+	_, err = MinimalInterchange(&format.EIPSlashingProtectionFormat{
+		Data: []*format.ProtectionData{{Pubkey: pubKey1, SignedBlocks: []*format.SignedBlock{{Slot: "a"}}}},
+	})
+	require.ErrorContains(t, "is not a valid slot", err)
+}
@@ -13,6 +13,7 @@ import (
 	grpc_retry "github.com/grpc-ecosystem/go-grpc-middleware/retry"
 	grpc_opentracing "github.com/grpc-ecosystem/go-grpc-middleware/tracing/opentracing"
 	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
+	pbrpc "github.com/prysmaticlabs/prysm/proto/beacon/rpc/v1"
 	ethsl "github.com/prysmaticlabs/prysm/proto/slashing"
 	"github.com/prysmaticlabs/prysm/shared/grpcutils"
 	"go.opencensus.io/plugin/ocgrpc"
@@ -30,6 +31,9 @@ type Service struct {
 	conn          *grpc.ClientConn
 	grpcHeaders   []string
 	slasherClient ethsl.SlasherClient
+	// beaconSlasherClient is set instead of slasherClient when slashing checks
+	// are served by the slasher API of a beacon node.
+	beaconSlasherClient pbrpc.SlasherClient
 }
 
 // Config for the validator service.
@@ -40,6 +44,9 @@ type Config struct {
 	GrpcRetriesFlag            uint
 	GrpcRetryDelay             time.Duration
 	GrpcHeadersFlag            string
+	// BeaconNodeAPI is set when the endpoint is a beacon node serving the
+	// slasher API rather than a standalone slasher.
+	BeaconNodeAPI bool
 }
 
 // NewService creates a new validator service for the service
@@ -56,19 +63,41 @@ func NewService(ctx context.Context, cfg *Config) (*Service, error) {
 
 // Start the slasher protection service and grpc client.
 func (s *Service) Start() {
-	if s.cfg.Endpoint != "" {
-		s.slasherClient = s.startSlasherClient()
+	if s.cfg.Endpoint == "" {
+		return
 	}
+	if s.cfg.BeaconNodeAPI {
+		s.beaconSlasherClient = s.startBeaconSlasherClient()
+		return
+	}
+	s.slasherClient = s.startSlasherClient()
 }
 
 func (s *Service) startSlasherClient() ethsl.SlasherClient {
+	if err := s.dialSlasher(); err != nil {
+		log.Errorf("Could not dial slasher endpoint: %s, %v", s.cfg.Endpoint, err)
+		return nil
+	}
+	log.Debug("Successfully started slasher gRPC connection")
+	return ethsl.NewSlasherClient(s.conn)
+}
+
+func (s *Service) startBeaconSlasherClient() pbrpc.SlasherClient {
+	if err := s.dialSlasher(); err != nil {
+		log.Errorf("Could not dial beacon node slasher endpoint: %s, %v", s.cfg.Endpoint, err)
+		return nil
+	}
+	log.Debug("Successfully started beacon node slasher gRPC connection")
+	return pbrpc.NewSlasherClient(s.conn)
+}
+
+func (s *Service) dialSlasher() error {
 	var dialOpt grpc.DialOption
 
 	if s.cfg.CertFlag != "" {
 		creds, err := credentials.NewClientTLSFromFile(s.cfg.CertFlag, "")
 		if err != nil {
-			log.Errorf("Could not get valid slasher credentials: %v", err)
-			return nil
+			return fmt.Errorf("could not get valid slasher credentials: %w", err)
 		}
 		dialOpt = grpc.WithTransportCredentials(creds)
 	} else {
@@ -114,13 +143,10 @@ func (s *Service) startSlasherClient() ethsl.SlasherClient {
 
 	conn, err := grpc.DialContext(s.ctx, slasherRpcAddr, opts...)
 	if err != nil {
-		log.Errorf("Could not dial slasher endpoint: %s, %v", slasherRpcAddr, err)
-		return nil
+		return err
 	}
-	log.Debug("Successfully started slasher gRPC connection")
 	s.conn = conn
-	return ethsl.NewSlasherClient(s.conn)
-
+	return nil
 }
 
 // Stop the validator service.
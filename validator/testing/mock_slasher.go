@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 
+	pbrpc "github.com/prysmaticlabs/prysm/proto/beacon/rpc/v1"
 	eth "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
 	slashpb "github.com/prysmaticlabs/prysm/proto/slashing"
 	"google.golang.org/grpc"
@@ -86,3 +87,50 @@ func (ms MockSlasher) IsSlashableBlockNoUpdate(_ context.Context, _ *eth.BeaconB
 		Slashable: ms.SlashBlock,
 	}, nil
 }
+
+// MockBeaconNodeSlasher mocks the slasher rpc server of a beacon node.
+type MockBeaconNodeSlasher struct {
+	SlashAttestation bool
+	SlashBlock       bool
+}
+
+// HighestAttestations will return an empty array of attestations.
+func (ms MockBeaconNodeSlasher) HighestAttestations(_ context.Context, _ *pbrpc.HighestAttestationRequest, _ ...grpc.CallOption) (*pbrpc.HighestAttestationResponse, error) {
+	return &pbrpc.HighestAttestationResponse{}, nil
+}
+
+// IsSlashableAttestation returns an attester slashing if slash attestation is set to true.
+func (ms MockBeaconNodeSlasher) IsSlashableAttestation(_ context.Context, in *eth.IndexedAttestation, _ ...grpc.CallOption) (*pbrpc.AttesterSlashingResponse, error) {
+	if !ms.SlashAttestation {
+		return &pbrpc.AttesterSlashingResponse{}, nil
+	}
+	slashingAtt, ok := proto.Clone(in).(*eth.IndexedAttestation)
+	if !ok {
+		return nil, errors.New("object is not of type *eth.IndexedAttestation")
+	}
+	slashingAtt.Data.BeaconBlockRoot = []byte("slashing")
+	return &pbrpc.AttesterSlashingResponse{
+		AttesterSlashing: &eth.AttesterSlashing{
+			Attestation_1: slashingAtt,
+			Attestation_2: in,
+		},
+	}, nil
+}
+
+// IsSlashableBlock returns a proposer slashing if slash block is set to true.
+func (ms MockBeaconNodeSlasher) IsSlashableBlock(_ context.Context, in *eth.SignedBeaconBlockHeader, _ ...grpc.CallOption) (*pbrpc.ProposerSlashingResponse, error) {
+	if !ms.SlashBlock {
+		return &pbrpc.ProposerSlashingResponse{}, nil
+	}
+	slashingBlk, ok := proto.Clone(in).(*eth.SignedBeaconBlockHeader)
+	if !ok {
+		return nil, errors.New("object is not of type *eth.SignedBeaconBlockHeader")
+	}
+	slashingBlk.Header.BodyRoot = []byte("slashing")
+	return &pbrpc.ProposerSlashingResponse{
+		ProposerSlashing: &eth.ProposerSlashing{
+			Header_1: slashingBlk,
+			Header_2: in,
+		},
+	}, nil
+}